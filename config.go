@@ -2,6 +2,7 @@ package main
 
 import (
 	"fmt"
+	"io/ioutil"
 	"os"
 	"os/user"
 	"path/filepath"
@@ -12,6 +13,8 @@ import (
 	"github.com/decred/dcrd/dcrutil/v2"
 	"github.com/decred/slog"
 	flags "github.com/jessevdk/go-flags"
+	"github.com/planetdecred/godcr/crash"
+	"github.com/planetdecred/godcr/ui"
 	"github.com/planetdecred/godcr/version"
 )
 
@@ -21,12 +24,15 @@ const (
 	defaultLogFilename    = "godcr.log"
 	defaultLogLevel       = "info"
 	defaultLogDirname     = "logs"
+	defaultLangDirname    = "lang"
+	defaultCrashDirname   = "crashes"
 )
 
 var (
 	defaultHomeDir        = dcrutil.AppDataDir("godcr", false)
 	defaultConfigFilename = filepath.Join(defaultHomeDir, defaultConfigFileName)
 	defaultLogDir         = filepath.Join(defaultHomeDir, defaultLogDirname)
+	defaultLangDir        = filepath.Join(defaultHomeDir, defaultLangDirname)
 )
 
 type config struct {
@@ -36,10 +42,12 @@ type config struct {
 	ShowVersion      bool   `short:"V" long:"version" description:"Display version information and exit"`
 	MaxLogZips       int    `long:"max-log-zips" description:"The number of zipped log files created by the log rotator to be retained. Setting to 0 will keep all."`
 	LogDir           string `long:"logdir" description:"Directory to log output."`
+	LangDir          string `long:"langdir" description:"Directory to load translation files (<lang>.strings) from"`
 	DebugLevel       string `short:"d" long:"debuglevel" description:"Logging level {trace, debug, info, warn, error, critical}"`
 	Quiet            bool   `short:"q" long:"quiet" description:"Easy way to set debuglevel to error"`
 	SpendUnconfirmed bool   `long:"spendunconfirmed" description:"Allow the multiwallet to use transactions that have not been confirmed"`
 	Profile          int    `long:"profile" description:"Runs local web server for profiling"`
+	FakeWallet       bool   `long:"fakewallet" description:"Run the UI against scripted wallets instead of wallet data (for development)"`
 }
 
 var defaultConfig = config{
@@ -47,6 +55,7 @@ var defaultConfig = config{
 	HomeDir:    defaultHomeDir,
 	ConfigFile: defaultConfigFilename,
 	LogDir:     defaultLogDir,
+	LangDir:    defaultLangDir,
 	DebugLevel: defaultLogLevel,
 }
 
@@ -121,6 +130,37 @@ func parseAndSetDebugLevels(debugLevel string) error {
 	return nil
 }
 
+// configFilePath is the config file loadConfig read, or would have read if
+// it existed.
+var configFilePath string
+
+// saveDebugLevel sets the debuglevel option in the config file loadConfig
+// read, replacing the value set there. The config file is created if it
+// doesn't exist.
+func saveDebugLevel(debugLevel string) error {
+	data, err := ioutil.ReadFile(configFilePath)
+	if err != nil && !os.IsNotExist(err) {
+		return err
+	}
+
+	option := "debuglevel=" + debugLevel
+	lines := strings.Split(string(data), "\n")
+	saved := false
+	for i, line := range lines {
+		key := strings.SplitN(line, "=", 2)[0]
+		if strings.EqualFold(strings.TrimSpace(key), "debuglevel") {
+			lines[i] = option
+			saved = true
+		}
+	}
+	if !saved {
+		// options before any section apply to the application options
+		lines = append([]string{option}, lines...)
+	}
+
+	return ioutil.WriteFile(configFilePath, []byte(strings.Join(lines, "\n")), 0600)
+}
+
 // loadConfig initializes and parses the config using a config file and command
 // line options.
 func loadConfig() (*config, error) {
@@ -173,6 +213,9 @@ func loadConfig() (*config, error) {
 		defaultConfigNow.ConfigFile = preCfg.ConfigFile
 	}
 
+	configFilePath = preCfg.ConfigFile
+	ui.UseConfigFile(configFilePath)
+
 	// Load additional config from file.
 	var configFileError error
 	// Config file name for logging.
@@ -232,11 +275,14 @@ func loadConfig() (*config, error) {
 	}
 
 	// If a non-default appdata folder is specified, it may be necessary to
-	// adjust the LogDir.
+	// adjust the LogDir and LangDir.
 	if defaultHomeDir != cfg.HomeDir {
 		if defaultLogDir == cfg.LogDir {
 			cfg.LogDir = filepath.Join(cfg.HomeDir, defaultLogDirname)
 		}
+		if defaultLangDir == cfg.LangDir {
+			cfg.LangDir = filepath.Join(cfg.HomeDir, defaultLangDirname)
+		}
 	}
 
 	// Warn about missing config file after the final command line parse
@@ -249,6 +295,7 @@ func loadConfig() (*config, error) {
 
 	logRotator = nil
 	cfg.LogDir = cleanAndExpandPath(cfg.LogDir)
+	cfg.LangDir = cleanAndExpandPath(cfg.LangDir)
 
 	// Initialize log rotation. After log rotation has been initialized, the
 	// logger variables may be used. This creates the LogDir if needed.
@@ -256,6 +303,7 @@ func loadConfig() (*config, error) {
 		cfg.MaxLogZips = 0
 	}
 	initLogRotator(filepath.Join(cfg.LogDir, defaultLogFilename), cfg.MaxLogZips)
+	crash.UseDir(filepath.Join(cfg.HomeDir, defaultCrashDirname))
 
 	// Special show command to list supported subsystems and exit.
 	if cfg.DebugLevel == "show" {
@@ -0,0 +1,223 @@
+// Package crash recovers panics and writes them as local crash reports.
+package crash
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"runtime"
+	"runtime/debug"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/planetdecred/godcr/version"
+)
+
+const (
+	// reportPrefix and reportExt name the crash report files.
+	reportPrefix = "crash-"
+	reportExt    = ".txt"
+
+	// logTailLines is how many of the last log lines a report includes.
+	logTailLines = 100
+	// logTailBytes limits how much of the log file is read for the tail.
+	logTailBytes = 64 * 1024
+)
+
+// Report is a crash report written for a recovered panic.
+type Report struct {
+	Path   string
+	Time   time.Time
+	Source string
+	Panic  string
+}
+
+var (
+	mu       sync.Mutex
+	dir      string
+	logFile  string
+	handlers []func(Report)
+)
+
+// UseDir sets the directory crash reports are written to. Reports are only
+// logged until it is set.
+func UseDir(path string) {
+	mu.Lock()
+	dir = path
+	mu.Unlock()
+}
+
+// UseLogFile sets the log file whose last lines are included in reports.
+func UseLogFile(path string) {
+	mu.Lock()
+	logFile = path
+	mu.Unlock()
+}
+
+// Handle calls f with every report written after a recovered panic.
+func Handle(f func(Report)) {
+	mu.Lock()
+	handlers = append(handlers, f)
+	mu.Unlock()
+}
+
+// Recover recovers a panic in the calling goroutine and writes a crash
+// report naming source as where it happened. It must be deferred directly:
+//
+//	defer crash.Recover("wallet GetAllTickets")
+func Recover(source string) {
+	if r := recover(); r != nil {
+		Write(source, r, debug.Stack())
+	}
+}
+
+// Write writes a crash report of the panic value r with its stack, the
+// version and the log tail, then notifies the handlers. The report is
+// returned even if it couldn't be saved.
+func Write(source string, r interface{}, stack []byte) Report {
+	mu.Lock()
+	reportDir, tailFile := dir, logFile
+	mu.Unlock()
+
+	report := Report{
+		Time:   time.Now(),
+		Source: source,
+		Panic:  fmt.Sprint(r),
+	}
+	log.Criticalf("Recovered panic in %s: %v\n%s", source, r, stack)
+
+	if reportDir != "" {
+		path, err := writeReport(reportDir, report, stack, tailFile)
+		if err != nil {
+			log.Errorf("Could not write crash report: %v", err)
+		} else {
+			report.Path = path
+			log.Infof("Crash report written to %s", path)
+		}
+	}
+
+	mu.Lock()
+	notify := append([]func(Report){}, handlers...)
+	mu.Unlock()
+	for _, f := range notify {
+		f(report)
+	}
+	return report
+}
+
+func writeReport(reportDir string, report Report, stack []byte, tailFile string) (string, error) {
+	if err := os.MkdirAll(reportDir, 0700); err != nil {
+		return "", err
+	}
+
+	var text strings.Builder
+	fmt.Fprintf(&text, "Time: %s\n", report.Time.Format(time.RFC3339Nano))
+	fmt.Fprintf(&text, "Version: %s\n", version.Version())
+	fmt.Fprintf(&text, "Go: %s %s/%s\n", runtime.Version(), runtime.GOOS, runtime.GOARCH)
+	fmt.Fprintf(&text, "Source: %s\n", report.Source)
+	fmt.Fprintf(&text, "Panic: %s\n", strings.Replace(report.Panic, "\n", " ", -1))
+	fmt.Fprintf(&text, "\nStack:\n%s\n", stack)
+	if tailFile != "" {
+		tail, err := logTail(tailFile)
+		if err != nil {
+			tail = err.Error() + "\n"
+		}
+		fmt.Fprintf(&text, "\nLog:\n%s", tail)
+	}
+
+	name := filepath.Join(reportDir, reportPrefix+report.Time.Format("20060102-150405.000")+reportExt)
+	return name, ioutil.WriteFile(name, []byte(text.String()), 0600)
+}
+
+// logTail returns the last lines of the log file at path.
+func logTail(path string) (string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+
+	info, err := f.Stat()
+	if err != nil {
+		return "", err
+	}
+	if info.Size() > logTailBytes {
+		if _, err = f.Seek(info.Size()-logTailBytes, io.SeekStart); err != nil {
+			return "", err
+		}
+	}
+
+	data, err := ioutil.ReadAll(f)
+	if err != nil {
+		return "", err
+	}
+	lines := strings.SplitAfter(string(data), "\n")
+	if len(lines) > logTailLines {
+		lines = lines[len(lines)-logTailLines:]
+	}
+	return strings.Join(lines, ""), nil
+}
+
+// Reports returns the crash reports in the report directory, newest first.
+func Reports() ([]Report, error) {
+	mu.Lock()
+	reportDir := dir
+	mu.Unlock()
+	if reportDir == "" {
+		return nil, nil
+	}
+
+	names, err := filepath.Glob(filepath.Join(reportDir, reportPrefix+"*"+reportExt))
+	if err != nil {
+		return nil, err
+	}
+
+	reports := make([]Report, 0, len(names))
+	for _, name := range names {
+		report, err := readReport(name)
+		if err != nil {
+			log.Warnf("Could not read crash report %s: %v", name, err)
+			continue
+		}
+		reports = append(reports, report)
+	}
+	sort.Slice(reports, func(i, j int) bool {
+		return reports[i].Time.After(reports[j].Time)
+	})
+	return reports, nil
+}
+
+// readReport reads the header of the crash report at path.
+func readReport(path string) (Report, error) {
+	report := Report{Path: path}
+	f, err := os.Open(path)
+	if err != nil {
+		return report, err
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() && scanner.Text() != "" {
+		fields := strings.SplitN(scanner.Text(), ": ", 2)
+		if len(fields) != 2 {
+			continue
+		}
+		switch fields[0] {
+		case "Time":
+			report.Time, err = time.Parse(time.RFC3339Nano, fields[1])
+			if err != nil {
+				return report, err
+			}
+		case "Source":
+			report.Source = fields[1]
+		case "Panic":
+			report.Panic = fields[1]
+		}
+	}
+	return report, scanner.Err()
+}
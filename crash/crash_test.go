@@ -0,0 +1,59 @@
+package crash_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/planetdecred/godcr/crash"
+)
+
+var _ = Describe("Crash reports", func() {
+	var dir string
+
+	BeforeEach(func() {
+		var err error
+		dir, err = ioutil.TempDir("", "godcr-crash")
+		Expect(err).To(BeNil())
+		crash.UseDir(dir)
+	})
+
+	AfterEach(func() {
+		crash.UseDir("")
+		crash.UseLogFile("")
+		os.RemoveAll(dir)
+	})
+
+	It("writes a report for a recovered panic", func() {
+		logFile := filepath.Join(dir, "godcr.log")
+		Expect(ioutil.WriteFile(logFile, []byte("2021-04-01 12:00:00.000 [INF] GDCR: last line\n"), 0600)).To(BeNil())
+		crash.UseLogFile(logFile)
+
+		reported := make(chan crash.Report, 1)
+		crash.Handle(func(report crash.Report) {
+			reported <- report
+		})
+
+		func() {
+			defer crash.Recover("test")
+			panic("boom")
+		}()
+
+		var report crash.Report
+		Eventually(reported).Should(Receive(&report))
+		Expect(report.Source).To(Equal("test"))
+		Expect(report.Panic).To(Equal("boom"))
+
+		data, err := ioutil.ReadFile(report.Path)
+		Expect(err).To(BeNil())
+		Expect(string(data)).To(ContainSubstring("Stack:"))
+		Expect(string(data)).To(ContainSubstring("last line"))
+
+		reports, err := crash.Reports()
+		Expect(err).To(BeNil())
+		Expect(reports).To(HaveLen(1))
+		Expect(reports[0].Panic).To(Equal("boom"))
+	})
+})
@@ -12,6 +12,7 @@ import (
 	"github.com/decred/slog"
 	"github.com/jrick/logrotate/rotator"
 	"github.com/planetdecred/dcrlibwallet"
+	"github.com/planetdecred/godcr/crash"
 	"github.com/planetdecred/godcr/ui"
 	"github.com/planetdecred/godcr/wallet"
 )
@@ -20,15 +21,20 @@ import (
 // the write-end pipe of an initialized log rotator.
 type logWriter struct{}
 
+// internalLog receives each line once it is written to the log rotator. The
+// log page reads new lines from the log file when notified, so a line is
+// dropped rather than blocking the logger when the channel is full.
 var internalLog = make(chan string, 10)
 
 // Write writes the data in p to standard out and the log rotator.
 func (l logWriter) Write(p []byte) (n int, err error) {
 	os.Stdout.Write(p)
-	go func() {
-		internalLog <- string(p)
-	}()
-	return logRotator.Write(p)
+	n, err = logRotator.Write(p)
+	select {
+	case internalLog <- string(p):
+	default:
+	}
+	return n, err
 }
 
 // Loggers per subsystem.  A single backend logger is created and all subsytem
@@ -54,13 +60,23 @@ var (
 	walletLog = backendLog.Logger("WALL")
 	winLog    = backendLog.Logger("UI")
 	dlwlLog   = backendLog.Logger("DLWL")
+
+	// dcrlibwallet internal subsystems.
+	lodrLog = backendLog.Logger("LODR")
+	wlltLog = backendLog.Logger("WLLT")
+	tkbyLog = backendLog.Logger("TKBY")
+	syncLog = backendLog.Logger("SYNC")
+	cmgrLog = backendLog.Logger("CMGR")
+	amgrLog = backendLog.Logger("AMGR")
 )
 
 // Initialize package-global logger variables.
 func init() {
 	wallet.UseLogger(walletLog)
 	ui.UseLogger(winLog)
-	dcrlibwallet.UseLogger(dlwlLog)
+	crash.UseLogger(log)
+	dcrlibwallet.UseLoggers(dlwlLog, lodrLog, wlltLog, tkbyLog, syncLog, cmgrLog, amgrLog)
+	ui.UseLogLevels(subsystemLoggers, parseAndSetDebugLevels, saveDebugLevel)
 }
 
 // subsystemLoggers maps each subsystem identifier to its associated logger.
@@ -69,6 +85,12 @@ var subsystemLoggers = map[string]slog.Logger{
 	"DLWL": dlwlLog,
 	"UI":   winLog,
 	"GDCR": log,
+	"LODR": lodrLog,
+	"WLLT": wlltLog,
+	"TKBY": tkbyLog,
+	"SYNC": syncLog,
+	"CMGR": cmgrLog,
+	"AMGR": amgrLog,
 }
 
 // initLogRotator initializes the logging rotater to write logs to logFile and
@@ -88,6 +110,8 @@ func initLogRotator(logFile string, maxRolls int) {
 	}
 
 	logRotator = r
+	ui.UseLogFile(logFile)
+	crash.UseLogFile(logFile)
 }
 
 // setLogLevel sets the logging level for provided subsystem.  Invalid
@@ -99,7 +123,6 @@ func setLogLevel(subsystemID string, logLevel string) {
 	if !ok {
 		return
 	}
-	dcrlibwallet.SetLogLevels("info")
 	// Defaults to info if the log level is invalid.
 	level, _ := slog.LevelFromString(logLevel)
 	logger.SetLevel(level)
@@ -14,6 +14,7 @@ import (
 
 	"github.com/planetdecred/dcrlibwallet"
 	"github.com/planetdecred/godcr/ui"
+	"github.com/planetdecred/godcr/ui/values"
 	"github.com/planetdecred/godcr/wallet"
 )
 
@@ -31,7 +32,9 @@ func main() {
 		}()
 	}
 
-	dcrlibwallet.SetLogLevels(cfg.DebugLevel)
+	if err = values.LoadTranslations(cfg.LangDir); err != nil {
+		log.Warn(err)
+	}
 
 	absoluteWdPath, err := ui.GetAbsolutePath()
 	if err != nil {
@@ -66,7 +69,13 @@ func main() {
 		confirms = 0
 	}
 
-	wal, err := wallet.NewWallet(cfg.HomeDir, cfg.Network, make(chan wallet.Response, 3), confirms)
+	var wal wallet.Backend
+	if cfg.FakeWallet {
+		log.Warn("Using scripted wallets, no wallet data will be read or written")
+		wal, err = wallet.NewFakeWallet(cfg.Network, make(chan wallet.Response, 3))
+	} else {
+		wal, err = wallet.NewWallet(cfg.HomeDir, cfg.Network, make(chan wallet.Response, 3), confirms)
+	}
 	if err != nil {
 		log.Error(err)
 		return
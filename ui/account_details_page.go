@@ -7,17 +7,17 @@ import (
 	"gioui.org/layout"
 	"gioui.org/widget"
 
-	"github.com/decred/dcrd/dcrutil/v3"
 	"github.com/planetdecred/dcrlibwallet"
 	"github.com/planetdecred/godcr/ui/decredmaterial"
 	"github.com/planetdecred/godcr/ui/values"
+	"github.com/planetdecred/godcr/wallet"
 )
 
 const PageAccountDetails = "AccountDetails"
 
 type acctDetailsPage struct {
 	common  *pageCommon
-	wallet  *dcrlibwallet.Wallet
+	wallet  *wallet.WalletSummary
 	account *dcrlibwallet.Account
 
 	theme                    *decredmaterial.Theme
@@ -39,7 +39,7 @@ type acctDetailsPage struct {
 func AcctDetailsPage(common *pageCommon, account *dcrlibwallet.Account) Page {
 	pg := &acctDetailsPage{
 		common:  common,
-		wallet:  common.multiWallet.WalletWithID(account.WalletID),
+		wallet:  common.wallet.WalletWithID(account.WalletID),
 		account: account,
 
 		theme: common.theme,
@@ -62,12 +62,12 @@ func (pg *acctDetailsPage) OnResume() {
 	pg.stakingBalance = balance.ImmatureReward + balance.LockedByTickets + balance.VotingAuthority +
 		balance.ImmatureStakeGeneration
 
-	pg.totalBalance = dcrutil.Amount(balance.Total).String()
-	pg.spendable = dcrutil.Amount(balance.Spendable).String()
-	pg.immatureRewards = dcrutil.Amount(balance.ImmatureReward).String()
-	pg.lockedByTickets = dcrutil.Amount(balance.LockedByTickets).String()
-	pg.votingAuthority = dcrutil.Amount(balance.VotingAuthority).String()
-	pg.immatureStakeGen = dcrutil.Amount(balance.ImmatureStakeGeneration).String()
+	pg.totalBalance = wallet.FormatAmount(balance.Total)
+	pg.spendable = wallet.FormatAmount(balance.Spendable)
+	pg.immatureRewards = wallet.FormatAmount(balance.ImmatureReward)
+	pg.lockedByTickets = wallet.FormatAmount(balance.LockedByTickets)
+	pg.votingAuthority = wallet.FormatAmount(balance.VotingAuthority)
+	pg.immatureStakeGen = wallet.FormatAmount(balance.ImmatureStakeGeneration)
 
 	pg.hdPath = pg.common.HDPrefix() + strconv.Itoa(int(pg.account.Number)) + "'"
 
@@ -181,7 +181,7 @@ func (pg *acctDetailsPage) accountBalanceLayout(gtx layout.Context, common *page
 
 func (pg *acctDetailsPage) acctBalLayout(gtx layout.Context, balType string, balance string, isTotalBalance bool) layout.Dimensions {
 
-	mainBalance, subBalance := breakBalance(pg.common.printer, balance)
+	mainBalance, subBalance := breakBalance(balance)
 
 	mainLabel := pg.theme.Body1(mainBalance)
 	subLabel := pg.theme.Caption(subBalance)
@@ -274,7 +274,7 @@ func (pg *acctDetailsPage) handle() {
 		textModal := newTextInputModal(common).
 			hint("Account name").
 			positiveButton(values.String(values.StrRename), func(newName string, tim *textInputModal) bool {
-				err := pg.wallet.RenameAccount(pg.account.Number, newName)
+				err := pg.common.wallet.RenameAccount(pg.wallet.ID, pg.account.Number, newName)
 				if err != nil {
 					tim.setError(err.Error())
 					tim.isLoading = false
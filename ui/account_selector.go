@@ -10,10 +10,10 @@ import (
 	"gioui.org/layout"
 	"gioui.org/text"
 	"gioui.org/widget"
-	"github.com/decred/dcrd/dcrutil/v3"
 	"github.com/planetdecred/dcrlibwallet"
 	"github.com/planetdecred/godcr/ui/decredmaterial"
 	"github.com/planetdecred/godcr/ui/values"
+	"github.com/planetdecred/godcr/wallet"
 )
 
 type accountSelector struct {
@@ -25,7 +25,7 @@ type accountSelector struct {
 
 	openSelectorDialog *widget.Clickable
 
-	wallets            []*dcrlibwallet.Wallet
+	wallets            []*wallet.WalletSummary
 	selectedAccount    *dcrlibwallet.Account
 	selectedWalletName string
 	totalBalance       string
@@ -77,7 +77,7 @@ func (as *accountSelector) selectFirstWalletValidAccount() error {
 	}
 
 	for _, wal := range as.wallets {
-		accountsResult, err := wal.GetAccountsRaw()
+		accountsResult, err := as.wallet.GetAccountsRaw(wal.ID)
 		if err != nil {
 			return err
 		}
@@ -96,11 +96,11 @@ func (as *accountSelector) selectFirstWalletValidAccount() error {
 }
 
 func (as *accountSelector) setupSelectedAccount(account *dcrlibwallet.Account) {
-	wal := as.multiWallet.WalletWithID(account.WalletID)
+	wal := as.wallet.WalletWithID(account.WalletID)
 
 	as.selectedAccount = account
 	as.selectedWalletName = wal.Name
-	as.totalBalance = dcrutil.Amount(account.TotalBalance).String()
+	as.totalBalance = wallet.FormatAmount(account.TotalBalance)
 }
 
 func (as *accountSelector) Layout(gtx layout.Context) layout.Dimensions {
@@ -194,8 +194,8 @@ type accountSelectorModal struct {
 	accountsList     layout.List
 
 	currentSelectedAccount *dcrlibwallet.Account
-	wallets                []*dcrlibwallet.Wallet // TODO sort array instead
-	filteredWallets        []*dcrlibwallet.Wallet
+	wallets                []*wallet.WalletSummary // TODO sort array instead
+	filteredWallets        []*wallet.WalletSummary
 	accounts               map[int][]*selectorAccount // key = wallet id
 	eventQueue             event.Queue
 }
@@ -205,7 +205,7 @@ type selectorAccount struct {
 	clickEvent *gesture.Click
 }
 
-func newAccountSelectorModal(common *pageCommon, currentSelectedAccount *dcrlibwallet.Account, wallets []*dcrlibwallet.Wallet) *accountSelectorModal {
+func newAccountSelectorModal(common *pageCommon, currentSelectedAccount *dcrlibwallet.Account, wallets []*wallet.WalletSummary) *accountSelectorModal {
 	asm := &accountSelectorModal{
 		pageCommon: common,
 
@@ -226,13 +226,13 @@ func newAccountSelectorModal(common *pageCommon, currentSelectedAccount *dcrlibw
 }
 
 func (asm *accountSelectorModal) OnResume() {
-	wallets := make([]*dcrlibwallet.Wallet, 0)
+	wallets := make([]*wallet.WalletSummary, 0)
 	walletAccounts := make(map[int][]*selectorAccount)
 
 	// TODO use a sorted wallet list
 	for _, wal := range asm.wallets {
 		// filter all accounts
-		accountsResult, err := wal.GetAccountsRaw()
+		accountsResult, err := asm.wallet.GetAccountsRaw(wal.ID)
 		if err != nil {
 			log.Errorf("Error getting accounts: %v", err)
 			return
@@ -410,13 +410,13 @@ func (asm *accountSelectorModal) walletAccountLayout(gtx layout.Context, account
 								acct := asm.theme.Label(values.TextSize18, account.Name)
 								acct.Color = asm.theme.Color.Text
 								return endToEndRow(gtx, acct.Layout, func(gtx C) D {
-									return asm.pageCommon.layoutBalance(gtx, dcrutil.Amount(account.TotalBalance).String(), true)
+									return asm.pageCommon.layoutBalance(gtx, wallet.FormatAmount(account.TotalBalance), true)
 								})
 							}),
 							layout.Rigid(func(gtx C) D {
 								spendable := asm.theme.Label(values.TextSize14, values.String(values.StrLabelSpendable))
 								spendable.Color = asm.theme.Color.Gray
-								spendableBal := asm.theme.Label(values.TextSize14, dcrutil.Amount(account.Balance.Spendable).String())
+								spendableBal := asm.theme.Label(values.TextSize14, wallet.FormatAmount(account.Balance.Spendable))
 								spendableBal.Color = asm.theme.Color.Gray
 								return endToEndRow(gtx, spendable.Layout, spendableBal.Layout)
 							}),
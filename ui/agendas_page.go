@@ -0,0 +1,303 @@
+package ui
+
+import (
+	"strings"
+
+	"gioui.org/layout"
+	"gioui.org/widget"
+
+	"github.com/planetdecred/godcr/ui/decredmaterial"
+	"github.com/planetdecred/godcr/ui/values"
+	"github.com/planetdecred/godcr/wallet"
+)
+
+const PageAgendas = "Agendas"
+
+type agendasPage struct {
+	common  *pageCommon
+	tickets **wallet.Tickets
+	wallets []*wallet.WalletSummary
+
+	container      layout.List
+	walletDropDown *decredmaterial.DropDown
+	pushButton     decredmaterial.Button
+	backButton     decredmaterial.IconButton
+
+	agendas []wallet.Agenda
+	err     error
+	// choiceGroups are the radio groups of the agendas, in the same order.
+	choiceGroups []*widget.Enum
+
+	// loaded are the tickets the VSP statuses were computed from.
+	loaded   *wallet.Tickets
+	choices  map[string]string
+	statuses []wallet.VSPVoteStatus
+}
+
+func AgendasPage(common *pageCommon) Page {
+	pg := &agendasPage{
+		common:    common,
+		tickets:   common.walletTickets,
+		container: layout.List{Axis: layout.Vertical},
+	}
+	pg.backButton, _ = common.SubPageHeaderButtons()
+	pg.pushButton = common.theme.Button(new(widget.Clickable), values.String(values.StrSendToVSPs))
+	pg.pushButton.TextSize = values.TextSize14
+	return pg
+}
+
+func (pg *agendasPage) OnResume() {
+	pg.wallets = pg.common.wallet.AllWallets()
+	pg.common.createOrUpdateWalletDropDown(&pg.walletDropDown, pg.wallets)
+
+	pg.agendas, pg.err = pg.common.wallet.Agendas()
+	pg.choiceGroups = make([]*widget.Enum, len(pg.agendas))
+	for i := range pg.choiceGroups {
+		pg.choiceGroups[i] = new(widget.Enum)
+	}
+	pg.update()
+}
+
+// selectedWallet returns the wallet selected in the dropdown.
+func (pg *agendasPage) selectedWallet() *wallet.WalletSummary {
+	index := pg.walletDropDown.SelectedIndex()
+	if index < 0 || index >= len(pg.wallets) {
+		return nil
+	}
+	return pg.wallets[index]
+}
+
+// update loads the choices of the selected wallet and computes what the VSPs
+// of its tickets recorded.
+func (pg *agendasPage) update() {
+	pg.loaded = *pg.tickets
+	pg.choices, pg.statuses = nil, nil
+	wal := pg.selectedWallet()
+	if wal == nil {
+		return
+	}
+
+	choices, err := pg.common.wallet.VoteChoices(wal.ID)
+	if err != nil {
+		pg.err = err
+		return
+	}
+	pg.choices = choices
+	for i, agenda := range pg.agendas {
+		pg.choiceGroups[i].Value = agendaChoice(agenda, choices)
+	}
+	pg.statuses = wallet.VoteStatuses(pg.loaded.Confirmed[wal.ID], choices)
+}
+
+// agendaChoice returns the choice on an agenda, or the abstain choice if none
+// was made.
+func agendaChoice(agenda wallet.Agenda, choices map[string]string) string {
+	if choice, ok := choices[agenda.ID]; ok {
+		return choice
+	}
+	for _, choice := range agenda.Choices {
+		if choice.IsAbstain {
+			return choice.ID
+		}
+	}
+	return ""
+}
+
+// canPush returns whether the selected wallet can sign the vote choices it
+// sends to the VSPs, which watch-only wallets cannot.
+func (pg *agendasPage) canPush() bool {
+	wal := pg.selectedWallet()
+	return wal != nil && !wal.IsWatchingOnly && len(pg.statuses) > 0
+}
+
+// pushVoteChoices asks for the spending passphrase of the selected wallet and
+// sends its vote choices to the VSPs, then reloads the tickets to show what
+// the VSPs recorded.
+func (pg *agendasPage) pushVoteChoices() {
+	common, wal := pg.common, pg.selectedWallet()
+	newPasswordModal(common).
+		title(values.StringF(values.StrSendVoteChoicesOf, wal.Name)).
+		negativeButton(values.String(values.StrCancel), func() {}).
+		positiveButton(values.String(values.StrSendToVSPs), func(password string, pm *passwordModal) bool {
+			go func() {
+				pushed, err := common.wallet.PushVoteChoices(wal.ID, []byte(password))
+				if err != nil {
+					pm.setError(err.Error())
+					pm.setLoading(false)
+					return
+				}
+				pm.Dismiss()
+				common.notify(values.StringF(values.StrVoteChoicesSent, pushed, wal.Name), true)
+				common.wallet.GetAllTickets()
+			}()
+			return false
+		}).Show()
+}
+
+func (pg *agendasPage) handle() {
+	if pg.loaded != *pg.tickets || pg.walletDropDown.Changed() {
+		pg.update()
+	}
+
+	if wal := pg.selectedWallet(); wal != nil {
+		for i, group := range pg.choiceGroups {
+			if !group.Changed() {
+				continue
+			}
+			err := pg.common.wallet.SetVoteChoice(wal.ID, pg.agendas[i].ID, group.Value)
+			if err != nil {
+				pg.common.notify(err.Error(), false)
+			}
+			pg.update()
+		}
+	}
+
+	for pg.pushButton.Button.Clicked() {
+		if pg.canPush() {
+			pg.pushVoteChoices()
+		}
+	}
+}
+
+func (pg *agendasPage) onClose() {}
+
+// card lays out a titled card of rows.
+func (pg *agendasPage) card(gtx C, title string, rows ...layout.Widget) D {
+	return pg.common.theme.Card().Layout(gtx, func(gtx C) D {
+		gtx.Constraints.Min.X = gtx.Constraints.Max.X
+		return Container{layout.UniformInset(values.MarginPadding15)}.Layout(gtx, func(gtx C) D {
+			children := []layout.FlexChild{
+				layout.Rigid(func(gtx C) D {
+					return layout.Inset{Bottom: values.MarginPadding10}.Layout(gtx, pg.common.theme.Body1(title).Layout)
+				}),
+			}
+			for _, row := range rows {
+				children = append(children, layout.Rigid(row))
+			}
+			return layout.Flex{Axis: layout.Vertical}.Layout(gtx, children...)
+		})
+	})
+}
+
+// note lays out a gray caption.
+func (pg *agendasPage) note(gtx C, text string) D {
+	txt := pg.common.theme.Caption(text)
+	txt.Color = pg.common.theme.Color.Gray2
+	return layout.Inset{Bottom: values.MarginPadding10}.Layout(gtx, txt.Layout)
+}
+
+func (pg *agendasPage) statusCard(gtx C) D {
+	common := pg.common
+	if len(pg.statuses) == 0 {
+		return pg.card(gtx, values.String(values.StrRecordedByVSPs), func(gtx C) D {
+			return pg.note(gtx, values.String(values.StrNoTicketsAtVSP))
+		})
+	}
+
+	var rows []layout.Widget
+	outdated := false
+	for _, status := range pg.statuses {
+		status := status
+		outdated = outdated || status.Recorded < status.Tickets
+		rows = append(rows, func(gtx C) D {
+			return layout.Inset{Bottom: values.MarginPadding10}.Layout(gtx, func(gtx C) D {
+				host := common.theme.Body2(status.Host)
+				host.Color = common.theme.Color.Gray3
+				recorded := common.theme.Body1(values.StringF(values.StrRecordedTickets, status.Recorded, status.Tickets))
+				recorded.Color = common.theme.Color.Success
+				if status.Recorded < status.Tickets {
+					recorded.Color = common.theme.Color.Danger
+				}
+				return endToEndRow(gtx, host.Layout, recorded.Layout)
+			})
+		})
+	}
+	if outdated {
+		rows = append(rows, func(gtx C) D {
+			return pg.note(gtx, values.String(values.StrSendVoteChoicesNote))
+		})
+	}
+	return pg.card(gtx, values.String(values.StrRecordedByVSPs), rows...)
+}
+
+func (pg *agendasPage) agendaCard(gtx C, i int) D {
+	common, agenda := pg.common, pg.agendas[i]
+	title := agenda.ID
+	var rows []layout.Widget
+	if agenda.TreasuryKey {
+		title = values.String(values.StrTreasurySpends)
+		rows = append(rows, func(gtx C) D {
+			return pg.note(gtx, values.StringF(values.StrTreasuryKeyValue, shortHash(agenda.ID)))
+		})
+	} else {
+		rows = append(rows, func(gtx C) D {
+			return pg.note(gtx, agenda.Description)
+		})
+		rows = append(rows, func(gtx C) D {
+			period := values.StringF(values.StrDateRange, values.FormatDate(agenda.StartTime), values.FormatDate(agenda.ExpireTime))
+			return layout.Inset{Bottom: values.MarginPadding10}.Layout(gtx, func(gtx C) D {
+				label := common.theme.Body2(values.String(values.StrVotingPeriod))
+				label.Color = common.theme.Color.Gray3
+				return endToEndRow(gtx, label.Layout, common.theme.Body1(period).Layout)
+			})
+		})
+	}
+	for _, choice := range agenda.Choices {
+		choice := choice
+		rows = append(rows, func(gtx C) D {
+			label := strings.Title(choice.ID) + ": " + choice.Description
+			return common.theme.RadioButton(pg.choiceGroups[i], choice.ID, label).Layout(gtx)
+		})
+	}
+	return pg.card(gtx, title, rows...)
+}
+
+func (pg *agendasPage) Layout(gtx C) D {
+	common := pg.common
+
+	body := func(gtx C) D {
+		page := SubPage{
+			title:      values.String(values.StrConsensusVotes),
+			backButton: pg.backButton,
+			back: func() {
+				common.changePage(PageTickets)
+			},
+			body: func(gtx C) D {
+				if pg.err != nil {
+					txt := common.theme.Body1(values.StringF(values.StrAgendasFailed, pg.err))
+					txt.Color = common.theme.Color.Danger
+					return txt.Layout(gtx)
+				}
+
+				sections := []layout.Widget{pg.statusCard}
+				for i := range pg.agendas {
+					i := i
+					sections = append(sections, func(gtx C) D {
+						return pg.agendaCard(gtx, i)
+					})
+				}
+				return layout.Stack{Alignment: layout.N}.Layout(gtx,
+					layout.Expanded(func(gtx C) D {
+						return layout.Inset{Top: values.MarginPadding60}.Layout(gtx, func(gtx C) D {
+							return pg.container.Layout(gtx, len(sections), func(gtx C, i int) D {
+								return layout.Inset{Bottom: values.MarginPadding10}.Layout(gtx, sections[i])
+							})
+						})
+					}),
+					layout.Stacked(func(gtx C) D {
+						gtx.Constraints.Min.X = gtx.Constraints.Max.X
+						return endToEndRow(gtx, pg.walletDropDown.Layout, func(gtx C) D {
+							if !pg.canPush() {
+								return layout.Dimensions{}
+							}
+							return pg.pushButton.Layout(gtx)
+						})
+					}),
+				)
+			},
+		}
+		return common.SubPageLayout(gtx, page)
+	}
+
+	return common.UniformPadding(gtx, body)
+}
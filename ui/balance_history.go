@@ -0,0 +1,277 @@
+package ui
+
+import (
+	"sort"
+	"strconv"
+	"time"
+
+	"gioui.org/layout"
+	"gioui.org/widget"
+
+	"github.com/planetdecred/dcrlibwallet"
+	"github.com/planetdecred/godcr/ui/decredmaterial"
+	"github.com/planetdecred/godcr/ui/values"
+	"github.com/planetdecred/godcr/wallet"
+)
+
+// balanceRange is a period of balance history. A zero duration covers the
+// whole history.
+type balanceRange struct {
+	label    string
+	duration time.Duration
+}
+
+var balanceRanges = []balanceRange{
+	{values.StrOneWeek, 7 * 24 * time.Hour},
+	{values.StrOneMonth, 30 * 24 * time.Hour},
+	{values.StrOneYear, 365 * 24 * time.Hour},
+	{values.StrAllTime, 0},
+}
+
+// balanceChartNow returns the time the balance history ends at. The page
+// rendering specs replace it to render the same chart every day.
+var balanceChartNow = time.Now
+
+// balanceDelta returns how much a transaction changed the balance of its
+// wallet: the outputs paid to the wallet's accounts less the inputs spent
+// from them.
+func balanceDelta(tx dcrlibwallet.Transaction) int64 {
+	var delta int64
+	for _, input := range tx.Inputs {
+		if input.AccountNumber >= 0 {
+			delta -= input.Amount
+		}
+	}
+	for _, output := range tx.Outputs {
+		if output.AccountNumber >= 0 {
+			delta += output.Amount
+		}
+	}
+	return delta
+}
+
+// balanceHistory works back from the current balance through the
+// transactions to the balance after each transaction since the given time,
+// oldest first. The history starts with the balance at since, or before the
+// first transaction if since is zero, and ends with the balance at now.
+func balanceHistory(txs []dcrlibwallet.Transaction, balance int64, since, now time.Time) []decredmaterial.ChartPoint {
+	sorted := make([]dcrlibwallet.Transaction, len(txs))
+	copy(sorted, txs)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].Timestamp < sorted[j].Timestamp
+	})
+
+	start := since.Unix()
+	if since.IsZero() {
+		start = now.Unix()
+		if len(sorted) > 0 {
+			start = sorted[0].Timestamp
+		}
+	}
+
+	points := []decredmaterial.ChartPoint{{X: float64(now.Unix()), Y: float64(balance)}}
+	for i := len(sorted) - 1; i >= 0 && sorted[i].Timestamp >= start; i-- {
+		points = append(points, decredmaterial.ChartPoint{X: float64(sorted[i].Timestamp), Y: float64(balance)})
+		balance -= balanceDelta(sorted[i])
+	}
+	points = append(points, decredmaterial.ChartPoint{X: float64(start), Y: float64(balance)})
+
+	for i, j := 0, len(points)-1; i < j; i, j = i+1, j-1 {
+		points[i], points[j] = points[j], points[i]
+	}
+	return points
+}
+
+// balanceChart shows the balance history of one or all wallets on the
+// overview page.
+type balanceChart struct {
+	*pageCommon
+	wallets []*wallet.WalletSummary
+
+	chart          *decredmaterial.LineChart
+	walletDropDown *decredmaterial.DropDown
+	rangeButtons   []decredmaterial.Button
+	fiat           *widget.Bool
+
+	selectedRange int
+	txs           []dcrlibwallet.Transaction
+	balance       int64
+	usdRate       float64
+}
+
+func newBalanceChart(common *pageCommon, wallets []*wallet.WalletSummary) *balanceChart {
+	bc := &balanceChart{
+		pageCommon:    common,
+		wallets:       wallets,
+		chart:         common.theme.LineChart(),
+		fiat:          new(widget.Bool),
+		selectedRange: 1,
+	}
+	bc.chart.Step = true
+	bc.chart.Fill = true
+
+	items := []decredmaterial.DropDownItem{{Text: values.String(values.StrAllWallets), Icon: common.icons.walletIcon}}
+	for _, wal := range wallets {
+		items = append(items, decredmaterial.DropDownItem{Text: wal.Name, Icon: common.icons.walletIcon})
+	}
+	bc.walletDropDown = common.theme.DropDown(items, 4)
+
+	bc.rangeButtons = newRangeButtons(common.theme)
+	bc.setFormatters()
+	return bc
+}
+
+// load reads the balance and the transactions of the selected wallets.
+func (bc *balanceChart) load() {
+	bc.usdRate = 0
+	exchange := bc.wallet.ReadStringConfigValueForKey(dcrlibwallet.CurrencyConversionConfigKey)
+	if exchange == USDExchangeValue && bc.dcrUsdtBittrex.LastTradeRate != "" {
+		rate, err := strconv.ParseFloat(bc.dcrUsdtBittrex.LastTradeRate, 64)
+		if err == nil {
+			bc.usdRate = rate
+		}
+	}
+
+	var (
+		txs []dcrlibwallet.Transaction
+		err error
+	)
+	walletID := -1
+	if index := bc.walletDropDown.SelectedIndex(); index > 0 {
+		walletID = bc.wallets[index-1].ID
+		txs, err = bc.wallet.GetWalletTransactionsRaw(walletID, 0, 0, dcrlibwallet.TxFilterAll, false)
+	} else {
+		txs, err = bc.wallet.GetTransactionsRaw(0, 0, dcrlibwallet.TxFilterAll, false)
+	}
+	if err != nil {
+		log.Error("Error getting transactions for balance history:", err)
+		return
+	}
+
+	var balance int64
+	for _, info := range bc.info.Wallets {
+		if walletID != -1 && info.ID != walletID {
+			continue
+		}
+		for _, account := range info.Accounts {
+			balance += account.Balance.Total
+		}
+	}
+
+	bc.txs, bc.balance = txs, balance
+	bc.updatePoints()
+}
+
+func (bc *balanceChart) updatePoints() {
+	var since time.Time
+	now := balanceChartNow()
+	if duration := balanceRanges[bc.selectedRange].duration; duration > 0 {
+		since = now.Add(-duration)
+	}
+	bc.chart.Series = []decredmaterial.ChartSeries{{
+		Points: balanceHistory(bc.txs, bc.balance, since, now),
+	}}
+}
+
+// setFormatters sets the chart labels, which include the fiat value at the
+// current exchange rate when the fiat overlay is on.
+func (bc *balanceChart) setFormatters() {
+	amount := func(atoms float64) string {
+		text := wallet.FormatAmount(int64(atoms))
+		if bc.fiat.Value && bc.usdRate > 0 {
+			text += " (" + formatUSDBalance(atoms/1e8*bc.usdRate) + ")"
+		}
+		return text
+	}
+
+	bc.chart.YLabel = amount
+	bc.chart.XLabel = func(x float64) string {
+		return values.FormatDate(time.Unix(int64(x), 0))
+	}
+	bc.chart.Tooltip = func(_ int, p decredmaterial.ChartPoint) string {
+		return values.FormatDateTime(time.Unix(int64(p.X), 0)) + "\n" + amount(p.Y)
+	}
+}
+
+func (bc *balanceChart) handle() {
+	if bc.walletDropDown.Changed() {
+		bc.load()
+	}
+
+	for i := range bc.rangeButtons {
+		for bc.rangeButtons[i].Button.Clicked() {
+			bc.selectedRange = i
+			bc.updatePoints()
+		}
+	}
+}
+
+func (bc *balanceChart) layoutRanges(gtx C) D {
+	return layoutRangeButtons(gtx, bc.theme, bc.rangeButtons, bc.selectedRange)
+}
+
+// newRangeButtons returns a button for each of the balanceRanges.
+func newRangeButtons(theme *decredmaterial.Theme) []decredmaterial.Button {
+	var buttons []decredmaterial.Button
+	for _, r := range balanceRanges {
+		button := theme.Button(new(widget.Clickable), values.String(r.label))
+		button.TextSize = values.TextSize12
+		button.Inset = layout.UniformInset(values.MarginPadding5)
+		buttons = append(buttons, button)
+	}
+	return buttons
+}
+
+// layoutRangeButtons lays out a row of range buttons with the selected one
+// highlighted.
+func layoutRangeButtons(gtx C, theme *decredmaterial.Theme, buttons []decredmaterial.Button, selected int) D {
+	var children []layout.FlexChild
+	for i := range buttons {
+		button := buttons[i]
+		button.Background = theme.Color.Surface
+		button.Color = theme.Color.Gray
+		if i == selected {
+			button.Background = theme.Color.Primary
+			button.Color = theme.Color.InvText
+		}
+		children = append(children, layout.Rigid(func(gtx C) D {
+			return layout.Inset{Left: values.MarginPadding5}.Layout(gtx, button.Layout)
+		}))
+	}
+	return layout.Flex{Alignment: layout.Middle}.Layout(gtx, children...)
+}
+
+// layout lays out the balance history card.
+func (bc *balanceChart) layout(gtx C) D {
+	bc.handle()
+
+	return bc.theme.Card().Layout(gtx, func(gtx C) D {
+		return Container{layout.UniformInset(values.MarginPadding15)}.Layout(gtx, func(gtx C) D {
+			return layout.Flex{Axis: layout.Vertical}.Layout(gtx,
+				layout.Rigid(func(gtx C) D {
+					title := bc.theme.Body2(values.String(values.StrBalanceHistory))
+					title.Color = bc.theme.Color.Gray3
+					return endToEndRow(gtx, title.Layout, bc.walletDropDown.Layout)
+				}),
+				layout.Rigid(func(gtx C) D {
+					if len(bc.txs) == 0 {
+						message := bc.theme.Body1(values.String(values.StrNoTransactionsYet))
+						message.Color = bc.theme.Color.Gray2
+						return layout.Inset{Top: values.MarginPadding10}.Layout(gtx, message.Layout)
+					}
+					return layout.Inset{Top: values.MarginPadding10, Bottom: values.MarginPadding10}.Layout(gtx, bc.chart.Layout)
+				}),
+				layout.Rigid(func(gtx C) D {
+					if len(bc.txs) == 0 {
+						return D{}
+					}
+					if bc.usdRate == 0 {
+						return bc.layoutRanges(gtx)
+					}
+					fiat := bc.theme.CheckBox(bc.fiat, values.String(values.StrShowFiatValue))
+					return endToEndRow(gtx, fiat.Layout, bc.layoutRanges)
+				}),
+			)
+		})
+	})
+}
@@ -0,0 +1,54 @@
+package ui
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/planetdecred/dcrlibwallet"
+	"github.com/planetdecred/godcr/ui/decredmaterial"
+)
+
+var _ = Describe("Balance history", func() {
+	now := time.Unix(1600000000, 0)
+	day := int64(24 * 60 * 60)
+
+	received := dcrlibwallet.Transaction{
+		Timestamp: now.Unix() - 10*day,
+		Inputs:    []*dcrlibwallet.TxInput{{Amount: 510, AccountNumber: -1}},
+		Outputs:   []*dcrlibwallet.TxOutput{{Amount: 500, AccountNumber: 0}},
+	}
+	sent := dcrlibwallet.Transaction{
+		Timestamp: now.Unix() - 2*day,
+		Inputs:    []*dcrlibwallet.TxInput{{Amount: 500, AccountNumber: 0}},
+		Outputs: []*dcrlibwallet.TxOutput{
+			{Amount: 200, AccountNumber: -1},
+			{Amount: 290, AccountNumber: 0},
+		},
+	}
+
+	It("counts only the wallet's inputs and outputs", func() {
+		Expect(balanceDelta(received)).To(Equal(int64(500)))
+		Expect(balanceDelta(sent)).To(Equal(int64(-210)))
+	})
+
+	It("works back from the current balance", func() {
+		points := balanceHistory([]dcrlibwallet.Transaction{sent, received}, 290, time.Time{}, now)
+		Expect(points).To(Equal([]decredmaterial.ChartPoint{
+			{X: float64(received.Timestamp), Y: 0},
+			{X: float64(received.Timestamp), Y: 500},
+			{X: float64(sent.Timestamp), Y: 290},
+			{X: float64(now.Unix()), Y: 290},
+		}))
+	})
+
+	It("starts at the balance at the start of the range", func() {
+		points := balanceHistory([]dcrlibwallet.Transaction{sent, received}, 290, now.Add(-7*24*time.Hour), now)
+		Expect(points).To(Equal([]decredmaterial.ChartPoint{
+			{X: float64(now.Unix() - 7*day), Y: 500},
+			{X: float64(sent.Timestamp), Y: 290},
+			{X: float64(now.Unix()), Y: 290},
+		}))
+	})
+})
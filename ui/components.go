@@ -12,7 +12,6 @@ import (
 	"gioui.org/layout"
 	"gioui.org/text"
 	"gioui.org/unit"
-	"github.com/decred/dcrd/dcrutil"
 	"github.com/planetdecred/dcrlibwallet"
 	"github.com/planetdecred/godcr/ui/decredmaterial"
 	"github.com/planetdecred/godcr/ui/values"
@@ -42,7 +41,7 @@ type (
 // balance at the baseline of the row.
 func (page *pageCommon) layoutBalance(gtx layout.Context, amount string, isSwitchColor bool) layout.Dimensions {
 	// todo: make "DCR" symbols small when there are no decimals in the balance
-	mainText, subText := breakBalance(page.printer, amount)
+	mainText, subText := breakBalance(amount)
 	return layout.Flex{Axis: layout.Horizontal, Alignment: layout.Baseline}.Layout(gtx,
 		layout.Rigid(func(gtx C) D {
 			label := page.theme.Label(values.TextSize20, mainText)
@@ -79,7 +78,7 @@ func transactionRow(gtx layout.Context, common *pageCommon, row TransactionRow)
 		directionIconTopMargin = values.MarginPadding0
 	}
 
-	wal := common.multiWallet.WalletWithID(row.transaction.WalletID)
+	wal := common.wallet.WalletWithID(row.transaction.WalletID)
 
 	return layout.Inset{Top: values.MarginPadding16}.Layout(gtx, func(gtx C) D {
 		return layout.Flex{Axis: layout.Horizontal}.Layout(gtx,
@@ -127,7 +126,7 @@ func transactionRow(gtx layout.Context, common *pageCommon, row TransactionRow)
 									return layout.Inset{Left: values.MarginPadding16}.Layout(gtx, func(gtx C) D {
 										return layout.Flex{Axis: layout.Vertical}.Layout(gtx,
 											layout.Rigid(func(gtx C) D {
-												return common.layoutBalance(gtx, dcrutil.Amount(row.transaction.Amount).String(), true)
+												return common.layoutBalance(gtx, wallet.FormatAmount(row.transaction.Amount), true)
 											}),
 											layout.Rigid(func(gtx C) D {
 												if row.showBadge {
@@ -178,7 +177,8 @@ func transactionRow(gtx layout.Context, common *pageCommon, row TransactionRow)
 func txConfirmations(common *pageCommon, transaction dcrlibwallet.Transaction) int32 {
 	if transaction.BlockHeight != -1 {
 		// TODO
-		return (common.multiWallet.WalletWithID(transaction.WalletID).GetBestBlock() - transaction.BlockHeight) + 1
+		bestBlock, _ := common.wallet.WalletBestBlock(transaction.WalletID)
+		return (bestBlock - transaction.BlockHeight) + 1
 	}
 
 	return 0
@@ -240,7 +240,7 @@ func (page *pageCommon) initSelectAccountWidget(wallAcct map[int][]walletAccount
 					evt:          &gesture.Click{},
 					accountName:  accounts[aindex].Name,
 					totalBalance: accounts[aindex].TotalBalance,
-					spendable:    dcrutil.Amount(accounts[aindex].SpendableBalance).String(),
+					spendable:    wallet.FormatAmount(accounts[aindex].SpendableBalance),
 					number:       accounts[aindex].Number,
 				}
 			}
@@ -425,12 +425,21 @@ func ticketCard(gtx layout.Context, c *pageCommon, t *wallet.Ticket, tooltip *de
 									}),
 								)
 							}),
+							layout.Rigid(func(gtx C) D {
+								text, col, ok := vspStatusText(c, *t)
+								if !ok {
+									return layout.Dimensions{}
+								}
+								txt := c.theme.Label(values.TextSize12, text)
+								txt.Color = col
+								return layout.Inset{Top: values.MarginPadding4}.Layout(gtx, txt.Layout)
+							}),
 							layout.Rigid(func(gtx C) D {
 								return layout.Inset{
 									Top:    values.MarginPadding16,
 									Bottom: values.MarginPadding16,
 								}.Layout(gtx, func(gtx C) D {
-									txt := c.theme.Label(values.TextSize14, t.MonthDay)
+									txt := c.theme.Label(values.TextSize14, values.FormatShortDate(time.Unix(t.Info.Ticket.Timestamp, 0)))
 									txt.Color = c.theme.Color.Gray2
 									return layout.Flex{Alignment: layout.Middle}.Layout(gtx,
 										layout.Rigid(func(gtx C) D {
@@ -585,7 +594,7 @@ func (page *pageCommon) handleToast() {
 
 // createOrUpdateWalletDropDown check for len of wallets to create dropDown,
 // also update the list when create, update, delete a wallet.
-func (page *pageCommon) createOrUpdateWalletDropDown(dwn **decredmaterial.DropDown, wallets []*dcrlibwallet.Wallet) {
+func (page *pageCommon) createOrUpdateWalletDropDown(dwn **decredmaterial.DropDown, wallets []*wallet.WalletSummary) {
 	var walletDropDownItems []decredmaterial.DropDownItem
 	for _, wal := range wallets {
 		item := decredmaterial.DropDownItem{
@@ -0,0 +1,137 @@
+package ui
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"runtime/debug"
+
+	"gioui.org/app"
+	"gioui.org/layout"
+	"gioui.org/widget"
+
+	"github.com/planetdecred/godcr/crash"
+	"github.com/planetdecred/godcr/ui/decredmaterial"
+	"github.com/planetdecred/godcr/ui/values"
+)
+
+// crashDialog offers to restart the app after a recovered panic. A panic in
+// the window loop is fatal: the page that panicked isn't shown again.
+type crashDialog struct {
+	report crash.Report
+	fatal  bool
+
+	modal   *decredmaterial.Modal
+	restart decredmaterial.Button
+	quit    decredmaterial.Button
+	dismiss decredmaterial.Button
+}
+
+// recoverPanic reports a panic recovered in the window loop and shows the
+// crash dialog in place of the page.
+func (win *Window) recoverPanic(r interface{}) {
+	source := fmt.Sprintf("ui %T", win.currentPage)
+	if mp, ok := win.currentPage.(*mainPage); ok {
+		source = "ui page " + mp.current
+	}
+	win.showCrash(crash.Write(source, r, debug.Stack()), true)
+}
+
+// showCrash shows the crash dialog for report. A dialog for a fatal panic is
+// kept over later reports.
+func (win *Window) showCrash(report crash.Report, fatal bool) {
+	if win.crash != nil && (win.crash.fatal || win.crash.report.Time.Equal(report.Time)) {
+		return
+	}
+
+	d := &crashDialog{
+		report:  report,
+		fatal:   fatal,
+		modal:   win.theme.Modal(),
+		restart: win.theme.Button(new(widget.Clickable), values.String(values.StrRestart)),
+		quit:    win.theme.Button(new(widget.Clickable), values.String(values.StrQuit)),
+		dismiss: win.theme.Button(new(widget.Clickable), values.String(values.StrDismiss)),
+	}
+	d.quit.Background = win.theme.Color.Danger
+	d.dismiss.Background = win.theme.Color.Gray
+	win.crash = d
+}
+
+// restartApp starts a new instance of the app with the same arguments.
+func restartApp() error {
+	exe, err := os.Executable()
+	if err != nil {
+		return err
+	}
+
+	cmd := exec.Command(exe, os.Args[1:]...)
+	cmd.Stdin, cmd.Stdout, cmd.Stderr = os.Stdin, os.Stdout, os.Stderr
+	return cmd.Start()
+}
+
+func (win *Window) handleCrash(w *app.Window) {
+	d := win.crash
+	for d.restart.Button.Clicked() {
+		// the wallet database must be closed before the new instance
+		// opens it
+		win.wallet.Shutdown()
+		if err := restartApp(); err != nil {
+			log.Error(values.StringF(values.StrRestartFailed, err))
+			os.Exit(1)
+		}
+		os.Exit(0)
+	}
+
+	for d.quit.Button.Clicked() {
+		w.Close()
+	}
+
+	for d.dismiss.Button.Clicked() {
+		if !d.fatal {
+			win.crash = nil
+		}
+	}
+}
+
+func (win *Window) layoutCrash(gtx C, w *app.Window) D {
+	win.handleCrash(w)
+	d := win.crash
+	if d == nil {
+		return D{}
+	}
+
+	message := values.StringF(values.StrCrashReportSaved, d.report.Source, d.report.Path)
+	if d.report.Path == "" {
+		message = values.StringF(values.StrCrashReportNotSaved, d.report.Source)
+	}
+
+	buttons := []layout.FlexChild{
+		layout.Rigid(d.restart.Layout),
+		layout.Rigid(func(gtx C) D {
+			return layout.Inset{Left: values.MarginPadding10}.Layout(gtx, d.quit.Layout)
+		}),
+	}
+	if !d.fatal {
+		buttons = append(buttons, layout.Rigid(func(gtx C) D {
+			return layout.Inset{Left: values.MarginPadding10}.Layout(gtx, d.dismiss.Layout)
+		}))
+	}
+
+	widgets := []layout.Widget{
+		win.theme.H6(values.String(values.StrCrashTitle)).Layout,
+		win.theme.Body1(message).Layout,
+		func(gtx C) D {
+			panicText := win.theme.Body2(d.report.Panic)
+			panicText.Color = win.theme.Color.Gray
+			return panicText.Layout(gtx)
+		},
+		func(gtx C) D {
+			return layout.Inset{Top: values.MarginPadding10}.Layout(gtx, func(gtx C) D {
+				return layout.E.Layout(gtx, func(gtx C) D {
+					return layout.Flex{}.Layout(gtx, buttons...)
+				})
+			})
+		},
+	}
+	return d.modal.Layout(gtx, widgets, 850)
+}
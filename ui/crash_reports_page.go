@@ -0,0 +1,106 @@
+package ui
+
+import (
+	"gioui.org/layout"
+
+	"github.com/planetdecred/godcr/crash"
+	"github.com/planetdecred/godcr/ui/decredmaterial"
+	"github.com/planetdecred/godcr/ui/values"
+)
+
+const PageCrashReports = "CrashReports"
+
+type crashReportsPage struct {
+	theme  *decredmaterial.Theme
+	common *pageCommon
+
+	backButton decredmaterial.IconButton
+	list       layout.List
+	reports    []crash.Report
+}
+
+func CrashReportsPage(common *pageCommon) Page {
+	pg := &crashReportsPage{
+		theme:  common.theme,
+		common: common,
+		list:   layout.List{Axis: layout.Vertical},
+	}
+
+	pg.backButton, _ = common.SubPageHeaderButtons()
+
+	return pg
+}
+
+func (pg *crashReportsPage) OnResume() {
+	reports, err := crash.Reports()
+	if err != nil {
+		log.Errorf("Could not read crash reports: %v", err)
+	}
+	pg.reports = reports
+}
+
+func (pg *crashReportsPage) handle()  {}
+func (pg *crashReportsPage) onClose() {}
+
+func (pg *crashReportsPage) layoutReport(gtx C, report crash.Report) D {
+	return layout.UniformInset(values.MarginPadding15).Layout(gtx, func(gtx C) D {
+		return layout.Flex{Axis: layout.Vertical}.Layout(gtx,
+			layout.Rigid(func(gtx C) D {
+				return endToEndRow(gtx,
+					pg.theme.Body1(report.Source).Layout,
+					pg.theme.Body2(values.FormatDateTime(report.Time)).Layout)
+			}),
+			layout.Rigid(func(gtx C) D {
+				label := pg.theme.Body2(report.Panic)
+				label.Color = pg.theme.Color.Danger
+				return label.Layout(gtx)
+			}),
+			layout.Rigid(func(gtx C) D {
+				label := pg.theme.Caption(report.Path)
+				label.Color = pg.theme.Color.Gray
+				return label.Layout(gtx)
+			}),
+		)
+	})
+}
+
+func (pg *crashReportsPage) layoutReports(gtx C) D {
+	card := pg.theme.Card()
+	card.Color = pg.theme.Color.Surface
+	return card.Layout(gtx, func(gtx C) D {
+		gtx.Constraints.Min.X = gtx.Constraints.Max.X
+		if len(pg.reports) == 0 {
+			return layout.UniformInset(values.MarginPadding15).Layout(gtx,
+				pg.theme.Body1(values.String(values.StrNoCrashReports)).Layout)
+		}
+
+		return pg.list.Layout(gtx, len(pg.reports), func(gtx C, i int) D {
+			return layout.Flex{Axis: layout.Vertical}.Layout(gtx,
+				layout.Rigid(func(gtx C) D {
+					if i == 0 {
+						return D{}
+					}
+					return pg.theme.Separator().Layout(gtx)
+				}),
+				layout.Rigid(func(gtx C) D {
+					return pg.layoutReport(gtx, pg.reports[i])
+				}),
+			)
+		})
+	})
+}
+
+func (pg *crashReportsPage) Layout(gtx C) D {
+	container := func(gtx C) D {
+		page := SubPage{
+			title:      values.String(values.StrCrashReports),
+			backButton: pg.backButton,
+			back: func() {
+				pg.common.changePage(PageDebug)
+			},
+			body: pg.layoutReports,
+		}
+		return pg.common.SubPageLayout(gtx, page)
+	}
+	return pg.common.UniformPadding(gtx, container)
+}
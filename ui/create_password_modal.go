@@ -83,6 +83,18 @@ func (cm *createPasswordModal) Dismiss() {
 	cm.dismissModal(cm)
 }
 
+func (cm *createPasswordModal) cancelButton() *widget.Clickable {
+	return cm.btnNegative.Button
+}
+
+func (cm *createPasswordModal) focusTargets() []focusTarget {
+	editors := []*widget.Editor{cm.passwordEditor.Editor, cm.confirmPasswordEditor.Editor}
+	if cm.walletNameEnabled {
+		editors = append([]*widget.Editor{cm.walletName.Editor}, editors...)
+	}
+	return append(editorTargets(editors...), buttonTargets(cm.btnNegative.Button, cm.btnPositve.Button)...)
+}
+
 func (cm *createPasswordModal) title(title string) *createPasswordModal {
 	cm.dialogTitle = title
 	return cm
@@ -97,7 +97,7 @@ func CreateRestorePage(common *pageCommon) Page {
 		},
 	}
 
-	if pg.common.multiWallet.LoadedWalletsCount() == 0 {
+	if pg.common.wallet.LoadedWalletsCount() == 0 {
 		pg.walletName.Editor.SetText("mywallet")
 	}
 
@@ -629,7 +629,7 @@ func (pg *createRestore) handle() {
 
 		go func() {
 			pg.restoringWallet = true
-			_, err := pg.common.multiWallet.RestoreWallet(walletName, pg.seedPhrase, pass, dcrlibwallet.PassphraseTypePass)
+			_, err := pg.common.wallet.RestoreWallet(walletName, pg.seedPhrase, pass)
 			pg.restoringWallet = false
 			if err != nil {
 				pg.errLabel.Text = translateErr(err)
@@ -641,7 +641,7 @@ func (pg *createRestore) handle() {
 
 			// Go back to wallets page if there's more than one wallet
 			// or launch main page.
-			if pg.common.multiWallet.LoadedWalletsCount() > 1 {
+			if pg.common.wallet.LoadedWalletsCount() > 1 {
 				pg.common.popWindowPage()
 			} else {
 				pg.common.wallet.SetupListeners()
@@ -72,6 +72,15 @@ func (cm *createWatchOnlyModal) Dismiss() {
 	cm.dismissModal(cm)
 }
 
+func (cm *createWatchOnlyModal) cancelButton() *widget.Clickable {
+	return cm.btnNegative.Button
+}
+
+func (cm *createWatchOnlyModal) focusTargets() []focusTarget {
+	return append(editorTargets(cm.walletName.Editor, cm.extendedPubKey.Editor),
+		buttonTargets(cm.btnNegative.Button, cm.btnPositve.Button)...)
+}
+
 func (cm *createWatchOnlyModal) setLoading(loading bool) {
 	cm.isLoading = loading
 }
@@ -1,6 +1,12 @@
 package ui
 
 import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
 	"gioui.org/layout"
 	"gioui.org/widget"
 
@@ -22,6 +28,14 @@ type debugPage struct {
 	common     *pageCommon
 
 	backButton decredmaterial.IconButton
+
+	subsystems     []string
+	levelDropDowns map[string]*decredmaterial.DropDown
+	levelsList     layout.List
+	debugLevel     decredmaterial.Editor
+	applyButton    decredmaterial.Button
+	saveToConfig   *widget.Bool
+	bundleButton   decredmaterial.Button
 }
 
 func DebugPage(common *pageCommon) Page {
@@ -36,21 +50,87 @@ func DebugPage(common *pageCommon) Page {
 			text:      "Check statistics",
 			page:      PageStat,
 		},
+		{
+			clickable: new(widget.Clickable),
+			text:      values.String(values.StrCrashReports),
+			page:      PageCrashReports,
+		},
 	}
 
 	pg := &debugPage{
-		theme:      common.theme,
-		debugItems: debugItems,
-		common:     common,
+		theme:          common.theme,
+		debugItems:     debugItems,
+		common:         common,
+		subsystems:     sortedSubsystems(),
+		levelDropDowns: make(map[string]*decredmaterial.DropDown),
+		levelsList:     layout.List{Axis: layout.Vertical},
+		saveToConfig:   new(widget.Bool),
 	}
 
 	pg.backButton, _ = common.SubPageHeaderButtons()
 
+	for _, subsystem := range pg.subsystems {
+		items := make([]decredmaterial.DropDownItem, len(debugLevelNames))
+		for i, name := range debugLevelNames {
+			items[i] = decredmaterial.DropDownItem{Text: name}
+		}
+		pg.levelDropDowns[subsystem] = common.theme.DropDown(items, 3)
+	}
+
+	pg.debugLevel = common.theme.Editor(&widget.Editor{SingleLine: true, Submit: true}, values.String(values.StrDebugLevelHint))
+	pg.applyButton = common.theme.Button(new(widget.Clickable), values.String(values.StrApply))
+	pg.applyButton.TextSize = values.TextSize14
+
+	pg.bundleButton = common.theme.Button(new(widget.Clickable), values.String(values.StrCreateDiagnosticsBundle))
+	pg.bundleButton.TextSize = values.TextSize14
+
 	return pg
 }
 
 func (pg *debugPage) OnResume() {
+	pg.showLogLevels()
+}
+
+// showLogLevels selects the current level of each subsystem logger.
+func (pg *debugPage) showLogLevels() {
+	for subsystem, dropDown := range pg.levelDropDowns {
+		dropDown.SetSelectedIndex(int(subsystemLoggers[subsystem].Level()))
+	}
+}
+
+// applyDebugLevel sets the log levels in debugLevel, which has the syntax of
+// the debuglevel option, and saves them to the config file if asked to.
+func (pg *debugPage) applyDebugLevel(debugLevel string) {
+	if err := setDebugLevel(debugLevel); err != nil {
+		pg.common.notify(err.Error(), false)
+		pg.showLogLevels()
+		return
+	}
+	log.Infof("Log levels set to %s", debugLevel)
+	pg.showLogLevels()
+
+	if !pg.saveToConfig.Value {
+		return
+	}
+	if err := saveDebugLevel(currentDebugLevel()); err != nil {
+		pg.common.notify(values.StringF(values.StrLogLevelsSaveFailed, err), false)
+	}
+}
 
+// createDiagnosticsBundle saves a diagnostics bundle next to the log file.
+func (pg *debugPage) createDiagnosticsBundle(diag diagnostics) {
+	dir := filepath.Dir(logFile)
+	if logFile == "" {
+		dir = pg.common.wallet.WalletDirectory()
+	}
+
+	name := filepath.Join(dir, fmt.Sprintf("godcr-diagnostics-%s.zip", time.Now().Format("20060102-150405")))
+	if err := writeDiagnosticsBundle(name, pg.common.wallet, diag); err != nil {
+		os.Remove(name)
+		pg.common.notify(values.StringF(values.StrDiagnosticsBundleFailed, err), false)
+		return
+	}
+	pg.common.notify(values.StringF(values.StrDiagnosticsBundleSaved, name), true)
 }
 
 func (pg *debugPage) handle() {
@@ -59,6 +139,27 @@ func (pg *debugPage) handle() {
 			pg.common.changePage(pg.debugItems[i].page)
 		}
 	}
+
+	for _, subsystem := range pg.subsystems {
+		dropDown := pg.levelDropDowns[subsystem]
+		for dropDown.Changed() {
+			pg.applyDebugLevel(subsystem + "=" + dropDown.Selected())
+		}
+	}
+
+	for pg.bundleButton.Button.Clicked() {
+		go pg.createDiagnosticsBundle(collectDiagnostics(pg.common))
+	}
+
+	apply := pg.applyButton.Button.Clicked()
+	for _, evt := range pg.debugLevel.Editor.Events() {
+		if _, ok := evt.(widget.SubmitEvent); ok {
+			apply = true
+		}
+	}
+	if debugLevel := strings.TrimSpace(pg.debugLevel.Editor.Text()); apply && debugLevel != "" {
+		pg.applyDebugLevel(debugLevel)
+	}
 }
 
 func (pg *debugPage) onClose() {}
@@ -80,11 +181,11 @@ func (pg *debugPage) debugItem(gtx C, i int, common *pageCommon) D {
 	})
 }
 
-func (pg *debugPage) layoutDebugItems(gtx C, common *pageCommon) {
+func (pg *debugPage) layoutDebugItems(gtx C, common *pageCommon) D {
 	background := common.theme.Color.Surface
 	card := common.theme.Card()
 	card.Color = background
-	card.Layout(gtx, func(gtx C) D {
+	return card.Layout(gtx, func(gtx C) D {
 		list := layout.List{Axis: layout.Vertical}
 		return list.Layout(gtx, len(pg.debugItems), func(gtx C, i int) D {
 			return pg.debugItem(gtx, i, common)
@@ -101,8 +202,18 @@ func (pg *debugPage) Layout(gtx C) D {
 				pg.common.changePage(PageMore)
 			},
 			body: func(gtx C) D {
-				pg.layoutDebugItems(gtx, pg.common)
-				return layout.Dimensions{Size: gtx.Constraints.Max}
+				children := []layout.FlexChild{
+					layout.Rigid(func(gtx C) D {
+						return pg.layoutDebugItems(gtx, pg.common)
+					}),
+					layout.Rigid(func(gtx C) D {
+						return layout.Inset{Top: values.MarginPadding15}.Layout(gtx, pg.bundleButton.Layout)
+					}),
+				}
+				if len(pg.subsystems) > 0 {
+					children = append(children, layout.Flexed(1, pg.layoutLogLevels))
+				}
+				return layout.Flex{Axis: layout.Vertical}.Layout(gtx, children...)
 			},
 		}
 		return pg.common.SubPageLayout(gtx, page)
@@ -110,3 +221,46 @@ func (pg *debugPage) Layout(gtx C) D {
 	}
 	return pg.common.UniformPadding(gtx, container)
 }
+
+// layoutLogLevels lays out a level drop down for every subsystem logger
+// and an editor for the debuglevel option syntax.
+func (pg *debugPage) layoutLogLevels(gtx C) D {
+	card := pg.theme.Card()
+	card.Color = pg.theme.Color.Surface
+	return layout.Inset{Top: values.MarginPadding15}.Layout(gtx, func(gtx C) D {
+		return card.Layout(gtx, func(gtx C) D {
+			gtx.Constraints.Min = gtx.Constraints.Max
+			return layout.UniformInset(values.MarginPadding15).Layout(gtx, func(gtx C) D {
+				return layout.Flex{Axis: layout.Vertical}.Layout(gtx,
+					layout.Rigid(pg.theme.H6(values.String(values.StrLogLevels)).Layout),
+					layout.Rigid(func(gtx C) D {
+						return layout.Inset{Top: values.MarginPadding10, Bottom: values.MarginPadding10}.Layout(gtx, func(gtx C) D {
+							return layout.Flex{Alignment: layout.Middle}.Layout(gtx,
+								layout.Flexed(1, pg.debugLevel.Layout),
+								layout.Rigid(func(gtx C) D {
+									return layout.Inset{Left: values.MarginPadding10}.Layout(gtx, pg.applyButton.Layout)
+								}),
+								layout.Rigid(func(gtx C) D {
+									return layout.Inset{Left: values.MarginPadding10}.Layout(gtx,
+										pg.theme.CheckBox(pg.saveToConfig, values.String(values.StrSaveToConfigFile)).Layout)
+								}),
+							)
+						})
+					}),
+					layout.Flexed(1, func(gtx C) D {
+						return pg.levelsList.Layout(gtx, len(pg.subsystems), func(gtx C, i int) D {
+							subsystem := pg.subsystems[i]
+							return layout.Flex{Alignment: layout.Middle}.Layout(gtx,
+								layout.Rigid(func(gtx C) D {
+									gtx.Constraints.Min.X = gtx.Px(values.MarginPadding70)
+									return pg.theme.Body1(subsystem).Layout(gtx)
+								}),
+								layout.Rigid(pg.levelDropDowns[subsystem].Layout),
+							)
+						})
+					}),
+				)
+			})
+		})
+	})
+}
@@ -0,0 +1,151 @@
+package decredmaterial
+
+import (
+	"image"
+	"math"
+
+	"gioui.org/f32"
+	"gioui.org/op"
+	"gioui.org/op/clip"
+	"gioui.org/op/paint"
+	"gioui.org/unit"
+)
+
+// StackedAreaChart stacks series that share their X values, drawing each as
+// the area between its total and the total of the series below it. The
+// values at the X nearest to the pointer are shown in a tooltip.
+type StackedAreaChart struct {
+	Series []ChartSeries
+	Height unit.Value
+
+	// XLabel and YLabel format the axis labels and Tooltip the text shown for
+	// the hovered X, by the index of its points. Nil formatters hide the
+	// labels or the tooltip.
+	XLabel  func(x float64) string
+	YLabel  func(y float64) string
+	Tooltip func(index int) string
+
+	theme *Theme
+	hover chartHover
+}
+
+func (t *Theme) StackedAreaChart() *StackedAreaChart {
+	return &StackedAreaChart{
+		Height: chartHeight,
+		theme:  t,
+	}
+}
+
+// stack returns the running totals of the series at each of the X values
+// all series have.
+func (c *StackedAreaChart) stack() [][]ChartPoint {
+	count := math.MaxInt32
+	for _, s := range c.Series {
+		if len(s.Points) < count {
+			count = len(s.Points)
+		}
+	}
+	if len(c.Series) == 0 || count == 0 {
+		return nil
+	}
+
+	totals := make([][]ChartPoint, len(c.Series))
+	for n, s := range c.Series {
+		totals[n] = make([]ChartPoint, count)
+		for i, p := range s.Points[:count] {
+			totals[n][i] = ChartPoint{X: p.X, Y: p.Y}
+			if n > 0 {
+				totals[n][i].Y += totals[n-1][i].Y
+			}
+		}
+	}
+	return totals
+}
+
+// Layout draws the chart across the width of the constraints.
+func (c *StackedAreaChart) Layout(gtx C) D {
+	c.hover.update(gtx)
+
+	frame := chartFrame{theme: c.theme, height: c.Height, legend: c.Series}
+	totals := c.stack()
+	if totals == nil {
+		return frame.layout(gtx, func(gtx C, size image.Point) {})
+	}
+
+	count := len(totals[0])
+	minX, maxX := totals[0][0].X, totals[0][count-1].X
+	if maxX == minX {
+		minX, maxX = minX-1, maxX+1
+	}
+	var minY, maxY float64
+	for _, series := range totals {
+		for _, p := range series {
+			minY, maxY = math.Min(minY, p.Y), math.Max(maxY, p.Y)
+		}
+	}
+	minY, maxY = niceRange(minY, maxY)
+	frame.minY, frame.maxY, frame.yLabel = minY, maxY, c.YLabel
+	frame.xLabels = xAxisLabels(minX, maxX, c.XLabel)
+
+	return frame.layout(gtx, func(gtx C, size image.Point) {
+		project := func(p ChartPoint) f32.Point {
+			return f32.Point{
+				X: float32((p.X - minX) / (maxX - minX) * float64(size.X)),
+				Y: float32((maxY - p.Y) / (maxY - minY) * float64(size.Y)),
+			}
+		}
+
+		zero := float32((maxY - 0) / (maxY - minY) * float64(size.Y))
+		bottom := []f32.Point{{X: project(totals[0][0]).X, Y: zero}, {X: project(totals[0][count-1]).X, Y: zero}}
+		var tops [][]f32.Point
+		for n, series := range totals {
+			top := make([]f32.Point, count)
+			for i, p := range series {
+				top[i] = project(p)
+			}
+			tops = append(tops, top)
+
+			col := c.theme.chartColor(c.Series[n].Color, n)
+			drawnTop := decimate(top)
+			var area clip.Path
+			area.Begin(gtx.Ops)
+			area.MoveTo(drawnTop[0])
+			traceLine(&area, drawnTop, false)
+			for i := len(bottom) - 1; i >= 0; i-- {
+				area.LineTo(bottom[i])
+			}
+			area.Close()
+			paint.FillShape(gtx.Ops, withAlpha(col, 150), clip.Outline{Path: area.End()}.Op())
+
+			var line clip.Path
+			line.Begin(gtx.Ops)
+			line.MoveTo(drawnTop[0])
+			traceLine(&line, drawnTop, false)
+			paint.FillShape(gtx.Ops, col, clip.Stroke{
+				Path:  line.End(),
+				Style: clip.StrokeStyle{Width: float32(gtx.Px(chartLineWidth)) / 2},
+			}.Op())
+			bottom = drawnTop
+		}
+
+		c.hover.add(gtx, size)
+		if !c.hover.hovered {
+			return
+		}
+
+		index := nearest(tops[0], c.hover.pos.X)
+		x := tops[0][index].X
+		st := op.Save(gtx.Ops)
+		clip.Rect{Min: image.Pt(int(x), 0), Max: image.Pt(int(x)+1, size.Y)}.Add(gtx.Ops)
+		paint.Fill(gtx.Ops, c.theme.Color.Gray1)
+		st.Load()
+
+		for n, top := range tops {
+			layoutChartMarker(gtx, c.theme, top[index], c.theme.chartColor(c.Series[n].Color, n))
+		}
+
+		if c.Tooltip != nil {
+			layoutChartTooltip(gtx, c.theme, size, tops[len(tops)-1][index], c.Tooltip(index))
+		}
+	})
+}
@@ -0,0 +1,103 @@
+package decredmaterial
+
+import (
+	"image"
+	"image/color"
+	"math"
+
+	"gioui.org/f32"
+	"gioui.org/op/clip"
+	"gioui.org/op/paint"
+	"gioui.org/unit"
+)
+
+// ChartBar is a bar of a bar chart. A zero Color is replaced by the first
+// color of the theme's chart palette.
+type ChartBar struct {
+	Label string
+	Value float64
+	Color color.NRGBA
+}
+
+// BarChart draws a bar for each value, labelled on the x axis. Negative
+// values go below the zero line and the hovered bar is shown in a tooltip.
+type BarChart struct {
+	Bars   []ChartBar
+	Height unit.Value
+
+	// YLabel formats the axis labels and Tooltip the text shown for the
+	// hovered bar. Nil formatters hide the labels or the tooltip.
+	YLabel  func(y float64) string
+	Tooltip func(bar int) string
+
+	theme *Theme
+	hover chartHover
+}
+
+func (t *Theme) BarChart() *BarChart {
+	return &BarChart{
+		Height: chartHeight,
+		theme:  t,
+	}
+}
+
+// Layout draws the chart across the width of the constraints.
+func (c *BarChart) Layout(gtx C) D {
+	c.hover.update(gtx)
+
+	frame := chartFrame{theme: c.theme, height: c.Height}
+	if len(c.Bars) == 0 {
+		return frame.layout(gtx, func(gtx C, size image.Point) {})
+	}
+
+	var minY, maxY float64
+	for i, bar := range c.Bars {
+		minY, maxY = math.Min(minY, bar.Value), math.Max(maxY, bar.Value)
+		frame.xLabels = append(frame.xLabels, chartLabel{
+			at:   (float32(i) + 0.5) / float32(len(c.Bars)),
+			text: bar.Label,
+		})
+	}
+	minY, maxY = niceRange(minY, maxY)
+	frame.minY, frame.maxY, frame.yLabel = minY, maxY, c.YLabel
+
+	return frame.layout(gtx, func(gtx C, size image.Point) {
+		project := func(y float64) float32 {
+			return float32((maxY - y) / (maxY - minY) * float64(size.Y))
+		}
+		slot := float32(size.X) / float32(len(c.Bars))
+		width := slot * 0.6
+		zero := project(0)
+
+		hovered := -1
+		if c.hover.hovered {
+			hovered = int(c.hover.pos.X / slot)
+			if hovered >= len(c.Bars) {
+				hovered = len(c.Bars) - 1
+			}
+		}
+
+		for i, bar := range c.Bars {
+			col := c.theme.chartColor(bar.Color, 0)
+			if hovered != -1 && i != hovered {
+				col = withAlpha(col, 160)
+			}
+			left := slot*float32(i) + (slot-width)/2
+			top, bottom := project(bar.Value), zero
+			if top > bottom {
+				top, bottom = bottom, top
+			}
+			if top == bottom {
+				continue
+			}
+			rect := f32.Rect(left, top, left+width, bottom)
+			paint.FillShape(gtx.Ops, col, clip.UniformRRect(rect, float32(gtx.Px(unit.Dp(2)))).Op(gtx.Ops))
+		}
+
+		c.hover.add(gtx, size)
+		if hovered != -1 && c.Tooltip != nil {
+			anchor := f32.Point{X: slot*float32(hovered) + slot/2, Y: project(c.Bars[hovered].Value)}
+			layoutChartTooltip(gtx, c.theme, size, anchor, c.Tooltip(hovered))
+		}
+	})
+}
@@ -15,6 +15,7 @@ import (
 
 type Button struct {
 	material.ButtonStyle
+	theme *Theme
 }
 
 type IconButton struct {
@@ -22,7 +23,7 @@ type IconButton struct {
 }
 
 func (t *Theme) Button(button *widget.Clickable, txt string) Button {
-	return Button{material.Button(t.Base, button, txt)}
+	return Button{material.Button(t.Base, button, txt), t}
 }
 
 func (t *Theme) IconButton(button *widget.Clickable, icon *widget.Icon) IconButton {
@@ -40,7 +41,7 @@ func Clickable(gtx layout.Context, button *widget.Clickable, w layout.Widget) la
 }
 
 func (b Button) Layout(gtx layout.Context) layout.Dimensions {
-	return b.ButtonStyle.Layout(gtx)
+	return b.theme.layoutFocus(gtx, b.Button, b.ButtonStyle.Layout)
 }
 
 func (b IconButton) Layout(gtx layout.Context) layout.Dimensions {
@@ -68,6 +69,10 @@ func (t *Theme) TextAndIconButton(btn *widget.Clickable, text string, icon *widg
 }
 
 func (b TextAndIconButton) Layout(gtx layout.Context) layout.Dimensions {
+	return b.theme.layoutFocus(gtx, b.Button, b.layout)
+}
+
+func (b TextAndIconButton) layout(gtx layout.Context) layout.Dimensions {
 	btnLayout := material.ButtonLayout(b.theme.Base, b.Button)
 	btnLayout.Background = b.BackgroundColor
 	b.icon.Color = b.Color
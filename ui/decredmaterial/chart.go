@@ -0,0 +1,386 @@
+package decredmaterial
+
+import (
+	"image"
+	"image/color"
+	"math"
+	"sort"
+
+	"gioui.org/f32"
+	"gioui.org/io/pointer"
+	"gioui.org/layout"
+	"gioui.org/op"
+	"gioui.org/op/clip"
+	"gioui.org/op/paint"
+	"gioui.org/unit"
+	"gioui.org/widget"
+)
+
+const (
+	chartGridLines = 4
+)
+
+var (
+	chartHeight     = unit.Dp(180)
+	chartLineWidth  = unit.Dp(2)
+	chartPointSize  = unit.Dp(4)
+	chartLabelInset = unit.Dp(4)
+	chartLegendSize = unit.Dp(10)
+)
+
+// ChartPoint is a point of a chart series. X is usually a unix timestamp.
+type ChartPoint struct {
+	X, Y float64
+}
+
+// ChartSeries is a named series of points sorted by X. A zero Color is
+// replaced by a color of the theme's chart palette.
+type ChartSeries struct {
+	Name   string
+	Points []ChartPoint
+	Color  color.NRGBA
+}
+
+// ChartPalette returns the colors given in order to chart series and slices
+// without a color. It follows the color mode of the theme.
+func (t *Theme) ChartPalette() []color.NRGBA {
+	return []color.NRGBA{t.Color.Primary, t.Color.Success, t.Color.Orange, turquoise, yellow, t.Color.Gray2}
+}
+
+// chartColor returns c, or the palette color of the nth series if c is zero.
+func (t *Theme) chartColor(c color.NRGBA, n int) color.NRGBA {
+	if c != (color.NRGBA{}) {
+		return c
+	}
+	palette := t.ChartPalette()
+	return palette[n%len(palette)]
+}
+
+// withAlpha returns c with the alpha channel set to a.
+func withAlpha(c color.NRGBA, a uint8) color.NRGBA {
+	c.A = a
+	return c
+}
+
+// niceStep returns the step of 1, 2 or 5 times a power of ten nearest above
+// step, so that axis ticks fall on round values.
+func niceStep(step float64) float64 {
+	if step <= 0 {
+		return 1
+	}
+	magnitude := math.Pow(10, math.Floor(math.Log10(step)))
+	for _, m := range []float64{1, 2, 5, 10} {
+		if step <= m*magnitude {
+			return m * magnitude
+		}
+	}
+	return 10 * magnitude
+}
+
+// niceRange widens min and max to round values that divide evenly into the
+// grid lines. A range without width is widened around its value.
+func niceRange(min, max float64) (float64, float64) {
+	if max == min {
+		pad := math.Abs(max) / 10
+		if pad == 0 {
+			pad = 1
+		}
+		min, max = min-pad, max+pad
+	}
+	step := niceStep((max - min) / chartGridLines)
+	min = math.Floor(min/step) * step
+	max = math.Ceil(max/step) * step
+	for (max-min)/step > chartGridLines {
+		step = niceStep(step * 1.01)
+		min = math.Floor(min/step) * step
+		max = math.Ceil(max/step) * step
+	}
+	return min, min + step*chartGridLines
+}
+
+// chartLabel is an axis label at a fraction of the axis length.
+type chartLabel struct {
+	at   float32
+	text string
+}
+
+// chartFrame lays out the grid, the axis labels and the legend around the
+// plot of a chart.
+type chartFrame struct {
+	theme  *Theme
+	height unit.Value
+
+	minY, maxY float64
+	yLabel     func(y float64) string
+	xLabels    []chartLabel
+	legend     []ChartSeries
+}
+
+// layout lays out the frame across the width of the constraints and calls
+// plot with the size of the plot area and the ops offset to its corner.
+func (f chartFrame) layout(gtx C, plot func(gtx C, size image.Point)) D {
+	gtx.Constraints.Min.X = gtx.Constraints.Max.X
+	return layout.Flex{Axis: layout.Vertical}.Layout(gtx,
+		layout.Rigid(func(gtx C) D {
+			return f.layoutPlot(gtx, plot)
+		}),
+		layout.Rigid(func(gtx C) D {
+			return layoutChartLegend(gtx, f.theme, f.legend)
+		}),
+	)
+}
+
+func (f chartFrame) axisLabel(text string) Label {
+	label := f.theme.Caption(text)
+	label.Color = f.theme.Color.Gray
+	return label
+}
+
+func (f chartFrame) layoutPlot(gtx C, plot func(gtx C, size image.Point)) D {
+	height := gtx.Px(f.height)
+	inset := gtx.Px(chartLabelInset)
+	labelGtx := gtx
+	labelGtx.Constraints.Min = image.Point{}
+
+	// the y labels are measured first to leave room for the widest
+	var gutter int
+	var yLabels []op.CallOp
+	var yHeights []int
+	if f.yLabel != nil {
+		for i := 0; i <= chartGridLines; i++ {
+			value := f.maxY - (f.maxY-f.minY)*float64(i)/chartGridLines
+			macro := op.Record(gtx.Ops)
+			dims := f.axisLabel(f.yLabel(value)).Layout(labelGtx)
+			yLabels = append(yLabels, macro.Stop())
+			yHeights = append(yHeights, dims.Size.Y)
+			if dims.Size.X+inset > gutter {
+				gutter = dims.Size.X + inset
+			}
+		}
+	}
+
+	size := image.Point{X: gtx.Constraints.Max.X - gutter, Y: height}
+	if size.X < 0 {
+		size.X = 0
+	}
+
+	grid := f.theme.Color.Gray1
+	for i := 0; i <= chartGridLines; i++ {
+		y := i * (size.Y - 1) / chartGridLines
+		st := op.Save(gtx.Ops)
+		clip.Rect{Min: image.Pt(gutter, y), Max: image.Pt(gutter+size.X, y+1)}.Add(gtx.Ops)
+		paint.Fill(gtx.Ops, grid)
+		st.Load()
+
+		if yLabels != nil {
+			top := y - yHeights[i]/2
+			if top < 0 {
+				top = 0
+			}
+			if top+yHeights[i] > size.Y {
+				top = size.Y - yHeights[i]
+			}
+			st := op.Save(gtx.Ops)
+			op.Offset(f32.Pt(0, float32(top))).Add(gtx.Ops)
+			yLabels[i].Add(gtx.Ops)
+			st.Load()
+		}
+	}
+
+	st := op.Save(gtx.Ops)
+	op.Offset(f32.Pt(float32(gutter), 0)).Add(gtx.Ops)
+	plot(gtx, size)
+	st.Load()
+
+	total := image.Point{X: gtx.Constraints.Max.X, Y: height}
+	if len(f.xLabels) == 0 {
+		return D{Size: total}
+	}
+
+	// x labels are centered on their position, kept inside the plot and
+	// skipped where they would overlap the previous label
+	var labelsHeight, lastEnd int
+	for i, l := range f.xLabels {
+		macro := op.Record(gtx.Ops)
+		dims := f.axisLabel(l.text).Layout(labelGtx)
+		call := macro.Stop()
+
+		x := gutter + int(l.at*float32(size.X)) - dims.Size.X/2
+		if x+dims.Size.X > gutter+size.X {
+			x = gutter + size.X - dims.Size.X
+		}
+		if x < gutter {
+			x = gutter
+		}
+		if i > 0 && x < lastEnd+inset {
+			continue
+		}
+		lastEnd = x + dims.Size.X
+
+		st := op.Save(gtx.Ops)
+		op.Offset(f32.Pt(float32(x), float32(height+inset))).Add(gtx.Ops)
+		call.Add(gtx.Ops)
+		st.Load()
+		if dims.Size.Y > labelsHeight {
+			labelsHeight = dims.Size.Y
+		}
+	}
+	total.Y += inset + labelsHeight
+	return D{Size: total}
+}
+
+// layoutChartLegend lays out a row with the color and name of each named
+// series.
+func layoutChartLegend(gtx C, t *Theme, series []ChartSeries) D {
+	var items []layout.FlexChild
+	for i, s := range series {
+		if s.Name == "" {
+			continue
+		}
+		name, col := s.Name, t.chartColor(s.Color, i)
+		items = append(items, layout.Rigid(func(gtx C) D {
+			return layout.Inset{Right: unit.Dp(12)}.Layout(gtx, func(gtx C) D {
+				return layout.Flex{Alignment: layout.Middle}.Layout(gtx,
+					layout.Rigid(func(gtx C) D {
+						size := gtx.Px(chartLegendSize)
+						rect := f32.Rectangle{Max: f32.Pt(float32(size), float32(size))}
+						paint.FillShape(gtx.Ops, col, clip.UniformRRect(rect, float32(size)/4).Op(gtx.Ops))
+						return D{Size: image.Pt(size, size)}
+					}),
+					layout.Rigid(func(gtx C) D {
+						label := t.Caption(name)
+						label.Color = t.Color.Gray
+						return layout.Inset{Left: chartLabelInset}.Layout(gtx, label.Layout)
+					}),
+				)
+			})
+		}))
+	}
+	if len(items) == 0 {
+		return D{}
+	}
+	return layout.Inset{Top: unit.Dp(8)}.Layout(gtx, func(gtx C) D {
+		return layout.Flex{Alignment: layout.Middle}.Layout(gtx, items...)
+	})
+}
+
+// chartHover tracks the pointer over the plot of a chart.
+type chartHover struct {
+	hovered bool
+	pos     f32.Point
+}
+
+func (h *chartHover) update(gtx C) {
+	for _, e := range gtx.Events(h) {
+		ev, ok := e.(pointer.Event)
+		if !ok {
+			continue
+		}
+
+		switch ev.Type {
+		case pointer.Enter, pointer.Move:
+			h.hovered = true
+			h.pos = ev.Position
+		case pointer.Leave, pointer.Cancel:
+			h.hovered = false
+		}
+	}
+}
+
+// add registers the plot area for pointer events.
+func (h *chartHover) add(gtx C, size image.Point) {
+	st := op.Save(gtx.Ops)
+	pointer.Rect(image.Rectangle{Max: size}).Add(gtx.Ops)
+	pointer.InputOp{
+		Tag:   h,
+		Types: pointer.Enter | pointer.Leave | pointer.Move,
+	}.Add(gtx.Ops)
+	st.Load()
+}
+
+// layoutChartTooltip shows text beside anchor, inside the plot of the given
+// size and on top of the rest of the window.
+func layoutChartTooltip(gtx C, t *Theme, size image.Point, anchor f32.Point, text string) {
+	macro := op.Record(gtx.Ops)
+	tipGtx := gtx
+	tipGtx.Constraints.Min = image.Point{}
+	card := t.Card()
+	card.Color = t.Color.Surface
+	border := widget.Border{Color: t.Color.Gray1, CornerRadius: unit.Dp(5), Width: unit.Dp(1)}
+	dims := border.Layout(tipGtx, func(gtx C) D {
+		return card.Layout(gtx, func(gtx C) D {
+			return layout.UniformInset(unit.Dp(8)).Layout(gtx, t.Caption(text).Layout)
+		})
+	})
+	call := macro.Stop()
+
+	// the tooltip goes on the side of the anchor with the most room
+	gap := float32(gtx.Px(chartPointSize)) * 2
+	offset := f32.Point{X: anchor.X + gap, Y: anchor.Y - float32(dims.Size.Y)/2}
+	if int(offset.X)+dims.Size.X > size.X {
+		offset.X = anchor.X - gap - float32(dims.Size.X)
+	}
+	if offset.X < 0 {
+		offset.X = 0
+	}
+	if int(offset.Y)+dims.Size.Y > size.Y {
+		offset.Y = float32(size.Y - dims.Size.Y)
+	}
+	if offset.Y < 0 {
+		offset.Y = 0
+	}
+
+	macro = op.Record(gtx.Ops)
+	op.Offset(offset).Add(gtx.Ops)
+	call.Add(gtx.Ops)
+	op.Defer(gtx.Ops, macro.Stop())
+}
+
+// layoutChartMarker marks a point of a series with a dot ringed in the
+// surface color, so that it stands out from the line through it.
+func layoutChartMarker(gtx C, t *Theme, pos f32.Point, col color.NRGBA) {
+	radius := float32(gtx.Px(chartPointSize))
+	ring := float32(gtx.Px(unit.Dp(2)))
+	paint.FillShape(gtx.Ops, t.Color.Surface, clip.Circle{Center: pos, Radius: radius + ring}.Op(gtx.Ops))
+	paint.FillShape(gtx.Ops, col, clip.Circle{Center: pos, Radius: radius}.Op(gtx.Ops))
+}
+
+// decimate reduces positions sorted by X to at most four per pixel column:
+// the first, the lowest, the highest and the last. The line through them
+// looks the same as through all positions.
+func decimate(positions []f32.Point) []f32.Point {
+	if len(positions) < 2 {
+		return positions
+	}
+	width := positions[len(positions)-1].X - positions[0].X
+	if float32(len(positions)) <= 4*(width+1) {
+		return positions
+	}
+
+	reduced := make([]f32.Point, 0, 4*int(width+1))
+	for start := 0; start < len(positions); {
+		column := int(positions[start].X)
+		end := start + 1
+		low, high := start, start
+		for end < len(positions) && int(positions[end].X) == column {
+			if positions[end].Y < positions[low].Y {
+				low = end
+			}
+			if positions[end].Y > positions[high].Y {
+				high = end
+			}
+			end++
+		}
+
+		// the points are kept in the order they are drawn
+		keep := []int{start, low, high, end - 1}
+		sort.Ints(keep)
+		for i, index := range keep {
+			if i == 0 || index != keep[i-1] {
+				reduced = append(reduced, positions[index])
+			}
+		}
+		start = end
+	}
+	return reduced
+}
@@ -7,6 +7,7 @@ import (
 
 type ClickableList struct {
 	layout.List
+	theme        *Theme
 	clickables   []*widget.Clickable
 	selectedItem int
 }
@@ -14,10 +15,17 @@ type ClickableList struct {
 func (t *Theme) NewClickableList(axis layout.Axis) *ClickableList {
 	return &ClickableList{
 		List:         layout.List{Axis: layout.Vertical},
+		theme:        t,
 		selectedItem: -1,
 	}
 }
 
+// Clickables returns the buttons of the items laid out last, in list order.
+// The keyboard focus is given to the items through them.
+func (cl *ClickableList) Clickables() []*widget.Clickable {
+	return cl.clickables
+}
+
 func (cl *ClickableList) ItemClicked() (bool, int) {
 	defer func() {
 		cl.selectedItem = -1
@@ -44,8 +52,10 @@ func (cl *ClickableList) handleClickables(count int) {
 func (cl *ClickableList) Layout(gtx layout.Context, count int, w layout.ListElement) layout.Dimensions {
 	cl.handleClickables(count)
 	return cl.List.Layout(gtx, count, func(gtx layout.Context, i int) layout.Dimensions {
-		return Clickable(gtx, cl.clickables[i], func(gtx layout.Context) layout.Dimensions {
-			return w(gtx, i)
+		return cl.theme.layoutFocus(gtx, cl.clickables[i], func(gtx layout.Context) layout.Dimensions {
+			return Clickable(gtx, cl.clickables[i], func(gtx layout.Context) layout.Dimensions {
+				return w(gtx, i)
+			})
 		})
 
 	})
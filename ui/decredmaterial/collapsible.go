@@ -149,6 +149,11 @@ func (c *CollapsibleWithOption) Layout(gtx layout.Context, header, body func(C)
 	})
 }
 
+// IsExpanded reports whether the body is shown.
+func (c *CollapsibleWithOption) IsExpanded() bool {
+	return c.isExpanded
+}
+
 func (c *CollapsibleWithOption) MoreTriggered() bool {
 	return c.moreIconButton.Button.Clicked()
 }
@@ -73,6 +73,15 @@ func (c *DropDown) SelectedIndex() int {
 	return c.selectedIndex - 1
 }
 
+// SetSelectedIndex selects the item at index without reporting a change.
+func (c *DropDown) SetSelectedIndex(index int) {
+	if index < 0 || index >= c.Len() {
+		return
+	}
+	c.selectedIndex = index + 1
+	c.items[0].label.Text = c.items[index+1].Text
+}
+
 func (c *DropDown) Len() int {
 	return len(c.items) - 1
 }
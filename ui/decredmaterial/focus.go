@@ -0,0 +1,34 @@
+package decredmaterial
+
+import (
+	"gioui.org/layout"
+	"gioui.org/unit"
+	"gioui.org/widget"
+)
+
+// The gio version godcr is built with gives the keyboard focus to editors
+// only, so the theme keeps track of the button or list item that has it and
+// draws a focus ring around it.
+
+// SetFocused gives the keyboard focus to button, or takes it from the focused
+// button if button is nil.
+func (t *Theme) SetFocused(button *widget.Clickable) {
+	t.focused = button
+}
+
+// Focused returns the button with the keyboard focus, or nil if no button has
+// it.
+func (t *Theme) Focused() *widget.Clickable {
+	return t.focused
+}
+
+// layoutFocus lays out w, the widget of button, with a focus ring around it
+// if button has the keyboard focus.
+func (t *Theme) layoutFocus(gtx layout.Context, button *widget.Clickable, w layout.Widget) layout.Dimensions {
+	if t == nil || button == nil || t.focused != button {
+		return w(gtx)
+	}
+
+	border := widget.Border{Color: t.Color.Primary, CornerRadius: unit.Dp(4), Width: unit.Dp(2)}
+	return border.Layout(gtx, w)
+}
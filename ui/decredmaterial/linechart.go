@@ -0,0 +1,191 @@
+package decredmaterial
+
+import (
+	"image"
+	"math"
+	"sort"
+
+	"gioui.org/f32"
+	"gioui.org/op"
+	"gioui.org/op/clip"
+	"gioui.org/op/paint"
+	"gioui.org/unit"
+)
+
+// LineChart draws series of points as lines, optionally over filled areas.
+// The point nearest to the pointer is shown in a tooltip and named series
+// are listed in a legend.
+type LineChart struct {
+	Series []ChartSeries
+	// Step draws the lines as steps, for values that hold until the next
+	// point such as a balance.
+	Step bool
+	// Fill fills the area beneath each line.
+	Fill   bool
+	Height unit.Value
+
+	// XLabel and YLabel format the axis labels and Tooltip the text shown for
+	// the hovered point. Nil formatters hide the labels or the tooltip.
+	XLabel  func(x float64) string
+	YLabel  func(y float64) string
+	Tooltip func(series int, p ChartPoint) string
+
+	theme *Theme
+	hover chartHover
+}
+
+func (t *Theme) LineChart() *LineChart {
+	return &LineChart{
+		Height: chartHeight,
+		theme:  t,
+	}
+}
+
+// bounds returns the ranges of the points of all series. It returns false if
+// there are no points.
+func (c *LineChart) bounds() (minX, maxX, minY, maxY float64, ok bool) {
+	minX, minY = math.Inf(1), math.Inf(1)
+	maxX, maxY = math.Inf(-1), math.Inf(-1)
+	for _, s := range c.Series {
+		for _, p := range s.Points {
+			minX, maxX = math.Min(minX, p.X), math.Max(maxX, p.X)
+			minY, maxY = math.Min(minY, p.Y), math.Max(maxY, p.Y)
+			ok = true
+		}
+	}
+	return
+}
+
+// xAxisLabels returns labels for the start, the middle and the end of the x
+// axis.
+func xAxisLabels(minX, maxX float64, format func(x float64) string) []chartLabel {
+	if format == nil {
+		return nil
+	}
+	labels := make([]chartLabel, 3)
+	for i := range labels {
+		at := float32(i) / float32(len(labels)-1)
+		labels[i] = chartLabel{at: at, text: format(minX + (maxX-minX)*float64(at))}
+	}
+	return labels
+}
+
+// traceLine adds the line through positions to path, which must have been
+// moved to the first position.
+func traceLine(path *clip.Path, positions []f32.Point, step bool) {
+	for i, pos := range positions[1:] {
+		if step {
+			path.LineTo(f32.Point{X: pos.X, Y: positions[i].Y})
+		}
+		path.LineTo(pos)
+	}
+}
+
+// nearest returns the index of the position sorted by X closest to x.
+func nearest(positions []f32.Point, x float32) int {
+	i := sort.Search(len(positions), func(i int) bool {
+		return positions[i].X >= x
+	})
+	if i == len(positions) {
+		return i - 1
+	}
+	if i > 0 && x-positions[i-1].X < positions[i].X-x {
+		return i - 1
+	}
+	return i
+}
+
+func (c *LineChart) layoutSeries(gtx C, size image.Point, positions []f32.Point, n int) {
+	col := c.theme.chartColor(c.Series[n].Color, n)
+	if len(positions) == 1 {
+		positions = []f32.Point{{X: 0, Y: positions[0].Y}, {X: float32(size.X), Y: positions[0].Y}}
+	}
+	positions = decimate(positions)
+	first, last := positions[0], positions[len(positions)-1]
+
+	if c.Fill {
+		var area clip.Path
+		area.Begin(gtx.Ops)
+		area.MoveTo(f32.Point{X: first.X, Y: float32(size.Y)})
+		area.LineTo(first)
+		traceLine(&area, positions, c.Step)
+		area.LineTo(f32.Point{X: last.X, Y: float32(size.Y)})
+		area.Close()
+		paint.FillShape(gtx.Ops, withAlpha(col, 50), clip.Outline{Path: area.End()}.Op())
+	}
+
+	var line clip.Path
+	line.Begin(gtx.Ops)
+	line.MoveTo(first)
+	traceLine(&line, positions, c.Step)
+	paint.FillShape(gtx.Ops, col, clip.Stroke{
+		Path:  line.End(),
+		Style: clip.StrokeStyle{Width: float32(gtx.Px(chartLineWidth))},
+	}.Op())
+}
+
+// Layout draws the chart across the width of the constraints.
+func (c *LineChart) Layout(gtx C) D {
+	c.hover.update(gtx)
+
+	frame := chartFrame{theme: c.theme, height: c.Height, legend: c.Series}
+	minX, maxX, minY, maxY, ok := c.bounds()
+	if !ok {
+		return frame.layout(gtx, func(gtx C, size image.Point) {})
+	}
+	if maxX == minX {
+		minX, maxX = minX-1, maxX+1
+	}
+	minY, maxY = niceRange(minY, maxY)
+	frame.minY, frame.maxY, frame.yLabel = minY, maxY, c.YLabel
+	frame.xLabels = xAxisLabels(minX, maxX, c.XLabel)
+
+	return frame.layout(gtx, func(gtx C, size image.Point) {
+		project := func(p ChartPoint) f32.Point {
+			return f32.Point{
+				X: float32((p.X - minX) / (maxX - minX) * float64(size.X)),
+				Y: float32((maxY - p.Y) / (maxY - minY) * float64(size.Y)),
+			}
+		}
+
+		hoverSeries, hoverIndex := -1, 0
+		var hoverPos f32.Point
+		bestDistance := float32(math.Inf(1))
+		for n, s := range c.Series {
+			if len(s.Points) == 0 {
+				continue
+			}
+			positions := make([]f32.Point, len(s.Points))
+			for i, p := range s.Points {
+				positions[i] = project(p)
+			}
+			c.layoutSeries(gtx, size, positions, n)
+
+			if !c.hover.hovered {
+				continue
+			}
+			i := nearest(positions, c.hover.pos.X)
+			distance := float32(math.Abs(float64(positions[i].X-c.hover.pos.X)) + math.Abs(float64(positions[i].Y-c.hover.pos.Y)))
+			if distance < bestDistance {
+				hoverSeries, hoverIndex, hoverPos, bestDistance = n, i, positions[i], distance
+			}
+		}
+
+		c.hover.add(gtx, size)
+		if hoverSeries == -1 {
+			return
+		}
+
+		st := op.Save(gtx.Ops)
+		clip.Rect{Min: image.Pt(int(hoverPos.X), 0), Max: image.Pt(int(hoverPos.X)+1, size.Y)}.Add(gtx.Ops)
+		paint.Fill(gtx.Ops, c.theme.Color.Gray1)
+		st.Load()
+
+		layoutChartMarker(gtx, c.theme, hoverPos, c.theme.chartColor(c.Series[hoverSeries].Color, hoverSeries))
+
+		if c.Tooltip != nil {
+			text := c.Tooltip(hoverSeries, c.Series[hoverSeries].Points[hoverIndex])
+			layoutChartTooltip(gtx, c.theme, size, hoverPos, text)
+		}
+	})
+}
@@ -0,0 +1,198 @@
+package decredmaterial
+
+import (
+	"image"
+	"image/color"
+	"math"
+
+	"gioui.org/f32"
+	"gioui.org/layout"
+	"gioui.org/op/clip"
+	"gioui.org/op/paint"
+	"gioui.org/unit"
+)
+
+// pieSegments is the number of line segments a full circle is drawn with.
+const pieSegments = 128
+
+// ChartSlice is a slice of a pie chart. A zero Color is replaced by a color
+// of the theme's chart palette.
+type ChartSlice struct {
+	Label string
+	Value float64
+	Color color.NRGBA
+}
+
+// PieChart draws the share of each slice of the total as a pie, or as a
+// donut with a hole. The hovered slice stands out and is shown in a tooltip,
+// and the slices are listed in a legend.
+type PieChart struct {
+	Slices []ChartSlice
+	// Donut is the size of the hole as a fraction of the radius. Zero draws
+	// a pie.
+	Donut float32
+	Size  unit.Value
+	// Center is laid out in the hole of a donut, for example for the total.
+	Center layout.Widget
+
+	// Tooltip returns the text shown for the hovered slice. A nil Tooltip
+	// hides the tooltip.
+	Tooltip func(slice int) string
+
+	theme *Theme
+	hover chartHover
+}
+
+func (t *Theme) PieChart() *PieChart {
+	return &PieChart{
+		Size:  chartHeight,
+		theme: t,
+	}
+}
+
+// total returns the sum of the positive slice values.
+func (c *PieChart) total() float64 {
+	var total float64
+	for _, s := range c.Slices {
+		if s.Value > 0 {
+			total += s.Value
+		}
+	}
+	return total
+}
+
+// sliceAt returns the index of the slice at angle, measured clockwise from
+// the top, or -1.
+func (c *PieChart) sliceAt(angle float64) int {
+	total := c.total()
+	var start float64
+	for i, s := range c.Slices {
+		if s.Value <= 0 {
+			continue
+		}
+		end := start + s.Value/total*2*math.Pi
+		if angle >= start && angle < end {
+			return i
+		}
+		start = end
+	}
+	return -1
+}
+
+// arc adds points on the circle around center from angle start to end,
+// measured clockwise from the top, to path.
+func arc(path *clip.Path, center f32.Point, radius float32, start, end float64) {
+	segments := int(math.Ceil(math.Abs(end-start) / (2 * math.Pi) * pieSegments))
+	if segments < 1 {
+		segments = 1
+	}
+	for i := 0; i <= segments; i++ {
+		angle := start + (end-start)*float64(i)/float64(segments)
+		path.LineTo(f32.Point{
+			X: center.X + radius*float32(math.Sin(angle)),
+			Y: center.Y - radius*float32(math.Cos(angle)),
+		})
+	}
+}
+
+func (c *PieChart) layoutPie(gtx C) D {
+	diameter := gtx.Px(c.Size)
+	if diameter > gtx.Constraints.Max.X {
+		diameter = gtx.Constraints.Max.X
+	}
+	size := image.Pt(diameter, diameter)
+	center := f32.Pt(float32(diameter)/2, float32(diameter)/2)
+	// the hovered slice grows into the margin
+	margin := float32(gtx.Px(chartPointSize))
+	radius := float32(diameter)/2 - margin
+	hole := radius * c.Donut
+
+	hovered := -1
+	if c.hover.hovered {
+		d := c.hover.pos.Sub(center)
+		distance := float32(math.Hypot(float64(d.X), float64(d.Y)))
+		if distance >= hole && distance <= radius+margin {
+			angle := math.Atan2(float64(d.X), float64(-d.Y))
+			if angle < 0 {
+				angle += 2 * math.Pi
+			}
+			hovered = c.sliceAt(angle)
+		}
+	}
+
+	total := c.total()
+	if total == 0 {
+		var ring clip.Path
+		ring.Begin(gtx.Ops)
+		ring.MoveTo(f32.Point{X: center.X, Y: center.Y - radius})
+		arc(&ring, center, radius, 0, 2*math.Pi)
+		paint.FillShape(gtx.Ops, c.theme.Color.Gray1, clip.Stroke{
+			Path:  ring.End(),
+			Style: clip.StrokeStyle{Width: float32(gtx.Px(chartLineWidth))},
+		}.Op())
+		return D{Size: size}
+	}
+
+	var start float64
+	for i, s := range c.Slices {
+		if s.Value <= 0 {
+			continue
+		}
+		end := start + s.Value/total*2*math.Pi
+		outer := radius
+		if i == hovered {
+			outer += margin
+		}
+
+		var slice clip.Path
+		slice.Begin(gtx.Ops)
+		if hole > 0 {
+			slice.MoveTo(f32.Point{
+				X: center.X + hole*float32(math.Sin(start)),
+				Y: center.Y - hole*float32(math.Cos(start)),
+			})
+			arc(&slice, center, outer, start, end)
+			arc(&slice, center, hole, end, start)
+		} else {
+			slice.MoveTo(center)
+			arc(&slice, center, outer, start, end)
+		}
+		slice.Close()
+		paint.FillShape(gtx.Ops, c.theme.chartColor(s.Color, i), clip.Outline{Path: slice.End()}.Op())
+		start = end
+	}
+
+	c.hover.add(gtx, size)
+	if c.Center != nil && hole > 0 {
+		centerGtx := gtx
+		centerGtx.Constraints = layout.Exact(size)
+		layout.Center.Layout(centerGtx, c.Center)
+	}
+	if hovered != -1 && c.Tooltip != nil {
+		layoutChartTooltip(gtx, c.theme, size, c.hover.pos, c.Tooltip(hovered))
+	}
+	return D{Size: size}
+}
+
+// Layout draws the chart with the legend beneath it.
+func (c *PieChart) Layout(gtx C) D {
+	c.hover.update(gtx)
+
+	legend := make([]ChartSeries, len(c.Slices))
+	for i, s := range c.Slices {
+		legend[i] = ChartSeries{Name: s.Label, Color: s.Color}
+	}
+
+	gtx.Constraints.Min.X = gtx.Constraints.Max.X
+	return layout.Flex{Axis: layout.Vertical}.Layout(gtx,
+		layout.Rigid(func(gtx C) D {
+			return layout.N.Layout(gtx, c.layoutPie)
+		}),
+		layout.Rigid(func(gtx C) D {
+			return layout.N.Layout(gtx, func(gtx C) D {
+				gtx.Constraints.Min.X = 0
+				return layoutChartLegend(gtx, c.theme, legend)
+			})
+		}),
+	)
+}
@@ -28,7 +28,9 @@ var (
 	darkblue = rgb(0x091440)
 
 	// decred complemetary colors
-	green = rgb(0x41bf53)
+	green     = rgb(0x41bf53)
+	turquoise = rgb(0x2ed6a1)
+	yellow    = rgb(0xffc84e)
 )
 
 type (
@@ -86,6 +88,9 @@ type Theme struct {
 
 	dropDownMenus []*DropDown
 
+	// focused is the button with the keyboard focus.
+	focused *widget.Clickable
+
 	DarkMode bool
 }
 
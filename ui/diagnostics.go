@@ -0,0 +1,313 @@
+package ui
+
+import (
+	"archive/zip"
+	"bufio"
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"regexp"
+	"runtime"
+	"runtime/pprof"
+	"strings"
+	"time"
+
+	"github.com/planetdecred/dcrlibwallet"
+	"github.com/planetdecred/godcr/version"
+	"github.com/planetdecred/godcr/wallet"
+)
+
+// configFile is the path of the godcr config file.
+var configFile string
+
+// UseConfigFile sets the config file a diagnostics bundle includes.
+func UseConfigFile(path string) {
+	configFile = path
+}
+
+// diagnosticsLogFiles is how many of the newest log files a diagnostics
+// bundle includes.
+const diagnosticsLogFiles = 3
+
+// diagnosticsConfigKeys are the user config keys a diagnostics bundle
+// includes. Keys that may hold secrets are left out.
+var diagnosticsConfigKeys = []string{
+	dcrlibwallet.LogLevelConfigKey,
+	dcrlibwallet.SpendUnconfirmedConfigKey,
+	dcrlibwallet.CurrencyConversionConfigKey,
+	dcrlibwallet.IsStartupSecuritySetConfigKey,
+	dcrlibwallet.StartupSecurityTypeConfigKey,
+	dcrlibwallet.IncomingTxNotificationsConfigKey,
+	dcrlibwallet.BeepNewBlocksConfigKey,
+	dcrlibwallet.SyncOnCellularConfigKey,
+	dcrlibwallet.NetworkModeConfigKey,
+	dcrlibwallet.SpvPersistentPeerAddressesConfigKey,
+	dcrlibwallet.UserAgentConfigKey,
+	dcrlibwallet.PoliteiaNotificationConfigKey,
+	dcrlibwallet.VSPHostConfigKey,
+	wallet.AmountUnitConfigKey,
+	wallet.AmountPrecisionConfigKey,
+	uiScaleConfigKey,
+	textScaleConfigKey,
+	keyBindingsConfigKey,
+}
+
+// sensitiveConfigWords mark config options whose values are redacted.
+var sensitiveConfigWords = []string{"seed", "pass", "pin", "xpub", "secret", "private"}
+
+// extendedKeyPattern matches serialized extended public and private keys.
+var extendedKeyPattern = regexp.MustCompile(`\b[a-z]{4}[1-9A-HJ-NP-Za-km-z]{100,}\b`)
+
+// sensitiveLogValuePattern matches the value of a key=value or key: value
+// pair in a log line whose key marks it as sensitive.
+var sensitiveLogValuePattern = regexp.MustCompile(`(?i)\b(\w*(?:` + strings.Join(sensitiveConfigWords, "|") +
+	`)\w*)(\s*[=:]\s*)[^\s,;]+`)
+
+const redacted = "<redacted>"
+
+func isSensitiveConfigKey(key string) bool {
+	key = strings.ToLower(key)
+	for _, word := range sensitiveConfigWords {
+		if strings.Contains(key, word) {
+			return true
+		}
+	}
+	return false
+}
+
+// sanitizeConfigFile redacts the sensitive options and any extended keys in
+// the text of an ini config file.
+func sanitizeConfigFile(text string) string {
+	lines := strings.Split(text, "\n")
+	for i, line := range lines {
+		fields := strings.SplitN(line, "=", 2)
+		if len(fields) == 2 && isSensitiveConfigKey(fields[0]) {
+			lines[i] = fields[0] + "=" + redacted
+			continue
+		}
+		lines[i] = extendedKeyPattern.ReplaceAllString(line, redacted)
+	}
+	return strings.Join(lines, "\n")
+}
+
+// sanitizeUserConfig redacts the sensitive values and any extended keys in
+// user config values.
+func sanitizeUserConfig(config map[string]interface{}) map[string]interface{} {
+	sanitized := make(map[string]interface{}, len(config))
+	for key, value := range config {
+		switch v := value.(type) {
+		case string:
+			value = extendedKeyPattern.ReplaceAllString(v, redacted)
+		}
+		if isSensitiveConfigKey(key) {
+			value = redacted
+		}
+		sanitized[key] = value
+	}
+	return sanitized
+}
+
+// sanitizeLogLine redacts the sensitive key=value pairs and any extended keys
+// in a log line.
+func sanitizeLogLine(line string) string {
+	line = sensitiveLogValuePattern.ReplaceAllString(line, "${1}${2}"+redacted)
+	return extendedKeyPattern.ReplaceAllString(line, redacted)
+}
+
+// diagnostics is the app state a diagnostics bundle includes that must be
+// read from the UI goroutine.
+type diagnostics struct {
+	statistics []statistic
+	syncStatus wallet.SyncStatus
+	synced     bool
+	syncing    bool
+	bestBlock  int32
+}
+
+func collectDiagnostics(common *pageCommon) diagnostics {
+	return diagnostics{
+		statistics: statistics(common),
+		syncStatus: *common.walletSyncStatus,
+		synced:     common.wallet.IsSynced(),
+		syncing:    common.wallet.IsSyncing(),
+		bestBlock:  common.info.BestBlockHeight,
+	}
+}
+
+// writeDiagnosticsBundle zips the recent logs, the sanitized config, the
+// statistics, the sync state, the connected peers, a goroutine dump and the
+// version into the file at name. The file is removed if the bundle cannot be
+// written completely.
+func writeDiagnosticsBundle(name string, wal wallet.Backend, diag diagnostics) error {
+	f, err := os.OpenFile(name, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0600)
+	if err != nil {
+		return err
+	}
+
+	err = writeDiagnostics(f, wal, diag)
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		os.Remove(name)
+	}
+	return err
+}
+
+// writeDiagnostics writes a diagnostics bundle to out.
+func writeDiagnostics(out io.Writer, wal wallet.Backend, diag diagnostics) error {
+	z := zip.NewWriter(out)
+	add := func(name string, write func(w io.Writer) error) error {
+		w, err := z.CreateHeader(&zip.FileHeader{Name: name, Method: zip.Deflate, Modified: time.Now()})
+		if err != nil {
+			return err
+		}
+		return write(w)
+	}
+	addText := func(name, text string) error {
+		return add(name, func(w io.Writer) error {
+			_, err := io.WriteString(w, text)
+			return err
+		})
+	}
+	addJSON := func(name string, v interface{}) error {
+		return add(name, func(w io.Writer) error {
+			enc := json.NewEncoder(w)
+			enc.SetIndent("", "  ")
+			return enc.Encode(v)
+		})
+	}
+
+	var err error
+	var versionInfo strings.Builder
+	fmt.Fprintf(&versionInfo, "godcr %s\n", version.Version())
+	fmt.Fprintf(&versionInfo, "Go %s %s/%s\n", runtime.Version(), runtime.GOOS, runtime.GOARCH)
+	fmt.Fprintf(&versionInfo, "Network %s\n", wal.Network())
+	fmt.Fprintf(&versionInfo, "Started %s\n", startupTime.Format(time.RFC3339))
+	fmt.Fprintf(&versionInfo, "Created %s\n", time.Now().Format(time.RFC3339))
+	if err = addText("version.txt", versionInfo.String()); err != nil {
+		return err
+	}
+
+	var stats strings.Builder
+	for _, stat := range diag.statistics {
+		fmt.Fprintf(&stats, "%s: %s\n", stat.name, stat.value)
+	}
+	if err = addText("statistics.txt", stats.String()); err != nil {
+		return err
+	}
+
+	err = addJSON("sync.json", map[string]interface{}{
+		"synced":     diag.synced,
+		"syncing":    diag.syncing,
+		"bestBlock":  diag.bestBlock,
+		"syncStatus": diag.syncStatus,
+	})
+	if err != nil {
+		return err
+	}
+
+	peers, err := wal.PeerInfo()
+	if err != nil {
+		err = addText("peers.txt", err.Error()+"\n")
+	} else {
+		err = addJSON("peers.json", peers)
+	}
+	if err != nil {
+		return err
+	}
+
+	err = addJSON("userconfig.json", sanitizeUserConfig(wal.ReadUserConfigValues(diagnosticsConfigKeys)))
+	if err != nil {
+		return err
+	}
+
+	if configFile != "" {
+		data, err := ioutil.ReadFile(configFile)
+		if err != nil && !os.IsNotExist(err) {
+			return err
+		}
+		if err == nil {
+			if err = addText(filepath.Base(configFile), sanitizeConfigFile(string(data))); err != nil {
+				return err
+			}
+		}
+	}
+
+	err = add("goroutines.txt", func(w io.Writer) error {
+		return pprof.Lookup("goroutine").WriteTo(w, 2)
+	})
+	if err != nil {
+		return err
+	}
+
+	if logFile != "" {
+		if err = addRecentLogs(add); err != nil {
+			return err
+		}
+	}
+
+	return z.Close()
+}
+
+// addRecentLogs adds the newest log files to a diagnostics bundle, with their
+// lines sanitized. Compressed rolls are added decompressed.
+func addRecentLogs(add func(name string, write func(w io.Writer) error) error) error {
+	rolls, err := rolledLogFiles(logFile)
+	if err != nil {
+		return err
+	}
+	files := append(rolls, logFile)
+	if len(files) > diagnosticsLogFiles {
+		files = files[len(files)-diagnosticsLogFiles:]
+	}
+
+	for _, name := range files {
+		entry := "logs/" + strings.TrimSuffix(filepath.Base(name), ".gz")
+		err := add(entry, func(w io.Writer) error {
+			return copySanitizedLog(w, name)
+		})
+		if err != nil && !os.IsNotExist(err) {
+			return err
+		}
+	}
+	return nil
+}
+
+// copySanitizedLog writes the sanitized lines of the log file at path to w.
+func copySanitizedLog(w io.Writer, path string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	var r io.Reader = f
+	if strings.HasSuffix(path, ".gz") {
+		z, err := gzip.NewReader(f)
+		if err != nil {
+			return err
+		}
+		defer z.Close()
+		r = z
+	}
+
+	lines := bufio.NewReader(r)
+	for {
+		line, err := lines.ReadString('\n')
+		if line != "" {
+			if _, werr := io.WriteString(w, sanitizeLogLine(line)); werr != nil {
+				return werr
+			}
+		}
+		if err == io.EOF {
+			return nil
+		} else if err != nil {
+			return err
+		}
+	}
+}
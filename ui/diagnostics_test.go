@@ -0,0 +1,115 @@
+package ui
+
+import (
+	"archive/zip"
+	"compress/gzip"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/planetdecred/godcr/wallet"
+)
+
+// readZipEntries returns the contents of the files in the zip archive at
+// path, keyed by name.
+func readZipEntries(path string) map[string]string {
+	z, err := zip.OpenReader(path)
+	Expect(err).To(BeNil())
+	defer z.Close()
+
+	entries := make(map[string]string)
+	for _, file := range z.File {
+		r, err := file.Open()
+		Expect(err).To(BeNil())
+		data, err := ioutil.ReadAll(r)
+		r.Close()
+		Expect(err).To(BeNil())
+		entries[file.Name] = string(data)
+	}
+	return entries
+}
+
+var _ = Describe("Diagnostics bundle", func() {
+	xpub := "tpubVpQL1h8RbRbRxgXnQNeW6pCPZ6LDYL5oVfeWkQqNxvMcAPG4iBSGnkuuvCMQKaTCK4GLLhvYyM2uRBAiXtYeJF5pCMd3aLRBLtRa8ZpyQHE"
+
+	It("redacts secrets in the config file", func() {
+		sanitized := sanitizeConfigFile("[Application Options]\nnetwork=mainnet\nwalletpass=hunter2\nextra=" + xpub + "\n")
+		Expect(sanitized).To(ContainSubstring("network=mainnet"))
+		Expect(sanitized).To(ContainSubstring("walletpass=" + redacted))
+		Expect(sanitized).To(ContainSubstring("extra=" + redacted))
+		Expect(sanitized).NotTo(ContainSubstring("hunter2"))
+	})
+
+	It("redacts secrets in the user config", func() {
+		sanitized := sanitizeUserConfig(map[string]interface{}{
+			"spend_unconfirmed": true,
+			"seed_backup":       "puppy yesteryear",
+			"vsp_host":          xpub,
+		})
+		Expect(sanitized["spend_unconfirmed"]).To(Equal(true))
+		Expect(sanitized["seed_backup"]).To(Equal(redacted))
+		Expect(sanitized["vsp_host"]).To(Equal(redacted))
+	})
+
+	It("redacts secrets in the log lines", func() {
+		line := sanitizeLogLine("2021-04-01 12:00:00.000 [INF] WALL: unlocking with passphrase=hunter2, xpub: " + xpub + "\n")
+		Expect(line).To(Equal("2021-04-01 12:00:00.000 [INF] WALL: unlocking with passphrase=" + redacted + ", xpub: " + redacted + "\n"))
+		Expect(sanitizeLogLine("[INF] SYNC: Synced 12 blocks\n")).To(Equal("[INF] SYNC: Synced 12 blocks\n"))
+	})
+
+	Context("written to a file", func() {
+		var (
+			dir string
+			wal *wallet.FakeWallet
+		)
+
+		BeforeEach(func() {
+			var err error
+			dir, err = ioutil.TempDir("", "godcr-diagnostics")
+			Expect(err).To(BeNil())
+			wal, err = wallet.NewFakeWallet("testnet3", make(chan wallet.Response, 3))
+			Expect(err).To(BeNil())
+			Expect(wal.InitMultiWallet()).To(BeNil())
+		})
+
+		AfterEach(func() {
+			UseConfigFile("")
+			UseLogFile("")
+			os.RemoveAll(dir)
+		})
+
+		It("includes the sanitized logs and rolled logs", func() {
+			log := filepath.Join(dir, "godcr.log")
+			Expect(ioutil.WriteFile(log, []byte("[INF] UI: walletpass=hunter2\n[INF] UI: opened\n"), 0600)).To(Succeed())
+
+			roll, err := os.Create(log + ".1.gz")
+			Expect(err).To(BeNil())
+			z := gzip.NewWriter(roll)
+			_, err = z.Write([]byte("[INF] WALL: pin: 1234\n"))
+			Expect(err).To(BeNil())
+			Expect(z.Close()).To(Succeed())
+			Expect(roll.Close()).To(Succeed())
+			UseLogFile(log)
+
+			name := filepath.Join(dir, "bundle.zip")
+			Expect(writeDiagnosticsBundle(name, wal, diagnostics{})).To(Succeed())
+
+			entries := readZipEntries(name)
+			Expect(entries).To(HaveKey("version.txt"))
+			Expect(entries).To(HaveKeyWithValue("logs/godcr.log", "[INF] UI: walletpass="+redacted+"\n[INF] UI: opened\n"))
+			Expect(entries).To(HaveKeyWithValue("logs/godcr.log.1", "[INF] WALL: pin: "+redacted+"\n"))
+		})
+
+		It("removes the file if the bundle cannot be written", func() {
+			// a directory cannot be read as the config file
+			UseConfigFile(dir)
+
+			name := filepath.Join(dir, "bundle.zip")
+			Expect(writeDiagnosticsBundle(name, wal, diagnostics{})).NotTo(Succeed())
+			_, err := os.Stat(name)
+			Expect(os.IsNotExist(err)).To(BeTrue())
+		})
+	})
+})
@@ -0,0 +1,321 @@
+//go:build golden
+// +build golden
+
+package ui
+
+// The page rendering specs lay out pages offscreen with Gio's headless
+// renderer and compare them against the golden images in testdata/golden.
+// The pages show the scripted wallets of the fake wallet backend, so the
+// images do not depend on the network or on wallet files on disk.
+//
+// The specs are built only with the golden build tag, since they need OpenGL
+// ES through EGL. On Linux machines without a GPU or display, Mesa renders in
+// software with EGL_PLATFORM=surfaceless, and the nox11 and nowayland build
+// tags remove the need for the windowing libraries:
+//
+//	EGL_PLATFORM=surfaceless go test -tags nox11,nowayland,golden ./ui
+//
+// The suite fails if no renderer is available. After an intended UI change,
+// regenerate the golden images with
+//
+//	EGL_PLATFORM=surfaceless go test -tags nox11,nowayland,golden ./ui -args -update-goldens
+//
+// and check them in with the change. Mismatching pages are written to
+// testdata/golden/failed together with an image of the differing pixels.
+
+import (
+	"flag"
+	"fmt"
+	"image"
+	"image/color"
+	"image/png"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"gioui.org/font/gofont"
+	"gioui.org/gpu/headless"
+	"gioui.org/io/router"
+	"gioui.org/layout"
+	"gioui.org/op"
+	"gioui.org/unit"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/planetdecred/godcr/wallet"
+)
+
+var updateGoldens = flag.Bool("update-goldens", false, "write the rendered pages as the golden images instead of comparing them")
+
+const (
+	goldenDir       = "testdata/golden"
+	goldenFailedDir = "testdata/golden/failed"
+
+	// goldenChannelTolerance is the largest difference of a color channel
+	// that is not counted as a mismatch, to allow for rasterizer rounding.
+	goldenChannelTolerance = 8
+
+	// goldenMaxMismatch is the fraction of pixels that may differ from the
+	// golden image, to allow for antialiasing differences between drivers.
+	goldenMaxMismatch = 0.002
+
+	// goldenFrames is the number of frames laid out before the screenshot, so
+	// that pages which update their state while being laid out have settled.
+	goldenFrames = 3
+)
+
+// goldenTime is the animation time of every rendered frame.
+var goldenTime = time.Date(2021, time.January, 1, 12, 0, 0, 0, time.UTC)
+
+var goldenSizes = []image.Point{
+	{X: 800, Y: 600},
+	{X: 1280, Y: 800},
+}
+
+var goldenThemes = []struct {
+	name string
+	dark bool
+}{
+	{"light", false},
+	{"dark", true},
+}
+
+var goldenPages = []struct {
+	name string
+	page func(*pageCommon) Page
+}{
+	{"overview", OverviewPage},
+	{"transactions", TransactionsPage},
+	{"wallets", WalletPage},
+	{"send", SendPage},
+	{"receive", ReceivePage},
+	{"tickets", TicketPage},
+	{"proposals", ProposalsPage},
+	{"more", MorePage},
+	{"settings", SettingsPage},
+	{"about", AboutPage},
+}
+
+// goldenEnv is a window showing the wallets of the fake backend, whose pages
+// are rendered offscreen.
+type goldenEnv struct {
+	win *Window
+	wal *wallet.FakeWallet
+}
+
+func newGoldenEnv() (*goldenEnv, error) {
+	wal, err := wallet.NewFakeWallet("testnet3", make(chan wallet.Response, 3))
+	if err != nil {
+		return nil, err
+	}
+	if err = wal.InitMultiWallet(); err != nil {
+		return nil, err
+	}
+	if err = wal.OpenWallets(nil); err != nil {
+		return nil, err
+	}
+
+	env := &goldenEnv{wal: wal}
+	env.win, err = newWindow(wal, loadIcons("assets/decredicons"), gofont.Collection(), make(chan string))
+	if err != nil {
+		return nil, err
+	}
+
+	// the balance history ends at the last block of the scripted wallets
+	// rather than today
+	bestBlockTime := time.Unix(wal.GetBestBlock().Timestamp, 0)
+	balanceChartNow = func() time.Time { return bestBlockTime }
+
+	wal.SetupListeners()
+	wal.GetMultiWalletInfo()
+	env.settle()
+	return env, nil
+}
+
+func (env *goldenEnv) close() {
+	env.wal.Shutdown()
+	balanceChartNow = time.Now
+}
+
+// settle applies the wallet responses to the window state until no more
+// arrive, as the window loop would.
+func (env *goldenEnv) settle() {
+	for {
+		select {
+		case resp := <-env.wal.Responses():
+			if resp.Err == nil {
+				env.win.updateStates(resp.Resp)
+			}
+		case <-time.After(500 * time.Millisecond):
+			return
+		}
+	}
+}
+
+// render lays out page at size and returns the rendered image.
+func (env *goldenEnv) render(page Page, size image.Point) (*image.RGBA, error) {
+	w, err := headless.NewWindow(size.X, size.Y)
+	if err != nil {
+		return nil, err
+	}
+	defer w.Release()
+
+	page.OnResume()
+	env.settle()
+
+	ops := new(op.Ops)
+	queue := new(router.Router)
+	for i := 0; i < goldenFrames; i++ {
+		ops.Reset()
+		gtx := layout.Context{
+			Ops:         ops,
+			Now:         goldenTime,
+			Queue:       queue,
+			Metric:      env.win.scale.metric(unit.Metric{PxPerDp: 1, PxPerSp: 1}),
+			Constraints: layout.Exact(size),
+		}
+		env.win.layoutPage(gtx, page)
+		queue.Frame(ops)
+		if err = w.Frame(ops); err != nil {
+			return nil, err
+		}
+	}
+
+	return w.Screenshot()
+}
+
+// loadIcons reads the PNG icons in dir, keyed by their file names without
+// extension.
+func loadIcons(dir string) map[string]image.Image {
+	icons := make(map[string]image.Image)
+	paths, _ := filepath.Glob(filepath.Join(dir, "*.png"))
+	for _, path := range paths {
+		img, err := readPNG(path)
+		if err != nil {
+			continue
+		}
+		icons[strings.TrimSuffix(filepath.Base(path), ".png")] = img
+	}
+	return icons
+}
+
+func readPNG(path string) (image.Image, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return png.Decode(f)
+}
+
+func writePNG(path string, img image.Image) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	if err = png.Encode(f, img); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// compareGolden compares img against the golden image called name, or
+// replaces the golden image if -update-goldens is set.
+func compareGolden(name string, img image.Image) error {
+	path := filepath.Join(goldenDir, name+".png")
+	if *updateGoldens {
+		return writePNG(path, img)
+	}
+
+	want, err := readPNG(path)
+	if os.IsNotExist(err) {
+		return fmt.Errorf("no golden image %s, run the tests with -update-goldens to create it", path)
+	} else if err != nil {
+		return err
+	}
+
+	mismatch, diff := imageDiff(img, want)
+	if mismatch <= goldenMaxMismatch {
+		return nil
+	}
+
+	writePNG(filepath.Join(goldenFailedDir, name+".png"), img)
+	writePNG(filepath.Join(goldenFailedDir, name+".diff.png"), diff)
+	return fmt.Errorf("%s differs from its golden image in %.2f%% of pixels, see %s", name, mismatch*100, goldenFailedDir)
+}
+
+// imageDiff returns the fraction of pixels of got that differ from want by
+// more than goldenChannelTolerance, and an image of got with the differing
+// pixels in red. Images of different sizes differ in all pixels.
+func imageDiff(got, want image.Image) (float64, *image.RGBA) {
+	bounds := got.Bounds()
+	diff := image.NewRGBA(bounds)
+	if bounds != want.Bounds() {
+		return 1, diff
+	}
+
+	var mismatched int
+	for y := bounds.Min.Y; y < bounds.Max.Y; y++ {
+		for x := bounds.Min.X; x < bounds.Max.X; x++ {
+			g := color.NRGBAModel.Convert(got.At(x, y)).(color.NRGBA)
+			w := color.NRGBAModel.Convert(want.At(x, y)).(color.NRGBA)
+			if channelDiff(g.R, w.R) > goldenChannelTolerance || channelDiff(g.G, w.G) > goldenChannelTolerance ||
+				channelDiff(g.B, w.B) > goldenChannelTolerance || channelDiff(g.A, w.A) > goldenChannelTolerance {
+				mismatched++
+				diff.Set(x, y, color.NRGBA{R: 0xff, A: 0xff})
+				continue
+			}
+			// unchanged pixels are faded so the mismatches stand out
+			diff.Set(x, y, color.NRGBA{R: g.R, G: g.G, B: g.B, A: 0x40})
+		}
+	}
+
+	return float64(mismatched) / float64(bounds.Dx()*bounds.Dy()), diff
+}
+
+func channelDiff(a, b uint8) int {
+	if a > b {
+		return int(a - b)
+	}
+	return int(b - a)
+}
+
+var env *goldenEnv
+
+var _ = BeforeSuite(func() {
+	w, err := headless.NewWindow(1, 1)
+	Expect(err).To(BeNil(), "headless rendering is not available")
+	w.Release()
+
+	env, err = newGoldenEnv()
+	Expect(err).To(BeNil())
+})
+
+var _ = AfterSuite(func() {
+	if env != nil {
+		env.close()
+	}
+})
+
+var _ = Describe("Page rendering", func() {
+	for _, theme := range goldenThemes {
+		for _, p := range goldenPages {
+			for _, size := range goldenSizes {
+				theme, p, size := theme, p, size
+				name := fmt.Sprintf("%s_%s_%dx%d", p.name, theme.name, size.X, size.Y)
+				It("renders the "+name+" golden image", func() {
+					env.win.theme.SwitchDarkMode(theme.dark)
+					img, err := env.render(p.page(env.win.common), size)
+					Expect(err).To(BeNil())
+					Expect(compareGolden(name, img)).To(Succeed())
+				})
+			}
+		}
+	}
+})
@@ -64,6 +64,24 @@ func (in *infoModal) Dismiss() {
 func (in *infoModal) OnResume() {
 }
 
+func (in *infoModal) cancelButton() *widget.Clickable {
+	if in.negativeButtonText == "" {
+		return nil
+	}
+	return in.btnNegative.Button
+}
+
+func (in *infoModal) focusTargets() []focusTarget {
+	var targets []focusTarget
+	if in.negativeButtonText != "" {
+		targets = append(targets, buttonTargets(in.btnNegative.Button)...)
+	}
+	if in.positiveButtonText != "" {
+		targets = append(targets, buttonTargets(in.btnPositve.Button)...)
+	}
+	return targets
+}
+
 func (in *infoModal) OnDismiss() {
 
 }
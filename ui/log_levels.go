@@ -0,0 +1,59 @@
+package ui
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/decred/slog"
+)
+
+// debugLevelNames are the level names accepted by the debuglevel option,
+// indexed by slog.Level.
+var debugLevelNames = []string{"trace", "debug", "info", "warn", "error", "critical", "off"}
+
+var (
+	// subsystemLoggers maps each subsystem tag to its logger.
+	subsystemLoggers map[string]slog.Logger
+	// setDebugLevel parses and applies a debuglevel option value.
+	setDebugLevel func(debugLevel string) error
+	// saveDebugLevel writes a debuglevel option value to the config file.
+	saveDebugLevel func(debugLevel string) error
+)
+
+// UseLogLevels lets the debug page change the level of the loggers at
+// runtime. set applies a value in the syntax of the debuglevel option and
+// save writes it to the config file.
+func UseLogLevels(loggers map[string]slog.Logger, set, save func(debugLevel string) error) {
+	subsystemLoggers = loggers
+	setDebugLevel = set
+	saveDebugLevel = save
+}
+
+// sortedSubsystems returns the tags of the subsystem loggers in order.
+func sortedSubsystems() []string {
+	subsystems := make([]string, 0, len(subsystemLoggers))
+	for subsystem := range subsystemLoggers {
+		subsystems = append(subsystems, subsystem)
+	}
+	sort.Strings(subsystems)
+	return subsystems
+}
+
+// debugLevelName returns the debuglevel option name of level.
+func debugLevelName(level slog.Level) string {
+	if int(level) >= len(debugLevelNames) {
+		return "off"
+	}
+	return debugLevelNames[level]
+}
+
+// currentDebugLevel returns the debuglevel option value that sets every
+// subsystem to its current level.
+func currentDebugLevel() string {
+	subsystems := sortedSubsystems()
+	pairs := make([]string, len(subsystems))
+	for i, subsystem := range subsystems {
+		pairs[i] = subsystem + "=" + debugLevelName(subsystemLoggers[subsystem].Level())
+	}
+	return strings.Join(pairs, ",")
+}
@@ -1,7 +1,12 @@
 package ui
 
 import (
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
 	"sync"
+	"time"
 
 	"gioui.org/io/clipboard"
 	"gioui.org/layout"
@@ -21,10 +26,27 @@ type logPage struct {
 	copyIcon   *widget.Image
 	backButton decredmaterial.IconButton
 
+	levels       map[string]*widget.Bool
+	subsystems   map[string]*widget.Bool
+	follow       *widget.Bool
+	searchEditor decredmaterial.Editor
+	exportButton decredmaterial.Button
+
 	entriesList layout.List
-	fullLog     string
-	logEntries  []decredmaterial.Label
 	entriesLock sync.Mutex
+	entries     []logEntry
+	// filtered holds the indexes in entries that pass the filters.
+	filtered []int
+	// hidden holds the unchecked levels and subsystems.
+	hidden    map[string]bool
+	search    string
+	following bool
+	// offset is how far logFile has been read.
+	offset int64
+	loaded bool
+	// followErr is the last error following logFile, logged once so that
+	// logging it doesn't follow the log again in a loop.
+	followErr string
 }
 
 func LogPage(common *pageCommon) Page {
@@ -36,8 +58,25 @@ func LogPage(common *pageCommon) Page {
 			ScrollToEnd: true,
 		},
 		copyLog:    new(widget.Clickable),
-		logEntries: make([]decredmaterial.Label, 0, 20),
+		levels:     make(map[string]*widget.Bool),
+		subsystems: make(map[string]*widget.Bool),
+		follow:     &widget.Bool{Value: true},
+		hidden:     make(map[string]bool),
+		following:  true,
+	}
+
+	for _, level := range logLevels {
+		pg.levels[level] = &widget.Bool{Value: true}
 	}
+	for _, subsystem := range logSubsystems {
+		pg.subsystems[subsystem] = &widget.Bool{Value: true}
+	}
+
+	pg.searchEditor = common.theme.Editor(&widget.Editor{SingleLine: true}, values.String(values.StrSearchLogs))
+	pg.searchEditor.Editor.Submit = true
+
+	pg.exportButton = common.theme.Button(new(widget.Clickable), values.String(values.StrExport))
+	pg.exportButton.TextSize = values.TextSize14
 
 	pg.copyIcon = common.icons.copyIcon
 	pg.copyIcon.Scale = 0.25
@@ -50,25 +89,153 @@ func LogPage(common *pageCommon) Page {
 }
 
 func (pg *logPage) OnResume() {
+	pg.entriesLock.Lock()
+	defer pg.entriesLock.Unlock()
+	if pg.loaded || logFile == "" {
+		return
+	}
 
+	entries, offset, err := logHistory(logFile)
+	if err != nil {
+		log.Errorf("Could not read log history: %v", err)
+	}
+	pg.entries, _ = trimLogEntries(append(entries, pg.entries...))
+	pg.offset = offset
+	pg.loaded = true
+	pg.filterEntries()
 }
 
-func (pg *logPage) copyLogEntries(gtx C) {
-	go func() {
-		pg.entriesLock.Lock()
-		defer pg.entriesLock.Unlock()
-		clipboard.WriteOp{Text: pg.fullLog}.Add(gtx.Ops)
-	}()
-}
-
+// watchLogs follows logFile each time a line is logged. Without a log file,
+// the logged lines themselves are shown.
 func (pg *logPage) watchLogs(internalLog chan string) {
 	for l := range internalLog {
-		entry := l[:len(l)-1]
+		if logFile != "" {
+			pg.followLog()
+			continue
+		}
+
 		pg.entriesLock.Lock()
-		pg.fullLog += l
-		pg.logEntries = append(pg.logEntries, pg.theme.Body1(entry))
+		if pg.following {
+			from := len(pg.entries)
+			pg.entries, _ = parseLogLines(strings.NewReader(l), pg.entries)
+			pg.addEntries(from)
+		}
+		pg.entriesLock.Unlock()
+		pg.common.refreshWindow()
+	}
+}
+
+// followLog reads the entries logged since the last read.
+func (pg *logPage) followLog() {
+	pg.entriesLock.Lock()
+	if !pg.loaded || !pg.following {
 		pg.entriesLock.Unlock()
+		return
+	}
+	var err error
+	from := len(pg.entries)
+	pg.entries, pg.offset, err = followLog(logFile, pg.offset, pg.entries)
+	pg.addEntries(from)
+
+	var report bool
+	if err != nil && err.Error() != pg.followErr {
+		pg.followErr, report = err.Error(), true
+	} else if err == nil {
+		pg.followErr = ""
+	}
+	pg.entriesLock.Unlock()
+
+	if report {
+		log.Errorf("Could not follow log: %v", err)
+	}
+	pg.common.refreshWindow()
+}
+
+// filterEntries updates the entries shown for the selected levels and
+// subsystems and the search text. The caller must hold entriesLock.
+func (pg *logPage) filterEntries() {
+	pg.filtered = pg.filtered[:0]
+	for i, entry := range pg.entries {
+		if pg.shown(entry) {
+			pg.filtered = append(pg.filtered, i)
+		}
+	}
+}
+
+// addEntries filters the entries parsed from index from on and drops the
+// oldest entries beyond maxLogEntries, without filtering the other entries
+// again. The caller must hold entriesLock.
+func (pg *logPage) addEntries(from int) {
+	// the last entry before from may have been continued
+	if from > 0 {
+		from--
+	}
+	for len(pg.filtered) > 0 && pg.filtered[len(pg.filtered)-1] >= from {
+		pg.filtered = pg.filtered[:len(pg.filtered)-1]
+	}
+	for i := from; i < len(pg.entries); i++ {
+		if pg.shown(pg.entries[i]) {
+			pg.filtered = append(pg.filtered, i)
+		}
+	}
+
+	var dropped int
+	pg.entries, dropped = trimLogEntries(pg.entries)
+	if dropped == 0 {
+		return
+	}
+	kept := pg.filtered[:0]
+	for _, i := range pg.filtered {
+		if i >= dropped {
+			kept = append(kept, i-dropped)
+		}
+	}
+	pg.filtered = kept
+}
+
+// shown reports whether entry passes the filters. The caller must hold
+// entriesLock.
+func (pg *logPage) shown(entry logEntry) bool {
+	if pg.hidden[entry.level] || pg.hidden[entry.subsystem] {
+		return false
+	}
+	return pg.search == "" || strings.Contains(strings.ToLower(entry.raw), pg.search)
+}
+
+// selection returns the filtered entries as log text.
+func (pg *logPage) selection() (string, int) {
+	pg.entriesLock.Lock()
+	defer pg.entriesLock.Unlock()
+
+	var text strings.Builder
+	for _, i := range pg.filtered {
+		text.WriteString(pg.entries[i].raw)
+		text.WriteString("\n")
 	}
+	return text.String(), len(pg.filtered)
+}
+
+func (pg *logPage) copyLogEntries(gtx C) {
+	text, _ := pg.selection()
+	clipboard.WriteOp{Text: text}.Add(gtx.Ops)
+}
+
+// exportLogEntries writes the filtered entries to a file next to the log
+// file.
+func (pg *logPage) exportLogEntries() {
+	text, count := pg.selection()
+	dir := filepath.Dir(logFile)
+	if logFile == "" {
+		dir = pg.common.wallet.WalletDirectory()
+	}
+
+	name := filepath.Join(dir, fmt.Sprintf("godcr-export-%s.log", time.Now().Format("20060102-150405")))
+	err := ioutil.WriteFile(name, []byte(text), 0600)
+	if err != nil {
+		pg.common.notify(values.StringF(values.StrLogExportFailed, err), false)
+		return
+	}
+	pg.common.notify(values.StringF(values.StrLogExported, count, name), true)
 }
 
 func (pg *logPage) Layout(gtx C) D {
@@ -96,21 +263,16 @@ func (pg *logPage) Layout(gtx C) D {
 				pg.copyLogEntries(gtx)
 			},
 			body: func(gtx C) D {
-				background := common.theme.Color.Surface
-				card := common.theme.Card()
-				card.Color = background
-				return card.Layout(gtx, func(gtx C) D {
-					gtx.Constraints.Min.X = gtx.Constraints.Max.X
-					gtx.Constraints.Min.Y = gtx.Constraints.Max.Y
-					return layout.UniformInset(values.MarginPadding15).Layout(gtx, func(gtx C) D {
-						return pg.entriesList.Layout(gtx, len(pg.logEntries), func(gtx C, i int) D {
-							pg.entriesLock.Lock()
-							defer pg.entriesLock.Unlock()
-							return pg.logEntries[i].Layout(gtx)
-						})
-					})
-
-				})
+				return layout.Flex{Axis: layout.Vertical}.Layout(gtx,
+					layout.Rigid(pg.filterBar),
+					layout.Rigid(func(gtx C) D {
+						return pg.filterToggles(gtx, logLevels, pg.levels)
+					}),
+					layout.Rigid(func(gtx C) D {
+						return pg.filterToggles(gtx, logSubsystems, pg.subsystems)
+					}),
+					layout.Flexed(1, pg.entriesCard),
+				)
 			},
 		}
 		return common.SubPageLayout(gtx, page)
@@ -118,5 +280,107 @@ func (pg *logPage) Layout(gtx C) D {
 	return pg.common.UniformPadding(gtx, container)
 }
 
-func (pg *logPage) handle()  {}
+func (pg *logPage) filterBar(gtx C) D {
+	return layout.Inset{Bottom: values.MarginPadding10}.Layout(gtx, func(gtx C) D {
+		return layout.Flex{Alignment: layout.Middle}.Layout(gtx,
+			layout.Flexed(1, pg.searchEditor.Layout),
+			layout.Rigid(func(gtx C) D {
+				return layout.Inset{Left: values.MarginPadding10}.Layout(gtx,
+					pg.theme.CheckBox(pg.follow, values.String(values.StrFollowLog)).Layout)
+			}),
+			layout.Rigid(func(gtx C) D {
+				return layout.Inset{Left: values.MarginPadding10}.Layout(gtx, pg.exportButton.Layout)
+			}),
+		)
+	})
+}
+
+func (pg *logPage) filterToggles(gtx C, names []string, toggles map[string]*widget.Bool) D {
+	children := make([]layout.FlexChild, len(names))
+	for i, name := range names {
+		toggle := pg.theme.CheckBox(toggles[name], name)
+		children[i] = layout.Rigid(func(gtx C) D {
+			return layout.Inset{Right: values.MarginPadding15}.Layout(gtx, toggle.Layout)
+		})
+	}
+	return layout.Flex{}.Layout(gtx, children...)
+}
+
+func (pg *logPage) entriesCard(gtx C) D {
+	card := pg.theme.Card()
+	card.Color = pg.theme.Color.Surface
+	return layout.Inset{Top: values.MarginPadding10}.Layout(gtx, func(gtx C) D {
+		return card.Layout(gtx, func(gtx C) D {
+			gtx.Constraints.Min.X = gtx.Constraints.Max.X
+			gtx.Constraints.Min.Y = gtx.Constraints.Max.Y
+			return layout.UniformInset(values.MarginPadding15).Layout(gtx, func(gtx C) D {
+				pg.entriesLock.Lock()
+				defer pg.entriesLock.Unlock()
+
+				if len(pg.filtered) == 0 {
+					return pg.theme.Body2(values.String(values.StrNoLogEntries)).Layout(gtx)
+				}
+
+				pg.entriesList.ScrollToEnd = pg.following
+				return pg.entriesList.Layout(gtx, len(pg.filtered), func(gtx C, i int) D {
+					return pg.entryLabel(pg.entries[pg.filtered[i]]).Layout(gtx)
+				})
+			})
+		})
+	})
+}
+
+func (pg *logPage) entryLabel(entry logEntry) decredmaterial.Label {
+	label := pg.theme.Body2(entry.raw)
+	switch entry.level {
+	case "ERR", "CRT":
+		label.Color = pg.theme.Color.Danger
+	case "WRN":
+		label.Color = pg.theme.Color.Orange
+	case "TRC", "DBG":
+		label.Color = pg.theme.Color.Gray
+	}
+	return label
+}
+
+func (pg *logPage) handle() {
+	changed := pg.follow.Changed()
+	for _, toggle := range pg.levels {
+		changed = toggle.Changed() || changed
+	}
+	for _, toggle := range pg.subsystems {
+		changed = toggle.Changed() || changed
+	}
+	for _, evt := range pg.searchEditor.Editor.Events() {
+		switch evt.(type) {
+		case widget.ChangeEvent, widget.SubmitEvent:
+			changed = true
+		}
+	}
+
+	if changed {
+		pg.entriesLock.Lock()
+		resume := pg.follow.Value && !pg.following
+		pg.following = pg.follow.Value
+		for name, toggle := range pg.levels {
+			pg.hidden[name] = !toggle.Value
+		}
+		for name, toggle := range pg.subsystems {
+			pg.hidden[name] = !toggle.Value
+		}
+		pg.search = strings.ToLower(strings.TrimSpace(pg.searchEditor.Editor.Text()))
+		pg.filterEntries()
+		pg.entriesLock.Unlock()
+
+		// catch up on the entries logged while paused
+		if resume && logFile != "" {
+			go pg.followLog()
+		}
+	}
+
+	for pg.exportButton.Button.Clicked() {
+		go pg.exportLogEntries()
+	}
+}
+
 func (pg *logPage) onClose() {}
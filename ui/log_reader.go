@@ -0,0 +1,209 @@
+package ui
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// logFile is the path of the log file the log rotator writes to.
+var logFile string
+
+// UseLogFile sets the log file the log page reads. Rolled files next to it
+// are read as history.
+func UseLogFile(path string) {
+	logFile = path
+}
+
+// logLevels are the slog level tags, from least to most severe.
+var logLevels = []string{"TRC", "DBG", "INF", "WRN", "ERR", "CRT"}
+
+// logSubsystems are the subsystem tags of the app and dcrlibwallet loggers.
+var logSubsystems = []string{"GDCR", "WALL", "UI", "DLWL", "LODR", "WLLT", "TKBY", "SYNC", "CMGR", "AMGR"}
+
+// logLinePattern matches the prefix slog writes before every message, e.g.
+// "2021-04-01 12:00:00.000 [INF] GDCR: message".
+var logLinePattern = regexp.MustCompile(`^(\d{4}-\d{2}-\d{2} \d{2}:\d{2}:\d{2}\.\d{3}) \[([A-Z]{3})\] ([^:\s]+): (.*)$`)
+
+// logEntry is a single slog message. Lines that don't start with a slog
+// prefix continue the message before them.
+type logEntry struct {
+	time      string
+	level     string
+	subsystem string
+	message   string
+	raw       string
+}
+
+// maxLogEntries is the most entries the log page keeps. The oldest entries
+// are dropped as new ones are logged.
+const maxLogEntries = 10000
+
+// trimLogEntries drops the oldest entries beyond maxLogEntries and returns the
+// entries kept and the number dropped.
+func trimLogEntries(entries []logEntry) ([]logEntry, int) {
+	dropped := len(entries) - maxLogEntries
+	if dropped <= 0 {
+		return entries, 0
+	}
+	n := copy(entries, entries[dropped:])
+	for i := n; i < len(entries); i++ {
+		entries[i] = logEntry{}
+	}
+	return entries[:n], dropped
+}
+
+// parseLogLines parses the slog lines read from r, continuing the entries in
+// entries.
+func parseLogLines(r io.Reader, entries []logEntry) ([]logEntry, error) {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if m := logLinePattern.FindStringSubmatch(line); m != nil {
+			entries = append(entries, logEntry{
+				time:      m[1],
+				level:     m[2],
+				subsystem: m[3],
+				message:   m[4],
+				raw:       line,
+			})
+			continue
+		}
+
+		if n := len(entries); n > 0 {
+			entries[n-1].message += "\n" + line
+			entries[n-1].raw += "\n" + line
+			continue
+		}
+		entries = append(entries, logEntry{message: line, raw: line})
+	}
+	return entries, scanner.Err()
+}
+
+// rolledLogFiles returns the files the rotator rolled logFile into, oldest
+// first. A roll that is still being compressed is read uncompressed.
+func rolledLogFiles(logFile string) ([]string, error) {
+	matches, err := filepath.Glob(logFile + ".*")
+	if err != nil {
+		return nil, err
+	}
+
+	rolls := make(map[int]string)
+	for _, name := range matches {
+		suffix := strings.TrimPrefix(name, logFile+".")
+		compressed := strings.HasSuffix(suffix, ".gz")
+		num, err := strconv.Atoi(strings.TrimSuffix(suffix, ".gz"))
+		if err != nil {
+			continue
+		}
+		if _, ok := rolls[num]; !ok || !compressed {
+			rolls[num] = name
+		}
+	}
+
+	nums := make([]int, 0, len(rolls))
+	for num := range rolls {
+		nums = append(nums, num)
+	}
+	sort.Ints(nums)
+
+	files := make([]string, len(nums))
+	for i, num := range nums {
+		files[i] = rolls[num]
+	}
+	return files, nil
+}
+
+// readLogFile parses the log file at path from offset, decompressing rolled
+// files. A trailing line without a newline is left for the next read. It
+// returns the offset read up to.
+func readLogFile(path string, offset int64, entries []logEntry) ([]logEntry, int64, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return entries, offset, err
+	}
+	defer f.Close()
+
+	var r io.Reader = f
+	if strings.HasSuffix(path, ".gz") {
+		z, err := gzip.NewReader(f)
+		if err != nil {
+			return entries, offset, err
+		}
+		defer z.Close()
+		r = z
+	}
+
+	if offset > 0 {
+		skipped, err := io.CopyN(ioutil.Discard, r, offset)
+		if err != nil && err != io.EOF {
+			return entries, offset, err
+		}
+		offset = skipped
+	}
+
+	data, err := ioutil.ReadAll(r)
+	if err != nil {
+		return entries, offset, err
+	}
+	data = data[:bytes.LastIndexByte(data, '\n')+1]
+
+	entries, err = parseLogLines(bytes.NewReader(data), entries)
+	return entries, offset + int64(len(data)), err
+}
+
+// logHistory reads the rolled log files and logFile. It returns the offset
+// in logFile that followLog continues from.
+func logHistory(logFile string) ([]logEntry, int64, error) {
+	rolls, err := rolledLogFiles(logFile)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	var entries []logEntry
+	for _, roll := range rolls {
+		entries, _, err = readLogFile(roll, 0, entries)
+		if err != nil {
+			log.Warnf("Could not read log file %s: %v", roll, err)
+		}
+		entries, _ = trimLogEntries(entries)
+	}
+
+	entries, offset, err := readLogFile(logFile, 0, entries)
+	if os.IsNotExist(err) {
+		err = nil
+	}
+	return entries, offset, err
+}
+
+// followLog parses the lines written to logFile since offset, continuing
+// entries. If the file was rolled since, the rest of the newest roll is read
+// first.
+func followLog(logFile string, offset int64, entries []logEntry) ([]logEntry, int64, error) {
+	info, err := os.Stat(logFile)
+	if err != nil {
+		return entries, offset, err
+	}
+
+	if info.Size() < offset {
+		rolls, err := rolledLogFiles(logFile)
+		if err == nil && len(rolls) > 0 {
+			entries, _, err = readLogFile(rolls[len(rolls)-1], offset, entries)
+		}
+		if err != nil {
+			log.Warnf("Could not read rolled log file: %v", err)
+		}
+		offset = 0
+	}
+
+	return readLogFile(logFile, offset, entries)
+}
@@ -0,0 +1,91 @@
+package ui
+
+import (
+	"compress/gzip"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Log reader", func() {
+	var dir, file string
+
+	BeforeEach(func() {
+		var err error
+		dir, err = ioutil.TempDir("", "godcr-log")
+		Expect(err).To(BeNil())
+		file = filepath.Join(dir, "godcr.log")
+	})
+
+	AfterEach(func() {
+		os.RemoveAll(dir)
+	})
+
+	writeGzip := func(name, text string) {
+		f, err := os.Create(name)
+		Expect(err).To(BeNil())
+		z := gzip.NewWriter(f)
+		_, err = z.Write([]byte(text))
+		Expect(err).To(BeNil())
+		Expect(z.Close()).To(BeNil())
+		Expect(f.Close()).To(BeNil())
+	}
+
+	It("parses levels, subsystems and continuation lines", func() {
+		entries, err := parseLogLines(strings.NewReader(
+			"2021-04-01 12:00:00.000 [INF] GDCR: Starting\n"+
+				"2021-04-01 12:00:01.000 [ERR] WALL: Failed\n"+
+				"  detail\n"), nil)
+		Expect(err).To(BeNil())
+		Expect(entries).To(HaveLen(2))
+		Expect(entries[0].level).To(Equal("INF"))
+		Expect(entries[0].subsystem).To(Equal("GDCR"))
+		Expect(entries[1].message).To(Equal("Failed\n  detail"))
+	})
+
+	It("reads rolled files oldest first and follows new lines", func() {
+		writeGzip(file+".1.gz", "2021-04-01 12:00:00.000 [INF] UI: first\n")
+		Expect(ioutil.WriteFile(file+".2", []byte("2021-04-01 12:00:01.000 [INF] UI: second\n"), 0600)).To(BeNil())
+		Expect(ioutil.WriteFile(file, []byte("2021-04-01 12:00:02.000 [INF] UI: third\n2021-04-01"), 0600)).To(BeNil())
+
+		entries, offset, err := logHistory(file)
+		Expect(err).To(BeNil())
+		Expect(entries).To(HaveLen(3))
+		Expect(entries[0].message).To(Equal("first"))
+		Expect(entries[2].message).To(Equal("third"))
+
+		f, err := os.OpenFile(file, os.O_APPEND|os.O_WRONLY, 0600)
+		Expect(err).To(BeNil())
+		_, err = f.WriteString(" 12:00:03.000 [WRN] DLWL: fourth\n")
+		Expect(err).To(BeNil())
+		Expect(f.Close()).To(BeNil())
+
+		entries, _, err = followLog(file, offset, entries)
+		Expect(err).To(BeNil())
+		Expect(entries).To(HaveLen(4))
+		Expect(entries[3].level).To(Equal("WRN"))
+		Expect(entries[3].subsystem).To(Equal("DLWL"))
+	})
+
+	It("keeps the newest entries", func() {
+		entries := make([]logEntry, maxLogEntries+3)
+		for i := range entries {
+			entries[i].message = strconv.Itoa(i)
+		}
+
+		kept, dropped := trimLogEntries(entries)
+		Expect(dropped).To(Equal(3))
+		Expect(kept).To(HaveLen(maxLogEntries))
+		Expect(kept[0].message).To(Equal("3"))
+		Expect(kept[maxLogEntries-1].message).To(Equal(strconv.Itoa(maxLogEntries + 2)))
+
+		kept, dropped = trimLogEntries(kept)
+		Expect(dropped).To(Equal(0))
+		Expect(kept).To(HaveLen(maxLogEntries))
+	})
+})
@@ -10,6 +10,7 @@ import (
 	"github.com/planetdecred/dcrlibwallet"
 	"github.com/planetdecred/godcr/ui/decredmaterial"
 	"github.com/planetdecred/godcr/ui/values"
+	"github.com/planetdecred/godcr/wallet"
 )
 
 const PageMain = "Main"
@@ -53,8 +54,8 @@ func newMainPage(common *pageCommon) *mainPage {
 	common.returnPage = &mp.previous
 	common.page = &mp.current
 	common.toggleSync = func() {
-		if mp.multiWallet.IsConnectedToDecredNetwork() {
-			mp.multiWallet.CancelSync()
+		if mp.wallet.IsConnectedToDecredNetwork() {
+			mp.wallet.CancelSync()
 		} else {
 			mp.startSyncing()
 		}
@@ -126,10 +127,10 @@ func (mp *mainPage) initNavItems() {
 
 func (mp *mainPage) OnResume() {
 	// register for notifications
-	mp.multiWallet.AddAccountMixerNotificationListener(mp, PageMain)
-	mp.multiWallet.Politeia.AddNotificationListener(mp, PageMain)
-	mp.multiWallet.AddTxAndBlockNotificationListener(mp, PageMain)
-	mp.multiWallet.AddSyncProgressListener(mp, PageMain)
+	mp.wallet.AddAccountMixerNotificationListener(mp, PageMain)
+	mp.wallet.AddProposalNotificationListener(mp, PageMain)
+	mp.wallet.AddTxAndBlockNotificationListener(mp, PageMain)
+	mp.wallet.AddSyncProgressListener(mp, PageMain)
 
 	mp.updateBalance()
 
@@ -157,7 +158,7 @@ func (mp *mainPage) updateBalance() {
 			usdExchangeRate, err := strconv.ParseFloat(mp.dcrUsdtBittrex.LastTradeRate, 64)
 			if err == nil {
 				balanceInUSD := totalBalance.ToCoin() * usdExchangeRate
-				mp.totalBalanceUSD = formatUSDBalance(mp.printer, balanceInUSD)
+				mp.totalBalanceUSD = formatUSDBalance(balanceInUSD)
 			}
 		}
 
@@ -167,7 +168,7 @@ func (mp *mainPage) updateBalance() {
 func (mp *mainPage) calculateTotalWalletsBalance() (dcrutil.Amount, error) {
 	totalBalance := int64(0)
 	for _, wallet := range mp.sortedWalletList() {
-		accountsResult, err := wallet.GetAccountsRaw()
+		accountsResult, err := mp.wallet.GetAccountsRaw(wallet.ID)
 		if err != nil {
 			return 0, err
 		}
@@ -182,27 +183,27 @@ func (mp *mainPage) calculateTotalWalletsBalance() (dcrutil.Amount, error) {
 
 func (mp *mainPage) startSyncing() {
 	for _, wal := range mp.sortedWalletList() {
-		if !wal.HasDiscoveredAccounts && wal.IsLocked() {
+		if !mp.wallet.HasDiscoveredAccounts(wal.ID) && mp.wallet.IsLocked(wal.ID) {
 			mp.unlockWalletForSyncing(wal)
 			return
 		}
 	}
 
-	err := mp.multiWallet.SpvSync()
+	err := mp.wallet.StartSync()
 	if err != nil {
 		// show error dialog
 		log.Info("Error starting sync:", err)
 	}
 }
 
-func (mp *mainPage) unlockWalletForSyncing(wal *dcrlibwallet.Wallet) {
+func (mp *mainPage) unlockWalletForSyncing(wal *wallet.WalletSummary) {
 	newPasswordModal(mp.pageCommon).
 		title(values.String(values.StrResumeAccountDiscoveryTitle)).
 		hint(wal.Name+" Spending password").
 		negativeButton(values.String(values.StrCancel), func() {}).
 		positiveButton(values.String(values.StrUnlock), func(password string, pm *passwordModal) bool {
 			go func() {
-				err := mp.multiWallet.UnlockWallet(wal.ID, []byte(password))
+				err := mp.wallet.UnlockWallet(wal.ID, []byte(password))
 				if err != nil {
 					errText := err.Error()
 					if err.Error() == "invalid_passphrase" {
@@ -233,34 +234,57 @@ func (mp *mainPage) handle() {
 
 	for i := range mp.appBarNavItems {
 		for mp.appBarNavItems[i].clickable.Clicked() {
-			mp.setReturnPage(mp.current)
-			mp.changePage(mp.appBarNavItems[i].page)
+			mp.openAppBarNavItem(i)
 		}
 	}
 
 	for i := range mp.drawerNavItems {
 		for mp.drawerNavItems[i].clickable.Clicked() {
-			if i == 0 {
-				mp.changeFragment(OverviewPage(mp.pageCommon), PageOverview)
-			} else if i == 1 {
-				mp.changeFragment(TransactionsPage(mp.pageCommon), PageTransactions)
-			} else if i == 2 {
-				mp.changeFragment(WalletPage(mp.pageCommon), PageWallet)
-			} else {
-				mp.changePage(mp.drawerNavItems[i].page)
-			}
+			mp.openDrawerNavItem(i)
 		}
 	}
 }
 
+func (mp *mainPage) openAppBarNavItem(i int) {
+	mp.setReturnPage(mp.current)
+	mp.changePage(mp.appBarNavItems[i].page)
+}
+
+func (mp *mainPage) openDrawerNavItem(i int) {
+	if i == 0 {
+		mp.changeFragment(OverviewPage(mp.pageCommon), PageOverview)
+	} else if i == 1 {
+		mp.changeFragment(TransactionsPage(mp.pageCommon), PageTransactions)
+	} else if i == 2 {
+		mp.changeFragment(WalletPage(mp.pageCommon), PageWallet)
+	} else {
+		mp.changePage(mp.drawerNavItems[i].page)
+	}
+}
+
+// back returns to the page set with setReturnPage, or to the overview if
+// there is none.
+func (mp *mainPage) back() {
+	if mp.previous != "" && mp.previous != mp.current {
+		previous := mp.previous
+		mp.previous = ""
+		mp.changePage(previous)
+		return
+	}
+
+	if mp.current != PageOverview {
+		mp.openDrawerNavItem(0)
+	}
+}
+
 func (mp *mainPage) onClose() {
 	if pg, ok := mp.pages[mp.current]; ok {
 		pg.onClose()
 	}
-	mp.multiWallet.RemoveAccountMixerNotificationListener(PageMain)
-	mp.multiWallet.Politeia.RemoveNotificationListener(PageMain)
-	mp.multiWallet.RemoveTxAndBlockNotificationListener(PageMain)
-	mp.multiWallet.RemoveSyncProgressListener(PageMain)
+	mp.wallet.RemoveAccountMixerNotificationListener(PageMain)
+	mp.wallet.RemoveProposalNotificationListener(PageMain)
+	mp.wallet.RemoveTxAndBlockNotificationListener(PageMain)
+	mp.wallet.RemoveSyncProgressListener(PageMain)
 }
 
 func (mp *mainPage) changeFragment(page Page, id string) {
@@ -355,7 +379,7 @@ func (mp *mainPage) layoutTopBar(gtx layout.Context) layout.Dimensions {
 										}),
 										layout.Rigid(func(gtx C) D {
 											return layout.Center.Layout(gtx, func(gtx C) D {
-												return mp.layoutBalance(gtx, mp.totalBalance.String(), true)
+												return mp.layoutBalance(gtx, wallet.FormatAmount(int64(mp.totalBalance)), true)
 											})
 										}),
 										layout.Rigid(func(gtx C) D {
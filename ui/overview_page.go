@@ -4,7 +4,6 @@ import (
 	"fmt"
 	"image"
 	"image/color"
-	"time"
 
 	"gioui.org/gesture"
 	"gioui.org/io/event"
@@ -37,8 +36,9 @@ type overviewPage struct {
 	theme *decredmaterial.Theme
 	tab   *decredmaterial.Tabs
 
-	allWallets   []*dcrlibwallet.Wallet
+	allWallets   []*wallet.WalletSummary
 	transactions []dcrlibwallet.Transaction
+	balanceChart *balanceChart
 
 	toTransactions    decredmaterial.TextAndIconButton
 	sync              decredmaterial.Button
@@ -81,7 +81,7 @@ func OverviewPage(c *pageCommon) Page {
 		walletSyncList:   &layout.List{Axis: layout.Vertical},
 		transactionsList: &layout.List{Axis: layout.Vertical},
 
-		bestBlock: c.multiWallet.GetBestBlock(),
+		bestBlock: c.wallet.GetBestBlock(),
 
 		syncButtonHeight: 50,
 		moreButtonWidth:  115,
@@ -116,22 +116,25 @@ func OverviewPage(c *pageCommon) Page {
 	pg.walletStatusIcon = c.icons.imageBrightness1
 	pg.cachedIcon = c.icons.cached
 
+	pg.balanceChart = newBalanceChart(c, pg.allWallets)
+
 	return pg
 }
 
 func (pg *overviewPage) OnResume() {
-	pg.walletSyncing = pg.multiWallet.IsSyncing()
-	pg.walletSynced = pg.multiWallet.IsSynced()
-	pg.isConnnected = pg.multiWallet.IsConnectedToDecredNetwork()
-	pg.connectedPeers = pg.multiWallet.ConnectedPeers()
-	pg.bestBlock = pg.multiWallet.GetBestBlock()
+	pg.walletSyncing = pg.wallet.IsSyncing()
+	pg.walletSynced = pg.wallet.IsSynced()
+	pg.isConnnected = pg.wallet.IsConnectedToDecredNetwork()
+	pg.connectedPeers = pg.wallet.ConnectedPeers()
+	pg.bestBlock = pg.wallet.GetBestBlock()
 
 	pg.loadTransactions()
+	pg.balanceChart.load()
 	pg.listenForSyncNotifications()
 }
 
 func (pg *overviewPage) loadTransactions() {
-	transactions, err := pg.multiWallet.GetTransactionsRaw(0, 5, dcrlibwallet.TxFilterAll, true)
+	transactions, err := pg.wallet.GetTransactionsRaw(0, 5, dcrlibwallet.TxFilterAll, true)
 	if err != nil {
 		log.Error("Error getting transactions:", err)
 		return
@@ -153,6 +156,7 @@ func (pg *overviewPage) Layout(gtx layout.Context) layout.Dimensions {
 	}
 
 	pageContent := []func(gtx C) D{
+		pg.balanceChart.layout,
 		func(gtx C) D {
 			return pg.recentTransactionsSection(gtx, c)
 		},
@@ -368,7 +372,7 @@ func (pg *overviewPage) blockInfoRow(gtx layout.Context) layout.Dimensions {
 			})
 		}),
 		layout.Rigid(func(gtx C) D {
-			currentSeconds := time.Now().UnixNano() / int64(time.Second)
+			currentSeconds := gtx.Now.Unix()
 			return layout.Inset{Right: values.MarginPadding5}.Layout(gtx, pg.theme.Body1(wallet.SecondsToDays(currentSeconds-pg.bestBlock.Timestamp)).Layout)
 		}),
 		layout.Rigid(func(gtx C) D {
@@ -530,17 +534,18 @@ func (pg *overviewPage) walletSyncRow(gtx layout.Context, inset layout.Inset) la
 			layout.Rigid(func(gtx C) D {
 				var walletSyncBoxes []layout.Widget
 
-				currentSeconds := time.Now().UnixNano() / int64(time.Second)
+				currentSeconds := gtx.Now.Unix()
 				for i := 0; i < len(pg.allWallets); i++ {
 					w := pg.allWallets[i]
 
 					status := "syncing..."
-					if w.IsWaiting() {
+					if pg.wallet.IsWaiting(w.ID) {
 						status = "waiting..."
 					}
 
-					blockHeightProgress := values.StringF(values.StrBlockHeaderFetchedCount, w.GetBestBlock(), pg.headersToFetchOrScan)
-					daysBehind := wallet.SecondsToDays(currentSeconds - w.GetBestBlockTimeStamp())
+					bestBlock, bestBlockTime := pg.wallet.WalletBestBlock(w.ID)
+					blockHeightProgress := values.StringF(values.StrBlockHeaderFetchedCount, bestBlock, pg.headersToFetchOrScan)
+					daysBehind := wallet.SecondsToDays(currentSeconds - bestBlockTime)
 					details := pg.syncDetail(w.Name, status, blockHeightProgress, daysBehind)
 					uniform := layout.UniformInset(values.MarginPadding5)
 					walletSyncBoxes = append(walletSyncBoxes,
@@ -640,6 +645,7 @@ func (pg *overviewPage) listenForSyncNotifications() {
 			switch n := notification.(type) {
 			case wallet.NewTransaction:
 				pg.loadTransactions()
+				pg.balanceChart.load()
 			case wallet.SyncStatusUpdate:
 				switch t := n.ProgressReport.(type) {
 				case wallet.SyncHeadersFetchProgress:
@@ -668,11 +674,12 @@ func (pg *overviewPage) listenForSyncNotifications() {
 					fallthrough
 				case wallet.SyncCompleted:
 					pg.loadTransactions()
-					pg.walletSyncing = pg.multiWallet.IsSyncing()
-					pg.walletSynced = pg.multiWallet.IsSynced()
-					pg.isConnnected = pg.multiWallet.IsConnectedToDecredNetwork()
+					pg.balanceChart.load()
+					pg.walletSyncing = pg.wallet.IsSyncing()
+					pg.walletSynced = pg.wallet.IsSynced()
+					pg.isConnnected = pg.wallet.IsConnectedToDecredNetwork()
 				case wallet.BlockAttached:
-					pg.bestBlock = pg.multiWallet.GetBestBlock()
+					pg.bestBlock = pg.wallet.GetBestBlock()
 				}
 			}
 
@@ -5,13 +5,10 @@ import (
 	"image"
 	"image/color"
 	"net/http"
-	"sort"
+	"strconv"
 
 	"gioui.org/unit"
 
-	"golang.org/x/text/language"
-	"golang.org/x/text/message"
-
 	"gioui.org/gesture"
 	"gioui.org/io/key"
 	"gioui.org/layout"
@@ -28,7 +25,7 @@ import (
 type pageIcons struct {
 	contentAdd, navigationCheck, navigationMore, actionCheckCircle, actionInfo, navigationArrowBack,
 	navigationArrowForward, actionCheck, chevronRight, navigationCancel, navMoreIcon,
-	imageBrightness1, contentClear, dropDownIcon, cached, contentRemove *widget.Icon
+	imageBrightness1, contentClear, dropDownIcon, cached, contentRemove, star, starBorder *widget.Icon
 
 	overviewIcon, overviewIconInactive, walletIcon, walletIconInactive,
 	receiveIcon, transactionIcon, transactionIconInactive, sendIcon, moreIcon, moreIconInactive,
@@ -85,11 +82,9 @@ type DCRUSDTBittrex struct {
 }
 
 type pageCommon struct {
-	printer             *message.Printer
-	multiWallet         *dcrlibwallet.MultiWallet
 	network             string
 	notificationsUpdate chan interface{}
-	wallet              *wallet.Wallet
+	wallet              wallet.Backend
 	walletAccount       **wallet.Account
 	info                *wallet.MultiWalletInfo
 	selectedWallet      *int
@@ -109,16 +104,20 @@ type pageCommon struct {
 	acctMixerStatus     *chan *wallet.AccountMixer
 	selectedProposal    **dcrlibwallet.Proposal
 	proposals           **wallet.Proposals
+	proposalVersions    **wallet.ProposalVersions
+	proposalComments    **wallet.ProposalComments
 	syncedProposal      chan *wallet.Proposal
-	txAuthor            *dcrlibwallet.TxAuthor
+	txAuthor            *wallet.TxAuthor
 	broadcastResult     *wallet.Broadcast
-	signatureResult     **wallet.Signature
 	walletTickets       **wallet.Tickets
 	vspInfo             **wallet.VSP
+	priceForecast       **wallet.TicketPriceForecast
 	unspentOutputs      **wallet.UnspentOutputs
 	showModal           func(Modal)
 	dismissModal        func(Modal)
 	toggleSync          func()
+	uiScale             *uiScale
+	shortcuts           *shortcuts
 
 	testButton decredmaterial.Button
 
@@ -155,6 +154,8 @@ func (win *Window) newPageCommon(decredIcons map[string]image.Image) *pageCommon
 		dropDownIcon:           mustIcon(widget.NewIcon(icons.NavigationArrowDropDown)),
 		cached:                 mustIcon(widget.NewIcon(icons.ActionCached)),
 		contentRemove:          mustIcon(widget.NewIcon(icons.ContentRemove)),
+		star:                   mustIcon(widget.NewIcon(icons.ToggleStar)),
+		starBorder:             mustIcon(widget.NewIcon(icons.ToggleStarBorder)),
 
 		overviewIcon:               &widget.Image{Src: paint.NewImageOp(decredIcons["overview"])},
 		overviewIconInactive:       &widget.Image{Src: paint.NewImageOp(decredIcons["overview_inactive"])},
@@ -216,9 +217,7 @@ func (win *Window) newPageCommon(decredIcons map[string]image.Image) *pageCommon
 	}
 
 	common := &pageCommon{
-		printer:             message.NewPrinter(language.English),
-		multiWallet:         win.wallet.GetMultiWallet(),
-		network:             win.wallet.Net,
+		network:             win.wallet.Network(),
 		notificationsUpdate: make(chan interface{}, 10),
 		wallet:              win.wallet,
 		walletAccount:       &win.walletAccount,
@@ -235,18 +234,22 @@ func (win *Window) newPageCommon(decredIcons map[string]image.Image) *pageCommon
 		acctMixerStatus:  &win.walletAcctMixerStatus,
 		selectedProposal: &win.selectedProposal,
 		proposals:        &win.proposals,
+		proposalVersions: &win.proposalVersions,
+		proposalComments: &win.proposalComments,
 		syncedProposal:   win.proposal,
 		txAuthor:         &win.txAuthor,
 		broadcastResult:  &win.broadcastResult,
-		signatureResult:  &win.signatureResult,
 		walletTickets:    &win.walletTickets,
 		vspInfo:          &win.vspInfo,
+		priceForecast:    &win.ticketPriceForecast,
 		unspentOutputs:   &win.walletUnspentOutputs,
 		showModal:        win.showModal,
 		dismissModal:     win.dismissModal,
 		changeWindowPage: win.changePage,
 		popWindowPage:    win.popPage,
 		refreshWindow:    win.refreshWindow,
+		uiScale:          &win.scale,
+		shortcuts:        win.shortcuts,
 
 		selectedUTXO: make(map[int]map[int32]map[string]*wallet.UnspentOutput),
 		toast:        &win.toast,
@@ -278,6 +281,7 @@ func (common *pageCommon) loadPages() map[string]Page {
 	pages[PageDebug] = DebugPage(common)
 	pages[PageLog] = LogPage(common)
 	pages[PageStat] = StatPage(common)
+	pages[PageCrashReports] = CrashReportsPage(common)
 	pages[PageAbout] = AboutPage(common)
 	pages[PageHelp] = HelpPage(common)
 	pages[PageUTXO] = UTXOPage(common)
@@ -285,6 +289,11 @@ func (common *pageCommon) loadPages() map[string]Page {
 	pages[ValidateAddress] = ValidateAddressPage(common)
 	pages[PageTicketsList] = TicketPageList(common)
 	pages[PageTicketsActivity] = TicketActivityPage(common)
+	pages[PageStakingRewards] = StakingRewardsPage(common)
+	pages[PageVSPs] = VSPsPage(common)
+	pages[PageAgendas] = AgendasPage(common)
+	pages[PageTicketPrice] = TicketPricePage(common)
+	pages[PageProposalVersions] = ProposalVersionsPage(common)
 
 	return pages
 }
@@ -296,6 +305,32 @@ func (common *pageCommon) refreshTheme() {
 	}
 }
 
+// applyAmountDisplay applies the saved amount unit and precision, and
+// formats amounts for the current language.
+func (common *pageCommon) applyAmountDisplay() {
+	wallet.SetAmountLocale(wallet.AmountLocale{
+		GroupDigits:      values.FormatInteger,
+		GroupSeparator:   values.GroupSeparator,
+		DecimalSeparator: values.DecimalSeparator,
+	})
+
+	precision, err := strconv.Atoi(common.wallet.ReadStringConfigValueForKey(wallet.AmountPrecisionConfigKey))
+	if err != nil {
+		precision = wallet.AmountPrecisionAuto
+	}
+	wallet.SetAmountDisplay(common.wallet.ReadStringConfigValueForKey(wallet.AmountUnitConfigKey), precision)
+}
+
+// refreshAmountDisplay applies the saved amount unit and precision, and
+// reloads the wallet info and tickets so that their amounts use them.
+func (common *pageCommon) refreshAmountDisplay() {
+	common.applyAmountDisplay()
+	if common.wallet.IsConfigLoaded() && common.wallet.LoadedWalletsCount() > 0 {
+		common.wallet.GetMultiWalletInfo()
+		common.wallet.GetAllTickets()
+	}
+}
+
 func (common *pageCommon) fetchExchangeValue(target interface{}) error {
 	url := "https://api.bittrex.com/v3/markets/DCR-USDT/ticker"
 	res, err := http.Get(url)
@@ -320,14 +355,8 @@ func (common *pageCommon) notify(text string, success bool) {
 	}
 }
 
-func (common *pageCommon) sortedWalletList() []*dcrlibwallet.Wallet {
-	wallets := common.multiWallet.AllWallets()
-
-	sort.Slice(wallets, func(i, j int) bool {
-		return wallets[i].ID < wallets[j].ID
-	})
-
-	return wallets
+func (common *pageCommon) sortedWalletList() []*wallet.WalletSummary {
+	return common.wallet.AllWallets()
 }
 
 func (common *pageCommon) HDPrefix() string {
@@ -77,6 +77,21 @@ func (pm *passwordModal) Dismiss() {
 	pm.dismissModal(pm)
 }
 
+func (pm *passwordModal) cancelButton() *widget.Clickable {
+	return pm.btnNegative.Button
+}
+
+func (pm *passwordModal) focusTargets() []focusTarget {
+	targets := editorTargets(pm.password.Editor)
+	if pm.negativeButtonText != "" {
+		targets = append(targets, buttonTargets(pm.btnNegative.Button)...)
+	}
+	if pm.positiveButtonText != "" && !pm.isLoading {
+		targets = append(targets, buttonTargets(pm.btnPositve.Button)...)
+	}
+	return targets
+}
+
 func (pm *passwordModal) title(title string) *passwordModal {
 	pm.dialogTitle = title
 	return pm
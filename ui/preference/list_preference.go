@@ -12,7 +12,7 @@ import (
 )
 
 type ListPreference struct {
-	wallet        *wallet.Wallet
+	wallet        wallet.Backend
 	preferenceKey string
 	defaultValue  string // str-key
 	initialValue  string
@@ -37,7 +37,7 @@ type ListPreference struct {
 	negativeButton        decredmaterial.Button
 }
 
-func NewListPreference(wallet *wallet.Wallet, theme *decredmaterial.Theme, preferenceKey, defaultValue string, items map[string]string) *ListPreference {
+func NewListPreference(wallet wallet.Backend, theme *decredmaterial.Theme, preferenceKey, defaultValue string, items map[string]string) *ListPreference {
 
 	// sort keys to keep order when refreshed
 	sortedKeys := make([]string, 0)
@@ -9,7 +9,6 @@ import (
 	"gioui.org/widget/material"
 	"golang.org/x/exp/shiny/materialdesign/icons"
 
-	"github.com/decred/dcrd/dcrutil/v3"
 	"github.com/planetdecred/dcrlibwallet"
 	"github.com/planetdecred/godcr/ui/decredmaterial"
 	"github.com/planetdecred/godcr/ui/values"
@@ -19,7 +18,7 @@ import (
 const PagePrivacy = "Privacy"
 
 type privacyPage struct {
-	wallet                               *dcrlibwallet.Wallet
+	wallet                               *wallet.WalletSummary
 	theme                                *decredmaterial.Theme
 	common                               *pageCommon
 	pageContainer                        layout.List
@@ -32,7 +31,7 @@ type privacyPage struct {
 	infoButton decredmaterial.IconButton
 }
 
-func PrivacyPage(common *pageCommon, wallet *dcrlibwallet.Wallet) Page {
+func PrivacyPage(common *pageCommon, wallet *wallet.WalletSummary) Page {
 	pg := &privacyPage{
 		wallet:                  wallet,
 		theme:                   common.theme,
@@ -67,7 +66,7 @@ func (pg *privacyPage) Layout(gtx layout.Context) layout.Dimensions {
 			},
 			infoTemplate: PrivacyInfoTemplate,
 			body: func(gtx layout.Context) layout.Dimensions {
-				if pg.wallet.AccountMixerConfigIsSet() {
+				if c.wallet.IsAccountMixerConfigSet(pg.wallet.ID) {
 					widgets := []func(gtx C) D{
 						func(gtx C) D {
 							return pg.mixerInfoLayout(gtx, c)
@@ -161,7 +160,7 @@ func (pg *privacyPage) mixerInfoStatusTextLayout(gtx layout.Context, c *pageComm
 	subtxt.Color = c.theme.Color.Gray
 	iconVisibility := false
 
-	if pg.wallet.IsAccountMixerActive() {
+	if c.wallet.IsAccountMixerActive(pg.wallet.ID) {
 		txt.Text = "Mixer is running..."
 		subtxt.Text = "Keep this app opened"
 		iconVisibility = true
@@ -213,12 +212,12 @@ func (pg *privacyPage) mixerInfoLayout(gtx layout.Context, c *pageCommon) layout
 						return layout.UniformInset(values.MarginPadding15).Layout(gtx, func(gtx C) D {
 							var mixedBalance = "0.00"
 							var unmixedBalance = "0.00"
-							accounts, _ := pg.wallet.GetAccountsRaw()
+							accounts, _ := c.wallet.GetAccountsRaw(pg.wallet.ID)
 							for _, acct := range accounts.Acc {
-								if acct.Number == pg.wallet.MixedAccountNumber() {
-									mixedBalance = dcrutil.Amount(acct.TotalBalance).String()
-								} else if acct.Number == pg.wallet.UnmixedAccountNumber() {
-									unmixedBalance = dcrutil.Amount(acct.TotalBalance).String()
+								if acct.Number == c.wallet.MixedAccountNumber(pg.wallet.ID) {
+									mixedBalance = wallet.FormatAmount(acct.TotalBalance)
+								} else if acct.Number == c.wallet.UnmixedAccountNumber(pg.wallet.ID) {
+									unmixedBalance = wallet.FormatAmount(acct.TotalBalance)
 								}
 							}
 
@@ -296,7 +295,7 @@ func (pg *privacyPage) mixerSettingsLayout(gtx layout.Context, c *pageCommon) la
 }
 
 func (pg *privacyPage) shufflePortForCurrentNet(c *pageCommon) string {
-	if c.wallet.Net == "testnet3" {
+	if c.wallet.Network() == "testnet3" {
 		return dcrlibwallet.TestnetShufflePort
 	}
 
@@ -342,7 +341,7 @@ func (pg *privacyPage) handle() {
 		if pg.toggleMixer.Value {
 			go pg.showModalPasswordStartAccountMixer(common)
 		} else {
-			go common.multiWallet.StopAccountMixer(pg.wallet.ID)
+			go common.wallet.StopAccountMixer(pg.wallet.ID)
 		}
 	}
 
@@ -369,7 +368,7 @@ func (pg *privacyPage) showModalSetupMixerInfo(common *pageCommon) {
 }
 
 func (pg *privacyPage) showModalSetupMixerAcct(common *pageCommon) {
-	accounts, _ := pg.wallet.GetAccountsRaw()
+	accounts, _ := common.wallet.GetAccountsRaw(pg.wallet.ID)
 	for _, acct := range accounts.Acc {
 		if acct.Name == "mixed" || acct.Name == "unmixed" {
 			alert := mustIcon(widget.NewIcon(icons.AlertError))
@@ -392,7 +391,7 @@ func (pg *privacyPage) showModalSetupMixerAcct(common *pageCommon) {
 		negativeButton("Cancel", func() {}).
 		positiveButton("Confirm", func(password string, pm *passwordModal) bool {
 			go func() {
-				err := pg.wallet.CreateMixerAccounts("mixed", "unmixed", password)
+				err := common.wallet.CreateMixerAccounts(pg.wallet.ID, "mixed", "unmixed", password)
 				if err != nil {
 					pm.setError(err.Error())
 					pm.setLoading(false)
@@ -413,7 +412,7 @@ func (pg *privacyPage) showModalPasswordStartAccountMixer(common *pageCommon) {
 		positiveButton("Confirm", func(password string, pm *passwordModal) bool {
 			go func() {
 
-				err := common.multiWallet.StartAccountMixer(pg.wallet.ID, password)
+				err := common.wallet.StartAccountMixer(pg.wallet.ID, password)
 				if err != nil {
 					pm.setError(err.Error())
 					pm.setLoading(false)
@@ -0,0 +1,280 @@
+package ui
+
+import (
+	"sort"
+
+	"gioui.org/layout"
+	"gioui.org/text"
+	"gioui.org/unit"
+	"gioui.org/widget"
+
+	"github.com/planetdecred/godcr/ui/decredmaterial"
+	"github.com/planetdecred/godcr/ui/renderers"
+	"github.com/planetdecred/godcr/ui/values"
+	"github.com/planetdecred/godcr/wallet"
+)
+
+// The orders the comments on a proposal can be sorted in.
+const (
+	sortTopComments = iota
+	sortNewestComments
+)
+
+var commentSortOrders = []string{values.StrTop, values.StrNewest}
+
+// commentRow is a comment in a thread, with the number of comments it is a
+// reply in.
+type commentRow struct {
+	comment *wallet.ProposalComment
+	depth   int
+}
+
+// flattenComments returns the rows of the threads of comments, with the
+// comments and the replies to each sorted in order and every comment followed
+// by its replies.
+func flattenComments(comments []*wallet.ProposalComment, order int) []commentRow {
+	var rows []commentRow
+	var add func(comments []*wallet.ProposalComment, depth int)
+	add = func(comments []*wallet.ProposalComment, depth int) {
+		sorted := append([]*wallet.ProposalComment(nil), comments...)
+		sort.SliceStable(sorted, func(i, j int) bool {
+			a, b := sorted[i], sorted[j]
+			if order == sortTopComments && a.Score != b.Score {
+				return a.Score > b.Score
+			}
+			return a.Timestamp.After(b.Timestamp)
+		})
+		for _, c := range sorted {
+			rows = append(rows, commentRow{comment: c, depth: depth})
+			add(c.Replies, depth+1)
+		}
+	}
+	add(comments, 0)
+	return rows
+}
+
+// commentsView lays out the threads of comments on the selected proposal.
+type commentsView struct {
+	common   *pageCommon
+	theme    *decredmaterial.Theme
+	comments **wallet.ProposalComments
+
+	container   layout.List
+	retryButton decredmaterial.Button
+	sortButtons []decredmaterial.Button
+
+	order int
+	// loaded are the comments the rows are of.
+	loaded *wallet.ProposalComments
+	rows   []commentRow
+	// rendered are the markdown widgets of the comments, by ID. They are
+	// rendered on the first layout after the comments are loaded.
+	rendered map[string][]layout.Widget
+	links    map[string]*widget.Clickable
+}
+
+func newCommentsView(common *pageCommon) *commentsView {
+	cv := &commentsView{
+		common:      common,
+		theme:       common.theme,
+		comments:    common.proposalComments,
+		container:   layout.List{Axis: layout.Vertical},
+		retryButton: common.theme.Button(new(widget.Clickable), values.String(values.StrRetry)),
+	}
+	for _, label := range commentSortOrders {
+		button := common.theme.Button(new(widget.Clickable), values.String(label))
+		button.TextSize = values.TextSize12
+		button.Inset = layout.UniformInset(values.MarginPadding5)
+		cv.sortButtons = append(cv.sortButtons, button)
+	}
+	return cv
+}
+
+// load gets the comments on the proposal with token unless they are loaded.
+func (cv *commentsView) load(token string) {
+	if cv.current(token) == nil {
+		cv.common.wallet.GetProposalComments(token)
+	}
+}
+
+// current returns the loaded comments if they are on the proposal with token.
+func (cv *commentsView) current(token string) *wallet.ProposalComments {
+	if cv.loaded == nil || cv.loaded.Token != token {
+		return nil
+	}
+	return cv.loaded
+}
+
+// sort orders the rows of the loaded comments.
+func (cv *commentsView) sort() {
+	cv.rows = nil
+	if cv.loaded.Err == nil {
+		cv.rows = flattenComments(cv.loaded.Comments, cv.order)
+	}
+}
+
+// render renders the markdown of the loaded comments.
+func (cv *commentsView) render(gtx C) {
+	cv.rendered = make(map[string][]layout.Widget)
+	cv.links = make(map[string]*widget.Clickable)
+	for _, row := range cv.rows {
+		if row.comment.Censored {
+			continue
+		}
+		widgets, links := renderers.RenderMarkdown(gtx, cv.theme, row.comment.Text).Layout()
+		cv.rendered[row.comment.ID] = widgets
+		for location, link := range links {
+			cv.links[location] = link
+		}
+	}
+}
+
+func (cv *commentsView) handle(token string) {
+	if comments := *cv.comments; comments != cv.loaded && comments.Token == token {
+		cv.loaded = comments
+		cv.sort()
+		cv.rendered = nil
+	}
+
+	for cv.retryButton.Button.Clicked() {
+		cv.loaded = nil
+		cv.load(token)
+	}
+
+	for i := range cv.sortButtons {
+		for cv.sortButtons[i].Button.Clicked() {
+			cv.order = i
+			if cv.loaded != nil {
+				cv.sort()
+			}
+		}
+	}
+
+	for location, link := range cv.links {
+		for link.Clicked() {
+			goToURL(location)
+		}
+	}
+}
+
+// layoutMessage lays out a message in place of the comments.
+func (cv *commentsView) layoutMessage(gtx C, message string, widgets ...layout.Widget) D {
+	txt := cv.theme.Body1(message)
+	txt.Color = cv.theme.Color.Gray2
+	children := []layout.FlexChild{layout.Rigid(txt.Layout)}
+	for _, w := range widgets {
+		w := w
+		children = append(children, layout.Rigid(func(gtx C) D {
+			return layout.Inset{Top: values.MarginPadding10}.Layout(gtx, w)
+		}))
+	}
+	return layout.Flex{Axis: layout.Vertical}.Layout(gtx, children...)
+}
+
+// layoutComment lays out a comment under its author, time and score, indented
+// by the number of comments it is a reply in.
+func (cv *commentsView) layoutComment(gtx C, row commentRow) D {
+	comment := row.comment
+	header := func(gtx C) D {
+		author := cv.theme.Body2(comment.Author)
+		author.Font.Weight = text.Bold
+		details := cv.theme.Caption(timeAgo(comment.Timestamp.Unix()) + " · " +
+			values.StringF(values.StrCommentScore, comment.Score))
+		details.Color = cv.theme.Color.Gray
+		return layout.Flex{Alignment: layout.Middle}.Layout(gtx,
+			layout.Rigid(author.Layout),
+			layout.Rigid(func(gtx C) D {
+				return layout.Inset{Left: values.MarginPadding10}.Layout(gtx, details.Layout)
+			}),
+		)
+	}
+	body := func(gtx C) D {
+		if comment.Censored {
+			txt := cv.theme.Body2(values.String(values.StrCommentCensored))
+			txt.Color = cv.theme.Color.Gray
+			txt.Font.Style = text.Italic
+			return txt.Layout(gtx)
+		}
+		widgets := cv.rendered[comment.ID]
+		children := make([]layout.FlexChild, len(widgets))
+		for i := range widgets {
+			children[i] = layout.Rigid(widgets[i])
+		}
+		return layout.Flex{Axis: layout.Vertical}.Layout(gtx, children...)
+	}
+
+	content := func(gtx C) D {
+		gtx.Constraints.Min.X = gtx.Constraints.Max.X
+		return layout.Flex{Axis: layout.Vertical}.Layout(gtx,
+			layout.Rigid(header),
+			layout.Rigid(func(gtx C) D {
+				return layout.Inset{Top: values.MarginPadding5}.Layout(gtx, body)
+			}),
+		)
+	}
+	if row.depth == 0 {
+		return layout.Inset{Top: values.MarginPadding10, Bottom: values.MarginPadding5}.Layout(gtx, content)
+	}
+
+	// replies are marked by a line on their left
+	indent := values.MarginPadding20
+	inset := layout.Inset{
+		Top:    values.MarginPadding5,
+		Bottom: values.MarginPadding5,
+		Left:   unit.Dp(indent.V * float32(row.depth-1)),
+	}
+	return inset.Layout(gtx, func(gtx C) D {
+		return layout.Stack{}.Layout(gtx,
+			layout.Expanded(func(gtx C) D {
+				gtx.Constraints.Min.X = gtx.Px(values.MarginPadding2)
+				return decredmaterial.Fill(gtx, cv.theme.Color.LightGray)
+			}),
+			layout.Stacked(func(gtx C) D {
+				return layout.Inset{Left: indent}.Layout(gtx, content)
+			}),
+		)
+	})
+}
+
+// layout lays out the comments on the proposal with token.
+func (cv *commentsView) layout(gtx C, token string) D {
+	return cv.theme.Card().Layout(gtx, func(gtx C) D {
+		gtx.Constraints.Min.X = gtx.Constraints.Max.X
+		return layout.UniformInset(values.MarginPadding16).Layout(gtx, func(gtx C) D {
+			comments := cv.current(token)
+			switch {
+			case comments == nil:
+				return cv.layoutMessage(gtx, values.String(values.StrLoadingComments))
+			case comments.Err != nil:
+				return cv.layoutMessage(gtx, values.StringF(values.StrCommentsFailed, comments.Err), cv.retryButton.Layout)
+			case comments.Count == 0:
+				return cv.layoutMessage(gtx, values.String(values.StrNoComments))
+			}
+
+			if cv.rendered == nil {
+				cv.render(gtx)
+			}
+			return cv.container.Layout(gtx, len(cv.rows)+1, func(gtx C, i int) D {
+				if i == 0 {
+					count := cv.theme.Body2(values.StringF(values.StrCommentCount, comments.Count))
+					count.Color = cv.theme.Color.Gray
+					return endToEndRow(gtx, count.Layout, func(gtx C) D {
+						return layoutRangeButtons(gtx, cv.theme, cv.sortButtons, cv.order)
+					})
+				}
+				row := cv.rows[i-1]
+				if row.depth > 0 || i == 1 {
+					return cv.layoutComment(gtx, row)
+				}
+				return layout.Flex{Axis: layout.Vertical}.Layout(gtx,
+					layout.Rigid(func(gtx C) D {
+						return layout.Inset{Top: values.MarginPadding10}.Layout(gtx, cv.theme.Separator().Layout)
+					}),
+					layout.Rigid(func(gtx C) D {
+						return cv.layoutComment(gtx, row)
+					}),
+				)
+			})
+		})
+	})
+}
@@ -0,0 +1,56 @@
+package ui
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/planetdecred/godcr/wallet"
+)
+
+var _ = Describe("Proposal comments", func() {
+	now := time.Date(2021, time.April, 1, 12, 0, 0, 0, time.UTC)
+	comment := func(id string, score int64, age time.Duration, replies ...*wallet.ProposalComment) *wallet.ProposalComment {
+		return &wallet.ProposalComment{ID: id, Score: score, Timestamp: now.Add(-age), Replies: replies}
+	}
+	comments := []*wallet.ProposalComment{
+		comment("1", 3, 5*time.Hour,
+			comment("2", 1, 4*time.Hour),
+			comment("3", 5, 3*time.Hour, comment("4", 0, time.Hour)),
+		),
+		comment("5", 3, 2*time.Hour),
+		comment("6", 7, 6*time.Hour),
+	}
+	ids := func(rows []commentRow) []string {
+		var ids []string
+		for _, row := range rows {
+			ids = append(ids, row.comment.ID)
+		}
+		return ids
+	}
+	depths := func(rows []commentRow) []int {
+		var depths []int
+		for _, row := range rows {
+			depths = append(depths, row.depth)
+		}
+		return depths
+	}
+
+	It("puts the top comments and replies first, the newest of equal score first", func() {
+		rows := flattenComments(comments, sortTopComments)
+		Expect(ids(rows)).To(Equal([]string{"6", "5", "1", "3", "4", "2"}))
+		Expect(depths(rows)).To(Equal([]int{0, 0, 0, 1, 2, 1}))
+	})
+
+	It("puts the newest comments and replies first", func() {
+		rows := flattenComments(comments, sortNewestComments)
+		Expect(ids(rows)).To(Equal([]string{"5", "1", "3", "4", "2", "6"}))
+	})
+
+	It("leaves the comments in their order", func() {
+		flattenComments(comments, sortTopComments)
+		Expect(comments[0].ID).To(Equal("1"))
+		Expect(comments[0].Replies[0].ID).To(Equal("2"))
+	})
+})
@@ -1,12 +1,18 @@
 package ui
 
 import (
+	"bytes"
 	"fmt"
+	"image"
+	"image/color"
+	_ "image/jpeg" // makes jpeg attachments decodable
+	"path"
 	"strings"
 	"time"
 
 	"gioui.org/font/gofont"
 	"gioui.org/layout"
+	"gioui.org/op/paint"
 	"gioui.org/text"
 	"gioui.org/unit"
 	"gioui.org/widget"
@@ -16,13 +22,22 @@ import (
 	"github.com/planetdecred/godcr/ui/decredmaterial"
 	"github.com/planetdecred/godcr/ui/renderers"
 	"github.com/planetdecred/godcr/ui/values"
+	"github.com/planetdecred/godcr/wallet"
 )
 
 const (
 	PageProposalDetails = "ProposalDetails"
 )
 
+// The tabs of the proposal details page.
+const (
+	descriptionTab = iota
+	commentsTab
+)
+
 type proposalItemWidgets struct {
+	// version is the version of the proposal the widgets were rendered for.
+	version    string
 	widgets    []layout.Widget
 	clickables map[string]*widget.Clickable
 }
@@ -35,6 +50,7 @@ type proposalDetails struct {
 	proposalItems      map[string]proposalItemWidgets
 	descriptionList    *layout.List
 	selectedProposal   **dcrlibwallet.Proposal
+	proposals          **wallet.Proposals
 	redirectIcon       *widget.Image
 	voteBar            decredmaterial.VoteBar
 	rejectedIcon       *widget.Icon
@@ -42,7 +58,11 @@ type proposalDetails struct {
 	timerIcon          *widget.Image
 	successIcon        *widget.Icon
 	vote               decredmaterial.Button
+	versionHistory     decredmaterial.Button
 	backButton         decredmaterial.IconButton
+	tabButtons         []decredmaterial.Button
+	tab                int
+	comments           *commentsView
 }
 
 func ProposalDetailsPage(common *pageCommon) Page {
@@ -53,6 +73,7 @@ func ProposalDetailsPage(common *pageCommon) Page {
 		descriptionCard:    common.theme.Card(),
 		descriptionList:    &layout.List{Axis: layout.Vertical},
 		selectedProposal:   common.selectedProposal,
+		proposals:          common.proposals,
 		redirectIcon:       common.icons.redirectIcon,
 		downloadIcon:       common.icons.downloadIcon,
 		voteBar:            common.theme.VoteBar(common.icons.actionInfo, common.icons.imageBrightness1),
@@ -60,6 +81,7 @@ func ProposalDetailsPage(common *pageCommon) Page {
 		rejectedIcon:       common.icons.navigationCancel,
 		successIcon:        common.icons.actionCheckCircle,
 		timerIcon:          common.icons.timerIcon,
+		comments:           newCommentsView(common),
 	}
 
 	pg.downloadIcon.Scale = 1
@@ -77,14 +99,54 @@ func ProposalDetailsPage(common *pageCommon) Page {
 		Right:  values.MarginPadding12,
 	}
 
+	pg.versionHistory = common.theme.Button(new(widget.Clickable), values.String(values.StrVersionHistory))
+	pg.versionHistory.TextSize = values.TextSize14
+	pg.versionHistory.Background = color.NRGBA{}
+	pg.versionHistory.Color = common.theme.Color.Primary
+	pg.versionHistory.Inset = layout.Inset{}
+
+	for _, label := range []string{values.StrProposal, values.StrCommentsCount} {
+		button := common.theme.Button(new(widget.Clickable), values.String(label))
+		button.TextSize = values.TextSize14
+		button.Inset = layout.UniformInset(values.MarginPadding8)
+		pg.tabButtons = append(pg.tabButtons, button)
+	}
+
 	return pg
 }
 
 func (pg *proposalDetails) OnResume() {
-
+	if pg.tab == commentsTab {
+		pg.comments.load((*pg.selectedProposal).Token)
+	}
 }
 
 func (pg *proposalDetails) handle() {
+	// show the proposal as of the last sync
+	for i := range (*pg.proposals).Proposals {
+		if proposal := &(*pg.proposals).Proposals[i]; proposal.Token == (*pg.selectedProposal).Token {
+			if proposal.Version != (*pg.selectedProposal).Version || proposal.VoteStatus != (*pg.selectedProposal).VoteStatus ||
+				proposal.YesVotes != (*pg.selectedProposal).YesVotes || proposal.NoVotes != (*pg.selectedProposal).NoVotes {
+				*pg.selectedProposal = proposal
+			}
+			break
+		}
+	}
+
+	for pg.versionHistory.Button.Clicked() {
+		pg.common.changePage(PageProposalVersions)
+	}
+
+	for i := range pg.tabButtons {
+		for pg.tabButtons[i].Button.Clicked() {
+			pg.tab = i
+			if i == commentsTab {
+				pg.comments.load((*pg.selectedProposal).Token)
+			}
+		}
+	}
+	pg.comments.handle((*pg.selectedProposal).Token)
+
 	for token := range pg.proposalItems {
 		for location, clickable := range pg.proposalItems[token].clickables {
 			if clickable.Clicked() {
@@ -306,14 +368,22 @@ func (pg *proposalDetails) layoutDescription(gtx C) D {
 					return layout.Inset{Top: values.MarginPaddingMinus22}.Layout(gtx, dotLabel.Layout)
 				}),
 				layout.Rigid(updatedLabel.Layout),
+				layout.Flexed(1, func(gtx C) D {
+					if proposal.Version == "1" {
+						return D{}
+					}
+					return layout.E.Layout(gtx, pg.versionHistory.Layout)
+				}),
 			)
 		},
 		pg.lineSeparator(layout.Inset{Top: values.MarginPadding16, Bottom: values.MarginPadding16}),
 	}
 
-	_, ok := pg.proposalItems[proposal.Token]
+	// the cached description of an earlier version is shown until the latest
+	// is loaded
+	item, ok := pg.proposalItems[proposal.Token]
 	if ok {
-		w = append(w, pg.proposalItems[proposal.Token].widgets...)
+		w = append(w, item.widgets...)
 	} else {
 		th := material.NewTheme(gofont.Collection())
 		loading := func(gtx C) D {
@@ -337,6 +407,57 @@ func (pg *proposalDetails) layoutDescription(gtx C) D {
 	})
 }
 
+// attachmentWidgets returns the widgets of the attachments of a proposal that
+// its description does not embed, each under its name. Images and CSV budgets
+// are laid out, other attachments are only named.
+func (pg *proposalDetails) attachmentWidgets(content *wallet.ProposalContent, images map[string]image.Image) []layout.Widget {
+	var widgets []layout.Widget
+	for _, attachment := range content.Attachments {
+		if strings.Contains(content.Description, "("+attachment.Name+")") {
+			continue
+		}
+		if widgets == nil {
+			title := pg.theme.H6(values.String(values.StrAttachments))
+			widgets = append(widgets, pg.lineSeparator(layout.Inset{Top: values.MarginPadding16, Bottom: values.MarginPadding16}), title.Layout)
+		}
+
+		name := pg.theme.Caption(attachment.Name)
+		name.Color = pg.theme.Color.Gray
+		widgets = append(widgets, func(gtx C) D {
+			return layout.Inset{Top: values.MarginPadding10, Bottom: values.MarginPadding5}.Layout(gtx, name.Layout)
+		})
+
+		if img, ok := images[attachment.Name]; ok {
+			src := paint.NewImageOp(img)
+			widgets = append(widgets, func(gtx C) D {
+				return widget.Image{Src: src, Fit: widget.ScaleDown, Position: layout.W, Scale: 1}.Layout(gtx)
+			})
+		} else if strings.EqualFold(path.Ext(attachment.Name), ".csv") {
+			table, err := renderers.RenderCSV(pg.theme, attachment.Data)
+			if err != nil {
+				log.Infof("Error reading proposal attachment %s: %v", attachment.Name, err)
+				continue
+			}
+			widgets = append(widgets, table)
+		}
+	}
+	return widgets
+}
+
+// layoutTabs lays out the buttons of the tabs, with the number of comments on
+// the proposal.
+func (pg *proposalDetails) layoutTabs(gtx C) D {
+	proposal := *pg.selectedProposal
+	count := int(proposal.NumComments)
+	if comments := pg.comments.current(proposal.Token); comments != nil && comments.Err == nil {
+		count = comments.Count
+	}
+	pg.tabButtons[commentsTab].Text = values.StringF(values.StrCommentsCount, count)
+	return layout.Inset{Left: values.MarginPaddingMinus5}.Layout(gtx, func(gtx C) D {
+		return layoutRangeButtons(gtx, pg.theme, pg.tabButtons, pg.tab)
+	})
+}
+
 func (pg *proposalDetails) layoutRedirect(text string, icon *widget.Image, btn *widget.Clickable) layout.Widget {
 	return func(gtx C) D {
 		return layout.Flex{Axis: layout.Vertical}.Layout(gtx,
@@ -376,28 +497,36 @@ func (pg *proposalDetails) Layout(gtx C) D {
 	common := pg.common
 
 	proposal := *pg.selectedProposal
-	_, ok := pg.proposalItems[proposal.Token]
-	if !ok && !pg.loadingDescription {
+	item, ok := pg.proposalItems[proposal.Token]
+	if (!ok || item.version != proposal.Version) && !pg.loadingDescription {
 		pg.loadingDescription = true
 		go func() {
-			var proposalDescription string
-			if proposal.IndexFile != "" && proposal.IndexFileVersion == proposal.Version {
-				proposalDescription = proposal.IndexFile
-			} else {
-				var err error
-				proposalDescription, err = common.wallet.FetchProposalDescription(proposal.Token)
+			content, err := common.wallet.FetchProposalContent(proposal.Token)
+			if err != nil {
+				log.Infof("Error loading proposal description: %v", err)
+				time.Sleep(7 * time.Second)
+				pg.loadingDescription = false
+				return
+			}
+
+			images := make(map[string]image.Image)
+			for _, attachment := range content.Attachments {
+				if !strings.HasPrefix(attachment.MIME, "image/") {
+					continue
+				}
+				img, _, err := image.Decode(bytes.NewReader(attachment.Data))
 				if err != nil {
-					log.Infof("Error loading proposal description: %v", err)
-					time.Sleep(7 * time.Second)
-					pg.loadingDescription = false
-					return
+					log.Infof("Error decoding proposal attachment %s: %v", attachment.Name, err)
+					continue
 				}
+				images[attachment.Name] = img
 			}
 
-			r := renderers.RenderMarkdown(gtx, pg.theme, proposalDescription)
+			r := renderers.RenderMarkdownWithImages(gtx, pg.theme, content.Description, images)
 			proposalWidgets, proposalClickables := r.Layout()
 			pg.proposalItems[proposal.Token] = proposalItemWidgets{
-				widgets:    proposalWidgets,
+				version:    proposal.Version,
+				widgets:    append(proposalWidgets, pg.attachmentWidgets(content, images)...),
 				clickables: proposalClickables,
 			}
 			pg.loadingDescription = false
@@ -417,7 +546,15 @@ func (pg *proposalDetails) Layout(gtx C) D {
 					layout.Rigid(func(gtx C) D {
 						return layout.Inset{Bottom: values.MarginPadding10}.Layout(gtx, pg.layoutTitle)
 					}),
-					layout.Rigid(pg.layoutDescription),
+					layout.Rigid(func(gtx C) D {
+						return layout.Inset{Bottom: values.MarginPadding10}.Layout(gtx, pg.layoutTabs)
+					}),
+					layout.Rigid(func(gtx C) D {
+						if pg.tab == commentsTab {
+							return pg.comments.layout(gtx, proposal.Token)
+						}
+						return pg.layoutDescription(gtx)
+					}),
 				)
 			},
 			extra: func(gtx C) D {
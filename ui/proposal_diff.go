@@ -0,0 +1,135 @@
+package ui
+
+import (
+	"regexp"
+	"strings"
+)
+
+// listItemRegexp matches the first line of an item of a list that is not
+// nested in another.
+var listItemRegexp = regexp.MustCompile(`^([*+-]|\d+[.)])\s`)
+
+// The kinds of the blocks of a diff.
+const (
+	diffUnchanged = iota
+	diffRemoved
+	diffAdded
+)
+
+// diffBlock is a run of markdown blocks of one kind in a diff.
+type diffBlock struct {
+	kind int
+	text string
+}
+
+// diffRow is a row of a side by side diff, with the blocks of the old version
+// on the left and those of the new version on the right. Unchanged blocks are
+// on both sides and a side without blocks is nil.
+type diffRow struct {
+	left, right *diffBlock
+}
+
+// markdownBlocks splits a markdown document into its blocks, which are
+// separated by blank lines outside fenced code. The items of a list are blocks
+// of their own so that a changed item does not mark the whole list changed.
+func markdownBlocks(doc string) []string {
+	var blocks []string
+	var block []string
+	var fenced bool
+	end := func() {
+		if len(block) > 0 {
+			blocks = append(blocks, strings.Join(block, "\n"))
+			block = nil
+		}
+	}
+
+	for _, line := range strings.Split(strings.ReplaceAll(doc, "\r\n", "\n"), "\n") {
+		trimmed := strings.TrimSpace(line)
+		if strings.HasPrefix(trimmed, "```") || strings.HasPrefix(trimmed, "~~~") {
+			fenced = !fenced
+		}
+		if !fenced && (trimmed == "" || listItemRegexp.MatchString(line)) {
+			end()
+		}
+		if trimmed == "" && !fenced {
+			continue
+		}
+		block = append(block, strings.TrimRight(line, " \t"))
+	}
+	end()
+	return blocks
+}
+
+// diffMarkdown returns the blocks removed from the markdown document a, kept
+// from it and added in b, in the order of the documents. Consecutive blocks
+// of the same kind are joined, and the blocks removed from a place come
+// before those added there.
+func diffMarkdown(a, b string) []diffBlock {
+	before, after := markdownBlocks(a), markdownBlocks(b)
+
+	// common[i][j] is the length of the longest common subsequence of
+	// before[i:] and after[j:].
+	common := make([][]int, len(before)+1)
+	for i := range common {
+		common[i] = make([]int, len(after)+1)
+	}
+	for i := len(before) - 1; i >= 0; i-- {
+		for j := len(after) - 1; j >= 0; j-- {
+			if before[i] == after[j] {
+				common[i][j] = common[i+1][j+1] + 1
+			} else if common[i+1][j] >= common[i][j+1] {
+				common[i][j] = common[i+1][j]
+			} else {
+				common[i][j] = common[i][j+1]
+			}
+		}
+	}
+
+	var blocks []diffBlock
+	add := func(kind int, text string) {
+		if n := len(blocks); n > 0 && blocks[n-1].kind == kind {
+			blocks[n-1].text += "\n\n" + text
+			return
+		}
+		blocks = append(blocks, diffBlock{kind: kind, text: text})
+	}
+
+	i, j := 0, 0
+	for i < len(before) || j < len(after) {
+		switch {
+		case i < len(before) && j < len(after) && before[i] == after[j]:
+			add(diffUnchanged, before[i])
+			i++
+			j++
+		case j == len(after) || (i < len(before) && common[i+1][j] >= common[i][j+1]):
+			add(diffRemoved, before[i])
+			i++
+		default:
+			add(diffAdded, after[j])
+			j++
+		}
+	}
+	return blocks
+}
+
+// diffRows pairs the blocks of a diff into the rows of a side by side diff.
+func diffRows(blocks []diffBlock) []diffRow {
+	var rows []diffRow
+	for i := 0; i < len(blocks); i++ {
+		block := &blocks[i]
+		switch block.kind {
+		case diffUnchanged:
+			rows = append(rows, diffRow{left: block, right: block})
+		case diffRemoved:
+			row := diffRow{left: block}
+			if i+1 < len(blocks) && blocks[i+1].kind == diffAdded {
+				row.right = &blocks[i+1]
+				i++
+			}
+			rows = append(rows, row)
+		case diffAdded:
+			rows = append(rows, diffRow{right: block})
+		}
+	}
+	return rows
+}
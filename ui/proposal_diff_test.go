@@ -0,0 +1,57 @@
+package ui
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Proposal diff", func() {
+	It("splits markdown into blocks outside fenced code", func() {
+		doc := "# Title\r\n\r\nFirst line\nsecond line  \n\n\n```\ncode\n\nmore code\n```\n"
+		Expect(markdownBlocks(doc)).To(Equal([]string{
+			"# Title",
+			"First line\nsecond line",
+			"```\ncode\n\nmore code\n```",
+		}))
+	})
+
+	It("makes each item of a list a block", func() {
+		Expect(markdownBlocks("Budget:\n* Development\n  * Audits\n1. Total\n   in DCR")).To(Equal([]string{
+			"Budget:",
+			"* Development\n  * Audits",
+			"1. Total\n   in DCR",
+		}))
+	})
+
+	It("finds the removed, unchanged and added blocks", func() {
+		before := "# Title\n\nIntro\n\n* Development: $40,000\n\nOutro"
+		after := "# Title\n\nIntro\n\n* Development: $60,000\n\n* Audits: $15,000\n\nOutro\n\nThanks"
+		Expect(diffMarkdown(before, after)).To(Equal([]diffBlock{
+			{diffUnchanged, "# Title\n\nIntro"},
+			{diffRemoved, "* Development: $40,000"},
+			{diffAdded, "* Development: $60,000\n\n* Audits: $15,000"},
+			{diffUnchanged, "Outro"},
+			{diffAdded, "Thanks"},
+		}))
+		Expect(diffMarkdown(before, before)).To(Equal([]diffBlock{{diffUnchanged, "# Title\n\nIntro\n\n* Development: $40,000\n\nOutro"}}))
+		Expect(diffMarkdown("", "Added")).To(Equal([]diffBlock{{diffAdded, "Added"}}))
+	})
+
+	It("pairs changed blocks side by side", func() {
+		blocks := []diffBlock{
+			{diffUnchanged, "a"},
+			{diffRemoved, "b"},
+			{diffAdded, "c"},
+			{diffRemoved, "d"},
+			{diffUnchanged, "e"},
+			{diffAdded, "f"},
+		}
+		Expect(diffRows(blocks)).To(Equal([]diffRow{
+			{&blocks[0], &blocks[0]},
+			{&blocks[1], &blocks[2]},
+			{&blocks[3], nil},
+			{&blocks[4], &blocks[4]},
+			{nil, &blocks[5]},
+		}))
+	})
+})
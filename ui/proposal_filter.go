@@ -0,0 +1,146 @@
+package ui
+
+import (
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/planetdecred/dcrlibwallet"
+	"github.com/planetdecred/godcr/ui/values"
+)
+
+// The vote outcomes a proposal can be filtered by.
+const (
+	anyVoteOutcome = iota
+	passingVoteOutcome
+	failingVoteOutcome
+	noVotesOutcome
+)
+
+var proposalVoteOutcomes = []string{values.StrAnyOutcome, values.StrPassing, values.StrFailing, values.StrNoVotes}
+
+// proposalDateRange is a period before now a proposal can be published in. A
+// zero duration covers all proposals.
+type proposalDateRange struct {
+	label    string
+	duration time.Duration
+}
+
+// proposalDateRanges are the periods a proposal can be filtered by.
+var proposalDateRanges = []proposalDateRange{
+	{values.StrAnyTime, 0},
+	{values.StrPastWeek, 7 * 24 * time.Hour},
+	{values.StrPastMonth, 30 * 24 * time.Hour},
+	{values.StrPastYear, 365 * 24 * time.Hour},
+}
+
+// The orders proposals can be sorted in.
+const (
+	sortNewest = iota
+	sortMostVotes
+	sortEndingSoonest
+)
+
+var proposalSortOrders = []string{values.StrNewest, values.StrMostVotes, values.StrEndingSoonest}
+
+// proposalFilter is what the proposals shown are filtered by and sorted in.
+type proposalFilter struct {
+	// query is found in the title or author, or starts the token.
+	query   string
+	outcome int
+	// since is the earliest time a proposal may be published at. The zero
+	// time matches all proposals.
+	since time.Time
+	order int
+}
+
+// proposalPassing returns whether a proposal has reached its quorum and its
+// pass percentage of yes votes.
+func proposalPassing(proposal dcrlibwallet.Proposal) bool {
+	votes := int64(proposal.YesVotes) + int64(proposal.NoVotes)
+	if votes == 0 {
+		return false
+	}
+	quorum := int64(proposal.EligibleTickets) * int64(proposal.QuorumPercentage) / 100
+	return votes >= quorum && int64(proposal.YesVotes)*100 >= votes*int64(proposal.PassPercentage)
+}
+
+// proposalPublished returns the unix time a proposal was published at. The
+// timestamp of a proposal is when it was last updated, which is used for
+// proposals that were not published.
+func proposalPublished(proposal dcrlibwallet.Proposal) int64 {
+	if proposal.PublishedAt > 0 {
+		return proposal.PublishedAt
+	}
+	return proposal.Timestamp
+}
+
+// matches returns whether the proposal passes the filter.
+func (f proposalFilter) matches(proposal dcrlibwallet.Proposal) bool {
+	if query := strings.ToLower(strings.TrimSpace(f.query)); query != "" &&
+		!strings.Contains(strings.ToLower(proposal.Name), query) &&
+		!strings.Contains(strings.ToLower(proposal.Username), query) &&
+		!strings.HasPrefix(strings.ToLower(proposal.Token), query) {
+		return false
+	}
+
+	if !f.since.IsZero() && time.Unix(proposalPublished(proposal), 0).Before(f.since) {
+		return false
+	}
+
+	votes := proposal.YesVotes + proposal.NoVotes
+	switch f.outcome {
+	case passingVoteOutcome:
+		return proposalPassing(proposal)
+	case failingVoteOutcome:
+		return votes > 0 && !proposalPassing(proposal)
+	case noVotesOutcome:
+		return votes == 0
+	}
+	return true
+}
+
+// filterProposals returns the items that pass the filter in its order.
+func filterProposals(items []*proposalItem, f proposalFilter) []*proposalItem {
+	var filtered []*proposalItem
+	for _, item := range items {
+		if f.matches(item.proposal) {
+			filtered = append(filtered, item)
+		}
+	}
+
+	newer := func(i, j int) bool {
+		return proposalPublished(filtered[i].proposal) > proposalPublished(filtered[j].proposal)
+	}
+	switch f.order {
+	case sortMostVotes:
+		sort.SliceStable(filtered, func(i, j int) bool {
+			a, b := filtered[i].proposal, filtered[j].proposal
+			if votesA, votesB := a.YesVotes+a.NoVotes, b.YesVotes+b.NoVotes; votesA != votesB {
+				return votesA > votesB
+			}
+			return newer(i, j)
+		})
+	case sortEndingSoonest:
+		// Votes last the same number of blocks, so the vote that started
+		// first ends first. dcrlibwallet doesn't keep the blocks votes start
+		// and end at, so proposals under vote are ordered by when they were
+		// published, oldest first, which is the order their votes usually
+		// started in. Proposals not under vote follow, newest first.
+		sort.SliceStable(filtered, func(i, j int) bool {
+			a, b := filtered[i].proposal, filtered[j].proposal
+			votingA := a.Category == dcrlibwallet.ProposalCategoryActive
+			votingB := b.Category == dcrlibwallet.ProposalCategoryActive
+			if votingA != votingB {
+				return votingA
+			}
+			if votingA {
+				return proposalPublished(a) < proposalPublished(b)
+			}
+			return newer(i, j)
+		})
+	default:
+		sort.SliceStable(filtered, newer)
+	}
+	return filtered
+}
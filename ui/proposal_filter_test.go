@@ -0,0 +1,74 @@
+package ui
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/planetdecred/dcrlibwallet"
+)
+
+var _ = Describe("Proposal filter", func() {
+	now := time.Unix(1600000000, 0)
+	day := int64(24 * 60 * 60)
+
+	// The proposals were last updated in another order than they were
+	// published in, so that sorting or filtering by the updates fails.
+	voting := &proposalItem{proposal: dcrlibwallet.Proposal{
+		Token: "a3def199af812b79", Name: "Wallet Integration", Username: "atom",
+		Category: dcrlibwallet.ProposalCategoryActive, PublishedAt: now.Unix() - 9*day, Timestamp: now.Unix() - day,
+		YesVotes: 8000, NoVotes: 2000, EligibleTickets: 40000, QuorumPercentage: 20, PassPercentage: 60,
+	}}
+	startedLater := &proposalItem{proposal: dcrlibwallet.Proposal{
+		Token: "0c1d5e4b2a8f6e3d", Name: "Bug Bounty", Username: "lotus",
+		Category: dcrlibwallet.ProposalCategoryActive, PublishedAt: now.Unix() - 3*day, Timestamp: now.Unix() - 2*day,
+		YesVotes: 1000, NoVotes: 900, EligibleTickets: 40000, QuorumPercentage: 20, PassPercentage: 60,
+	}}
+	rejected := &proposalItem{proposal: dcrlibwallet.Proposal{
+		Token: "fa38a3593d9a3f6c", Name: "Racing Team", Username: "velocity",
+		Category: dcrlibwallet.ProposalCategoryRejected, PublishedAt: now.Unix() - 45*day, Timestamp: now.Unix() - 3*day,
+		YesVotes: 2000, NoVotes: 13000, EligibleTickets: 40000, QuorumPercentage: 20, PassPercentage: 60,
+	}}
+	discussed := &proposalItem{proposal: dcrlibwallet.Proposal{
+		Token: "7d2b0e9c1f4a6b58", Name: "Marketing in Q3", Username: "atomic",
+		Category: dcrlibwallet.ProposalCategoryPre, PublishedAt: now.Unix() - day, Timestamp: now.Unix() - 10*day,
+	}}
+	unpublished := &proposalItem{proposal: dcrlibwallet.Proposal{
+		Token: "5e8a1c3b9d7f2e40", Name: "Draft", Username: "quill",
+		Category: dcrlibwallet.ProposalCategoryPre, Timestamp: now.Unix() - 60*day,
+	}}
+	items := []*proposalItem{voting, startedLater, rejected, discussed, unpublished}
+
+	It("passes proposals over their quorum and pass percentage", func() {
+		Expect(proposalPassing(voting.proposal)).To(Equal(true))
+		Expect(proposalPassing(startedLater.proposal)).To(Equal(false))
+		Expect(proposalPassing(rejected.proposal)).To(Equal(false))
+		Expect(proposalPassing(discussed.proposal)).To(Equal(false))
+	})
+
+	It("searches the title, author and start of the token", func() {
+		Expect(filterProposals(items, proposalFilter{query: " racing "})).To(Equal([]*proposalItem{rejected}))
+		Expect(filterProposals(items, proposalFilter{query: "ATOM"})).To(Equal([]*proposalItem{discussed, voting}))
+		Expect(filterProposals(items, proposalFilter{query: "fa38a"})).To(Equal([]*proposalItem{rejected}))
+		Expect(filterProposals(items, proposalFilter{query: "3f6c"})).To(BeEmpty())
+	})
+
+	It("filters by vote outcome and date", func() {
+		Expect(filterProposals(items, proposalFilter{outcome: passingVoteOutcome})).To(Equal([]*proposalItem{voting}))
+		Expect(filterProposals(items, proposalFilter{outcome: failingVoteOutcome})).To(Equal([]*proposalItem{startedLater, rejected}))
+		Expect(filterProposals(items, proposalFilter{outcome: noVotesOutcome})).To(Equal([]*proposalItem{discussed, unpublished}))
+
+		since := now.Add(-7 * 24 * time.Hour)
+		Expect(filterProposals(items, proposalFilter{since: since})).To(Equal([]*proposalItem{discussed, startedLater}))
+	})
+
+	It("sorts by newest, most votes and ending soonest", func() {
+		Expect(filterProposals(items, proposalFilter{order: sortNewest})).
+			To(Equal([]*proposalItem{discussed, startedLater, voting, rejected, unpublished}))
+		Expect(filterProposals(items, proposalFilter{order: sortMostVotes})).
+			To(Equal([]*proposalItem{rejected, voting, startedLater, discussed, unpublished}))
+		Expect(filterProposals(items, proposalFilter{order: sortEndingSoonest})).
+			To(Equal([]*proposalItem{voting, startedLater, discussed, rejected, unpublished}))
+	})
+})
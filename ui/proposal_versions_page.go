@@ -0,0 +1,339 @@
+package ui
+
+import (
+	"gioui.org/layout"
+	"gioui.org/widget"
+
+	"github.com/planetdecred/dcrlibwallet"
+	"github.com/planetdecred/godcr/ui/decredmaterial"
+	"github.com/planetdecred/godcr/ui/renderers"
+	"github.com/planetdecred/godcr/ui/values"
+	"github.com/planetdecred/godcr/wallet"
+)
+
+const PageProposalVersions = "ProposalVersions"
+
+// The ways the changes between two versions are shown.
+const (
+	inlineDiff = iota
+	sideBySideDiff
+)
+
+type proposalVersionsPage struct {
+	common           *pageCommon
+	theme            *decredmaterial.Theme
+	selectedProposal **dcrlibwallet.Proposal
+	versions         **wallet.ProposalVersions
+
+	container    layout.List
+	backButton   decredmaterial.IconButton
+	retryButton  decredmaterial.Button
+	modeButtons  []decredmaterial.Button
+	fromDropDown *decredmaterial.DropDown
+	toDropDown   *decredmaterial.DropDown
+
+	mode int
+	// loaded are the versions the dropdowns are for.
+	loaded   *wallet.ProposalVersions
+	from, to int
+	diff     []diffBlock
+	// rendered are the markdown widgets of the blocks of the diff. They are
+	// rendered on the first layout after the diff changes.
+	rendered map[*diffBlock][]layout.Widget
+	links    map[string]*widget.Clickable
+	added    int
+	removed  int
+}
+
+func ProposalVersionsPage(common *pageCommon) Page {
+	pg := &proposalVersionsPage{
+		common:           common,
+		theme:            common.theme,
+		selectedProposal: common.selectedProposal,
+		versions:         common.proposalVersions,
+		container:        layout.List{Axis: layout.Vertical},
+		retryButton:      common.theme.Button(new(widget.Clickable), values.String(values.StrRetry)),
+	}
+	pg.backButton, _ = common.SubPageHeaderButtons()
+
+	for _, label := range []string{values.StrInline, values.StrSideBySide} {
+		button := common.theme.Button(new(widget.Clickable), values.String(label))
+		button.TextSize = values.TextSize12
+		button.Inset = layout.UniformInset(values.MarginPadding5)
+		pg.modeButtons = append(pg.modeButtons, button)
+	}
+	return pg
+}
+
+func (pg *proposalVersionsPage) OnResume() {
+	pg.common.wallet.GetProposalVersions((*pg.selectedProposal).Token)
+}
+
+// current returns the loaded versions if they are of the selected proposal.
+func (pg *proposalVersionsPage) current() *wallet.ProposalVersions {
+	if pg.loaded == nil || pg.loaded.Token != (*pg.selectedProposal).Token {
+		return nil
+	}
+	return pg.loaded
+}
+
+// load creates the version dropdowns and compares the last two versions, or
+// the versions compared before if the proposal has not been updated since.
+func (pg *proposalVersionsPage) load(versions *wallet.ProposalVersions) {
+	keep := pg.loaded != nil && pg.loaded.Token == versions.Token && len(pg.loaded.Versions) == len(versions.Versions)
+	pg.loaded = versions
+	if versions.Err != nil || len(versions.Versions) < 2 {
+		return
+	}
+
+	items := make([]decredmaterial.DropDownItem, len(versions.Versions))
+	for i, v := range versions.Versions {
+		items[i] = decredmaterial.DropDownItem{
+			Text: values.StringF(values.StrVersionDate, v.Version, values.FormatDate(v.Timestamp)),
+		}
+	}
+	if !keep {
+		pg.from, pg.to = len(items)-2, len(items)-1
+	}
+	pg.fromDropDown = pg.theme.DropDown(items, 6)
+	pg.fromDropDown.SetSelectedIndex(pg.from)
+	pg.toDropDown = pg.theme.DropDown(append([]decredmaterial.DropDownItem(nil), items...), 6)
+	pg.toDropDown.SetSelectedIndex(pg.to)
+	pg.compare()
+}
+
+// compare diffs the selected versions.
+func (pg *proposalVersionsPage) compare() {
+	versions := pg.loaded.Versions
+	pg.diff = diffMarkdown(versions[pg.from].Description, versions[pg.to].Description)
+	pg.rendered = nil
+
+	pg.added, pg.removed = 0, 0
+	for _, block := range pg.diff {
+		switch block.kind {
+		case diffAdded:
+			pg.added += len(markdownBlocks(block.text))
+		case diffRemoved:
+			pg.removed += len(markdownBlocks(block.text))
+		}
+	}
+}
+
+// render renders the markdown of the blocks of the diff.
+func (pg *proposalVersionsPage) render(gtx C) {
+	pg.rendered = make(map[*diffBlock][]layout.Widget)
+	pg.links = make(map[string]*widget.Clickable)
+	for i := range pg.diff {
+		widgets, links := renderers.RenderMarkdown(gtx, pg.theme, pg.diff[i].text).Layout()
+		pg.rendered[&pg.diff[i]] = widgets
+		for location, link := range links {
+			pg.links[location] = link
+		}
+	}
+}
+
+func (pg *proposalVersionsPage) handle() {
+	if versions := *pg.versions; versions != pg.loaded && versions.Token == (*pg.selectedProposal).Token {
+		pg.load(versions)
+	}
+
+	for pg.retryButton.Button.Clicked() {
+		pg.loaded = nil
+		pg.OnResume()
+	}
+
+	for i := range pg.modeButtons {
+		for pg.modeButtons[i].Button.Clicked() {
+			pg.mode = i
+		}
+	}
+
+	if versions := pg.current(); versions != nil && versions.Err == nil && len(versions.Versions) >= 2 {
+		from, to := pg.fromDropDown.SelectedIndex(), pg.toDropDown.SelectedIndex()
+		if from != pg.from || to != pg.to {
+			pg.from, pg.to = from, to
+			pg.compare()
+		}
+	}
+
+	for location, link := range pg.links {
+		for link.Clicked() {
+			goToURL(location)
+		}
+	}
+}
+
+func (pg *proposalVersionsPage) onClose() {}
+
+// layoutMessage lays out a message in place of the changes.
+func (pg *proposalVersionsPage) layoutMessage(gtx C, message string, widgets ...layout.Widget) D {
+	return pg.theme.Card().Layout(gtx, func(gtx C) D {
+		gtx.Constraints.Min.X = gtx.Constraints.Max.X
+		return Container{layout.UniformInset(values.MarginPadding15)}.Layout(gtx, func(gtx C) D {
+			txt := pg.theme.Body1(message)
+			txt.Color = pg.theme.Color.Gray2
+			children := []layout.FlexChild{layout.Rigid(txt.Layout)}
+			for _, w := range widgets {
+				w := w
+				children = append(children, layout.Rigid(func(gtx C) D {
+					return layout.Inset{Top: values.MarginPadding10}.Layout(gtx, w)
+				}))
+			}
+			return layout.Flex{Axis: layout.Vertical}.Layout(gtx, children...)
+		})
+	})
+}
+
+// layoutControls lays out the versions compared and how their changes are
+// shown.
+func (pg *proposalVersionsPage) layoutControls(gtx C) D {
+	gtx.Constraints.Min.X = gtx.Constraints.Max.X
+	return endToEndRow(gtx, func(gtx C) D {
+		return layout.Flex{Alignment: layout.Middle}.Layout(gtx,
+			layout.Rigid(pg.fromDropDown.Layout),
+			layout.Rigid(func(gtx C) D {
+				arrow := pg.theme.H6("→")
+				arrow.Color = pg.theme.Color.Gray
+				return layout.UniformInset(values.MarginPadding10).Layout(gtx, arrow.Layout)
+			}),
+			layout.Rigid(pg.toDropDown.Layout),
+		)
+	}, func(gtx C) D {
+		return layoutRangeButtons(gtx, pg.theme, pg.modeButtons, pg.mode)
+	})
+}
+
+// layoutBlock lays out a block of the diff, highlighting the added and
+// removed blocks.
+func (pg *proposalVersionsPage) layoutBlock(gtx C, block *diffBlock) D {
+	if block == nil {
+		return D{}
+	}
+
+	content := func(gtx C) D {
+		gtx.Constraints.Min.X = gtx.Constraints.Max.X
+		widgets := pg.rendered[block]
+		children := make([]layout.FlexChild, len(widgets))
+		for i := range widgets {
+			children[i] = layout.Rigid(widgets[i])
+		}
+		return layout.Flex{Axis: layout.Vertical}.Layout(gtx, children...)
+	}
+	if block.kind == diffUnchanged {
+		return content(gtx)
+	}
+
+	col := pg.theme.Color.Success
+	if block.kind == diffRemoved {
+		col = pg.theme.Color.Danger
+	}
+	background := col
+	background.A = 0x20
+	return layout.Inset{Top: values.MarginPadding4, Bottom: values.MarginPadding4}.Layout(gtx, func(gtx C) D {
+		return layout.Stack{}.Layout(gtx,
+			layout.Expanded(func(gtx C) D {
+				decredmaterial.Fill(gtx, background)
+				gtx.Constraints.Min.X = gtx.Px(values.MarginPadding4)
+				return decredmaterial.Fill(gtx, col)
+			}),
+			layout.Stacked(func(gtx C) D {
+				return layout.Inset{
+					Top:    values.MarginPadding5,
+					Bottom: values.MarginPadding5,
+					Left:   values.MarginPadding15,
+					Right:  values.MarginPadding10,
+				}.Layout(gtx, content)
+			}),
+		)
+	})
+}
+
+// layoutChanges lays out the changes between the compared versions, inline
+// or side by side.
+func (pg *proposalVersionsPage) layoutChanges(gtx C) D {
+	if pg.rendered == nil {
+		pg.render(gtx)
+	}
+	if pg.from == pg.to || (len(pg.diff) == 1 && pg.diff[0].kind == diffUnchanged) {
+		return pg.layoutMessage(gtx, values.String(values.StrNoChanges))
+	}
+
+	var rows []layout.Widget
+	if pg.mode == sideBySideDiff {
+		for _, row := range diffRows(pg.diff) {
+			row := row
+			rows = append(rows, func(gtx C) D {
+				return layout.Flex{}.Layout(gtx,
+					layout.Flexed(0.5, func(gtx C) D {
+						return layout.Inset{Right: values.MarginPadding5}.Layout(gtx, func(gtx C) D {
+							return pg.layoutBlock(gtx, row.left)
+						})
+					}),
+					layout.Flexed(0.5, func(gtx C) D {
+						return layout.Inset{Left: values.MarginPadding5}.Layout(gtx, func(gtx C) D {
+							return pg.layoutBlock(gtx, row.right)
+						})
+					}),
+				)
+			})
+		}
+	} else {
+		for i := range pg.diff {
+			block := &pg.diff[i]
+			rows = append(rows, func(gtx C) D {
+				return pg.layoutBlock(gtx, block)
+			})
+		}
+	}
+
+	return pg.theme.Card().Layout(gtx, func(gtx C) D {
+		gtx.Constraints.Min.X = gtx.Constraints.Max.X
+		return Container{layout.UniformInset(values.MarginPadding15)}.Layout(gtx, func(gtx C) D {
+			return pg.container.Layout(gtx, len(rows)+1, func(gtx C, i int) D {
+				if i == 0 {
+					summary := pg.theme.Caption(values.StringF(values.StrDiffSummary, pg.added, pg.removed))
+					summary.Color = pg.theme.Color.Gray
+					return layout.Inset{Bottom: values.MarginPadding10}.Layout(gtx, summary.Layout)
+				}
+				return rows[i-1](gtx)
+			})
+		})
+	})
+}
+
+func (pg *proposalVersionsPage) layoutBody(gtx C) D {
+	versions := pg.current()
+	switch {
+	case versions == nil:
+		return pg.layoutMessage(gtx, values.String(values.StrLoadingVersions))
+	case versions.Err != nil:
+		return pg.layoutMessage(gtx, values.StringF(values.StrVersionsFailed, versions.Err), pg.retryButton.Layout)
+	case len(versions.Versions) < 2:
+		return pg.layoutMessage(gtx, values.String(values.StrOneVersion))
+	}
+
+	// the dropdowns open over the changes
+	return layout.Stack{Alignment: layout.N}.Layout(gtx,
+		layout.Expanded(func(gtx C) D {
+			return layout.Inset{Top: values.MarginPadding60}.Layout(gtx, pg.layoutChanges)
+		}),
+		layout.Stacked(pg.layoutControls),
+	)
+}
+
+func (pg *proposalVersionsPage) Layout(gtx C) D {
+	common := pg.common
+	body := func(gtx C) D {
+		page := SubPage{
+			title:      values.String(values.StrVersionHistory),
+			subTitle:   truncateString((*pg.selectedProposal).Name, 40),
+			backButton: pg.backButton,
+			back: func() {
+				common.changePage(PageProposalDetails)
+			},
+			body: pg.layoutBody,
+		}
+		return common.SubPageLayout(gtx, page)
+	}
+	return common.UniformPadding(gtx, body)
+}
@@ -98,6 +98,15 @@ func (cm *voteModal) Dismiss() {
 	cm.dismissModal(cm)
 }
 
+func (cm *voteModal) cancelButton() *widget.Clickable {
+	return cm.btnNegative.Button
+}
+
+func (cm *voteModal) focusTargets() []focusTarget {
+	return append(editorTargets(cm.yesVote.input.Editor, cm.noVote.input.Editor, cm.passwordEditor.Editor),
+		buttonTargets(cm.btnNegative.Button, cm.btnPositve.Button)...)
+}
+
 func (i *inputVoteOptionsWidgets) handleVoteCountButtons() {
 	if i.increment.Button.Clicked() {
 		value, err := strconv.Atoi(i.input.Editor.Text())
@@ -17,7 +17,6 @@ import (
 	"gioui.org/widget"
 	"gioui.org/widget/material"
 
-	"github.com/ararog/timeago"
 	"github.com/planetdecred/dcrlibwallet"
 	"github.com/planetdecred/godcr/ui/decredmaterial"
 	"github.com/planetdecred/godcr/ui/values"
@@ -28,6 +27,7 @@ const PageProposals = "Proposals"
 
 type proposalItem struct {
 	btn               *widget.Clickable
+	watchBtn          *widget.Clickable
 	proposal          dcrlibwallet.Proposal
 	voteBar           decredmaterial.VoteBar
 	infoIcon          *widget.Icon
@@ -51,7 +51,7 @@ type tabs struct {
 type proposalsPage struct {
 	theme            *decredmaterial.Theme
 	common           *pageCommon
-	wallet           *wallet.Wallet
+	wallet           wallet.Backend
 	selectedProposal **dcrlibwallet.Proposal
 	proposals        **wallet.Proposals
 	syncedProposal   chan *wallet.Proposal
@@ -69,8 +69,25 @@ type proposalsPage struct {
 	timerIcon        *widget.Image
 	isSynced         bool
 	proposalsItemSet bool
+
+	searchEditor    decredmaterial.Editor
+	outcomeDropDown *decredmaterial.DropDown
+	dateDropDown    *decredmaterial.DropDown
+	orderDropDown   *decredmaterial.DropDown
+	filter          proposalFilter
+	// watched are the tokens of the watched proposals.
+	watched map[string]bool
+	// shown are the proposals of every tab that pass the filter.
+	shown [][]*proposalItem
+	// refilter is set when the proposals or the watched proposals changed
+	// since shown was last filtered.
+	refilter bool
 }
 
+// watchingCategory is the category of the tab of the watched proposals, which
+// are from every other tab.
+const watchingCategory int32 = 0
+
 var (
 	proposalCategoryTitles = []string{"In discussion", "Voting", "Approved", "Rejected", "Abandoned"}
 	proposalCategories     = []int32{
@@ -121,12 +138,38 @@ func ProposalsPage(common *pageCommon) Page {
 			},
 		)
 	}
+	pg.tabs.tabs = append(pg.tabs.tabs, tab{
+		title:     values.String(values.StrWatching),
+		btn:       new(widget.Clickable),
+		category:  watchingCategory,
+		container: &layout.List{Axis: layout.Vertical},
+	})
+	pg.shown = make([][]*proposalItem, len(pg.tabs.tabs))
+
+	pg.searchEditor = common.theme.Editor(&widget.Editor{SingleLine: true}, values.String(values.StrSearchProposals))
+	pg.outcomeDropDown = common.theme.DropDown(proposalDropDownItems(proposalVoteOutcomes), 6)
+	dateRanges := make([]string, len(proposalDateRanges))
+	for i, r := range proposalDateRanges {
+		dateRanges[i] = r.label
+	}
+	pg.dateDropDown = common.theme.DropDown(proposalDropDownItems(dateRanges), 6)
+	pg.orderDropDown = common.theme.DropDown(proposalDropDownItems(proposalSortOrders), 6)
 
 	return pg
 }
 
-func (pg *proposalsPage) OnResume() {
+// proposalDropDownItems returns the dropdown items of the strings with keys.
+func proposalDropDownItems(keys []string) []decredmaterial.DropDownItem {
+	items := make([]decredmaterial.DropDownItem, len(keys))
+	for i, key := range keys {
+		items[i] = decredmaterial.DropDownItem{Text: values.String(key)}
+	}
+	return items
+}
 
+func (pg *proposalsPage) OnResume() {
+	pg.watched = pg.wallet.WatchedProposals()
+	pg.refilter = true
 }
 
 func (pg *proposalsPage) handle() {
@@ -137,10 +180,17 @@ func (pg *proposalsPage) handle() {
 		}
 
 		for k := range pg.tabs.tabs[i].proposals {
-			for pg.tabs.tabs[i].proposals[k].btn.Clicked() {
-				*pg.selectedProposal = &pg.tabs.tabs[i].proposals[k].proposal
+			item := &pg.tabs.tabs[i].proposals[k]
+			for item.btn.Clicked() {
+				*pg.selectedProposal = &item.proposal
 				common.changePage(PageProposalDetails)
 			}
+			for item.watchBtn.Clicked() {
+				token := item.proposal.Token
+				pg.wallet.WatchProposal(token, !pg.watched[token])
+				pg.watched = pg.wallet.WatchedProposals()
+				pg.refilter = true
+			}
 		}
 	}
 
@@ -154,7 +204,8 @@ func (pg *proposalsPage) handle() {
 			if !pg.proposalsItemSet {
 				pg.initializeProposaltabItems()
 			}
-			go pg.updateProposalState()
+			pg.updateProposalState()
+			pg.refilter = true
 			pg.isSynced = true
 		} else if prop.ProposalStatus == wallet.NewProposalFound {
 			pg.addDiscoveredProposal(false, *prop.Proposal)
@@ -169,6 +220,53 @@ func (pg *proposalsPage) handle() {
 			pg.isSynced = false
 		})
 	}
+
+	// the dropdowns are all checked so that each reports its change once
+	changed := pg.refilter
+	for _, dropDown := range []*decredmaterial.DropDown{pg.outcomeDropDown, pg.dateDropDown, pg.orderDropDown} {
+		changed = dropDown.Changed() || changed
+	}
+	for _, evt := range pg.searchEditor.Editor.Events() {
+		if _, ok := evt.(widget.ChangeEvent); ok {
+			changed = true
+		}
+	}
+	if changed {
+		pg.filterProposals()
+	}
+}
+
+// filterProposals updates the shown proposals of every tab to those that pass
+// the search and filters.
+func (pg *proposalsPage) filterProposals() {
+	pg.refilter = false
+	pg.filter = proposalFilter{
+		query:   pg.searchEditor.Editor.Text(),
+		outcome: pg.outcomeDropDown.SelectedIndex(),
+		order:   pg.orderDropDown.SelectedIndex(),
+	}
+	if duration := proposalDateRanges[pg.dateDropDown.SelectedIndex()].duration; duration > 0 {
+		pg.filter.since = time.Now().Add(-duration)
+	}
+
+	var watched []*proposalItem
+	for i := range pg.tabs.tabs {
+		var items []*proposalItem
+		for k := range pg.tabs.tabs[i].proposals {
+			item := &pg.tabs.tabs[i].proposals[k]
+			items = append(items, item)
+			if pg.watched[item.proposal.Token] {
+				watched = append(watched, item)
+			}
+		}
+		pg.shown[i] = filterProposals(items, pg.filter)
+	}
+
+	for i := range pg.tabs.tabs {
+		if pg.tabs.tabs[i].category == watchingCategory {
+			pg.shown[i] = filterProposals(watched, pg.filter)
+		}
+	}
 }
 
 func (pg *proposalsPage) layoutTabs(gtx C) D {
@@ -201,7 +299,7 @@ func (pg *proposalsPage) layoutTabs(gtx C) D {
 												c.Color = pg.theme.Color.LightGray
 												r := float32(8.5)
 												c.Radius = decredmaterial.CornerRadius{NE: r, NW: r, SE: r, SW: r}
-												lbl := pg.theme.Body2(strconv.Itoa(len(pg.tabs.tabs[i].proposals)))
+												lbl := pg.theme.Body2(strconv.Itoa(len(pg.shown[i])))
 												lbl.Color = pg.theme.Color.Gray
 												if pg.tabs.selected == i {
 													c.Color = pg.theme.Color.Primary
@@ -238,10 +336,12 @@ func (pg *proposalsPage) layoutTabs(gtx C) D {
 }
 
 func (pg *proposalsPage) addDiscoveredProposal(first bool, proposal dcrlibwallet.Proposal) {
+	pg.refilter = true
 	for i := range pg.tabs.tabs {
 		if pg.tabs.tabs[i].category == proposal.Category {
 			item := proposalItem{
 				btn:               new(widget.Clickable),
+				watchBtn:          new(widget.Clickable),
 				proposal:          proposal,
 				voteBar:           pg.theme.VoteBar(pg.infoIcon, pg.legendIcon),
 				infoIcon:          pg.infoIcon,
@@ -296,14 +396,23 @@ func (pg *proposalsPage) updateProposalState() {
 }
 
 func (pg *proposalsPage) layoutNoProposalsFound(gtx C) D {
-	str := "No " + strings.ToLower(proposalCategoryTitles[pg.tabs.selected]) + " proposals"
+	selected := pg.tabs.tabs[pg.tabs.selected]
+	var str string
+	switch {
+	case selected.category == watchingCategory && len(pg.watched) == 0:
+		str = values.String(values.StrNoWatchedProposals)
+	case selected.category == watchingCategory || len(selected.proposals) > 0:
+		str = values.String(values.StrNoMatchingProposals)
+	default:
+		str = "No " + strings.ToLower(proposalCategoryTitles[pg.tabs.selected]) + " proposals"
+	}
 
 	gtx.Constraints.Min.X = gtx.Constraints.Max.X
 	return layout.Center.Layout(gtx, pg.theme.Body1(str).Layout)
 }
 
-func (pg *proposalsPage) layoutAuthorAndDate(gtx C, i int, proposal dcrlibwallet.Proposal) D {
-	p := pg.tabs.tabs[pg.tabs.selected]
+func (pg *proposalsPage) layoutAuthorAndDate(gtx C, item *proposalItem) D {
+	proposal := item.proposal
 	grayCol := pg.theme.Color.Gray
 
 	nameLabel := pg.theme.Body2(proposal.Username)
@@ -359,7 +468,7 @@ func (pg *proposalsPage) layoutAuthorAndDate(gtx C, i int, proposal dcrlibwallet
 					return layout.Inset{Top: values.MarginPaddingMinus22}.Layout(gtx, dotLabel.Layout)
 				}),
 				layout.Rigid(func(gtx C) D {
-					if p.title == "In discussion" {
+					if proposal.Category == dcrlibwallet.ProposalCategoryPre {
 						return layout.Flex{}.Layout(gtx,
 							layout.Rigid(stateLabel.Layout),
 							layout.Rigid(func(gtx C) D {
@@ -368,9 +477,9 @@ func (pg *proposalsPage) layoutAuthorAndDate(gtx C, i int, proposal dcrlibwallet
 									Max: gtx.Constraints.Max,
 								}
 								rect.Max.Y = 20
-								pg.layoutInfoTooltip(gtx, i, proposal.VoteStatus, rect)
+								pg.layoutInfoTooltip(gtx, item, rect)
 								return layout.Inset{Left: values.MarginPadding5}.Layout(gtx, func(gtx C) D {
-									return p.proposals[i].infoIcon.Layout(gtx, unit.Dp(20))
+									return item.infoIcon.Layout(gtx, unit.Dp(20))
 								})
 							}),
 						)
@@ -378,7 +487,7 @@ func (pg *proposalsPage) layoutAuthorAndDate(gtx C, i int, proposal dcrlibwallet
 					pg.timerIcon.Scale = 1
 					return layout.Flex{}.Layout(gtx,
 						layout.Rigid(func(gtx C) D {
-							if p.title == "Voting" {
+							if proposal.Category == dcrlibwallet.ProposalCategoryActive {
 								return layout.Inset{
 									Right: values.MarginPadding4,
 									Top:   values.MarginPadding3,
@@ -394,24 +503,41 @@ func (pg *proposalsPage) layoutAuthorAndDate(gtx C, i int, proposal dcrlibwallet
 	)
 }
 
-func (pg *proposalsPage) layoutInfoTooltip(gtx C, i int, state int32, rect image.Rectangle) {
-	proposal := pg.tabs.tabs[pg.tabs.selected].proposals[i]
+func (pg *proposalsPage) layoutInfoTooltip(gtx C, item *proposalItem, rect image.Rectangle) {
+	state := item.proposal.VoteStatus
 	inset := layout.Inset{Top: values.MarginPadding20, Left: values.MarginPaddingMinus230}
-	proposal.stateInfoTooltip.Layout(gtx, rect, inset, func(gtx C) D {
-		proposal.stateTooltipLabel.Color = pg.theme.Color.Gray
+	item.stateInfoTooltip.Layout(gtx, rect, inset, func(gtx C) D {
+		item.stateTooltipLabel.Color = pg.theme.Color.Gray
 		if state == 1 {
-			proposal.stateTooltipLabel.Text = "Waiting for author to authorize voting"
+			item.stateTooltipLabel.Text = "Waiting for author to authorize voting"
 		} else if state == 2 {
-			proposal.stateTooltipLabel.Text = "Waiting for admin to trigger the start of voting"
+			item.stateTooltipLabel.Text = "Waiting for admin to trigger the start of voting"
 		}
-		return proposal.stateTooltipLabel.Layout(gtx)
+		return item.stateTooltipLabel.Layout(gtx)
 	})
 }
 
-func (pg *proposalsPage) layoutTitle(gtx C, proposal dcrlibwallet.Proposal) D {
-	lbl := pg.theme.H6(proposal.Name)
+// layoutTitle lays out the title of the proposal with the button that watches
+// or unwatches it.
+func (pg *proposalsPage) layoutTitle(gtx C, item *proposalItem) D {
+	lbl := pg.theme.H6(item.proposal.Name)
 	lbl.Font.Weight = text.Bold
-	return layout.Inset{Top: values.MarginPadding4}.Layout(gtx, lbl.Layout)
+
+	icon, color := pg.common.icons.starBorder, pg.theme.Color.Gray
+	if pg.watched[item.proposal.Token] {
+		icon, color = pg.common.icons.star, pg.theme.Color.Primary
+	}
+	watchButton := pg.theme.PlainIconButton(item.watchBtn, icon)
+	watchButton.Color = color
+	watchButton.Size = values.MarginPadding20
+	watchButton.Inset = layout.UniformInset(values.MarginPadding4)
+
+	return layout.Inset{Top: values.MarginPadding4}.Layout(gtx, func(gtx C) D {
+		return layout.Flex{Alignment: layout.Middle}.Layout(gtx,
+			layout.Flexed(1, lbl.Layout),
+			layout.Rigid(watchButton.Layout),
+		)
+	})
 }
 
 func (pg *proposalsPage) layoutProposalVoteBar(gtx C, proposalItem proposalItem) D {
@@ -426,33 +552,33 @@ func (pg *proposalsPage) layoutProposalVoteBar(gtx C, proposalItem proposalItem)
 
 func (pg *proposalsPage) layoutProposalsList(gtx C) D {
 	selected := pg.tabs.tabs[pg.tabs.selected]
-	wdgs := make([]func(gtx C) D, len(selected.proposals))
-	for i := range selected.proposals {
-		index := i
-		proposalItem := selected.proposals[index]
-		wdgs[index] = func(gtx C) D {
+	shown := pg.shown[pg.tabs.selected]
+	wdgs := make([]func(gtx C) D, len(shown))
+	for i := range shown {
+		proposalItem := shown[i]
+		wdgs[i] = func(gtx C) D {
 			return layout.Inset{
 				Top:    values.MarginPadding2,
 				Bottom: values.MarginPadding2,
 				Left:   values.MarginPadding2,
 				Right:  values.MarginPadding2,
 			}.Layout(gtx, func(gtx C) D {
-				return decredmaterial.Clickable(gtx, selected.proposals[index].btn, func(gtx C) D {
+				return decredmaterial.Clickable(gtx, proposalItem.btn, func(gtx C) D {
 					return pg.itemCard.Layout(gtx, func(gtx C) D {
 						gtx.Constraints.Min.X = gtx.Constraints.Max.X
 						return layout.UniformInset(values.MarginPadding16).Layout(gtx, func(gtx C) D {
 							return layout.Flex{Axis: layout.Vertical}.Layout(gtx,
 								layout.Rigid(func(gtx C) D {
-									return pg.layoutAuthorAndDate(gtx, index, proposalItem.proposal)
+									return pg.layoutAuthorAndDate(gtx, proposalItem)
 								}),
 								layout.Rigid(func(gtx C) D {
-									return pg.layoutTitle(gtx, proposalItem.proposal)
+									return pg.layoutTitle(gtx, proposalItem)
 								}),
 								layout.Rigid(func(gtx C) D {
 									if proposalItem.proposal.Category == dcrlibwallet.ProposalCategoryActive ||
 										proposalItem.proposal.Category == dcrlibwallet.ProposalCategoryApproved ||
 										proposalItem.proposal.Category == dcrlibwallet.ProposalCategoryRejected {
-										return pg.layoutProposalVoteBar(gtx, proposalItem)
+										return pg.layoutProposalVoteBar(gtx, *proposalItem)
 									}
 									return D{}
 								}),
@@ -469,13 +595,37 @@ func (pg *proposalsPage) layoutProposalsList(gtx C) D {
 }
 
 func (pg *proposalsPage) layoutContent(gtx C) D {
-	selected := pg.tabs.tabs[pg.tabs.selected]
-	if len(selected.proposals) == 0 {
+	if len(pg.shown[pg.tabs.selected]) == 0 {
 		return pg.layoutNoProposalsFound(gtx)
 	}
 	return pg.layoutProposalsList(gtx)
 }
 
+// layoutFilters lays out the search and the filters of the proposals. The
+// dropdowns are stacked over the proposals so that they open over them.
+func (pg *proposalsPage) layoutFilters(gtx C) D {
+	return layout.Flex{Axis: layout.Vertical}.Layout(gtx,
+		layout.Rigid(func(gtx C) D {
+			return layout.Inset{Bottom: values.MarginPadding10}.Layout(gtx, pg.searchEditor.Layout)
+		}),
+		layout.Flexed(1, func(gtx C) D {
+			return layout.Stack{Alignment: layout.N}.Layout(gtx,
+				layout.Expanded(func(gtx C) D {
+					return layout.Inset{Top: values.MarginPadding60}.Layout(gtx, pg.layoutContent)
+				}),
+				layout.Stacked(func(gtx C) D {
+					gtx.Constraints.Min.X = gtx.Constraints.Max.X
+					return layout.Flex{Spacing: layout.SpaceBetween}.Layout(gtx,
+						layout.Rigid(pg.outcomeDropDown.Layout),
+						layout.Rigid(pg.dateDropDown.Layout),
+						layout.Rigid(pg.orderDropDown.Layout),
+					)
+				}),
+			)
+		}),
+	)
+}
+
 func (pg *proposalsPage) layoutIsSyncedSection(gtx C) D {
 	return layout.Flex{}.Layout(gtx,
 		layout.Rigid(func(gtx C) D {
@@ -523,6 +673,10 @@ func (pg *proposalsPage) initializeProposaltabItems() {
 			pg.addDiscoveredProposal(true, (*pg.proposals).Proposals[i])
 		}
 	}
+	// the items are set up while laying out, after handle filtered them
+	if pg.refilter {
+		pg.filterProposals()
+	}
 }
 
 func (pg *proposalsPage) Layout(gtx C) D {
@@ -557,7 +711,7 @@ func (pg *proposalsPage) Layout(gtx C) D {
 			)
 		}),
 		layout.Flexed(1, func(gtx C) D {
-			return pg.common.UniformPadding(gtx, pg.layoutContent)
+			return pg.common.UniformPadding(gtx, pg.layoutFilters)
 		}),
 	)
 }
@@ -565,8 +719,7 @@ func (pg *proposalsPage) Layout(gtx C) D {
 func (pg *proposalsPage) onClose() {}
 
 func timeAgo(timestamp int64) string {
-	timeAgo, _ := timeago.TimeAgoWithTime(time.Now(), time.Unix(timestamp, 0))
-	return timeAgo
+	return values.TimeAgo(time.Unix(timestamp, 0))
 }
 
 func truncateString(str string, num int) string {
@@ -84,8 +84,7 @@ func ReceivePage(common *pageCommon) Page {
 	page.selector = newAccountSelector(common).
 		title("Receiving account").
 		accountSelected(func(selectedAccount *dcrlibwallet.Account) {
-			selectedWallet := page.multiWallet.WalletWithID(selectedAccount.WalletID)
-			currentAddress, err := selectedWallet.CurrentAddress(selectedAccount.Number)
+			currentAddress, err := page.wallet.CurrentAddress(selectedAccount.WalletID, selectedAccount.Number)
 			if err != nil {
 				log.Errorf("Error getting current address: %v", err)
 			} else {
@@ -97,9 +96,8 @@ func ReceivePage(common *pageCommon) Page {
 		accountValidator(func(account *dcrlibwallet.Account) bool {
 
 			// Filter out imported account and mixed.
-			wal := page.multiWallet.WalletWithID(account.WalletID)
 			if account.Number == MaxInt32 ||
-				account.Number == wal.MixedAccountNumber() {
+				account.Number == page.wallet.MixedAccountNumber(account.WalletID) {
 				return false
 			}
 			return true
@@ -367,10 +365,10 @@ func (pg *receivePage) handle() {
 	}
 }
 func (pg *receivePage) generateNewAddress() (string, error) {
-	selectedWallet := pg.multiWallet.WalletWithID(pg.selector.selectedAccount.WalletID)
+	selectedAccount := pg.selector.selectedAccount
 
 generateAddress:
-	newAddr, err := selectedWallet.NextAddress(pg.selector.selectedAccount.Number)
+	newAddr, err := pg.wallet.NextAddress(selectedAccount.WalletID, selectedAccount.Number)
 	if err != nil {
 		return "", err
 	}
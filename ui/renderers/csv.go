@@ -0,0 +1,39 @@
+package renderers
+
+import (
+	"bytes"
+	"encoding/csv"
+	"errors"
+
+	"gioui.org/layout"
+
+	"github.com/planetdecred/godcr/ui/decredmaterial"
+)
+
+// RenderCSV renders CSV data as a table, with its first record as the header.
+func RenderCSV(theme *decredmaterial.Theme, data []byte) (layout.Widget, error) {
+	reader := csv.NewReader(bytes.NewReader(data))
+	reader.FieldsPerRecord = -1
+	records, err := reader.ReadAll()
+	if err != nil {
+		return nil, err
+	}
+	if len(records) == 0 {
+		return nil, errors.New("no records")
+	}
+
+	table := newTableRenderer(theme)
+	for _, field := range records[0] {
+		table.AddHeaderCell(field, CellAlignLeft)
+	}
+	for _, record := range records[1:] {
+		table.NextBodyRow()
+		for i, field := range record {
+			// the table is only as wide as its header
+			if i < len(records[0]) {
+				table.AddBodyCell(field, CellAlignCopyHeader)
+			}
+		}
+	}
+	return table.Render(), nil
+}
@@ -1,6 +1,7 @@
 package renderers
 
 import (
+	"image"
 	"strings"
 
 	"gioui.org/layout"
@@ -20,6 +21,13 @@ type MarkdownRenderer struct {
 }
 
 func RenderMarkdown(gtx layout.Context, theme *decredmaterial.Theme, source string) *MarkdownRenderer {
+	return RenderMarkdownWithImages(gtx, theme, source, nil)
+}
+
+// RenderMarkdownWithImages renders markdown that embeds images, which are laid
+// out from images by their destination. The alt text of an image not in
+// images is laid out instead.
+func RenderMarkdownWithImages(gtx layout.Context, theme *decredmaterial.Theme, source string, images map[string]image.Image) *MarkdownRenderer {
 	extensions := parser.NoIntraEmphasis        // Ignore emphasis markers inside words
 	extensions |= parser.Tables                 // Parse tables
 	extensions |= parser.FencedCode             // Parse fenced code blocks
@@ -37,6 +45,7 @@ func RenderMarkdown(gtx layout.Context, theme *decredmaterial.Theme, source stri
 	r := &MarkdownRenderer{
 		newRenderer(theme, false),
 	}
+	r.images = images
 
 	source = r.prepareDocForTable(source)
 	nodes := md.Parse([]byte(source), p)
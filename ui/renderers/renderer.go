@@ -2,12 +2,14 @@ package renderers
 
 import (
 	"fmt"
+	"image"
 	"io"
 	"regexp"
 	"strings"
 	"unicode"
 
 	"gioui.org/layout"
+	"gioui.org/op/paint"
 	"gioui.org/text"
 	"gioui.org/widget"
 	"gioui.org/widget/material"
@@ -30,6 +32,8 @@ type Renderer struct {
 	containers     []layout.Widget
 	styleGroups    []map[string]string
 	isHTML         bool
+	// images are the images the document may embed, by their destination.
+	images map[string]image.Image
 
 	table *tableRenderer
 }
@@ -95,6 +99,11 @@ func (r *Renderer) RenderNode(w io.Writer, node ast.Node, entering bool) ast.Wal
 			r.renderLink(node)
 			return ast.SkipChildren
 		}
+	case *ast.Image:
+		if entering {
+			r.renderImage(node)
+		}
+		return ast.SkipChildren
 	case *ast.Text:
 		r.renderText(node)
 	case *ast.Table:
@@ -167,6 +176,29 @@ func (r *Renderer) renderLink(node *ast.Link) {
 	r.stringBuilder.WriteString(word)
 }
 
+// renderImage lays out an embedded image on a line of its own, or its alt
+// text if the image is not one of the renderer's images.
+func (r *Renderer) renderImage(node *ast.Image) {
+	img, ok := r.images[string(node.Destination)]
+	if !ok {
+		for _, child := range node.GetChildren() {
+			if leaf := child.AsLeaf(); leaf != nil {
+				r.stringBuilder.Write(leaf.Literal)
+			}
+		}
+		return
+	}
+
+	// the text before the image is laid out above it
+	if strings.TrimSpace(r.stringBuilder.String()) != "" {
+		r.renderWords(r.theme.Body1(""))
+	}
+	src := paint.NewImageOp(img)
+	r.containers = append(r.containers, func(gtx C) D {
+		return widget.Image{Src: src, Fit: widget.ScaleDown, Position: layout.W, Scale: 1}.Layout(gtx)
+	})
+}
+
 func (r *Renderer) renderText(node *ast.Text) {
 	if string(node.Literal) == "\n" {
 		return
@@ -0,0 +1,104 @@
+package ui
+
+import (
+	"gioui.org/unit"
+
+	"github.com/planetdecred/godcr/ui/values"
+)
+
+const (
+	uiScaleConfigKey   = "ui_scale"
+	textScaleConfigKey = "text_scale"
+
+	// defaultScale is the display and text scale in percent used until one
+	// is chosen.
+	defaultScale = 100
+)
+
+// uiScale holds the display and text scale preferences in percent of the
+// platform default size. It is applied to the metric of every frame so that
+// all values dimensions and theme text sizes are scaled consistently.
+type uiScale struct {
+	display int
+	text    int
+}
+
+func defaultUIScale() uiScale {
+	return uiScale{
+		display: defaultScale,
+		text:    defaultScale,
+	}
+}
+
+// metric returns m with the display scale applied to both dp and sp values,
+// and the text scale additionally applied to sp values.
+func (s uiScale) metric(m unit.Metric) unit.Metric {
+	m.PxPerDp *= float32(s.display) / 100
+	m.PxPerSp *= float32(s.display) / 100 * float32(s.text) / 100
+	return m
+}
+
+// stepScale returns the scale adjacent to current in values.ArrUIScales,
+// the next larger one if up is true and the next smaller one otherwise.
+// The returned scale is clamped to the smallest and largest scales.
+func stepScale(current int, up bool) int {
+	scales := values.ArrUIScales
+	if up {
+		for _, s := range scales {
+			if s > current {
+				return s
+			}
+		}
+		return scales[len(scales)-1]
+	}
+
+	for i := len(scales) - 1; i >= 0; i-- {
+		if scales[i] < current {
+			return scales[i]
+		}
+	}
+	return scales[0]
+}
+
+// validScale returns s if it is one of values.ArrUIScales, or the default
+// scale otherwise.
+func validScale(s int) int {
+	for _, v := range values.ArrUIScales {
+		if v == s {
+			return s
+		}
+	}
+	return defaultScale
+}
+
+// refreshScale loads the saved scale preferences. It must only be called
+// once the multiwallet config is available.
+func (common *pageCommon) refreshScale() {
+	*common.uiScale = uiScale{
+		display: validScale(common.wallet.ReadIntConfigValueForKey(uiScaleConfigKey, defaultScale)),
+		text:    validScale(common.wallet.ReadIntConfigValueForKey(textScaleConfigKey, defaultScale)),
+	}
+}
+
+// setDisplayScale saves and applies a new display scale.
+func (common *pageCommon) setDisplayScale(scale int) {
+	common.uiScale.display = validScale(scale)
+	common.wallet.SaveConfigValueForKey(uiScaleConfigKey, common.uiScale.display)
+	common.refreshWindow()
+}
+
+// setTextScale saves and applies a new text scale.
+func (common *pageCommon) setTextScale(scale int) {
+	common.uiScale.text = validScale(scale)
+	common.wallet.SaveConfigValueForKey(textScaleConfigKey, common.uiScale.text)
+	common.refreshWindow()
+}
+
+// zoom applies a display scale selected with a keyboard shortcut, saving it
+// if the wallet config is loaded.
+func (win *Window) zoom(scale int) {
+	win.scale.display = scale
+	if win.wallet.IsConfigLoaded() {
+		win.wallet.SaveConfigValueForKey(uiScaleConfigKey, scale)
+	}
+}
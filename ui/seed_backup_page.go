@@ -45,7 +45,7 @@ type (
 type backupPage struct {
 	theme  *decredmaterial.Theme
 	common *pageCommon
-	wal    *wallet.Wallet
+	wal    wallet.Backend
 	info   *wallet.MultiWalletInfo
 
 	backButton     decredmaterial.IconButton
@@ -7,9 +7,9 @@ import (
 	"gioui.org/layout"
 	"gioui.org/unit"
 	"gioui.org/widget/material"
-	"github.com/decred/dcrd/dcrutil"
 	"github.com/planetdecred/godcr/ui/decredmaterial"
 	"github.com/planetdecred/godcr/ui/values"
+	"github.com/planetdecred/godcr/wallet"
 )
 
 const ModalSendConfirm = "send_confirm_modal"
@@ -44,6 +44,11 @@ func (scm *sendConfirmModal) Dismiss() {
 	scm.dismissModal(scm)
 }
 
+func (scm *sendConfirmModal) focusTargets() []focusTarget {
+	return append(editorTargets(scm.passwordEditor.Editor),
+		buttonTargets(scm.closeConfirmationModalButton.Button, scm.confirmButton.Button)...)
+}
+
 func (scm *sendConfirmModal) OnResume() {
 }
 
@@ -61,9 +66,9 @@ func (scm *sendConfirmModal) handle() {
 
 func (scm *sendConfirmModal) Layout(gtx layout.Context) D {
 	receiveAcct := scm.destinationAccountSelector.selectedAccount
-	receiveWallet := scm.multiWallet.WalletWithID(receiveAcct.WalletID)
+	receiveWallet := scm.wallet.WalletWithID(receiveAcct.WalletID)
 	sendAcct := scm.sourceAccountSelector.selectedAccount
-	sendWallet := scm.multiWallet.WalletWithID(sendAcct.WalletID)
+	sendWallet := scm.wallet.WalletWithID(sendAcct.WalletID)
 
 	w := []layout.Widget{
 		func(gtx C) D {
@@ -200,7 +205,7 @@ func (scm *sendConfirmModal) Layout(gtx layout.Context) D {
 								return material.Loader(th).Layout(gtx)
 							})
 						}
-						scm.confirmButton.Text = fmt.Sprintf("Send %s", dcrutil.Amount(scm.totalCostDCR).String())
+						scm.confirmButton.Text = fmt.Sprintf("Send %s", wallet.FormatAmount(scm.totalCostDCR))
 						return scm.confirmButton.Layout(gtx)
 					}),
 				)
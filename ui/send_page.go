@@ -3,7 +3,6 @@ package ui
 import (
 	"fmt"
 	"image/color"
-	"reflect"
 	"strconv"
 	"strings"
 
@@ -36,9 +35,9 @@ type sendPage struct {
 	common        *pageCommon
 	theme         *decredmaterial.Theme
 
-	txAuthor        *dcrlibwallet.TxAuthor
+	txAuthor        *wallet.TxAuthor
 	broadcastResult *wallet.Broadcast
-	wallet          *wallet.Wallet
+	wallet          wallet.Backend
 
 	destinationAddressEditor decredmaterial.Editor
 	leftAmountEditor         decredmaterial.Editor
@@ -161,12 +160,12 @@ func SendPage(common *pageCommon) Page {
 
 	pg.accountSwitch = common.theme.SwitchButtonText([]decredmaterial.SwitchItem{{Text: "Address"}, {Text: "My account"}})
 
-	pg.balanceAfterSendValue = "- DCR"
+	pg.balanceAfterSendValue = fmt.Sprintf("- %s", wallet.AmountUnit())
 
 	pg.nextButton = common.theme.Button(new(widget.Clickable), "Next")
 	pg.nextButton.Background = pg.theme.Color.InactiveGray
 
-	activeEditorHint := fmt.Sprintf("Amount (%s)", pg.leftExchangeValue)
+	activeEditorHint := amountHint(pg.leftExchangeValue)
 	pg.leftAmountEditor = common.theme.Editor(new(widget.Editor), activeEditorHint)
 	pg.leftAmountEditor.Editor.SetText("")
 	pg.leftAmountEditor.IsCustomButton = true
@@ -176,7 +175,7 @@ func SendPage(common *pageCommon) Page {
 	pg.leftAmountEditor.CustomButton.Text = "Max"
 	pg.leftAmountEditor.CustomButton.CornerRadius = values.MarginPadding0
 
-	inactiveEditorHint := fmt.Sprintf("Amount (%s)", pg.rightExchangeValue)
+	inactiveEditorHint := amountHint(pg.rightExchangeValue)
 	pg.rightAmountEditor = common.theme.Editor(new(widget.Editor), inactiveEditorHint)
 	pg.rightAmountEditor.Editor.SetText("")
 	pg.rightAmountEditor.IsCustomButton = true
@@ -225,22 +224,22 @@ func SendPage(common *pageCommon) Page {
 			pg.shouldInitializeTxAuthor = true
 		}).
 		accountValidator(func(account *dcrlibwallet.Account) bool {
-			wal := pg.common.multiWallet.WalletWithID(account.WalletID)
+			wal := pg.common.wallet.WalletWithID(account.WalletID)
 
 			// Imported and watch only wallet accounts are invalid for sending
-			accountIsValid := account.Number != MaxInt32 && !wal.IsWatchingOnlyWallet()
+			accountIsValid := account.Number != MaxInt32 && !wal.IsWatchingOnly
 
-			if wal.ReadBoolConfigValueForKey(dcrlibwallet.AccountMixerConfigSet, false) {
+			if pg.common.wallet.IsAccountMixerConfigSet(account.WalletID) {
 				// privacy is enabled for selected wallet
 
 				if pg.sendToOption == "Address" { //Todo
 					// only mixed can send to address
-					accountIsValid = account.Number == wal.MixedAccountNumber()
+					accountIsValid = account.Number == pg.common.wallet.MixedAccountNumber(account.WalletID)
 				} else {
 					// send to account, check if selected destination account belongs to wallet
 					destinationAccount := pg.destinationAccountSelector.selectedAccount
 					if destinationAccount.WalletID != account.WalletID {
-						accountIsValid = account.Number == wal.MixedAccountNumber()
+						accountIsValid = account.Number == pg.common.wallet.MixedAccountNumber(account.WalletID)
 					}
 				}
 			}
@@ -257,9 +256,8 @@ func SendPage(common *pageCommon) Page {
 		accountValidator(func(account *dcrlibwallet.Account) bool {
 
 			// Filter out imported account and mixed.
-			wal := pg.common.multiWallet.WalletWithID(account.WalletID)
 			if account.Number == MaxInt32 ||
-				account.Number == wal.MixedAccountNumber() {
+				account.Number == pg.common.wallet.MixedAccountNumber(account.WalletID) {
 				return false
 			}
 
@@ -384,7 +382,7 @@ func (pg *sendPage) toSection(gtx layout.Context, common *pageCommon) layout.Dim
 				if pg.usdExchangeSet {
 					return layout.Flex{Axis: layout.Horizontal}.Layout(gtx,
 						layout.Flexed(0.45, func(gtx C) D {
-							pg.leftAmountEditor.Hint = fmt.Sprintf("Amount (%s)", pg.leftExchangeValue)
+							pg.leftAmountEditor.Hint = amountHint(pg.leftExchangeValue)
 							return pg.leftAmountEditor.Layout(gtx)
 						}),
 						layout.Flexed(0.1, func(gtx C) D {
@@ -399,7 +397,7 @@ func (pg *sendPage) toSection(gtx layout.Context, common *pageCommon) layout.Dim
 							})
 						}),
 						layout.Flexed(0.45, func(gtx C) D {
-							pg.rightAmountEditor.Hint = fmt.Sprintf("Amount (%s)", pg.rightExchangeValue)
+							pg.rightAmountEditor.Hint = amountHint(pg.rightExchangeValue)
 							return pg.rightAmountEditor.Layout(gtx)
 						}),
 					)
@@ -620,7 +618,7 @@ func (pg *sendPage) validateDestinationAddress() bool {
 
 func (pg *sendPage) validateLeftAmount() bool {
 	if pg.inputsNotEmpty(pg.leftAmountEditor.Editor) {
-		_, err := strconv.ParseFloat(pg.leftAmountEditor.Editor.Text(), 64)
+		_, err := parseInputAmount(pg.leftAmountEditor.Editor.Text(), pg.leftExchangeValue)
 		if err != nil {
 			pg.leftAmountEditor.SetError("Invalid amount")
 			return false
@@ -634,7 +632,7 @@ func (pg *sendPage) validateLeftAmount() bool {
 
 func (pg *sendPage) validateRightAmount() bool {
 	if pg.inputsNotEmpty(pg.rightAmountEditor.Editor) {
-		_, err := strconv.ParseFloat(pg.rightAmountEditor.Editor.Text(), 64)
+		_, err := parseInputAmount(pg.rightAmountEditor.Editor.Text(), pg.rightExchangeValue)
 		if err != nil {
 			pg.rightAmountEditor.SetError("Invalid amount")
 			return false
@@ -656,7 +654,7 @@ func (pg *sendPage) inputsNotEmpty(editors ...*widget.Editor) bool {
 }
 
 func (pg *sendPage) calculateValues(isUpdateAmountInput bool) {
-	defaultLeftValues := fmt.Sprintf("- %s", "DCR")
+	defaultLeftValues := fmt.Sprintf("- %s", wallet.AmountUnit())
 	defaultRightValues := "($ -)"
 
 	pg.leftTransactionFeeValue = defaultLeftValues
@@ -670,13 +668,13 @@ func (pg *sendPage) calculateValues(isUpdateAmountInput bool) {
 	pg.sendAmountDCR = defaultLeftValues
 	pg.confirmTxModal.sendAmountUSD = defaultRightValues
 
-	if reflect.DeepEqual(pg.txAuthor, &dcrlibwallet.TxAuthor{}) || !pg.validate() {
+	if *pg.txAuthor == nil || !pg.validate() {
 		return
 	}
 
-	pg.inputAmount, _ = strconv.ParseFloat(pg.leftAmountEditor.Editor.Text(), 64)
+	pg.inputAmount, _ = parseInputAmount(pg.leftAmountEditor.Editor.Text(), pg.leftExchangeValue)
 	if pg.usdExchangeSet && pg.rightAmountEditor.Editor.Focused() {
-		pg.inputAmount, _ = strconv.ParseFloat(pg.rightAmountEditor.Editor.Text(), 64)
+		pg.inputAmount, _ = parseInputAmount(pg.rightAmountEditor.Editor.Text(), pg.rightExchangeValue)
 	}
 
 	if pg.usdExchangeSet && pg.LastTradeRate != "" {
@@ -694,13 +692,13 @@ func (pg *sendPage) calculateValues(isUpdateAmountInput bool) {
 func (pg *sendPage) updateAmountInputsValues(isUpdateAmountInput bool) {
 	switch {
 	case pg.leftExchangeValue == "USD" && pg.LastTradeRate != "" && pg.leftAmountEditor.Editor.Focused():
-		pg.rightAmountEditor.Editor.SetText(fmt.Sprintf("%f", pg.amountUSDtoDCR))
+		pg.rightAmountEditor.Editor.SetText(dcrInputText(pg.amountUSDtoDCR))
 		pg.setDestinationAddr(pg.amountUSDtoDCR)
 	case pg.leftExchangeValue == "USD" && pg.LastTradeRate != "" && pg.rightAmountEditor.Editor.Focused():
 		pg.leftAmountEditor.Editor.SetText(fmt.Sprintf("%f", pg.amountDCRtoUSD))
 		pg.setDestinationAddr(pg.inputAmount)
 	case pg.leftExchangeValue == "DCR" && pg.LastTradeRate != "" && pg.rightAmountEditor.Editor.Focused():
-		pg.leftAmountEditor.Editor.SetText(fmt.Sprintf("%f", pg.amountUSDtoDCR))
+		pg.leftAmountEditor.Editor.SetText(dcrInputText(pg.amountUSDtoDCR))
 		pg.setDestinationAddr(pg.amountUSDtoDCR)
 	case pg.leftExchangeValue == "DCR" && pg.LastTradeRate != "" && pg.leftAmountEditor.Editor.Focused():
 		pg.rightAmountEditor.Editor.SetText(fmt.Sprintf("%f", pg.amountDCRtoUSD))
@@ -737,19 +735,18 @@ func (pg *sendPage) setDestinationAddr(sendAmount float64) {
 		return
 	}
 
-	pg.txAuthor.RemoveSendDestination(0)
+	(*pg.txAuthor).RemoveSendDestination(0)
 	addr := pg.destinationAddressEditor.Editor.Text()
 	if pg.sendToOption == "My account" {
 		selectedAccount := pg.destinationAccountSelector.selectedAccount
-		wal := pg.common.multiWallet.WalletWithID(selectedAccount.WalletID)
-		address, err := wal.CurrentAddress(selectedAccount.Number)
+		address, err := pg.common.wallet.CurrentAddress(selectedAccount.WalletID, selectedAccount.Number)
 		if err != nil {
 			pg.feeEstimationError(err.Error(), "destination address")
 		} else {
 			addr = address
 		}
 	}
-	pg.txAuthor.AddSendDestination(addr, pg.amountAtoms, false)
+	(*pg.txAuthor).AddSendDestination(addr, pg.amountAtoms, false)
 }
 
 func (pg *sendPage) amountValues() amountValue {
@@ -758,35 +755,35 @@ func (pg *sendPage) amountValues() amountValue {
 	switch {
 	case pg.leftExchangeValue == "USD" && pg.LastTradeRate != "":
 		return amountValue{
-			sendAmountDCR:            dcrutil.Amount(pg.amountAtoms).String(),
+			sendAmountDCR:            wallet.FormatAmount(pg.amountAtoms),
 			sendAmountUSD:            fmt.Sprintf("$ %f", dcrutil.Amount(pg.amountAtoms).ToCoin()*pg.usdExchangeRate),
 			leftTransactionFeeValue:  fmt.Sprintf("%f USD", txFeeValueUSD),
-			rightTransactionFeeValue: fmt.Sprintf("(%s)", dcrutil.Amount(pg.txFee).String()),
+			rightTransactionFeeValue: fmt.Sprintf("(%s)", wallet.FormatAmount(pg.txFee)),
 			leftTotalCostValue:       fmt.Sprintf("%s USD", strconv.FormatFloat(pg.inputAmount+txFeeValueUSD, 'f', 7, 64)),
-			rightTotalCostValue:      fmt.Sprintf("(%s )", dcrutil.Amount(pg.totalCostDCR).String()),
+			rightTotalCostValue:      fmt.Sprintf("(%s )", wallet.FormatAmount(pg.totalCostDCR)),
 		}
 	case pg.leftExchangeValue == "DCR" && pg.LastTradeRate != "":
 		return amountValue{
-			sendAmountDCR:            dcrutil.Amount(pg.amountAtoms).String(),
+			sendAmountDCR:            wallet.FormatAmount(pg.amountAtoms),
 			sendAmountUSD:            fmt.Sprintf("$ %s", strconv.FormatFloat(pg.amountDCRtoUSD, 'f', 2, 64)),
-			leftTransactionFeeValue:  dcrutil.Amount(pg.txFee).String(),
+			leftTransactionFeeValue:  wallet.FormatAmount(pg.txFee),
 			rightTransactionFeeValue: fmt.Sprintf("($ %s)", strconv.FormatFloat(txFeeValueUSD, 'f', 2, 64)),
-			leftTotalCostValue:       dcrutil.Amount(pg.totalCostDCR).String(),
+			leftTotalCostValue:       wallet.FormatAmount(pg.totalCostDCR),
 			rightTotalCostValue:      fmt.Sprintf("($ %s)", strconv.FormatFloat(pg.amountDCRtoUSD+txFeeValueUSD, 'f', 2, 64)),
 		}
 	default:
 		return amountValue{
-			sendAmountDCR:           dcrutil.Amount(pg.amountAtoms).String(),
+			sendAmountDCR:           wallet.FormatAmount(pg.amountAtoms),
 			sendAmountUSD:           "",
-			leftTransactionFeeValue: dcrutil.Amount(pg.txFee).String(),
-			leftTotalCostValue:      dcrutil.Amount(pg.totalCostDCR).String(),
+			leftTransactionFeeValue: wallet.FormatAmount(pg.txFee),
+			leftTotalCostValue:      wallet.FormatAmount(pg.totalCostDCR),
 		}
 	}
 }
 
 func (pg *sendPage) getTxFee() {
 	// calculate transaction fee
-	feeAndSize, err := pg.txAuthor.EstimateFeeAndSize()
+	feeAndSize, err := (*pg.txAuthor).EstimateFeeAndSize()
 	if err != nil {
 		pg.feeEstimationError(err.Error(), "fee")
 		return
@@ -805,7 +802,7 @@ func (pg *sendPage) balanceAfterSend(isInputAmountEmpty bool) {
 	} else {
 		pg.remainingBalance = sendAcct.Balance.Spendable - pg.totalCostDCR
 	}
-	pg.balanceAfterSendValue = dcrutil.Amount(pg.remainingBalance).String()
+	pg.balanceAfterSendValue = wallet.FormatAmount(pg.remainingBalance)
 }
 
 func (pg *sendPage) feeEstimationError(err, errorPath string) {
@@ -902,7 +899,7 @@ func (pg *sendPage) setMaxAmount() {
 
 	if atomValue > 0 {
 		// Estimate max send value
-		amount, err := pg.txAuthor.EstimateMaxSendAmount()
+		amount, err := (*pg.txAuthor).EstimateMaxSendAmount()
 		if err == nil {
 			atomValue = amount.AtomValue
 			dcrValue := amount.DcrValue
@@ -913,7 +910,7 @@ func (pg *sendPage) setMaxAmount() {
 		// Adjust value
 		step := int64(10)
 		for {
-			_, err := pg.txAuthor.EstimateFeeAndSize()
+			_, err := (*pg.txAuthor).EstimateFeeAndSize()
 			if err != nil {
 				atomValue -= step
 				pg.updateAmountField(dcrutil.Amount(atomValue).ToCoin())
@@ -929,7 +926,7 @@ func (pg *sendPage) setMaxAmount() {
 
 func (pg *sendPage) updateAmountField(spendableBalanceDCR float64) {
 	if !pg.usdExchangeSet {
-		pg.leftAmountEditor.Editor.SetText(strconv.FormatFloat(spendableBalanceDCR, 'f', 7, 64))
+		pg.leftAmountEditor.Editor.SetText(dcrInputText(spendableBalanceDCR))
 	} else {
 		pg.fetchExchangeValue()
 		pg.usdExchangeRate, _ = strconv.ParseFloat(pg.LastTradeRate, 64)
@@ -938,20 +935,62 @@ func (pg *sendPage) updateAmountField(spendableBalanceDCR float64) {
 		switch {
 		case pg.leftExchangeValue == "USD":
 			pg.leftAmountEditor.Editor.SetText(strconv.FormatFloat(spendableBalanceUSD, 'f', 7, 64))
-			pg.rightAmountEditor.Editor.SetText(strconv.FormatFloat(spendableBalanceDCR, 'f', 7, 64))
+			pg.rightAmountEditor.Editor.SetText(dcrInputText(spendableBalanceDCR))
 		case pg.leftExchangeValue == "DCR":
-			pg.leftAmountEditor.Editor.SetText(strconv.FormatFloat(spendableBalanceDCR, 'f', 7, 64))
+			pg.leftAmountEditor.Editor.SetText(dcrInputText(spendableBalanceDCR))
 			pg.rightAmountEditor.Editor.SetText(strconv.FormatFloat(spendableBalanceUSD, 'f', 7, 64))
 		}
 	}
 }
 
+// amountHint returns the hint of an amount editor in the currency
+// exchangeValue, showing DCR amounts in the display unit.
+func amountHint(exchangeValue string) string {
+	if exchangeValue == "DCR" {
+		exchangeValue = wallet.AmountUnit()
+	}
+	return fmt.Sprintf("Amount (%s)", exchangeValue)
+}
+
+// parseInputAmount parses the text of an amount editor in the currency
+// exchangeValue. DCR amounts are entered in the display unit and returned
+// in coins.
+func parseInputAmount(text, exchangeValue string) (float64, error) {
+	if exchangeValue == "DCR" {
+		atoms, err := wallet.ParseAmount(text)
+		return dcrutil.Amount(atoms).ToCoin(), err
+	}
+	return strconv.ParseFloat(text, 64)
+}
+
+// dcrInputText returns a DCR amount in coins as amount editor text in the
+// display unit.
+func dcrInputText(coins float64) string {
+	amount, err := dcrutil.NewAmount(coins)
+	if err != nil {
+		return ""
+	}
+	return wallet.AmountInputValue(int64(amount))
+}
+
 func (pg *sendPage) sendFund() {
 	if !pg.inputsNotEmpty(pg.passwordEditor.Editor) {
 		return
 	}
 	pg.isBroadcastingTransaction = true
-	pg.wallet.BroadcastTransaction(pg.txAuthor, []byte(pg.passwordEditor.Editor.Text()), pg.broadcastErrChan)
+	pg.wallet.BroadcastTransaction(*pg.txAuthor, []byte(pg.passwordEditor.Editor.Text()), pg.broadcastErrChan)
+}
+
+func (pg *sendPage) focusTargets() []focusTarget {
+	var editors []*widget.Editor
+	if pg.sendToOption != "My account" {
+		editors = append(editors, pg.destinationAddressEditor.Editor)
+	}
+	editors = append(editors, pg.leftAmountEditor.Editor)
+	if pg.usdExchangeSet {
+		editors = append(editors, pg.rightAmountEditor.Editor)
+	}
+	return append(editorTargets(editors...), buttonTargets(pg.nextButton.Button)...)
 }
 
 func (pg *sendPage) handle() {
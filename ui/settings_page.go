@@ -32,7 +32,7 @@ type settingsPage struct {
 	pageContainer layout.List
 	theme         *decredmaterial.Theme
 	walletInfo    *wallet.MultiWalletInfo
-	wal           *wallet.Wallet
+	wal           wallet.Backend
 
 	updateConnectToPeer *widget.Clickable
 	updateUserAgent     *widget.Clickable
@@ -51,13 +51,18 @@ type settingsPage struct {
 
 	peerLabel, agentLabel decredmaterial.Label
 
+	displayScaleDown, displayScaleUp decredmaterial.IconButton
+	textScaleDown, textScaleUp       decredmaterial.IconButton
+
 	isStartupPassword bool
 	peerAddr          string
 	agentValue        string
 	errorReceiver     chan error
 
-	currencyPreference *preference.ListPreference
-	languagePreference *preference.ListPreference
+	currencyPreference  *preference.ListPreference
+	languagePreference  *preference.ListPreference
+	unitPreference      *preference.ListPreference
+	precisionPreference *preference.ListPreference
 }
 
 func SettingsPage(common *pageCommon) Page {
@@ -92,6 +97,11 @@ func SettingsPage(common *pageCommon) Page {
 
 	pg.backButton, _ = common.SubPageHeaderButtons()
 
+	pg.displayScaleDown = pg.scaleButton(common.icons.contentRemove)
+	pg.displayScaleUp = pg.scaleButton(common.icons.contentAdd)
+	pg.textScaleDown = pg.scaleButton(common.icons.contentRemove)
+	pg.textScaleUp = pg.scaleButton(common.icons.contentAdd)
+
 	languagePreference := preference.NewListPreference(common.wallet, common.theme, languagePreferenceKey,
 		values.DefaultLangauge, values.ArrLanguages).
 		Title(values.StrLanguage).
@@ -112,6 +122,18 @@ func SettingsPage(common *pageCommon) Page {
 		NegativeButton(values.StrCancel, func() {})
 	pg.currencyPreference = currencyPreference
 
+	pg.unitPreference = preference.NewListPreference(common.wallet, common.theme,
+		wallet.AmountUnitConfigKey, wallet.UnitDCR, values.ArrAmountUnits).
+		Title(values.StrAmountUnit).
+		PostiveButton(values.StrConfirm, common.refreshAmountDisplay).
+		NegativeButton(values.StrCancel, func() {})
+
+	pg.precisionPreference = preference.NewListPreference(common.wallet, common.theme,
+		wallet.AmountPrecisionConfigKey, values.DefaultAmountPrecision, values.ArrAmountPrecisions).
+		Title(values.StrAmountPrecision).
+		PostiveButton(values.StrConfirm, common.refreshAmountDisplay).
+		NegativeButton(values.StrCancel, func() {})
+
 	color := common.theme.Color.LightGray
 
 	pg.peerLabel = common.theme.Body1("")
@@ -164,6 +186,14 @@ func (pg *settingsPage) Layout(gtx layout.Context) layout.Dimensions {
 		return pg.languagePreference.Layout(gtx, common.UniformPadding(gtx, body))
 	}
 
+	if pg.unitPreference.IsShowing {
+		return pg.unitPreference.Layout(gtx, common.UniformPadding(gtx, body))
+	}
+
+	if pg.precisionPreference.IsShowing {
+		return pg.precisionPreference.Layout(gtx, common.UniformPadding(gtx, body))
+	}
+
 	return common.UniformPadding(gtx, body)
 }
 
@@ -197,11 +227,67 @@ func (pg *settingsPage) general() layout.Widget {
 					}
 					return pg.clickableRow(gtx, languageRow)
 				}),
+				layout.Rigid(pg.lineSeparator()),
+				layout.Rigid(func(gtx C) D {
+					unitRow := row{
+						title:     values.String(values.StrAmountUnit),
+						clickable: pg.unitPreference.Clickable(),
+						icon:      pg.chevronRightIcon,
+						label:     pg.theme.Body2(wallet.AmountUnit()),
+					}
+					return pg.clickableRow(gtx, unitRow)
+				}),
+				layout.Rigid(pg.lineSeparator()),
+				layout.Rigid(func(gtx C) D {
+					precision := pg.wal.ReadStringConfigValueForKey(wallet.AmountPrecisionConfigKey)
+					if _, ok := values.ArrAmountPrecisions[precision]; !ok {
+						precision = values.DefaultAmountPrecision
+					}
+					precisionRow := row{
+						title:     values.String(values.StrAmountPrecision),
+						clickable: pg.precisionPreference.Clickable(),
+						icon:      pg.chevronRightIcon,
+						label:     pg.theme.Body2(values.String(values.ArrAmountPrecisions[precision])),
+					}
+					return pg.clickableRow(gtx, precisionRow)
+				}),
+				layout.Rigid(pg.lineSeparator()),
+				layout.Rigid(func(gtx C) D {
+					return pg.scaleRow(gtx, values.String(values.StrDisplayScale), pg.common.uiScale.display,
+						pg.displayScaleDown, pg.displayScaleUp)
+				}),
+				layout.Rigid(pg.lineSeparator()),
+				layout.Rigid(func(gtx C) D {
+					return pg.scaleRow(gtx, values.String(values.StrTextSize), pg.common.uiScale.text,
+						pg.textScaleDown, pg.textScaleUp)
+				}),
 			)
 		})
 	}
 }
 
+func (pg *settingsPage) scaleButton(icon *widget.Icon) decredmaterial.IconButton {
+	btn := pg.theme.PlainIconButton(new(widget.Clickable), icon)
+	btn.Color = pg.theme.Color.Gray3
+	btn.Size = values.MarginPadding20
+	btn.Inset = layout.UniformInset(values.MarginPadding2)
+	return btn
+}
+
+// scaleRow lays out a scale preference with buttons to step it down and up.
+func (pg *settingsPage) scaleRow(gtx layout.Context, title string, scale int, down, up decredmaterial.IconButton) layout.Dimensions {
+	return pg.subSection(gtx, title, func(gtx C) D {
+		return layout.Flex{Alignment: layout.Middle}.Layout(gtx,
+			layout.Rigid(down.Layout),
+			layout.Rigid(func(gtx C) D {
+				txt := pg.theme.Body2(values.StringF(values.StrScalePercent, scale))
+				return layout.Inset{Left: values.MarginPadding10, Right: values.MarginPadding10}.Layout(gtx, txt.Layout)
+			}),
+			layout.Rigid(up.Layout),
+		)
+	})
+}
+
 func (pg *settingsPage) notification() layout.Widget {
 	return func(gtx C) D {
 		return pg.mainSection(gtx, values.String(values.StrNotifications), func(gtx C) D {
@@ -375,6 +461,8 @@ func (pg *settingsPage) handle() {
 	common := pg.common
 	pg.languagePreference.Handle()
 	pg.currencyPreference.Handle()
+	pg.unitPreference.Handle()
+	pg.precisionPreference.Handle()
 
 	if pg.isDarkModeOn.Changed() {
 		pg.wal.SaveConfigValueForKey("isDarkModeOn", pg.isDarkModeOn.Value)
@@ -389,6 +477,22 @@ func (pg *settingsPage) handle() {
 		pg.wal.SaveConfigValueForKey(dcrlibwallet.BeepNewBlocksConfigKey, pg.beepNewBlocks.Value)
 	}
 
+	for pg.displayScaleDown.Button.Clicked() {
+		common.setDisplayScale(stepScale(common.uiScale.display, false))
+	}
+
+	for pg.displayScaleUp.Button.Clicked() {
+		common.setDisplayScale(stepScale(common.uiScale.display, true))
+	}
+
+	for pg.textScaleDown.Button.Clicked() {
+		common.setTextScale(stepScale(common.uiScale.text, false))
+	}
+
+	for pg.textScaleUp.Button.Clicked() {
+		common.setTextScale(stepScale(common.uiScale.text, true))
+	}
+
 	for pg.changeStartupPass.Clicked() {
 
 		newPasswordModal(common).
@@ -397,7 +501,7 @@ func (pg *settingsPage) handle() {
 			negativeButton(values.String(values.StrCancel), func() {}).
 			positiveButton(values.String(values.StrConfirm), func(password string, pm *passwordModal) bool {
 				go func() {
-					err := pg.wal.GetMultiWallet().VerifyStartupPassphrase([]byte(password))
+					err := pg.wal.VerifyStartupPassphrase([]byte(password))
 					if err != nil {
 						pm.setError(err.Error())
 						pm.setLoading(false)
@@ -413,7 +517,7 @@ func (pg *settingsPage) handle() {
 						confirmPasswordHint("Confirm new startup password").
 						passwordCreated(func(walletName, newPassword string, m *createPasswordModal) bool {
 							go func() {
-								err := pg.wal.GetMultiWallet().ChangeStartupPassphrase([]byte(password), []byte(newPassword), dcrlibwallet.PassphraseTypePass)
+								err := pg.wal.ChangeStartupPassphrase([]byte(password), []byte(newPassword))
 								if err != nil {
 									m.setError(err.Error())
 									m.setLoading(false)
@@ -440,7 +544,7 @@ func (pg *settingsPage) handle() {
 				confirmPasswordHint("Confirm startup password").
 				passwordCreated(func(walletName, password string, m *createPasswordModal) bool {
 					go func() {
-						err := pg.wal.GetMultiWallet().SetStartupPassphrase([]byte(password), dcrlibwallet.PassphraseTypePass)
+						err := pg.wal.SetStartupPassphrase([]byte(password))
 						if err != nil {
 							m.setError(err.Error())
 							m.setLoading(false)
@@ -458,7 +562,7 @@ func (pg *settingsPage) handle() {
 				negativeButton(values.String(values.StrCancel), func() {}).
 				positiveButton(values.String(values.StrConfirm), func(password string, pm *passwordModal) bool {
 					go func() {
-						err := pg.wal.GetMultiWallet().RemoveStartupPassphrase([]byte(password))
+						err := pg.wal.RemoveStartupPassphrase([]byte(password))
 						if err != nil {
 							pm.setError(err.Error())
 							pm.setLoading(false)
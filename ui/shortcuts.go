@@ -0,0 +1,530 @@
+package ui
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+
+	"gioui.org/io/key"
+	"gioui.org/op"
+	"gioui.org/widget"
+
+	"github.com/planetdecred/godcr/ui/decredmaterial"
+	"github.com/planetdecred/godcr/ui/values"
+	"github.com/planetdecred/godcr/wallet"
+)
+
+const keyBindingsConfigKey = "key_bindings"
+
+type shortcutAction string
+
+const (
+	actionOverview      shortcutAction = "overview"
+	actionTransactions  shortcutAction = "transactions"
+	actionWallets       shortcutAction = "wallets"
+	actionProposals     shortcutAction = "proposals"
+	actionTickets       shortcutAction = "tickets"
+	actionMore          shortcutAction = "more"
+	actionSend          shortcutAction = "send"
+	actionReceive       shortcutAction = "receive"
+	actionBack          shortcutAction = "back"
+	actionCloseModal    shortcutAction = "close_modal"
+	actionFocusNext     shortcutAction = "focus_next"
+	actionFocusPrevious shortcutAction = "focus_previous"
+	actionActivate      shortcutAction = "activate"
+	actionZoomIn        shortcutAction = "zoom_in"
+	actionZoomOut       shortcutAction = "zoom_out"
+	actionZoomReset     shortcutAction = "zoom_reset"
+	actionCheatSheet    shortcutAction = "cheat_sheet"
+)
+
+// shortcutActions lists the actions in the order they are shown in the
+// cheat sheet, with the string keys of their titles.
+var shortcutActions = []struct {
+	action shortcutAction
+	title  string
+}{
+	{actionOverview, values.StrOverview},
+	{actionTransactions, values.StrTransactions},
+	{actionWallets, values.StrWallets},
+	{actionProposals, values.StrProposals},
+	{actionTickets, values.StrTickets},
+	{actionMore, values.StrMore},
+	{actionSend, values.StrSend},
+	{actionReceive, values.StrReceive},
+	{actionBack, values.StrGoBack},
+	{actionCloseModal, values.StrCloseDialog},
+	{actionFocusNext, values.StrNextField},
+	{actionFocusPrevious, values.StrPreviousField},
+	{actionActivate, values.StrActivateFocused},
+	{actionZoomIn, values.StrZoomIn},
+	{actionZoomOut, values.StrZoomOut},
+	{actionZoomReset, values.StrResetZoom},
+	{actionCheatSheet, values.StrKeyboardShortcuts},
+}
+
+// drawerShortcuts are the actions that open the drawer nav items, in the
+// order of mainPage.drawerNavItems.
+var drawerShortcuts = []shortcutAction{actionOverview, actionTransactions, actionWallets,
+	actionProposals, actionTickets, actionMore}
+
+// appBarShortcuts are the actions that open the app bar nav items, in the
+// order of mainPage.appBarNavItems.
+var appBarShortcuts = []shortcutAction{actionSend, actionReceive}
+
+// keyNames maps the names of special keys to the names shown to users and
+// saved in the config.
+var keyNames = map[string]string{
+	key.NameLeftArrow:      "Left",
+	key.NameRightArrow:     "Right",
+	key.NameUpArrow:        "Up",
+	key.NameDownArrow:      "Down",
+	key.NameReturn:         "Return",
+	key.NameEnter:          "Enter",
+	key.NameEscape:         "Esc",
+	key.NameHome:           "Home",
+	key.NameEnd:            "End",
+	key.NameDeleteBackward: "Backspace",
+	key.NameDeleteForward:  "Delete",
+	key.NamePageUp:         "PageUp",
+	key.NamePageDown:       "PageDown",
+	key.NameTab:            "Tab",
+	key.NameSpace:          "Space",
+}
+
+var modifierNames = []struct {
+	modifier key.Modifiers
+	name     string
+}{
+	{key.ModCtrl, "Ctrl"},
+	{key.ModCommand, "Cmd"},
+	{key.ModAlt, "Alt"},
+	{key.ModShift, "Shift"},
+	{key.ModSuper, "Super"},
+}
+
+var errInvalidKeyBinding = errors.New("invalid key binding")
+
+// keyBinding is a key pressed with an exact set of modifiers.
+type keyBinding struct {
+	name      string
+	modifiers key.Modifiers
+}
+
+func (b keyBinding) String() string {
+	var parts []string
+	for _, m := range modifierNames {
+		if b.modifiers.Contain(m.modifier) {
+			parts = append(parts, m.name)
+		}
+	}
+
+	name := b.name
+	if display, ok := keyNames[name]; ok {
+		name = display
+	}
+	return strings.Join(append(parts, name), "+")
+}
+
+// parseKeyBinding parses a key binding written as by keyBinding.String,
+// such as "Ctrl+Shift+Tab" or "Ctrl++".
+func parseKeyBinding(s string) (keyBinding, error) {
+	if s == "" {
+		return keyBinding{}, errInvalidKeyBinding
+	}
+
+	// the last separator is searched before the last character so that the
+	// plus key can be bound.
+	var b keyBinding
+	i := strings.LastIndex(s[:len(s)-1], "+")
+	b.name = s[i+1:]
+	if b.name != "+" && strings.Contains(b.name, "+") {
+		return keyBinding{}, errInvalidKeyBinding
+	}
+	for name, display := range keyNames {
+		if display == b.name {
+			b.name = name
+		}
+	}
+
+	if i < 0 {
+		return b, nil
+	}
+
+	for _, part := range strings.Split(s[:i], "+") {
+		found := false
+		for _, m := range modifierNames {
+			if m.name == part {
+				b.modifiers |= m.modifier
+				found = true
+			}
+		}
+		if !found {
+			return keyBinding{}, errInvalidKeyBinding
+		}
+	}
+
+	return b, nil
+}
+
+func (b keyBinding) matches(evt key.Event) bool {
+	return evt.Name == b.name && evt.Modifiers == b.modifiers
+}
+
+func defaultKeyBindings() map[shortcutAction][]keyBinding {
+	return map[shortcutAction][]keyBinding{
+		actionOverview:      {{"1", key.ModShortcut}},
+		actionTransactions:  {{"2", key.ModShortcut}},
+		actionWallets:       {{"3", key.ModShortcut}},
+		actionProposals:     {{"4", key.ModShortcut}},
+		actionTickets:       {{"5", key.ModShortcut}},
+		actionMore:          {{"6", key.ModShortcut}},
+		actionSend:          {{"S", key.ModShortcut}},
+		actionReceive:       {{"R", key.ModShortcut}},
+		actionBack:          {{key.NameLeftArrow, key.ModAlt}},
+		actionCloseModal:    {{key.NameEscape, 0}},
+		actionFocusNext:     {{key.NameTab, 0}},
+		actionFocusPrevious: {{key.NameTab, key.ModShift}},
+		actionActivate:      {{key.NameReturn, 0}, {key.NameEnter, 0}, {key.NameSpace, 0}},
+		actionZoomIn:        {{"=", key.ModShortcut}, {"+", key.ModShortcut}, {"+", key.ModShortcut | key.ModShift}},
+		actionZoomOut:       {{"-", key.ModShortcut}},
+		actionZoomReset:     {{"0", key.ModShortcut}},
+		actionCheatSheet:    {{"/", key.ModShortcut}},
+	}
+}
+
+// shortcuts holds the key bindings of the shortcut actions.
+type shortcuts struct {
+	bindings map[shortcutAction][]keyBinding
+
+	// capture, if set, receives the next key press instead of the shortcuts
+	// and the pages. It is used to record new key bindings.
+	capture func(key.Event)
+}
+
+func newShortcuts() *shortcuts {
+	return &shortcuts{bindings: defaultKeyBindings()}
+}
+
+// load replaces the default key bindings with the ones saved in the config.
+// Actions without a valid saved binding keep their default ones.
+func (s *shortcuts) load(wal wallet.Backend) {
+	s.bindings = defaultKeyBindings()
+
+	var saved map[shortcutAction][]string
+	if err := json.Unmarshal([]byte(wal.ReadStringConfigValueForKey(keyBindingsConfigKey)), &saved); err != nil {
+		return
+	}
+
+	for action, keys := range saved {
+		if _, ok := s.bindings[action]; !ok {
+			continue
+		}
+
+		var bindings []keyBinding
+		for _, k := range keys {
+			b, err := parseKeyBinding(k)
+			if err != nil {
+				log.Warnf("Ignoring key binding %q for %s: %v", k, action, err)
+				continue
+			}
+			bindings = append(bindings, b)
+		}
+		if len(bindings) > 0 {
+			s.bindings[action] = bindings
+		}
+	}
+}
+
+func (s *shortcuts) save(wal wallet.Backend) {
+	saved := make(map[shortcutAction][]string)
+	for action, bindings := range s.bindings {
+		for _, b := range bindings {
+			saved[action] = append(saved[action], b.String())
+		}
+	}
+
+	data, err := json.Marshal(saved)
+	if err != nil {
+		log.Error(err)
+		return
+	}
+	wal.SaveConfigValueForKey(keyBindingsConfigKey, string(data))
+}
+
+// match returns the action bound to a key press.
+func (s *shortcuts) match(evt key.Event) (shortcutAction, bool) {
+	if evt.State != key.Press {
+		return "", false
+	}
+
+	for action, bindings := range s.bindings {
+		for _, b := range bindings {
+			if b.matches(evt) {
+				return action, true
+			}
+		}
+	}
+	return "", false
+}
+
+// rebind binds action to b only, removing b from any other action.
+func (s *shortcuts) rebind(action shortcutAction, b keyBinding) {
+	for other, bindings := range s.bindings {
+		kept := bindings[:0]
+		for _, existing := range bindings {
+			if existing != b {
+				kept = append(kept, existing)
+			}
+		}
+		s.bindings[other] = kept
+	}
+	s.bindings[action] = []keyBinding{b}
+}
+
+// describe returns the key bindings of action for display.
+func (s *shortcuts) describe(action shortcutAction) string {
+	var keys []string
+	for _, b := range s.bindings[action] {
+		keys = append(keys, b.String())
+	}
+	return strings.Join(keys, " / ")
+}
+
+// focusTarget is a text field, button or list item the keyboard focus can
+// be moved to. Either editor or button is set.
+type focusTarget struct {
+	editor *widget.Editor
+	button *widget.Clickable
+}
+
+func editorTargets(editors ...*widget.Editor) []focusTarget {
+	targets := make([]focusTarget, len(editors))
+	for i, editor := range editors {
+		targets[i].editor = editor
+	}
+	return targets
+}
+
+func buttonTargets(buttons ...*widget.Clickable) []focusTarget {
+	targets := make([]focusTarget, len(buttons))
+	for i, button := range buttons {
+		targets[i].button = button
+	}
+	return targets
+}
+
+// focusable is implemented by pages and modals with text fields, buttons or
+// list items that can be cycled through with the keyboard. focusTargets
+// returns the ones that are currently shown, in focus order. Buttons must be
+// laid out by the theme, which draws the focus ring around the focused one.
+type focusable interface {
+	focusTargets() []focusTarget
+}
+
+// cancelable is implemented by modals with a cancel button. Esc clicks it so
+// that the modal runs its own cancel handling. cancelButton returns nil if the
+// modal is shown without one.
+type cancelable interface {
+	cancelButton() *widget.Clickable
+}
+
+// focusedIndex returns the index of the target with the keyboard focus, or
+// -1 if none has it.
+func focusedIndex(theme *decredmaterial.Theme, targets []focusTarget) int {
+	for i, target := range targets {
+		if target.button != nil && target.button == theme.Focused() {
+			return i
+		}
+	}
+	for i, target := range targets {
+		if target.editor != nil && target.editor.Focused() {
+			return i
+		}
+	}
+	return -1
+}
+
+// cycleFocus moves the focus to the next target, or to the previous one if
+// backward is true. It returns false if there are no targets to focus.
+func cycleFocus(gtx C, theme *decredmaterial.Theme, targets []focusTarget, backward bool) bool {
+	if len(targets) == 0 {
+		return false
+	}
+
+	next := 0
+	if backward {
+		next = len(targets) - 1
+	}
+	if i := focusedIndex(theme, targets); i >= 0 {
+		if backward {
+			next = (i - 1 + len(targets)) % len(targets)
+		} else {
+			next = (i + 1) % len(targets)
+		}
+	}
+
+	target := targets[next]
+	if target.editor != nil {
+		theme.SetFocused(nil)
+		target.editor.Focus()
+		return true
+	}
+
+	// take the focus from the editor that has it, if any
+	key.FocusOp{}.Add(gtx.Ops)
+	theme.SetFocused(target.button)
+	return true
+}
+
+// topModal returns the modal shown on top, or nil if no modal is shown.
+func (win *Window) topModal() Modal {
+	win.modalMutex.Lock()
+	defer win.modalMutex.Unlock()
+	if len(win.modals) == 0 {
+		return nil
+	}
+	return win.modals[len(win.modals)-1]
+}
+
+// shownShortcutsModal returns the cheat sheet if it is shown, or nil.
+func (win *Window) shownShortcutsModal() Modal {
+	win.modalMutex.Lock()
+	defer win.modalMutex.Unlock()
+	for _, m := range win.modals {
+		if _, ok := m.(*shortcutsModal); ok {
+			return m
+		}
+	}
+	return nil
+}
+
+// focusTargets returns the focus targets of the top modal, or of the current
+// page if no modal is shown.
+func (win *Window) focusTargets() []focusTarget {
+	var f focusable
+	if top := win.topModal(); top != nil {
+		f, _ = top.(focusable)
+	} else if mp, ok := win.currentPage.(*mainPage); ok {
+		f, _ = mp.pages[mp.current].(focusable)
+	} else {
+		f, _ = win.currentPage.(focusable)
+	}
+
+	if f == nil {
+		return nil
+	}
+	return f.focusTargets()
+}
+
+// focusedButton returns the focused button if it is shown.
+func (win *Window) focusedButton() *widget.Clickable {
+	for _, target := range win.focusTargets() {
+		if target.button != nil && target.button == win.theme.Focused() {
+			return target.button
+		}
+	}
+	return nil
+}
+
+// updateFocus takes the focus from the focused button once it is no longer
+// shown, or once an editor is given the focus with the mouse.
+func (win *Window) updateFocus() {
+	var editor *widget.Editor
+	for _, target := range win.focusTargets() {
+		if target.editor != nil && target.editor.Focused() {
+			editor = target.editor
+		}
+	}
+
+	// an editor Tab moved the focus from keeps it until the next frame, so
+	// only an editor that was not focused before takes the focus
+	if (editor != nil && editor != win.focusedEditor) || win.focusedButton() == nil {
+		win.theme.SetFocused(nil)
+	}
+	win.focusedEditor = editor
+}
+
+// acceptShortcut reports whether action applies to the current window state.
+// Actions that do not apply are passed on to the pages as key events.
+func (win *Window) acceptShortcut(action shortcutAction) bool {
+	switch action {
+	case actionCloseModal:
+		return win.topModal() != nil
+	case actionFocusNext, actionFocusPrevious:
+		return len(win.focusTargets()) > 0
+	case actionActivate:
+		return win.focusedButton() != nil
+	case actionBack:
+		_, isMain := win.currentPage.(*mainPage)
+		return isMain || len(win.pageBackStack) > 0
+	case actionZoomIn, actionZoomOut, actionZoomReset, actionCheatSheet:
+		return true
+	default:
+		_, isMain := win.currentPage.(*mainPage)
+		return isMain
+	}
+}
+
+// handleShortcuts runs the shortcut actions received since the last frame.
+func (win *Window) handleShortcuts(gtx C) {
+	actions := win.pendingShortcuts
+	win.pendingShortcuts = nil
+	if len(actions) > 0 {
+		// redraw with the state the actions leave behind
+		op.InvalidateOp{}.Add(gtx.Ops)
+	}
+
+	win.updateFocus()
+
+	for _, action := range actions {
+		switch action {
+		case actionZoomIn:
+			win.zoom(stepScale(win.scale.display, true))
+		case actionZoomOut:
+			win.zoom(stepScale(win.scale.display, false))
+		case actionZoomReset:
+			win.zoom(defaultScale)
+		case actionCheatSheet:
+			if open := win.shownShortcutsModal(); open != nil {
+				open.Dismiss()
+			} else {
+				newShortcutsModal(win.common).Show()
+			}
+		case actionCloseModal:
+			top := win.topModal()
+			if c, ok := top.(cancelable); ok && c.cancelButton() != nil {
+				c.cancelButton().Click()
+			} else if top != nil {
+				top.Dismiss()
+			}
+		case actionFocusNext, actionFocusPrevious:
+			cycleFocus(gtx, win.theme, win.focusTargets(), action == actionFocusPrevious)
+		case actionActivate:
+			if button := win.focusedButton(); button != nil {
+				button.Click()
+			}
+		case actionBack:
+			if !win.popPage() {
+				if mp, ok := win.currentPage.(*mainPage); ok {
+					mp.back()
+				}
+			}
+		default:
+			mp, ok := win.currentPage.(*mainPage)
+			if !ok {
+				continue
+			}
+			for i, a := range drawerShortcuts {
+				if a == action {
+					mp.openDrawerNavItem(i)
+				}
+			}
+			for i, a := range appBarShortcuts {
+				if a == action {
+					mp.openAppBarNavItem(i)
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,160 @@
+package ui
+
+import (
+	"fmt"
+
+	"gioui.org/io/key"
+	"gioui.org/layout"
+	"gioui.org/text"
+	"gioui.org/widget"
+	"github.com/planetdecred/godcr/ui/decredmaterial"
+	"github.com/planetdecred/godcr/ui/values"
+)
+
+const ModalShortcuts = "shortcuts_modal"
+
+// shortcutsModal lists the keyboard shortcuts and lets users bind each action
+// to new keys.
+type shortcutsModal struct {
+	*pageCommon
+	randomID string
+	modal    decredmaterial.Modal
+
+	changeButtons map[shortcutAction]decredmaterial.Button
+	btnReset      decredmaterial.Button
+	btnClose      decredmaterial.Button
+
+	// capturing is the action whose new keys are being recorded.
+	capturing shortcutAction
+}
+
+func newShortcutsModal(common *pageCommon) *shortcutsModal {
+	sm := &shortcutsModal{
+		pageCommon:    common,
+		randomID:      fmt.Sprintf("%s-%d", ModalShortcuts, generateRandomNumber()),
+		modal:         *common.theme.ModalFloatTitle(),
+		changeButtons: make(map[shortcutAction]decredmaterial.Button),
+		btnReset:      common.theme.Button(new(widget.Clickable), values.String(values.StrResetDefaults)),
+		btnClose:      common.theme.Button(new(widget.Clickable), values.String(values.StrClose)),
+	}
+
+	for _, s := range shortcutActions {
+		btn := common.theme.Button(new(widget.Clickable), values.String(values.StrChange))
+		btn.Background, btn.Color = common.theme.Color.Surface, common.theme.Color.Primary
+		btn.TextSize = values.TextSize14
+		sm.changeButtons[s.action] = btn
+	}
+
+	sm.btnReset.TextSize, sm.btnClose.TextSize = values.TextSize16, values.TextSize16
+	sm.btnReset.Font.Weight, sm.btnClose.Font.Weight = text.Bold, text.Bold
+	sm.btnReset.Background, sm.btnReset.Color = common.theme.Color.Surface, common.theme.Color.Primary
+	sm.btnClose.Background, sm.btnClose.Color = common.theme.Color.Surface, common.theme.Color.Primary
+
+	return sm
+}
+
+func (sm *shortcutsModal) modalID() string {
+	return sm.randomID
+}
+
+func (sm *shortcutsModal) Show() {
+	sm.showModal(sm)
+}
+
+func (sm *shortcutsModal) Dismiss() {
+	sm.dismissModal(sm)
+}
+
+func (sm *shortcutsModal) OnResume() {
+}
+
+func (sm *shortcutsModal) OnDismiss() {
+	sm.stopCapture()
+}
+
+// startCapture records the next key press as the binding of action. Esc
+// cancels without changing the binding.
+func (sm *shortcutsModal) startCapture(action shortcutAction) {
+	sm.capturing = action
+	sm.shortcuts.capture = func(evt key.Event) {
+		if evt.Name != key.NameEscape || evt.Modifiers != 0 {
+			sm.shortcuts.rebind(action, keyBinding{name: evt.Name, modifiers: evt.Modifiers})
+			sm.saveShortcuts()
+		}
+		sm.stopCapture()
+	}
+}
+
+func (sm *shortcutsModal) stopCapture() {
+	sm.capturing = ""
+	sm.shortcuts.capture = nil
+}
+
+// saveShortcuts saves the bindings if the wallet config is loaded.
+func (sm *shortcutsModal) saveShortcuts() {
+	if sm.wallet.IsConfigLoaded() {
+		sm.shortcuts.save(sm.wallet)
+	}
+}
+
+func (sm *shortcutsModal) handle() {
+	for action, btn := range sm.changeButtons {
+		for btn.Button.Clicked() {
+			sm.startCapture(action)
+		}
+	}
+
+	for sm.btnReset.Button.Clicked() {
+		sm.stopCapture()
+		sm.shortcuts.bindings = defaultKeyBindings()
+		sm.saveShortcuts()
+	}
+
+	for sm.btnClose.Button.Clicked() {
+		sm.Dismiss()
+	}
+}
+
+func (sm *shortcutsModal) Layout(gtx layout.Context) D {
+	w := []layout.Widget{
+		func(gtx C) D {
+			t := sm.theme.H6(values.String(values.StrKeyboardShortcuts))
+			t.Font.Weight = text.Bold
+			return t.Layout(gtx)
+		},
+	}
+
+	for _, s := range shortcutActions {
+		w = append(w, sm.shortcutRow(s.action, s.title))
+	}
+
+	w = append(w, func(gtx C) D {
+		return layout.E.Layout(gtx, func(gtx C) D {
+			return layout.Flex{Axis: layout.Horizontal}.Layout(gtx,
+				layout.Rigid(sm.btnReset.Layout),
+				layout.Rigid(sm.btnClose.Layout),
+			)
+		})
+	})
+
+	return sm.modal.Layout(gtx, w, 850)
+}
+
+func (sm *shortcutsModal) shortcutRow(action shortcutAction, title string) layout.Widget {
+	return func(gtx C) D {
+		return layout.Flex{Axis: layout.Horizontal, Alignment: layout.Middle}.Layout(gtx,
+			layout.Flexed(1, sm.theme.Body1(values.String(title)).Layout),
+			layout.Rigid(func(gtx C) D {
+				keys := sm.theme.Body1(sm.shortcuts.describe(action))
+				if sm.capturing == action {
+					keys = sm.theme.Body1(values.String(values.StrPressKeys))
+				}
+				keys.Color = sm.theme.Color.Gray
+				return keys.Layout(gtx)
+			}),
+			layout.Rigid(func(gtx C) D {
+				return layout.Inset{Left: values.MarginPadding10}.Layout(gtx, sm.changeButtons[action].Layout)
+			}),
+		)
+	}
+}
@@ -0,0 +1,129 @@
+package ui
+
+import (
+	"gioui.org/io/key"
+	"gioui.org/layout"
+	"gioui.org/op"
+	"gioui.org/widget"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/planetdecred/godcr/ui/decredmaterial"
+)
+
+var _ = Describe("Keyboard shortcuts", func() {
+	It("parses key bindings", func() {
+		for s, want := range map[string]keyBinding{
+			"S":              {"S", 0},
+			"Ctrl+S":         {"S", key.ModCtrl},
+			"Ctrl+Shift+Tab": {key.NameTab, key.ModCtrl | key.ModShift},
+			"Shift+Ctrl+Tab": {key.NameTab, key.ModCtrl | key.ModShift},
+			"Alt+Left":       {key.NameLeftArrow, key.ModAlt},
+			"Esc":            {key.NameEscape, 0},
+			"+":              {"+", 0},
+			"Ctrl++":         {"+", key.ModCtrl},
+			"Cmd+Shift++":    {"+", key.ModCommand | key.ModShift},
+			"Ctrl+-":         {"-", key.ModCtrl},
+		} {
+			b, err := parseKeyBinding(s)
+			Expect(err).To(BeNil(), s)
+			Expect(b).To(Equal(want), s)
+		}
+	})
+
+	It("rejects invalid key bindings", func() {
+		for _, s := range []string{"", "Hyper+S", "ctrl+S", "Ctrl+", "Ctrl++S", "++", "+S"} {
+			_, err := parseKeyBinding(s)
+			Expect(err).To(Equal(errInvalidKeyBinding), s)
+		}
+	})
+
+	It("parses the key bindings it writes", func() {
+		for action, bindings := range defaultKeyBindings() {
+			for _, b := range bindings {
+				parsed, err := parseKeyBinding(b.String())
+				Expect(err).To(BeNil(), string(action))
+				Expect(parsed).To(Equal(b), string(action))
+			}
+		}
+		Expect(keyBinding{key.NameTab, key.ModShift}.String()).To(Equal("Shift+Tab"))
+		Expect(keyBinding{"+", key.ModCtrl | key.ModShift}.String()).To(Equal("Ctrl+Shift++"))
+	})
+
+	It("matches key presses with the exact modifiers", func() {
+		s := newShortcuts()
+		action, ok := s.match(key.Event{Name: key.NameTab, Modifiers: key.ModShift, State: key.Press})
+		Expect(ok).To(Equal(true))
+		Expect(action).To(Equal(actionFocusPrevious))
+
+		_, ok = s.match(key.Event{Name: key.NameTab, Modifiers: key.ModShift, State: key.Release})
+		Expect(ok).To(Equal(false))
+		_, ok = s.match(key.Event{Name: key.NameTab, Modifiers: key.ModShift | key.ModAlt, State: key.Press})
+		Expect(ok).To(Equal(false))
+
+		s.rebind(actionCheatSheet, keyBinding{key.NameTab, 0})
+		action, _ = s.match(key.Event{Name: key.NameTab, State: key.Press})
+		Expect(action).To(Equal(actionCheatSheet))
+		Expect(s.describe(actionFocusNext)).To(Equal(""))
+	})
+})
+
+var _ = Describe("Keyboard focus", func() {
+	var (
+		theme   *decredmaterial.Theme
+		gtx     layout.Context
+		editor  *widget.Editor
+		buttons []*widget.Clickable
+		targets []focusTarget
+	)
+
+	BeforeEach(func() {
+		theme = &decredmaterial.Theme{}
+		gtx = layout.Context{Ops: new(op.Ops)}
+		editor = new(widget.Editor)
+		buttons = []*widget.Clickable{new(widget.Clickable), new(widget.Clickable), new(widget.Clickable)}
+		targets = buttonTargets(buttons...)
+	})
+
+	It("cycles through the buttons forwards and backwards", func() {
+		Expect(focusedIndex(theme, targets)).To(Equal(-1))
+
+		Expect(cycleFocus(gtx, theme, targets, false)).To(Equal(true))
+		Expect(theme.Focused()).To(BeIdenticalTo(buttons[0]))
+		cycleFocus(gtx, theme, targets, false)
+		cycleFocus(gtx, theme, targets, false)
+		Expect(focusedIndex(theme, targets)).To(Equal(2))
+		cycleFocus(gtx, theme, targets, false)
+		Expect(theme.Focused()).To(BeIdenticalTo(buttons[0]))
+
+		cycleFocus(gtx, theme, targets, true)
+		Expect(theme.Focused()).To(BeIdenticalTo(buttons[2]))
+		cycleFocus(gtx, theme, targets, true)
+		Expect(focusedIndex(theme, targets)).To(Equal(1))
+	})
+
+	It("starts from the last target backwards", func() {
+		cycleFocus(gtx, theme, targets, true)
+		Expect(theme.Focused()).To(BeIdenticalTo(buttons[2]))
+	})
+
+	It("takes the focus ring from the buttons when an editor is focused", func() {
+		targets = append(buttonTargets(buttons[0]), editorTargets(editor)...)
+		cycleFocus(gtx, theme, targets, false)
+		Expect(theme.Focused()).To(BeIdenticalTo(buttons[0]))
+
+		cycleFocus(gtx, theme, targets, false)
+		Expect(theme.Focused()).To(BeNil())
+	})
+
+	It("does nothing without targets", func() {
+		Expect(cycleFocus(gtx, theme, nil, false)).To(Equal(false))
+		Expect(theme.Focused()).To(BeNil())
+	})
+
+	It("does not find a focused button that is not shown", func() {
+		theme.SetFocused(new(widget.Clickable))
+		Expect(focusedIndex(theme, targets)).To(Equal(-1))
+	})
+})
@@ -10,6 +10,7 @@ import (
 	"github.com/planetdecred/dcrlibwallet"
 	"github.com/planetdecred/godcr/ui/decredmaterial"
 	"github.com/planetdecred/godcr/ui/values"
+	"github.com/planetdecred/godcr/wallet"
 )
 
 const PageSignMessage = "SignMessage"
@@ -18,7 +19,7 @@ type signMessagePage struct {
 	common    *pageCommon
 	theme     *decredmaterial.Theme
 	container layout.List
-	wallet    *dcrlibwallet.Wallet
+	wallet    *wallet.WalletSummary
 
 	isSigningMessage                           bool
 	titleLabel, errorLabel, signedMessageLabel decredmaterial.Label
@@ -32,7 +33,7 @@ type signMessagePage struct {
 	infoButton decredmaterial.IconButton
 }
 
-func SignMessagePage(common *pageCommon, wallet *dcrlibwallet.Wallet) Page {
+func SignMessagePage(common *pageCommon, wallet *wallet.WalletSummary) Page {
 	addressEditor := common.theme.Editor(new(widget.Editor), "Address")
 	addressEditor.Editor.SingleLine, addressEditor.Editor.Submit = true, true
 	messageEditor := common.theme.Editor(new(widget.Editor), "Message")
@@ -202,6 +203,11 @@ func (pg *signMessagePage) updateColors(common *pageCommon) {
 	}
 }
 
+func (pg *signMessagePage) focusTargets() []focusTarget {
+	return append(editorTargets(pg.addressEditor.Editor, pg.messageEditor.Editor),
+		buttonTargets(pg.clearButton.Button, pg.signButton.Button)...)
+}
+
 func (pg *signMessagePage) handle() {
 	gtx := pg.gtx
 	common := pg.common
@@ -223,7 +229,7 @@ func (pg *signMessagePage) handle() {
 				positiveButton("Confirm", func(password string, pm *passwordModal) bool {
 
 					go func() {
-						sig, err := pg.wallet.SignMessage([]byte(password), address, message)
+						sig, err := common.wallet.SignMessage(pg.wallet.ID, []byte(password), address, message)
 						if err != nil {
 							pm.setError(err.Error())
 							pm.setLoading(false)
@@ -263,13 +269,13 @@ func (pg *signMessagePage) validateAddress(ignoreEmpty bool) bool {
 	}
 
 	if address != "" {
-		isValid := pg.common.multiWallet.IsAddressValid(address)
+		isValid, _ := pg.common.wallet.IsAddressValid(address)
 		if !isValid {
 			pg.addressEditor.SetError("Invalid address")
 			return false
 		}
 
-		exist := pg.wallet.HaveAddress(address)
+		exist := pg.common.wallet.HaveWalletAddress(pg.wallet.ID, address)
 
 		if !exist {
 			pg.addressEditor.SetError("Address not owned by this wallet")
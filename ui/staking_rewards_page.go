@@ -0,0 +1,233 @@
+package ui
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"gioui.org/layout"
+	"gioui.org/widget"
+
+	"github.com/planetdecred/godcr/ui/decredmaterial"
+	"github.com/planetdecred/godcr/ui/values"
+	"github.com/planetdecred/godcr/wallet"
+)
+
+const PageStakingRewards = "StakingRewards"
+
+type stakingRewardsPage struct {
+	common  *pageCommon
+	tickets **wallet.Tickets
+	wallets []*wallet.WalletSummary
+
+	container      layout.List
+	walletDropDown *decredmaterial.DropDown
+	chart          *decredmaterial.BarChart
+	exportButton   decredmaterial.Button
+	backButton     decredmaterial.IconButton
+
+	// loaded are the tickets the stats were computed from.
+	loaded   *wallet.Tickets
+	selected []wallet.Ticket
+	stats    stakingStats
+}
+
+func StakingRewardsPage(common *pageCommon) Page {
+	pg := &stakingRewardsPage{
+		common:    common,
+		tickets:   common.walletTickets,
+		wallets:   common.wallet.AllWallets(),
+		container: layout.List{Axis: layout.Vertical},
+		chart:     common.theme.BarChart(),
+	}
+	pg.backButton, _ = common.SubPageHeaderButtons()
+	pg.exportButton = common.theme.Button(new(widget.Clickable), values.String(values.StrExport))
+	pg.exportButton.TextSize = values.TextSize14
+
+	items := []decredmaterial.DropDownItem{{Text: values.String(values.StrAllWallets), Icon: common.icons.walletIcon}}
+	for _, wal := range pg.wallets {
+		items = append(items, decredmaterial.DropDownItem{Text: wal.Name, Icon: common.icons.walletIcon})
+	}
+	pg.walletDropDown = common.theme.DropDown(items, 5)
+
+	pg.chart.YLabel = func(y float64) string {
+		return wallet.FormatAmount(int64(y))
+	}
+	pg.chart.Tooltip = func(bar int) string {
+		reward := pg.stats.monthlyRewards[bar]
+		return values.FormatMonth(reward.month) + "\n" + wallet.FormatAmount(reward.rewards)
+	}
+
+	return pg
+}
+
+func (pg *stakingRewardsPage) OnResume() {
+	pg.loaded = nil
+}
+
+// update recomputes the stats of the selected wallet's tickets, or of all
+// tickets, when the tickets or the selection change.
+func (pg *stakingRewardsPage) update() {
+	pg.loaded = *pg.tickets
+	pg.selected = pg.selected[:0]
+	if index := pg.walletDropDown.SelectedIndex(); index > 0 {
+		pg.selected = append(pg.selected, pg.loaded.Confirmed[pg.wallets[index-1].ID]...)
+	} else {
+		for _, wal := range pg.wallets {
+			pg.selected = append(pg.selected, pg.loaded.Confirmed[wal.ID]...)
+		}
+	}
+
+	pg.stats = computeStakingStats(pg.selected)
+	pg.chart.Bars = pg.chart.Bars[:0]
+	for _, reward := range pg.stats.monthlyRewards {
+		pg.chart.Bars = append(pg.chart.Bars, decredmaterial.ChartBar{
+			Label: values.FormatMonth(reward.month),
+			Value: float64(reward.rewards),
+		})
+	}
+}
+
+// exportTickets writes the selected tickets to a CSV file next to the log
+// file.
+func (pg *stakingRewardsPage) exportTickets(tickets []wallet.Ticket) {
+	dir := filepath.Dir(logFile)
+	if logFile == "" {
+		dir = pg.common.wallet.WalletDirectory()
+	}
+
+	name := filepath.Join(dir, fmt.Sprintf("godcr-staking-%s.csv", time.Now().Format("20060102-150405")))
+	file, err := os.OpenFile(name, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0600)
+	if err != nil {
+		pg.common.notify(values.StringF(values.StrTicketsExportFailed, err), false)
+		return
+	}
+	err = writeStakingCSV(file, tickets)
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		os.Remove(name)
+		pg.common.notify(values.StringF(values.StrTicketsExportFailed, err), false)
+		return
+	}
+	pg.common.notify(values.StringF(values.StrTicketsExported, len(tickets), name), true)
+}
+
+func (pg *stakingRewardsPage) handle() {
+	if pg.loaded != *pg.tickets || pg.walletDropDown.Changed() {
+		pg.update()
+	}
+
+	for pg.exportButton.Button.Clicked() {
+		tickets := make([]wallet.Ticket, len(pg.selected))
+		copy(tickets, pg.selected)
+		go pg.exportTickets(tickets)
+	}
+}
+
+func (pg *stakingRewardsPage) onClose() {}
+
+// statRow lays out a stat with its label on the left and value on the right.
+func (pg *stakingRewardsPage) statRow(gtx C, label, value string) D {
+	return layout.Inset{Bottom: values.MarginPadding10}.Layout(gtx, func(gtx C) D {
+		left := pg.common.theme.Body2(label)
+		left.Color = pg.common.theme.Color.Gray3
+		return endToEndRow(gtx, left.Layout, pg.common.theme.Body1(value).Layout)
+	})
+}
+
+// ticketsShare formats a ticket count with its share of the closed tickets.
+func ticketsShare(count int, rate float64) string {
+	return values.StringF(values.StrTicketsShare, count, values.FormatDecimal(rate*100, 1))
+}
+
+func (pg *stakingRewardsPage) summaryCard(gtx C) D {
+	stats := pg.stats
+	rows := []struct{ label, value string }{
+		{values.String(values.StrRewardsEarned), wallet.FormatAmount(stats.rewards)},
+		{values.String(values.StrTxFeesPaid), wallet.FormatAmount(stats.txFees)},
+		{values.String(values.StrVSPFeesPaid), wallet.FormatAmount(stats.vspFees)},
+		{values.String(values.StrNetRewards), wallet.FormatAmount(stats.net())},
+		{values.String(values.StrAnnualReturn), values.StringF(values.StrPercentValue, values.FormatDecimal(stats.roi*100, 2))},
+		{values.String(values.StrAverageTimeToVote), values.StringF(values.StrDaysValue, values.FormatDecimal(stats.avgDaysToVote, 1))},
+		{values.String(values.StrVotedTickets), fmt.Sprintf("%d", stats.voted)},
+		{values.String(values.StrMissedTickets), ticketsShare(stats.missed, stats.missedRate())},
+		{values.String(values.StrExpiredTickets), ticketsShare(stats.expired, stats.expiredRate())},
+		{values.String(values.StrRevokedTickets), fmt.Sprintf("%d", stats.revoked)},
+	}
+
+	return pg.common.theme.Card().Layout(gtx, func(gtx C) D {
+		return Container{layout.UniformInset(values.MarginPadding15)}.Layout(gtx, func(gtx C) D {
+			children := make([]layout.FlexChild, 0, len(rows)+1)
+			for _, row := range rows {
+				row := row
+				children = append(children, layout.Rigid(func(gtx C) D {
+					return pg.statRow(gtx, row.label, row.value)
+				}))
+			}
+			children = append(children, layout.Rigid(func(gtx C) D {
+				note := pg.common.theme.Caption(values.String(values.StrVSPFeeNote))
+				note.Color = pg.common.theme.Color.Gray2
+				return note.Layout(gtx)
+			}))
+			return layout.Flex{Axis: layout.Vertical}.Layout(gtx, children...)
+		})
+	})
+}
+
+func (pg *stakingRewardsPage) chartCard(gtx C) D {
+	return pg.common.theme.Card().Layout(gtx, func(gtx C) D {
+		return Container{layout.UniformInset(values.MarginPadding15)}.Layout(gtx, func(gtx C) D {
+			return layout.Flex{Axis: layout.Vertical}.Layout(gtx,
+				layout.Rigid(func(gtx C) D {
+					title := pg.common.theme.Body2(values.String(values.StrRewardsPerMonth))
+					title.Color = pg.common.theme.Color.Gray3
+					return title.Layout(gtx)
+				}),
+				layout.Rigid(func(gtx C) D {
+					if len(pg.chart.Bars) == 0 {
+						message := pg.common.theme.Body1(values.String(values.StrNoVotesYet))
+						message.Color = pg.common.theme.Color.Gray2
+						return layout.Inset{Top: values.MarginPadding10}.Layout(gtx, message.Layout)
+					}
+					return layout.Inset{Top: values.MarginPadding10}.Layout(gtx, pg.chart.Layout)
+				}),
+			)
+		})
+	})
+}
+
+func (pg *stakingRewardsPage) Layout(gtx C) D {
+	common := pg.common
+	sections := []layout.Widget{pg.summaryCard, pg.chartCard}
+
+	body := func(gtx C) D {
+		page := SubPage{
+			title:      values.String(values.StrStakingRewards),
+			backButton: pg.backButton,
+			back: func() {
+				common.changePage(PageTickets)
+			},
+			body: func(gtx C) D {
+				return layout.Stack{Alignment: layout.N}.Layout(gtx,
+					layout.Expanded(func(gtx C) D {
+						return layout.Inset{Top: values.MarginPadding60}.Layout(gtx, func(gtx C) D {
+							return pg.container.Layout(gtx, len(sections), func(gtx C, i int) D {
+								return layout.Inset{Bottom: values.MarginPadding10}.Layout(gtx, sections[i])
+							})
+						})
+					}),
+					layout.Stacked(func(gtx C) D {
+						gtx.Constraints.Min.X = gtx.Constraints.Max.X
+						return endToEndRow(gtx, pg.walletDropDown.Layout, pg.exportButton.Layout)
+					}),
+				)
+			},
+		}
+		return common.SubPageLayout(gtx, page)
+	}
+
+	return common.UniformPadding(gtx, body)
+}
@@ -0,0 +1,285 @@
+package ui
+
+import (
+	"encoding/csv"
+	"io"
+	"sort"
+	"strconv"
+	"time"
+
+	dcrwallet "decred.org/dcrwallet/wallet"
+	"github.com/planetdecred/godcr/wallet"
+)
+
+// stakingStats are the staking results of a set of tickets. Amounts are in
+// atoms.
+type stakingStats struct {
+	tickets int
+	voted   int
+	missed  int
+	expired int
+	revoked int
+
+	// rewards are the vote outputs paid to the wallet less the ticket
+	// prices of the voted tickets.
+	rewards int64
+	// txFees are the fees of the ticket purchases, votes, revocations and
+	// VSP fee transactions.
+	txFees int64
+	// vspFees are the fees paid to stake pools within ticket purchases and
+	// the fees paid to a vspd in a fee transaction from the wallet.
+	vspFees int64
+
+	// avgDaysToVote is the average number of days between the purchase and
+	// the vote of the voted tickets.
+	avgDaysToVote float64
+	// roi is the net return of the spent tickets per year their price was
+	// locked, as a fraction of the price.
+	roi float64
+
+	monthlyRewards []monthlyReward
+}
+
+// monthlyReward are the rewards of the votes cast in a month.
+type monthlyReward struct {
+	month   time.Time
+	rewards int64
+}
+
+// closed returns the number of tickets that can no longer vote.
+func (s stakingStats) closed() int {
+	return s.voted + s.missed + s.expired + s.revoked
+}
+
+// missedRate returns the share of the closed tickets that were missed, or
+// zero if no tickets are closed.
+func (s stakingStats) missedRate() float64 {
+	if s.closed() == 0 {
+		return 0
+	}
+	return float64(s.missed) / float64(s.closed())
+}
+
+// expiredRate returns the share of the closed tickets that expired, or zero
+// if no tickets are closed.
+func (s stakingStats) expiredRate() float64 {
+	if s.closed() == 0 {
+		return 0
+	}
+	return float64(s.expired) / float64(s.closed())
+}
+
+// net returns the rewards less the fees paid.
+func (s stakingStats) net() int64 {
+	return s.rewards - s.txFees - s.vspFees
+}
+
+func sumOutputs(tx *dcrwallet.TransactionSummary) int64 {
+	if tx == nil {
+		return 0
+	}
+	var sum int64
+	for _, output := range tx.MyOutputs {
+		sum += int64(output.Amount)
+	}
+	return sum
+}
+
+func sumInputs(tx *dcrwallet.TransactionSummary) int64 {
+	if tx == nil {
+		return 0
+	}
+	var sum int64
+	for _, input := range tx.MyInputs {
+		sum += int64(input.PreviousAmount)
+	}
+	return sum
+}
+
+// ticketPrice returns the amount a ticket locks, which is the value of its
+// stake submission output, output 0. The other outputs are the commitment and
+// the change.
+func ticketPrice(ticket wallet.Ticket) int64 {
+	if ticket.Info.Ticket == nil {
+		return 0
+	}
+	for _, output := range ticket.Info.Ticket.MyOutputs {
+		if output.Index == 0 {
+			return int64(output.Amount)
+		}
+	}
+	return 0
+}
+
+// ticketReward returns the vote outputs paid to the wallet less the ticket
+// price, or zero if the ticket has not voted.
+func ticketReward(ticket wallet.Ticket) int64 {
+	if ticket.Info.Status != "VOTED" || ticket.Info.Spender == nil {
+		return 0
+	}
+	return sumOutputs(ticket.Info.Spender) - ticketPrice(ticket)
+}
+
+// ticketTxFees returns the fees of the ticket purchase, of its vote or
+// revocation and of the transaction paying its VSP fee.
+func ticketTxFees(ticket wallet.Ticket) int64 {
+	var fees int64
+	if ticket.Info.Ticket != nil {
+		fees += int64(ticket.Info.Ticket.Fee)
+	}
+	if ticket.Info.Spender != nil {
+		fees += int64(ticket.Info.Spender.Fee)
+	}
+	if ticket.FeeTx != nil {
+		fees += ticket.FeeTx.Fee
+	}
+	return fees
+}
+
+// ticketVSPFee returns the fee paid to the VSP of a ticket: what the wallet
+// spent in the ticket purchase beyond the ticket price, its change and the
+// transaction fee, which is the fee of a stake pool, and the amount sent by
+// the fee transaction of a vspd.
+func ticketVSPFee(ticket wallet.Ticket) int64 {
+	var fee int64
+	if ticket.Info.Ticket != nil {
+		poolFee := sumInputs(ticket.Info.Ticket) - sumOutputs(ticket.Info.Ticket) - int64(ticket.Info.Ticket.Fee)
+		if poolFee > 0 {
+			fee += poolFee
+		}
+	}
+	if ticket.FeeTx != nil {
+		fee += ticket.FeeTx.Amount
+	}
+	return fee
+}
+
+// ticketDaysToSpend returns the days between the purchase of a ticket and
+// its vote or revocation, and false if it has not been spent.
+func ticketDaysToSpend(ticket wallet.Ticket) (float64, bool) {
+	if ticket.Info.Ticket == nil || ticket.Info.Spender == nil {
+		return 0, false
+	}
+	seconds := ticket.Info.Spender.Timestamp - ticket.Info.Ticket.Timestamp
+	return float64(seconds) / (24 * 60 * 60), true
+}
+
+// computeStakingStats sums up the staking results of tickets.
+func computeStakingStats(tickets []wallet.Ticket) stakingStats {
+	var stats stakingStats
+	var daysToVote, lockedYears float64
+	var spentNet int64
+	months := make(map[time.Time]int64)
+
+	for _, ticket := range tickets {
+		stats.tickets++
+		switch ticket.Info.Status {
+		case "VOTED":
+			stats.voted++
+		case "MISSED":
+			stats.missed++
+		case "EXPIRED":
+			stats.expired++
+		case "REVOKED":
+			stats.revoked++
+		}
+
+		reward, txFees, vspFee := ticketReward(ticket), ticketTxFees(ticket), ticketVSPFee(ticket)
+		stats.rewards += reward
+		stats.txFees += txFees
+		stats.vspFees += vspFee
+
+		days, spent := ticketDaysToSpend(ticket)
+		if !spent {
+			continue
+		}
+		spentNet += reward - txFees - vspFee
+		lockedYears += float64(ticketPrice(ticket)) * days / 365
+		if ticket.Info.Status == "VOTED" {
+			daysToVote += days
+			voteTime := time.Unix(ticket.Info.Spender.Timestamp, 0).UTC()
+			months[time.Date(voteTime.Year(), voteTime.Month(), 1, 0, 0, 0, 0, time.UTC)] += reward
+		}
+	}
+
+	if stats.voted > 0 {
+		stats.avgDaysToVote = daysToVote / float64(stats.voted)
+	}
+	if lockedYears > 0 {
+		stats.roi = float64(spentNet) / lockedYears
+	}
+	stats.monthlyRewards = fillMonths(months)
+	return stats
+}
+
+// fillMonths returns the rewards of each month from the first to the last
+// month in months, in order, with zero rewards for the months in between.
+func fillMonths(months map[time.Time]int64) []monthlyReward {
+	if len(months) == 0 {
+		return nil
+	}
+	sorted := make([]time.Time, 0, len(months))
+	for month := range months {
+		sorted = append(sorted, month)
+	}
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].Before(sorted[j])
+	})
+
+	var rewards []monthlyReward
+	for month := sorted[0]; !month.After(sorted[len(sorted)-1]); month = month.AddDate(0, 1, 0) {
+		rewards = append(rewards, monthlyReward{month: month, rewards: months[month]})
+	}
+	return rewards
+}
+
+// stakingCSVHeader are the columns of a staking CSV export.
+var stakingCSVHeader = []string{
+	"wallet", "ticket", "status", "purchased", "spent", "days",
+	"price", "reward", "tx fees", "vsp fee",
+}
+
+// writeStakingCSV writes a row for each ticket to w. Dates are RFC 3339 and
+// amounts are in DCR.
+func writeStakingCSV(w io.Writer, tickets []wallet.Ticket) error {
+	amount := func(atoms int64) string {
+		return strconv.FormatFloat(float64(atoms)/1e8, 'f', 8, 64)
+	}
+	date := func(tx *dcrwallet.TransactionSummary) string {
+		if tx == nil {
+			return ""
+		}
+		return time.Unix(tx.Timestamp, 0).UTC().Format(time.RFC3339)
+	}
+
+	writer := csv.NewWriter(w)
+	if err := writer.Write(stakingCSVHeader); err != nil {
+		return err
+	}
+	for _, ticket := range tickets {
+		var hash, days string
+		if ticket.Info.Ticket != nil && ticket.Info.Ticket.Hash != nil {
+			hash = ticket.Info.Ticket.Hash.String()
+		}
+		if d, ok := ticketDaysToSpend(ticket); ok {
+			days = strconv.FormatFloat(d, 'f', 1, 64)
+		}
+		err := writer.Write([]string{
+			ticket.WalletName,
+			hash,
+			ticket.Info.Status,
+			date(ticket.Info.Ticket),
+			date(ticket.Info.Spender),
+			days,
+			amount(ticketPrice(ticket)),
+			amount(ticketReward(ticket)),
+			amount(ticketTxFees(ticket)),
+			amount(ticketVSPFee(ticket)),
+		})
+		if err != nil {
+			return err
+		}
+	}
+	writer.Flush()
+	return writer.Error()
+}
@@ -0,0 +1,106 @@
+package ui
+
+import (
+	"bytes"
+	"strings"
+	"time"
+
+	dcrwallet "decred.org/dcrwallet/wallet"
+	"github.com/decred/dcrd/dcrutil/v3"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/planetdecred/dcrlibwallet"
+	"github.com/planetdecred/godcr/wallet"
+)
+
+var _ = Describe("Staking stats", func() {
+	day := int64(24 * 60 * 60)
+	bought := time.Date(2021, time.January, 20, 0, 0, 0, 0, time.UTC).Unix()
+
+	ticket := func(status string, poolFee int64, spender *dcrwallet.TransactionSummary) wallet.Ticket {
+		return wallet.Ticket{
+			WalletName: "default",
+			Info: dcrlibwallet.TicketInfo{
+				Status: status,
+				Ticket: &dcrwallet.TransactionSummary{
+					Fee:       300,
+					Timestamp: bought,
+					MyInputs:  []dcrwallet.TransactionSummaryInput{{PreviousAmount: dcrutil.Amount(10000 + 300 + poolFee)}},
+					MyOutputs: []dcrwallet.TransactionSummaryOutput{{Amount: 10000}},
+				},
+				Spender: spender,
+			},
+		}
+	}
+	spender := func(days, returned, fee int64) *dcrwallet.TransactionSummary {
+		return &dcrwallet.TransactionSummary{
+			Fee:       dcrutil.Amount(fee),
+			Timestamp: bought + days*day,
+			MyOutputs: []dcrwallet.TransactionSummaryOutput{{Amount: dcrutil.Amount(returned)}},
+		}
+	}
+
+	tickets := []wallet.Ticket{
+		ticket("VOTED", 0, spender(10, 10500, 0)),
+		ticket("VOTED", 50, spender(30, 10600, 0)),
+		ticket("REVOKED", 0, spender(146, 9800, 200)),
+		ticket("MISSED", 0, nil),
+		ticket("LIVE", 0, nil),
+	}
+
+	It("sums up rewards and fees", func() {
+		stats := computeStakingStats(tickets)
+		Expect(stats.tickets).To(Equal(5))
+		Expect(stats.voted).To(Equal(2))
+		Expect(stats.revoked).To(Equal(1))
+		Expect(stats.missed).To(Equal(1))
+		Expect(stats.rewards).To(Equal(int64(1100)))
+		Expect(stats.txFees).To(Equal(int64(5*300 + 200)))
+		Expect(stats.vspFees).To(Equal(int64(50)))
+		Expect(stats.avgDaysToVote).To(BeNumerically("~", 20))
+		Expect(stats.missedRate()).To(BeNumerically("~", 0.25))
+	})
+
+	It("annualizes the return of the spent tickets", func() {
+		stats := computeStakingStats(tickets)
+		net := float64(1100 - 3*300 - 200 - 50)
+		lockedYears := 10000 * float64(10+30+146) / 365
+		Expect(stats.roi).To(BeNumerically("~", net/lockedYears))
+	})
+
+	It("fills the months between votes", func() {
+		stats := computeStakingStats([]wallet.Ticket{
+			ticket("VOTED", 0, spender(10, 10500, 0)),
+			ticket("VOTED", 0, spender(70, 10400, 0)),
+		})
+		Expect(stats.monthlyRewards).To(Equal([]monthlyReward{
+			{month: time.Date(2021, time.January, 1, 0, 0, 0, 0, time.UTC), rewards: 500},
+			{month: time.Date(2021, time.February, 1, 0, 0, 0, 0, time.UTC), rewards: 0},
+			{month: time.Date(2021, time.March, 1, 0, 0, 0, 0, time.UTC), rewards: 400},
+		}))
+	})
+
+	It("prices tickets by their stake output and adds the vspd fee transaction", func() {
+		vspd := ticket("VOTED", 0, spender(10, 10500, 0))
+		vspd.Info.Ticket.MyInputs[0].PreviousAmount += 700
+		vspd.Info.Ticket.MyOutputs = append(vspd.Info.Ticket.MyOutputs,
+			dcrwallet.TransactionSummaryOutput{Index: 2, Amount: 700})
+		vspd.FeeTx = &dcrlibwallet.Transaction{Amount: 40, Fee: 250}
+
+		Expect(ticketPrice(vspd)).To(Equal(int64(10000)))
+		Expect(ticketReward(vspd)).To(Equal(int64(500)))
+		Expect(ticketVSPFee(vspd)).To(Equal(int64(40)))
+		Expect(ticketTxFees(vspd)).To(Equal(int64(300 + 250)))
+	})
+
+	It("exports a row for each ticket", func() {
+		var buf bytes.Buffer
+		Expect(writeStakingCSV(&buf, tickets[:2])).To(Succeed())
+		lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+		Expect(lines).To(HaveLen(3))
+		Expect(lines[0]).To(Equal(strings.Join(stakingCSVHeader, ",")))
+		Expect(lines[2]).To(Equal("default,,VOTED,2021-01-20T00:00:00Z,2021-02-19T00:00:00Z,30.0," +
+			"0.00010000,0.00000600,0.00000300,0.00000050"))
+	})
+})
@@ -6,7 +6,6 @@ import (
 	"gioui.org/layout"
 	"gioui.org/text"
 	"gioui.org/widget"
-	"github.com/planetdecred/dcrlibwallet"
 	"github.com/planetdecred/godcr/ui/decredmaterial"
 	"github.com/planetdecred/godcr/ui/values"
 )
@@ -54,15 +53,19 @@ func newStartPage(common *pageCommon) *startPage {
 
 func (sp *startPage) OnResume() {
 	sp.wallet.InitMultiWallet()
-	sp.multiWallet = sp.wallet.GetMultiWallet()
 
-	// refresh theme now that config is available
+	// refresh theme, scale, language, amount display and key bindings now
+	// that config is available
 	sp.refreshTheme()
+	sp.refreshScale()
+	values.SetUserLanguage(sp.wallet.ReadStringConfigValueForKey(languagePreferenceKey))
+	sp.applyAmountDisplay()
+	sp.shortcuts.load(sp.wallet)
 
-	if sp.multiWallet.LoadedWalletsCount() > 0 {
+	if sp.wallet.LoadedWalletsCount() > 0 {
 		sp.loadStatus.Text = "Opening wallets"
 
-		if sp.multiWallet.IsStartupSecuritySet() {
+		if sp.wallet.IsStartupSecuritySet() {
 			sp.unlock()
 		} else {
 			go sp.openWallets("")
@@ -77,7 +80,7 @@ func (sp *startPage) unlock() {
 	newPasswordModal(sp.pageCommon).
 		title("Unlock with passphrase").
 		negativeButton("Exit", func() {
-			sp.multiWallet.Shutdown()
+			sp.wallet.Shutdown()
 			os.Exit(0)
 		}).
 		positiveButton("Unlock", func(password string, m *passwordModal) bool {
@@ -96,7 +99,7 @@ func (sp *startPage) unlock() {
 }
 
 func (sp *startPage) openWallets(passphrase string) error {
-	err := sp.multiWallet.OpenWallets([]byte(passphrase))
+	err := sp.wallet.OpenWallets([]byte(passphrase))
 	if err != nil {
 		log.Info("Error opening wallet:", err)
 		// show err dialog
@@ -118,7 +121,7 @@ func (sp *startPage) handle() {
 			title("Create new wallet").
 			passwordCreated(func(_, password string, m *createPasswordModal) bool {
 				go func() {
-					_, err := sp.multiWallet.CreateNewWallet("mywallet", password, dcrlibwallet.PassphraseTypePass)
+					_, err := sp.wallet.CreateNewWallet("mywallet", password)
 					if err != nil {
 						m.setError(err.Error())
 						m.setLoading(false)
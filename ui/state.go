@@ -2,7 +2,6 @@ package ui
 
 import (
 	"gioui.org/op"
-	"github.com/planetdecred/dcrlibwallet"
 	"github.com/planetdecred/godcr/ui/decredmaterial"
 	"github.com/planetdecred/godcr/wallet"
 )
@@ -60,31 +59,24 @@ func (win *Window) updateStates(update interface{}) {
 	case *wallet.VSP:
 		win.vspInfo = e
 		return
+	case *wallet.TicketPriceForecast:
+		win.ticketPriceForecast = e
+		return
+	case *wallet.ProposalVersions:
+		win.proposalVersions = e
+		return
+	case *wallet.ProposalComments:
+		win.proposalComments = e
+		return
 	case *wallet.Proposals:
 		win.states.loading = false
 		win.proposals = e
 		return
-	case wallet.Restored:
-		win.states.creating = false
-		op.InvalidateOp{}.Add(win.ops)
-	case wallet.DeletedWallet:
-		win.selected = 0
-		win.notifyOnSuccess("Wallet removed")
-	case wallet.UpdatedAccount:
-		win.notifyOnSuccess("Account renamed")
-	case *wallet.Signature:
-		win.notifyOnSuccess("Message signed")
-		win.signatureResult = update.(*wallet.Signature)
-	case *dcrlibwallet.TxAuthor:
-		txAuthor := update.(*dcrlibwallet.TxAuthor)
-		win.txAuthor = *txAuthor
+	case wallet.TxAuthor:
+		win.txAuthor = e
 	case *wallet.Broadcast:
 		broadcastResult := update.(*wallet.Broadcast)
 		win.broadcastResult = *broadcastResult
-	case *wallet.ChangePassword:
-		win.notifyOnSuccess("Spending password changed")
-	case *wallet.StartupPassphrase:
-		win.notifyOnSuccess(update.(*wallet.StartupPassphrase).Msg)
 	case wallet.SetupAccountMixer:
 		win.notifyOnSuccess("Mixer setup completed")
 	case *wallet.TicketPurchase:
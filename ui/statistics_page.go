@@ -10,45 +10,71 @@ import (
 	"gioui.org/op"
 	"github.com/planetdecred/godcr/ui/decredmaterial"
 	"github.com/planetdecred/godcr/ui/values"
-	"github.com/planetdecred/godcr/wallet"
 )
 
 const PageStat = "Stat"
 
+// startupTime is when the app started, for the uptime statistic.
+var startupTime = time.Now()
+
 type statPage struct {
-	common      *pageCommon
-	txs         **wallet.Transactions
-	theme       *decredmaterial.Theme
-	l           layout.List
-	startupTime time.Time
-	syncStatus  *wallet.SyncStatus
-	netType     string
+	common *pageCommon
+	theme  *decredmaterial.Theme
+	l      layout.List
 
 	backButton decredmaterial.IconButton
 }
 
+// statistic is a named value shown on the statistics page.
+type statistic struct {
+	name, value string
+}
+
 func StatPage(common *pageCommon) Page {
 	pg := &statPage{
-		txs:    common.walletTransactions,
 		common: common,
 		theme:  common.theme,
 		l: layout.List{
 			Axis: layout.Vertical,
 		},
 	}
-	pg.startupTime = time.Now()
-	pg.syncStatus = common.walletSyncStatus
-	if common.wallet.Net == "testnet3" {
-		pg.netType = "Testnet"
-	} else {
-		pg.netType = strings.Title(common.wallet.Net)
-	}
 
 	pg.backButton, _ = common.SubPageHeaderButtons()
 
 	return pg
 }
 
+// statistics returns the app and wallet statistics. It reads the window
+// state, so it must be called from the UI goroutine.
+func statistics(common *pageCommon) []statistic {
+	netType := strings.Title(common.wallet.Network())
+	if common.wallet.Network() == "testnet3" {
+		netType = "Testnet"
+	}
+
+	uptime := func(t time.Time) string {
+		v := int(time.Since(t).Seconds())
+		h := v / 3600
+		m := (v - h*3600) / 60
+		s := v - h*3600 - m*60
+		return fmt.Sprintf("%02d:%02d:%02d", h, m, s)
+	}(startupTime)
+
+	return []statistic{
+		{"Build", netType + ", " + values.FormatDate(time.Now())},
+		{"Peers connected", strconv.Itoa(int(common.walletSyncStatus.ConnectedPeers))},
+		{"Uptime", uptime},
+		{"Network", netType},
+		{"Best block", fmt.Sprintf("%d", common.info.BestBlockHeight)},
+		{"Best block timestamp", values.FormatDateTime(time.Unix(common.info.BestBlockTime, 0))},
+		{"Best block age", common.info.LastSyncTime},
+		{"Wallet data directory", common.wallet.WalletDirectory()},
+		{"Wallet data", common.wallet.DataSize()},
+		{"Transactions", fmt.Sprintf("%d", (*common.walletTransactions).Total)},
+		{"Wallets", fmt.Sprintf("%d", len(common.info.Wallets))},
+	}
+}
+
 func (pg *statPage) OnResume() {
 
 }
@@ -74,36 +100,12 @@ func (pg *statPage) layoutStats(gtx C) D {
 			})
 		}
 	}
-	uptime := func(t time.Time) string {
-		v := int(time.Since(t).Seconds())
-		h := v / 3600
-		m := (v - h*3600) / 60
-		s := v - h*3600 - m*60
-		return fmt.Sprintf("%02d:%02d:%02d", h, m, s)
-	}(pg.startupTime)
-
-	items := []layout.Widget{
-		item("Build", pg.netType+", "+time.Now().Format("2006-01-02")),
-		pg.theme.Separator().Layout,
-		item("Peers connected", strconv.Itoa(int(pg.syncStatus.ConnectedPeers))),
-		pg.theme.Separator().Layout,
-		item("Uptime", uptime),
-		pg.theme.Separator().Layout,
-		item("Network", pg.netType),
-		pg.theme.Separator().Layout,
-		item("Best block", fmt.Sprintf("%d", pg.common.info.BestBlockHeight)),
-		pg.theme.Separator().Layout,
-		item("Best block timestamp", time.Unix(pg.common.info.BestBlockTime, 0).Format("2006-01-02 03:04:05 -0700")),
-		pg.theme.Separator().Layout,
-		item("Best block age", pg.common.info.LastSyncTime),
-		pg.theme.Separator().Layout,
-		item("Wallet data directory", pg.common.wallet.WalletDirectory()),
-		pg.theme.Separator().Layout,
-		item("Wallet data", pg.common.wallet.DataSize()),
-		pg.theme.Separator().Layout,
-		item("Transactions", fmt.Sprintf("%d", (*pg.txs).Total)),
-		pg.theme.Separator().Layout,
-		item("Wallets", fmt.Sprintf("%d", len(pg.common.info.Wallets))),
+	var items []layout.Widget
+	for i, stat := range statistics(pg.common) {
+		if i > 0 {
+			items = append(items, pg.theme.Separator().Layout)
+		}
+		items = append(items, item(stat.name, stat.value))
 	}
 
 	return card.Layout(gtx, func(gtx C) D {
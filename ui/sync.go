@@ -23,7 +23,7 @@ func (win *Window) updateSyncProgress(report interface{}) {
 		status.TotalSteps = wallet.TotalSyncSteps
 		status.Steps = wallet.FetchHeadersSteps
 		status.CurrentBlockHeight = t.Progress.CurrentHeaderHeight
-		win.wallet.OverallBlockHeight = t.Progress.TotalHeadersToFetch
+		win.wallet.SetOverallBlockHeight(t.Progress.TotalHeadersToFetch)
 		win.wallet.GetMultiWalletInfo()
 	case wallet.SyncAddressDiscoveryProgress:
 		status.RescanHeadersProgress = t.Progress.AddressDiscoveryProgress
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"image"
 	"log"
+	"math/rand"
 	"os"
 	"path"
 	"path/filepath"
@@ -51,6 +52,13 @@ type TestStruct struct {
 
 	collapsible *decredmaterial.Collapsible
 	dropDown    *decredmaterial.DropDown
+
+	charts struct {
+		line  *decredmaterial.LineChart
+		bar   *decredmaterial.BarChart
+		area  *decredmaterial.StackedAreaChart
+		donut *decredmaterial.PieChart
+	}
 }
 
 type (
@@ -171,6 +179,76 @@ func (t *TestStruct) initWidgets() {
 		},
 	}
 	t.dropDown = theme.DropDown(dropDownItems, 1)
+
+	t.initCharts()
+}
+
+// initCharts fills the chart widgets with generated data. The line chart has
+// enough points to show that large series stay cheap to draw.
+func (t *TestStruct) initCharts() {
+	theme := t.theme
+	random := rand.New(rand.NewSource(1))
+	format := func(v float64) string {
+		return fmt.Sprintf("%.1f", v)
+	}
+
+	t.charts.line = theme.LineChart()
+	t.charts.line.Fill = true
+	for _, name := range []string{"Series A", "Series B"} {
+		series := decredmaterial.ChartSeries{Name: name}
+		value := 50.0
+		for i := 0; i < 5000; i++ {
+			value += random.NormFloat64()
+			series.Points = append(series.Points, decredmaterial.ChartPoint{X: float64(i), Y: value})
+		}
+		t.charts.line.Series = append(t.charts.line.Series, series)
+	}
+	t.charts.line.XLabel = format
+	t.charts.line.YLabel = format
+	t.charts.line.Tooltip = func(series int, p decredmaterial.ChartPoint) string {
+		return fmt.Sprintf("%s\n%.0f: %.2f", t.charts.line.Series[series].Name, p.X, p.Y)
+	}
+
+	t.charts.bar = theme.BarChart()
+	for _, month := range []string{"Jan", "Feb", "Mar", "Apr", "May", "Jun", "Jul", "Aug", "Sep", "Oct", "Nov", "Dec"} {
+		t.charts.bar.Bars = append(t.charts.bar.Bars, decredmaterial.ChartBar{Label: month, Value: random.Float64()*20 - 4})
+	}
+	t.charts.bar.YLabel = format
+	t.charts.bar.Tooltip = func(bar int) string {
+		return fmt.Sprintf("%s: %.2f", t.charts.bar.Bars[bar].Label, t.charts.bar.Bars[bar].Value)
+	}
+
+	t.charts.area = theme.StackedAreaChart()
+	for _, name := range []string{"Live", "Immature", "Unmined"} {
+		series := decredmaterial.ChartSeries{Name: name}
+		for i := 0; i < 60; i++ {
+			series.Points = append(series.Points, decredmaterial.ChartPoint{X: float64(i), Y: 5 + random.Float64()*10})
+		}
+		t.charts.area.Series = append(t.charts.area.Series, series)
+	}
+	t.charts.area.XLabel = format
+	t.charts.area.YLabel = format
+	t.charts.area.Tooltip = func(index int) string {
+		var text string
+		for _, s := range t.charts.area.Series {
+			text += fmt.Sprintf("%s: %.2f\n", s.Name, s.Points[index].Y)
+		}
+		return text[:len(text)-1]
+	}
+
+	t.charts.donut = theme.PieChart()
+	t.charts.donut.Donut = 0.6
+	t.charts.donut.Slices = []decredmaterial.ChartSlice{
+		{Label: "Spendable", Value: 120},
+		{Label: "Staked", Value: 80},
+		{Label: "Immature", Value: 15},
+		{Label: "Locked", Value: 5},
+	}
+	t.charts.donut.Center = theme.Body1("220 DCR").Layout
+	t.charts.donut.Tooltip = func(slice int) string {
+		s := t.charts.donut.Slices[slice]
+		return fmt.Sprintf("%s: %.0f", s.Label, s.Value)
+	}
 }
 
 func (t *TestStruct) TestPage(gtx layout.Context) {
@@ -342,6 +420,15 @@ func (t *TestStruct) testPageContents(gtx layout.Context) layout.Dimensions {
 	}
 
 	pageContent = append(pageContent, pageContent...)
+	pageContent = append(pageContent,
+		func(gtx C) D {
+			return t.theme.H4("Charts").Layout(gtx)
+		},
+		t.charts.line.Layout,
+		t.charts.bar.Layout,
+		t.charts.area.Layout,
+		t.charts.donut.Layout,
+	)
 	return pageContainer.Layout(gtx, pageContent)
 }
 
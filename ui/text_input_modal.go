@@ -40,6 +40,10 @@ func (tm *textInputModal) Dismiss() {
 	tm.pageCommon.dismissModal(tm)
 }
 
+func (tm *textInputModal) focusTargets() []focusTarget {
+	return append(editorTargets(tm.textInput.Editor), tm.infoModal.focusTargets()...)
+}
+
 func (tm *textInputModal) hint(hint string) *textInputModal {
 	tm.textInput.Hint = hint
 	return tm
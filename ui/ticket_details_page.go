@@ -0,0 +1,422 @@
+package ui
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"gioui.org/layout"
+	"gioui.org/widget"
+
+	"github.com/planetdecred/dcrlibwallet"
+	"github.com/planetdecred/godcr/ui/decredmaterial"
+	"github.com/planetdecred/godcr/ui/values"
+	"github.com/planetdecred/godcr/wallet"
+)
+
+const PageTicketDetails = "TicketDetails"
+
+type ticketDetailsPage struct {
+	common *pageCommon
+	ticket wallet.Ticket
+	// returnPage is the page the details were opened from. The common return
+	// page is taken over by the transaction details opened from here.
+	returnPage string
+
+	// loaded are the tickets the ticket and its details were found in.
+	loaded   *wallet.Tickets
+	walletID int
+	details  *wallet.TicketDetails
+	err      error
+
+	container   layout.List
+	backButton  decredmaterial.IconButton
+	toPurchase  *widget.Clickable
+	toFeeTx     *widget.Clickable
+	toSpender   *widget.Clickable
+	checkStatus decredmaterial.Button
+	retryFee    decredmaterial.Button
+}
+
+func TicketDetailsPage(common *pageCommon, ticket wallet.Ticket, returnPage string) Page {
+	pg := &ticketDetailsPage{
+		common:     common,
+		ticket:     ticket,
+		returnPage: returnPage,
+		container:  layout.List{Axis: layout.Vertical},
+		toPurchase: new(widget.Clickable),
+		toFeeTx:    new(widget.Clickable),
+		toSpender:  new(widget.Clickable),
+	}
+	pg.backButton, _ = common.SubPageHeaderButtons()
+	pg.checkStatus = common.theme.Button(new(widget.Clickable), values.String(values.StrCheckVSPStatus))
+	pg.checkStatus.TextSize = values.TextSize14
+	pg.retryFee = common.theme.Button(new(widget.Clickable), values.String(values.StrRetryFeePayment))
+	pg.retryFee.TextSize = values.TextSize14
+	return pg
+}
+
+// openTicketDetails shows the details of a ticket, returning to the page from
+// when done.
+func (common *pageCommon) openTicketDetails(ticket wallet.Ticket, from string) {
+	common.changeFragment(TicketDetailsPage(common, ticket, from), PageTicketDetails)
+}
+
+// ticketClicks are the clickables of a list of tickets that open the details
+// of the ticket clicked.
+type ticketClicks struct {
+	clicks  []*widget.Clickable
+	tickets []wallet.Ticket
+}
+
+// layout lays out the ticket at index of the tickets being listed.
+func (tc *ticketClicks) layout(gtx C, tickets []wallet.Ticket, index int, w layout.Widget) D {
+	tc.tickets = tickets
+	for len(tc.clicks) < len(tickets) {
+		tc.clicks = append(tc.clicks, new(widget.Clickable))
+	}
+	return decredmaterial.Clickable(gtx, tc.clicks[index], w)
+}
+
+func (tc *ticketClicks) handle(common *pageCommon, from string) {
+	for i := 0; i < len(tc.tickets) && i < len(tc.clicks); i++ {
+		if tc.clicks[i].Clicked() {
+			common.openTicketDetails(tc.tickets[i], from)
+			return
+		}
+	}
+}
+
+// OnResume reloads the ticket and its details, so that they are current when
+// coming back from a transaction.
+func (pg *ticketDetailsPage) OnResume() {
+	pg.reload()
+}
+
+// reload finds the ticket in the loaded tickets and looks up its details.
+func (pg *ticketDetailsPage) reload() {
+	pg.loaded = *pg.common.walletTickets
+	for walletID, tickets := range pg.loaded.Confirmed {
+		for _, ticket := range tickets {
+			if ticket.Info.Ticket.Hash.IsEqual(pg.ticket.Info.Ticket.Hash) {
+				pg.ticket, pg.walletID = ticket, walletID
+				pg.details, pg.err = pg.common.wallet.TicketDetails(walletID, ticket)
+				return
+			}
+		}
+	}
+	pg.details, pg.err = nil, wallet.ErrIDNotExist
+}
+
+// canSign returns whether the wallet of the ticket can sign requests to the VSP
+// and pay its fee, which watch-only wallets cannot.
+func (pg *ticketDetailsPage) canSign() bool {
+	wal := pg.common.wallet.WalletWithID(pg.walletID)
+	return wal != nil && !wal.IsWatchingOnly
+}
+
+// canRetryFee returns whether the ticket can still vote but no VSP has been
+// paid to vote it.
+func (pg *ticketDetailsPage) canRetryFee() bool {
+	switch pg.ticket.Info.Status {
+	case "UNMINED", "IMMATURE", "LIVE":
+		return pg.ticket.VSP != nil && !pg.ticket.VSP.Voting()
+	}
+	return false
+}
+
+func (pg *ticketDetailsPage) handle() {
+	common := pg.common
+	if pg.loaded != *common.walletTickets {
+		pg.reload()
+	}
+	if pg.details == nil {
+		return
+	}
+
+	for pg.checkStatus.Button.Clicked() {
+		common.checkVSPTickets(pg.walletID, pg.ticket.WalletName)
+	}
+
+	for pg.retryFee.Button.Clicked() {
+		host := pg.details.VSPHost
+		if host == "" {
+			host = common.wallet.GetRememberVSP()
+		}
+		if host == "" {
+			common.notify(values.String(values.StrRememberVSPFirst), false)
+			continue
+		}
+		common.retryVSPFee(pg.walletID, pg.ticket, host)
+	}
+
+	txs := []struct {
+		click *widget.Clickable
+		txn   *dcrlibwallet.Transaction
+	}{
+		{pg.toPurchase, pg.details.Purchase},
+		{pg.toFeeTx, pg.details.FeeTx},
+		{pg.toSpender, pg.details.Spender},
+	}
+	for _, tx := range txs {
+		if tx.click.Clicked() && tx.txn != nil {
+			common.setReturnPage(PageTicketDetails)
+			common.changeFragment(TransactionDetailsPage(common, tx.txn), "txdetails")
+			return
+		}
+	}
+}
+
+func (pg *ticketDetailsPage) onClose() {}
+
+// shortHash returns the start and end of a transaction hash.
+func shortHash(hash string) string {
+	if len(hash) <= 20 {
+		return hash
+	}
+	return hash[:10] + "..." + hash[len(hash)-10:]
+}
+
+// vspFeeStatusText returns the text of the VSP fee status of a ticket.
+func vspFeeStatusText(status wallet.VSPFeeStatus) string {
+	switch status {
+	case wallet.VSPFeeUnpaid:
+		return values.String(values.StrFeeUnpaid)
+	case wallet.VSPFeePaid:
+		return values.String(values.StrFeePaid)
+	case wallet.VSPFeeConfirmed:
+		return values.String(values.StrFeeConfirmed)
+	case wallet.VSPFeeErrored:
+		return values.String(values.StrFeeErrored)
+	}
+	return values.String(values.StrUnknown)
+}
+
+// row lays out a detail with its label on the left and value on the right.
+func (pg *ticketDetailsPage) row(gtx C, label string, value layout.Widget) D {
+	return layout.Inset{Bottom: values.MarginPadding10}.Layout(gtx, func(gtx C) D {
+		left := pg.common.theme.Body2(label)
+		left.Color = pg.common.theme.Color.Gray3
+		return endToEndRow(gtx, left.Layout, value)
+	})
+}
+
+// textRow lays out a detail with a text value.
+func (pg *ticketDetailsPage) textRow(gtx C, label, value string) D {
+	return pg.row(gtx, label, pg.common.theme.Body1(value).Layout)
+}
+
+// txRow lays out a transaction of the ticket as a link to its details, or
+// missing if the transaction is not known.
+func (pg *ticketDetailsPage) txRow(gtx C, label string, click *widget.Clickable, txn *dcrlibwallet.Transaction, missing string) D {
+	if txn == nil {
+		return pg.textRow(gtx, label, missing)
+	}
+	return pg.row(gtx, label, func(gtx C) D {
+		return decredmaterial.Clickable(gtx, click, func(gtx C) D {
+			link := pg.common.theme.Body1(shortHash(txn.Hash))
+			link.Color = pg.common.theme.Color.Primary
+			return link.Layout(gtx)
+		})
+	})
+}
+
+// card lays out a titled card of rows.
+func (pg *ticketDetailsPage) card(gtx C, title string, rows ...layout.Widget) D {
+	return pg.common.theme.Card().Layout(gtx, func(gtx C) D {
+		gtx.Constraints.Min.X = gtx.Constraints.Max.X
+		return Container{layout.UniformInset(values.MarginPadding15)}.Layout(gtx, func(gtx C) D {
+			children := []layout.FlexChild{
+				layout.Rigid(func(gtx C) D {
+					txt := pg.common.theme.Body2(title)
+					txt.Color = pg.common.theme.Color.Gray2
+					return layout.Inset{Bottom: values.MarginPadding10}.Layout(gtx, txt.Layout)
+				}),
+			}
+			for _, row := range rows {
+				children = append(children, layout.Rigid(row))
+			}
+			return layout.Flex{Axis: layout.Vertical}.Layout(gtx, children...)
+		})
+	})
+}
+
+func (pg *ticketDetailsPage) ticketCard(gtx C) D {
+	details, info := pg.details, pg.ticket.Info
+	height := func(height int32) string {
+		if height == 0 {
+			return values.String(values.StrNotMinedYet)
+		}
+		return fmt.Sprintf("%d", height)
+	}
+
+	return pg.card(gtx, pg.ticket.WalletName,
+		func(gtx C) D {
+			return layout.Inset{Bottom: values.MarginPadding10}.Layout(gtx, func(gtx C) D {
+				return layout.Flex{Alignment: layout.Middle}.Layout(gtx,
+					layout.Rigid(func(gtx C) D {
+						st := ticketStatusIcon(pg.common, info.Status)
+						if st == nil {
+							return layout.Dimensions{}
+						}
+						st.icon.Scale = 0.6
+						return layout.Inset{Right: values.MarginPadding16}.Layout(gtx, st.icon.Layout)
+					}),
+					layout.Rigid(func(gtx C) D {
+						return pg.common.layoutBalance(gtx, pg.ticket.Amount, true)
+					}),
+				)
+			})
+		},
+		func(gtx C) D {
+			status := pg.common.theme.Body1(strings.Title(strings.ToLower(info.Status)))
+			if st := ticketStatusIcon(pg.common, info.Status); st != nil {
+				status.Color = st.color
+			}
+			return pg.row(gtx, values.String(values.StrTicketStatus), status.Layout)
+		},
+		func(gtx C) D {
+			return pg.textRow(gtx, values.String(values.StrPurchased), values.FormatDateTime(time.Unix(info.Ticket.Timestamp, 0)))
+		},
+		func(gtx C) D {
+			return pg.txRow(gtx, values.String(values.StrPurchaseTx), pg.toPurchase, details.Purchase, values.String(values.StrUnknown))
+		},
+		func(gtx C) D {
+			return pg.textRow(gtx, values.String(values.StrMaturityHeight), height(details.MaturityHeight))
+		},
+		func(gtx C) D {
+			return pg.textRow(gtx, values.String(values.StrExpiryHeight), height(details.ExpiryHeight))
+		},
+	)
+}
+
+func (pg *ticketDetailsPage) vspCard(gtx C) D {
+	details := pg.details
+	host := details.VSPHost
+	switch {
+	case host == "" && pg.ticket.VSP != nil:
+		host = values.String(values.StrNoVSP)
+	case host == "":
+		host = values.String(values.StrUnknown)
+	}
+
+	checked := values.String(values.StrNotChecked)
+	if pg.ticket.VSP != nil {
+		checked = values.FormatDateTime(time.Unix(pg.ticket.VSP.CheckedAt, 0))
+	}
+
+	return pg.card(gtx, values.String(values.StrVSP),
+		func(gtx C) D {
+			return pg.textRow(gtx, values.String(values.StrVSPHost), host)
+		},
+		func(gtx C) D {
+			status := pg.common.theme.Body1(vspFeeStatusText(details.FeeStatus))
+			if wallet.IsUnvoted(pg.ticket) {
+				status.Color = pg.common.theme.Color.Danger
+			}
+			return pg.row(gtx, values.String(values.StrVSPFeeStatus), status.Layout)
+		},
+		func(gtx C) D {
+			return pg.txRow(gtx, values.String(values.StrFeeTx), pg.toFeeTx, details.FeeTx, values.String(values.StrUnknown))
+		},
+		func(gtx C) D {
+			return pg.textRow(gtx, values.String(values.StrLastChecked), checked)
+		},
+		func(gtx C) D {
+			if !pg.canSign() {
+				return layout.Dimensions{}
+			}
+			return layout.Flex{Alignment: layout.Middle}.Layout(gtx,
+				layout.Rigid(pg.checkStatus.Layout),
+				layout.Rigid(func(gtx C) D {
+					if !pg.canRetryFee() {
+						return layout.Dimensions{}
+					}
+					return layout.Inset{Left: values.MarginPadding10}.Layout(gtx, pg.retryFee.Layout)
+				}),
+			)
+		},
+	)
+}
+
+func (pg *ticketDetailsPage) spenderCard(gtx C) D {
+	details, info := pg.details, pg.ticket.Info
+	title, label := values.String(values.StrVote), values.String(values.StrVoteTx)
+	if info.Status == "REVOKED" {
+		title, label = values.String(values.StrRevocation), values.String(values.StrRevocationTx)
+	}
+	locked := values.String(values.StrNotSpentYet)
+	if days, ok := ticketDaysToSpend(pg.ticket); ok {
+		locked = values.StringF(values.StrDaysValue, values.FormatDecimal(days, 1))
+	}
+
+	return pg.card(gtx, title,
+		func(gtx C) D {
+			return pg.txRow(gtx, label, pg.toSpender, details.Spender, values.String(values.StrNotSpentYet))
+		},
+		func(gtx C) D {
+			return pg.textRow(gtx, values.String(values.StrReward), wallet.FormatAmount(ticketReward(pg.ticket)))
+		},
+		func(gtx C) D {
+			return pg.textRow(gtx, values.String(values.StrTimeLocked), locked)
+		},
+	)
+}
+
+func (pg *ticketDetailsPage) voteChoicesCard(gtx C) D {
+	choices := pg.details.VoteChoices
+	agendas := make([]string, 0, len(choices))
+	for agenda := range choices {
+		agendas = append(agendas, agenda)
+	}
+	sort.Strings(agendas)
+
+	rows := make([]layout.Widget, 0, len(agendas)+1)
+	for _, agenda := range agendas {
+		agenda := agenda
+		rows = append(rows, func(gtx C) D {
+			return pg.textRow(gtx, agenda, choices[agenda])
+		})
+	}
+	if len(rows) == 0 {
+		rows = append(rows, func(gtx C) D {
+			txt := pg.common.theme.Body1(values.String(values.StrNoVoteChoices))
+			txt.Color = pg.common.theme.Color.Gray2
+			return txt.Layout(gtx)
+		})
+	}
+	return pg.card(gtx, values.String(values.StrVoteChoices), rows...)
+}
+
+func (pg *ticketDetailsPage) Layout(gtx C) D {
+	common := pg.common
+
+	body := func(gtx C) D {
+		page := SubPage{
+			title:      values.String(values.StrTicketDetails),
+			backButton: pg.backButton,
+			back: func() {
+				common.changePage(pg.returnPage)
+			},
+			body: func(gtx C) D {
+				if pg.err != nil {
+					txt := common.theme.Body1(values.StringF(values.StrTicketDetailsFailed, pg.err))
+					txt.Color = common.theme.Color.Danger
+					return txt.Layout(gtx)
+				}
+				if pg.details == nil {
+					return layout.Dimensions{}
+				}
+
+				sections := []layout.Widget{pg.ticketCard, pg.vspCard, pg.spenderCard, pg.voteChoicesCard}
+				return pg.container.Layout(gtx, len(sections), func(gtx C, i int) D {
+					return layout.Inset{Bottom: values.MarginPadding10}.Layout(gtx, sections[i])
+				})
+			},
+		}
+		return common.SubPageLayout(gtx, page)
+	}
+
+	return common.UniformPadding(gtx, body)
+}
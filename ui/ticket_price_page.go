@@ -0,0 +1,204 @@
+package ui
+
+import (
+	"time"
+
+	"gioui.org/layout"
+
+	"github.com/planetdecred/godcr/ui/decredmaterial"
+	"github.com/planetdecred/godcr/ui/values"
+	"github.com/planetdecred/godcr/wallet"
+)
+
+const PageTicketPrice = "TicketPrice"
+
+type ticketPricePage struct {
+	common   *pageCommon
+	forecast **wallet.TicketPriceForecast
+
+	container    layout.List
+	backButton   decredmaterial.IconButton
+	chart        *decredmaterial.LineChart
+	rangeButtons []decredmaterial.Button
+
+	selectedRange int
+	// loaded is the forecast shown in the chart.
+	loaded *wallet.TicketPriceForecast
+}
+
+func TicketPricePage(common *pageCommon) Page {
+	pg := &ticketPricePage{
+		common:        common,
+		forecast:      common.priceForecast,
+		container:     layout.List{Axis: layout.Vertical},
+		chart:         common.theme.LineChart(),
+		rangeButtons:  newRangeButtons(common.theme),
+		selectedRange: 2,
+	}
+	pg.backButton, _ = common.SubPageHeaderButtons()
+
+	pg.chart.Step = true
+	pg.chart.YLabel = func(y float64) string {
+		return wallet.FormatAmount(int64(y))
+	}
+	pg.chart.XLabel = func(x float64) string {
+		return values.FormatDate(time.Unix(int64(x), 0))
+	}
+	pg.chart.Tooltip = func(_ int, p decredmaterial.ChartPoint) string {
+		return values.FormatDate(time.Unix(int64(p.X), 0)) + "\n" + wallet.FormatAmount(int64(p.Y))
+	}
+	return pg
+}
+
+func (pg *ticketPricePage) OnResume() {
+	pg.common.wallet.GetTicketPriceForecast()
+	pg.update()
+}
+
+// update charts the price history in the selected range up to the block the
+// forecast is from.
+func (pg *ticketPricePage) update() {
+	pg.loaded = *pg.forecast
+	forecast := pg.loaded
+	pg.chart.Series = nil
+
+	now := forecast.BlockTime
+	var since time.Time
+	if duration := balanceRanges[pg.selectedRange].duration; duration > 0 {
+		since = now.Add(-duration)
+	}
+	var points []decredmaterial.ChartPoint
+	for _, p := range forecast.History {
+		if p.Time.Before(since) {
+			continue
+		}
+		points = append(points, decredmaterial.ChartPoint{X: float64(p.Time.Unix()), Y: float64(p.Price)})
+	}
+	if len(points) == 0 {
+		return
+	}
+
+	current := decredmaterial.ChartPoint{X: float64(now.Unix()), Y: float64(forecast.Price)}
+	pg.chart.Series = []decredmaterial.ChartSeries{{
+		Name:   values.String(values.StrTicketPrice),
+		Points: append(points, current),
+	}}
+}
+
+func (pg *ticketPricePage) handle() {
+	if pg.loaded != *pg.forecast {
+		pg.update()
+	}
+
+	for i := range pg.rangeButtons {
+		for pg.rangeButtons[i].Button.Clicked() {
+			pg.selectedRange = i
+			pg.update()
+		}
+	}
+}
+
+func (pg *ticketPricePage) onClose() {}
+
+// row lays out a detail of the forecast with its label on the left and value
+// on the right.
+func (pg *ticketPricePage) row(gtx C, label, value string) D {
+	return layout.Inset{Bottom: values.MarginPadding10}.Layout(gtx, func(gtx C) D {
+		left := pg.common.theme.Body2(label)
+		left.Color = pg.common.theme.Color.Gray3
+		return endToEndRow(gtx, left.Layout, pg.common.theme.Body1(value).Layout)
+	})
+}
+
+// blocksLeft formats the blocks left at the current price of a forecast.
+func blocksLeft(forecast *wallet.TicketPriceForecast) string {
+	left := int(forecast.BlocksLeft)
+	return values.StringP(values.StrBlocksLeft, left, left, values.FormatDuration(forecast.TimeLeft))
+}
+
+func (pg *ticketPricePage) forecastCard(gtx C) D {
+	common, forecast := pg.common, pg.loaded
+	if forecast.WindowSize == 0 {
+		return pg.card(gtx, func(gtx C) D {
+			txt := common.theme.Body1(values.String(values.StrLoadingForecast))
+			txt.Color = common.theme.Color.Gray2
+			return txt.Layout(gtx)
+		})
+	}
+
+	return pg.card(gtx,
+		func(gtx C) D {
+			return pg.row(gtx, values.String(values.StrCurrentPrice), wallet.FormatAmount(forecast.Price))
+		},
+		func(gtx C) D {
+			return pg.row(gtx, values.String(values.StrPriceChangesIn), blocksLeft(forecast))
+		},
+		func(gtx C) D {
+			note := common.theme.Caption(values.StringF(values.StrForecastNote, forecast.WindowSize))
+			note.Color = common.theme.Color.Gray2
+			return note.Layout(gtx)
+		},
+	)
+}
+
+// card lays out rows in a card.
+func (pg *ticketPricePage) card(gtx C, rows ...layout.Widget) D {
+	return pg.common.theme.Card().Layout(gtx, func(gtx C) D {
+		gtx.Constraints.Min.X = gtx.Constraints.Max.X
+		return Container{layout.UniformInset(values.MarginPadding15)}.Layout(gtx, func(gtx C) D {
+			children := make([]layout.FlexChild, len(rows))
+			for i, row := range rows {
+				children[i] = layout.Rigid(row)
+			}
+			return layout.Flex{Axis: layout.Vertical}.Layout(gtx, children...)
+		})
+	})
+}
+
+func (pg *ticketPricePage) chartCard(gtx C) D {
+	theme := pg.common.theme
+	return theme.Card().Layout(gtx, func(gtx C) D {
+		return Container{layout.UniformInset(values.MarginPadding15)}.Layout(gtx, func(gtx C) D {
+			return layout.Flex{Axis: layout.Vertical}.Layout(gtx,
+				layout.Rigid(func(gtx C) D {
+					title := theme.Body2(values.String(values.StrTicketPriceHistory))
+					title.Color = theme.Color.Gray3
+					return endToEndRow(gtx, title.Layout, func(gtx C) D {
+						return layoutRangeButtons(gtx, theme, pg.rangeButtons, pg.selectedRange)
+					})
+				}),
+				layout.Rigid(func(gtx C) D {
+					if len(pg.chart.Series) == 0 {
+						message := theme.Body1(values.String(values.StrNoPriceHistory))
+						message.Color = theme.Color.Gray2
+						return layout.Inset{Top: values.MarginPadding10}.Layout(gtx, message.Layout)
+					}
+					return layout.Inset{Top: values.MarginPadding10}.Layout(gtx, pg.chart.Layout)
+				}),
+			)
+		})
+	})
+}
+
+func (pg *ticketPricePage) Layout(gtx C) D {
+	common := pg.common
+	sections := []layout.Widget{pg.forecastCard, pg.chartCard}
+
+	body := func(gtx C) D {
+		page := SubPage{
+			title:      values.String(values.StrTicketPriceForecast),
+			backButton: pg.backButton,
+			back: func() {
+				common.changePage(PageTickets)
+			},
+			body: func(gtx C) D {
+				return pg.container.Layout(gtx, len(sections), func(gtx C, i int) D {
+					return layout.Inset{Bottom: values.MarginPadding10}.Layout(gtx, sections[i])
+				})
+			},
+		}
+		return common.SubPageLayout(gtx, page)
+	}
+
+	return common.UniformPadding(gtx, body)
+}
@@ -0,0 +1,83 @@
+package ui
+
+import (
+	"image/color"
+
+	"github.com/planetdecred/godcr/ui/values"
+	"github.com/planetdecred/godcr/wallet"
+)
+
+// vspStatusText returns the text and color of the VSP status of a ticket, and
+// false if the VSPs were not asked about the ticket.
+func vspStatusText(common *pageCommon, ticket wallet.Ticket) (string, color.NRGBA, bool) {
+	status := ticket.VSP
+	if status == nil {
+		return "", color.NRGBA{}, false
+	}
+
+	text := values.String(values.StrNoVSP)
+	if status.Host != "" {
+		text = values.StringF(values.StrVSPFeeValue, vspFeeStatusText(status.FeeStatus))
+	}
+	if wallet.IsUnvoted(ticket) {
+		return text, common.theme.Color.Danger, true
+	}
+	return text, common.theme.Color.Gray2, true
+}
+
+// unvotedTickets returns the tickets of every wallet that no VSP is voting, in
+// the order of the wallet list.
+func (common *pageCommon) unvotedTickets(tickets *wallet.Tickets) []wallet.Ticket {
+	var unvoted []wallet.Ticket
+	for _, wal := range common.sortedWalletList() {
+		unvoted = append(unvoted, wallet.UnvotedTickets(tickets.Confirmed[wal.ID])...)
+	}
+	return unvoted
+}
+
+// checkVSPTickets asks for the spending passphrase of a wallet and asks the
+// VSPs about its tickets, then reloads the tickets to show their status.
+func (common *pageCommon) checkVSPTickets(walletID int, walletName string) {
+	newPasswordModal(common).
+		title(values.StringF(values.StrCheckVSPStatusOf, walletName)).
+		negativeButton(values.String(values.StrCancel), func() {}).
+		positiveButton(values.String(values.StrCheckVSPStatus), func(password string, pm *passwordModal) bool {
+			go func() {
+				checked, err := common.wallet.CheckVSPTickets(walletID, []byte(password))
+				if err != nil {
+					pm.setError(err.Error())
+					pm.setLoading(false)
+					return
+				}
+				pm.Dismiss()
+				common.notify(values.StringF(values.StrVSPTicketsChecked, checked, walletName), true)
+				common.wallet.GetAllTickets()
+			}()
+			return false
+		}).Show()
+}
+
+// retryVSPFee asks for the spending passphrase of the wallet of a ticket and
+// pays the fee of the ticket to the VSP at host, then reloads the tickets,
+// transactions and balances to show the payment.
+func (common *pageCommon) retryVSPFee(walletID int, ticket wallet.Ticket, host string) {
+	newPasswordModal(common).
+		title(values.StringF(values.StrPayVSPFee, host)).
+		negativeButton(values.String(values.StrCancel), func() {}).
+		positiveButton(values.String(values.StrPay), func(password string, pm *passwordModal) bool {
+			go func() {
+				err := common.wallet.RetryVSPFee(walletID, ticket, host, []byte(password))
+				if err != nil {
+					pm.setError(err.Error())
+					pm.setLoading(false)
+					return
+				}
+				pm.Dismiss()
+				common.notify(values.StringF(values.StrVSPFeeRetried, host), true)
+				common.wallet.GetAllTickets()
+				common.wallet.GetAllTransactions(0, 0, 0)
+				common.wallet.GetMultiWalletInfo()
+			}()
+			return false
+		}).Show()
+}
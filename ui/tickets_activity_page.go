@@ -5,7 +5,6 @@ import (
 	"strings"
 	"time"
 
-	"github.com/planetdecred/dcrlibwallet"
 	"github.com/planetdecred/godcr/wallet"
 
 	"gioui.org/layout"
@@ -27,9 +26,10 @@ type ticketsActivityPage struct {
 	walletDropDown     *decredmaterial.DropDown
 	common             *pageCommon
 
-	wallets []*dcrlibwallet.Wallet
+	wallets []*wallet.WalletSummary
 
 	backButton decredmaterial.IconButton
+	clicks     ticketClicks
 }
 
 func TicketActivityPage(c *pageCommon) Page {
@@ -38,7 +38,7 @@ func TicketActivityPage(c *pageCommon) Page {
 		common:      c,
 		tickets:     c.walletTickets,
 		ticketsList: layout.List{Axis: layout.Vertical},
-		wallets:     c.multiWallet.AllWallets(),
+		wallets:     c.wallet.AllWallets(),
 	}
 	pg.orderDropDown = createOrderDropDown(c)
 	pg.ticketTypeDropDown = c.theme.DropDown([]decredmaterial.DropDownItem{
@@ -93,7 +93,9 @@ func (pg *ticketsActivityPage) Layout(gtx layout.Context) layout.Dimensions {
 								}
 								return layout.UniformInset(values.MarginPadding16).Layout(gtx, func(gtx C) D {
 									return pg.ticketsList.Layout(gtx, len(tickets), func(gtx C, index int) D {
-										return ticketActivityRow(gtx, c, tickets[index], index)
+										return pg.clicks.layout(gtx, tickets, index, func(gtx C) D {
+											return ticketActivityRow(gtx, c, tickets[index], index)
+										})
 									})
 								})
 							})
@@ -149,6 +151,7 @@ func filterTickets(tickets []wallet.Ticket, f func(string) bool) []wallet.Ticket
 }
 
 func (pg *ticketsActivityPage) handle() {
+	pg.clicks.handle(pg.common, PageTicketsActivity)
 
 	sortSelection := pg.orderDropDown.SelectedIndex()
 	if pg.filterSorter != sortSelection {
@@ -6,7 +6,6 @@ import (
 	"strings"
 	"time"
 
-	"github.com/planetdecred/dcrlibwallet"
 	"github.com/planetdecred/godcr/wallet"
 
 	"gioui.org/layout"
@@ -32,9 +31,10 @@ type ticketPageList struct {
 	common             *pageCommon
 	statusTooltips     []*decredmaterial.Tooltip
 
-	wallets []*dcrlibwallet.Wallet
+	wallets []*wallet.WalletSummary
 
 	backButton decredmaterial.IconButton
+	clicks     ticketClicks
 }
 
 func TicketPageList(c *pageCommon) Page {
@@ -46,7 +46,7 @@ func TicketPageList(c *pageCommon) Page {
 		toggleViewType: new(widget.Clickable),
 		isGridView:     true,
 
-		wallets: c.multiWallet.AllWallets(),
+		wallets: c.wallet.AllWallets(),
 	}
 	pg.backButton, _ = c.SubPageHeaderButtons()
 
@@ -200,88 +200,99 @@ func (pg *ticketPageList) ticketListLayout(gtx layout.Context, c *pageCommon, ti
 			return layout.Dimensions{}
 		}
 
-		return layout.Flex{Alignment: layout.Middle}.Layout(gtx,
-			layout.Rigid(func(gtx C) D {
-				return layout.Inset{Right: values.MarginPadding16}.Layout(gtx, func(gtx C) D {
-					var progressBarWidth int
-					return layout.Stack{Alignment: layout.S}.Layout(gtx,
-						layout.Stacked(func(gtx C) D {
-							wrapIcon := c.theme.Card()
-							wrapIcon.Color = st.background
-							wrapIcon.Radius = decredmaterial.CornerRadius{NE: 8, NW: 8, SE: 8, SW: 8}
-							st.icon.Scale = 0.6
-							dims := wrapIcon.Layout(gtx, func(gtx C) D {
-								return layout.UniformInset(values.MarginPadding10).Layout(gtx, st.icon.Layout)
-							})
-							progressBarWidth = dims.Size.X
-							return dims
+		return pg.clicks.layout(gtx, tickets, index, func(gtx C) D {
+			return layout.Flex{Alignment: layout.Middle}.Layout(gtx,
+				layout.Rigid(func(gtx C) D {
+					return layout.Inset{Right: values.MarginPadding16}.Layout(gtx, func(gtx C) D {
+						var progressBarWidth int
+						return layout.Stack{Alignment: layout.S}.Layout(gtx,
+							layout.Stacked(func(gtx C) D {
+								wrapIcon := c.theme.Card()
+								wrapIcon.Color = st.background
+								wrapIcon.Radius = decredmaterial.CornerRadius{NE: 8, NW: 8, SE: 8, SW: 8}
+								st.icon.Scale = 0.6
+								dims := wrapIcon.Layout(gtx, func(gtx C) D {
+									return layout.UniformInset(values.MarginPadding10).Layout(gtx, st.icon.Layout)
+								})
+								progressBarWidth = dims.Size.X
+								return dims
+							}),
+							layout.Stacked(func(gtx C) D {
+								gtx.Constraints.Max.X = progressBarWidth - 4
+								p := c.theme.ProgressBar(20)
+								p.Height, p.Radius = values.MarginPadding4, values.MarginPadding2
+								p.Color = st.color
+								return p.Layout(gtx)
+							}),
+						)
+					})
+				}),
+				layout.Rigid(func(gtx C) D {
+					return layout.Flex{Axis: layout.Vertical}.Layout(gtx,
+						layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+							if index == 0 {
+								return layout.Dimensions{}
+							}
+							gtx.Constraints.Min.X = gtx.Constraints.Max.X
+							separator := pg.th.Separator()
+							separator.Width = gtx.Constraints.Max.X
+							return layout.E.Layout(gtx, separator.Layout)
 						}),
-						layout.Stacked(func(gtx C) D {
-							gtx.Constraints.Max.X = progressBarWidth - 4
-							p := c.theme.ProgressBar(20)
-							p.Height, p.Radius = values.MarginPadding4, values.MarginPadding2
-							p.Color = st.color
-							return p.Layout(gtx)
+						layout.Rigid(func(gtx C) D {
+							return layout.Inset{
+								Top:    values.MarginPadding6,
+								Bottom: values.MarginPadding10,
+							}.Layout(gtx, func(gtx C) D {
+								return layout.Flex{Axis: layout.Vertical}.Layout(gtx,
+									layout.Rigid(func(gtx C) D {
+										dtime := c.theme.Label(values.TextSize14, values.FormatDateTime(time.Unix(tickets[index].Info.Ticket.Timestamp, 0)))
+										dtime.Color = c.theme.Color.Gray2
+										return endToEndRow(gtx, func(gtx C) D { return c.layoutBalance(gtx, tickets[index].Amount, true) }, dtime.Layout)
+									}),
+									layout.Rigid(func(gtx C) D {
+										l := func(gtx C) layout.Dimensions {
+											return layout.Flex{Alignment: layout.Middle}.Layout(gtx,
+												layout.Rigid(func(gtx C) D {
+													txt := c.theme.Label(values.MarginPadding14, tickets[index].Info.Status)
+													txt.Color = st.color
+													return txt.Layout(gtx)
+												}),
+												layout.Rigid(func(gtx C) D {
+													return layout.Inset{
+														Left:  values.MarginPadding4,
+														Right: values.MarginPadding4,
+													}.Layout(gtx, func(gtx C) D {
+														ic := c.icons.imageBrightness1
+														ic.Color = c.theme.Color.Gray2
+														return c.icons.imageBrightness1.Layout(gtx, values.MarginPadding5)
+													})
+												}),
+												layout.Rigid(c.theme.Label(values.MarginPadding14, tickets[index].WalletName).Layout),
+												layout.Rigid(func(gtx C) D {
+													text, col, ok := vspStatusText(c, tickets[index])
+													if !ok {
+														return layout.Dimensions{}
+													}
+													txt := c.theme.Label(values.MarginPadding14, text)
+													txt.Color = col
+													return layout.Inset{Left: values.MarginPadding8}.Layout(gtx, txt.Layout)
+												}),
+											)
+										}
+										r := func(gtx C) layout.Dimensions {
+											txt := c.theme.Label(values.TextSize14, tickets[index].DaysBehind)
+											txt.Color = c.theme.Color.Gray2
+											return txt.Layout(gtx)
+										}
+										return endToEndRow(gtx, l, r)
+									}),
+								)
+							})
 						}),
 					)
-				})
-			}),
-			layout.Rigid(func(gtx C) D {
-				return layout.Flex{Axis: layout.Vertical}.Layout(gtx,
-					layout.Rigid(func(gtx layout.Context) layout.Dimensions {
-						if index == 0 {
-							return layout.Dimensions{}
-						}
-						gtx.Constraints.Min.X = gtx.Constraints.Max.X
-						separator := pg.th.Separator()
-						separator.Width = gtx.Constraints.Max.X
-						return layout.E.Layout(gtx, separator.Layout)
-					}),
-					layout.Rigid(func(gtx C) D {
-						return layout.Inset{
-							Top:    values.MarginPadding6,
-							Bottom: values.MarginPadding10,
-						}.Layout(gtx, func(gtx C) D {
-							return layout.Flex{Axis: layout.Vertical}.Layout(gtx,
-								layout.Rigid(func(gtx C) D {
-									dtime := c.theme.Label(values.TextSize14, tickets[index].DateTime)
-									dtime.Color = c.theme.Color.Gray2
-									return endToEndRow(gtx, func(gtx C) D { return c.layoutBalance(gtx, tickets[index].Amount, true) }, dtime.Layout)
-								}),
-								layout.Rigid(func(gtx C) D {
-									l := func(gtx C) layout.Dimensions {
-										return layout.Flex{Alignment: layout.Middle}.Layout(gtx,
-											layout.Rigid(func(gtx C) D {
-												txt := c.theme.Label(values.MarginPadding14, tickets[index].Info.Status)
-												txt.Color = st.color
-												return txt.Layout(gtx)
-											}),
-											layout.Rigid(func(gtx C) D {
-												return layout.Inset{
-													Left:  values.MarginPadding4,
-													Right: values.MarginPadding4,
-												}.Layout(gtx, func(gtx C) D {
-													ic := c.icons.imageBrightness1
-													ic.Color = c.theme.Color.Gray2
-													return c.icons.imageBrightness1.Layout(gtx, values.MarginPadding5)
-												})
-											}),
-											layout.Rigid(c.theme.Label(values.MarginPadding14, tickets[index].WalletName).Layout),
-										)
-									}
-									r := func(gtx C) layout.Dimensions {
-										txt := c.theme.Label(values.TextSize14, tickets[index].DaysBehind)
-										txt.Color = c.theme.Color.Gray2
-										return txt.Layout(gtx)
-									}
-									return endToEndRow(gtx, l, r)
-								}),
-							)
-						})
-					}),
-				)
-			}),
-		)
+				}),
+			)
+		})
 	})
 }
 
@@ -300,7 +311,9 @@ func (pg *ticketPageList) ticketListGridLayout(gtx layout.Context, c *pageCommon
 						Right:  values.MarginPadding4,
 						Bottom: values.MarginPadding8,
 					}.Layout(gtx, func(gtx C) D {
-						return ticketCard(gtx, c, &tickets[index], pg.statusTooltips[index])
+						return pg.clicks.layout(gtx, tickets, index, func(gtx C) D {
+							return ticketCard(gtx, c, &tickets[index], pg.statusTooltips[index])
+						})
 					})
 				})
 			})
@@ -318,6 +331,7 @@ func (pg *ticketPageList) initTicketTooltips(common pageCommon) {
 }
 
 func (pg *ticketPageList) handle() {
+	pg.clicks.handle(pg.common, PageTicketsList)
 
 	if pg.toggleViewType.Clicked() {
 		pg.isGridView = !pg.isGridView
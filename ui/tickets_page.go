@@ -13,7 +13,6 @@ import (
 	"gioui.org/io/pointer"
 	"gioui.org/widget"
 	"gioui.org/widget/material"
-	"github.com/decred/dcrd/dcrutil"
 	"github.com/planetdecred/dcrlibwallet"
 	"github.com/planetdecred/godcr/ui/values"
 	"github.com/planetdecred/godcr/wallet"
@@ -26,7 +25,7 @@ const PageTickets = "Tickets"
 
 type ticketPage struct {
 	th     *decredmaterial.Theme
-	wal    *wallet.Wallet
+	wal    wallet.Backend
 	vspd   *dcrlibwallet.VSP
 	common *pageCommon
 
@@ -41,6 +40,7 @@ type ticketPage struct {
 	submitPurchase        decredmaterial.Button
 	tickets               **wallet.Tickets
 	ticketPrice           string
+	forecast              **wallet.TicketPriceForecast
 	totalCost             string
 	remainingBalance      string
 	ticketAmount          decredmaterial.Editor
@@ -52,6 +52,10 @@ type ticketPage struct {
 	autoPurchaseEnabled     *widget.Bool
 	toTickets               decredmaterial.TextAndIconButton
 	toTicketsActivity       decredmaterial.TextAndIconButton
+	toStakingRewards        decredmaterial.TextAndIconButton
+	toVSPs                  decredmaterial.TextAndIconButton
+	toAgendas               decredmaterial.TextAndIconButton
+	toTicketPrice           decredmaterial.TextAndIconButton
 	purchaseErrChan         chan error
 
 	vspInfo          **wallet.VSP
@@ -67,14 +71,25 @@ type ticketPage struct {
 	vspErrChan       chan error
 
 	isPurchaseLoading bool
+
+	// loadedTickets are the tickets rewardsEarned and unvoted were found
+	// from.
+	loadedTickets *wallet.Tickets
+	rewardsEarned string
+	unvoted       []wallet.Ticket
+
+	liveClicks     ticketClicks
+	activityClicks ticketClicks
+	unvotedClicks  ticketClicks
 }
 
 func TicketPage(c *pageCommon) Page {
 	pg := &ticketPage{
-		th:      c.theme,
-		wal:     c.wallet,
-		tickets: c.walletTickets,
-		common:  c,
+		th:       c.theme,
+		wal:      c.wallet,
+		tickets:  c.walletTickets,
+		forecast: c.priceForecast,
+		common:   c,
 
 		ticketsLive:           layout.List{Axis: layout.Horizontal},
 		ticketsActivity:       layout.List{Axis: layout.Vertical},
@@ -87,6 +102,10 @@ func TicketPage(c *pageCommon) Page {
 		autoPurchaseEnabled:   new(widget.Bool),
 		toTickets:             c.theme.TextAndIconButton(new(widget.Clickable), "See All", c.icons.navigationArrowForward),
 		toTicketsActivity:     c.theme.TextAndIconButton(new(widget.Clickable), "See All", c.icons.navigationArrowForward),
+		toStakingRewards:      c.theme.TextAndIconButton(new(widget.Clickable), "See All", c.icons.navigationArrowForward),
+		toVSPs:                c.theme.TextAndIconButton(new(widget.Clickable), values.String(values.StrManageVSPs), c.icons.navigationArrowForward),
+		toAgendas:             c.theme.TextAndIconButton(new(widget.Clickable), values.String(values.StrSetVotes), c.icons.navigationArrowForward),
+		toTicketPrice:         c.theme.TextAndIconButton(new(widget.Clickable), values.String(values.StrPriceForecast), c.icons.navigationArrowForward),
 		purchaseOptions:       c.theme.Modal(),
 		ticketAmount:          c.theme.Editor(new(widget.Editor), ""),
 		purchaseErrChan:       make(chan error),
@@ -100,6 +119,7 @@ func TicketPage(c *pageCommon) Page {
 		vspErrChan:            make(chan error),
 	}
 	pg.ticketAmount.Editor.SetText("1")
+	pg.rewardsEarned = wallet.FormatAmount(0)
 
 	pg.purchaseTicket.TextSize = values.TextSize12
 	pg.purchaseTicket.Background = c.theme.Color.Primary
@@ -115,6 +135,18 @@ func TicketPage(c *pageCommon) Page {
 	pg.toTicketsActivity.Color = c.theme.Color.Primary
 	pg.toTicketsActivity.BackgroundColor = c.theme.Color.Surface
 
+	pg.toStakingRewards.Color = c.theme.Color.Primary
+	pg.toStakingRewards.BackgroundColor = c.theme.Color.Surface
+
+	pg.toVSPs.Color = c.theme.Color.Primary
+	pg.toVSPs.BackgroundColor = c.theme.Color.Surface
+
+	pg.toAgendas.Color = c.theme.Color.Primary
+	pg.toAgendas.BackgroundColor = c.theme.Color.Surface
+
+	pg.toTicketPrice.Color = c.theme.Color.Primary
+	pg.toTicketPrice.BackgroundColor = c.theme.Color.Surface
+
 	pg.purchaseAccountSelector = newAccountSelector(c).
 		title("Purchasing account").
 		accountSelected(func(selectedAccount *dcrlibwallet.Account) {
@@ -123,15 +155,15 @@ func TicketPage(c *pageCommon) Page {
 			}
 		}).
 		accountValidator(func(account *dcrlibwallet.Account) bool {
-			wal := pg.common.multiWallet.WalletWithID(account.WalletID)
+			wal := pg.common.wallet.WalletWithID(account.WalletID)
 
 			// Imported and watch only wallet accounts are invalid for sending
-			accountIsValid := account.Number != MaxInt32 && !wal.IsWatchingOnlyWallet()
+			accountIsValid := account.Number != MaxInt32 && !wal.IsWatchingOnly
 
-			if wal.ReadBoolConfigValueForKey(dcrlibwallet.AccountMixerConfigSet, false) {
+			if pg.common.wallet.IsAccountMixerConfigSet(account.WalletID) {
 				// privacy is enabled for selected wallet
 
-				accountIsValid = account.Number == wal.MixedAccountNumber()
+				accountIsValid = account.Number == pg.common.wallet.MixedAccountNumber(account.WalletID)
 			}
 			return accountIsValid
 		})
@@ -141,6 +173,7 @@ func TicketPage(c *pageCommon) Page {
 
 func (pg *ticketPage) OnResume() {
 	pg.purchaseAccountSelector.selectFirstWalletValidAccount()
+	pg.common.wallet.GetTicketPriceForecast()
 }
 
 func (pg *ticketPage) Layout(gtx layout.Context) layout.Dimensions {
@@ -150,6 +183,9 @@ func (pg *ticketPage) Layout(gtx layout.Context) layout.Dimensions {
 			func(ctx layout.Context) layout.Dimensions {
 				return pg.ticketPriceSection(gtx, c)
 			},
+			func(ctx layout.Context) layout.Dimensions {
+				return pg.unvotedSection(gtx, c)
+			},
 			func(ctx layout.Context) layout.Dimensions {
 				return pg.ticketsLiveSection(gtx, c)
 			},
@@ -159,6 +195,9 @@ func (pg *ticketPage) Layout(gtx layout.Context) layout.Dimensions {
 			func(ctx layout.Context) layout.Dimensions {
 				return pg.stackingRecordSection(gtx, c)
 			},
+			func(ctx layout.Context) layout.Dimensions {
+				return pg.agendasSection(gtx, c)
+			},
 		}
 
 		return pg.ticketPageContainer.Layout(gtx, len(sections), func(gtx C, i int) D {
@@ -208,7 +247,14 @@ func (pg *ticketPage) ticketPriceSection(gtx layout.Context, c *pageCommon) layo
 				}.Layout(gtx, func(gtx C) D {
 					tit := c.theme.Label(values.TextSize14, "Ticket Price")
 					tit.Color = c.theme.Color.Gray2
-					return pg.titleRow(gtx, tit.Layout, material.Switch(pg.th.Base, pg.autoPurchaseEnabled).Layout)
+					return pg.titleRow(gtx, tit.Layout, func(gtx C) D {
+						return layout.Flex{Alignment: layout.Middle}.Layout(gtx,
+							layout.Rigid(pg.toTicketPrice.Layout),
+							layout.Rigid(func(gtx C) D {
+								return layout.Inset{Left: values.MarginPadding8}.Layout(gtx, material.Switch(pg.th.Base, pg.autoPurchaseEnabled).Layout)
+							}),
+						)
+					})
 				})
 			}),
 			layout.Rigid(func(gtx C) D {
@@ -225,7 +271,7 @@ func (pg *ticketPage) ticketPriceSection(gtx layout.Context, c *pageCommon) layo
 					Bottom: values.MarginPadding16,
 				}.Layout(gtx, func(gtx C) D {
 					return layout.Center.Layout(gtx, func(gtx C) D {
-						mainText, subText := breakBalance(c.printer, pg.ticketPrice)
+						mainText, subText := breakBalance(pg.ticketPrice)
 						return layout.Flex{Axis: layout.Horizontal, Alignment: layout.Baseline}.Layout(gtx,
 							layout.Rigid(func(gtx C) D {
 								label := pg.th.Label(values.TextSize28, mainText)
@@ -241,11 +287,30 @@ func (pg *ticketPage) ticketPriceSection(gtx layout.Context, c *pageCommon) layo
 					})
 				})
 			}),
+			layout.Rigid(func(gtx C) D {
+				return pg.forecastNotes(gtx, c)
+			}),
 			layout.Rigid(pg.purchaseTicket.Layout),
 		)
 	})
 }
 
+// forecastNotes lays out when the ticket price changes, once the forecast is
+// loaded.
+func (pg *ticketPage) forecastNotes(gtx layout.Context, c *pageCommon) layout.Dimensions {
+	forecast := *pg.forecast
+	if forecast.WindowSize == 0 {
+		return layout.Dimensions{}
+	}
+
+	note := c.theme.Caption(values.StringF(values.StrNewPriceIn, blocksLeft(forecast)))
+	note.Color = c.theme.Color.Gray2
+	return layout.Inset{Bottom: values.MarginPadding16}.Layout(gtx, func(gtx C) D {
+		gtx.Constraints.Min.X = gtx.Constraints.Max.X
+		return layout.Center.Layout(gtx, note.Layout)
+	})
+}
+
 func (pg *ticketPage) ticketsLiveSection(gtx layout.Context, c *pageCommon) layout.Dimensions {
 	return pg.pageSections(gtx, func(gtx C) D {
 		return layout.Flex{Axis: layout.Vertical}.Layout(gtx,
@@ -289,7 +354,9 @@ func (pg *ticketPage) ticketsLiveSection(gtx layout.Context, c *pageCommon) layo
 				tickets := (*pg.tickets).LiveRecent
 				return pg.ticketsLive.Layout(gtx, len(tickets), func(gtx C, index int) D {
 					return layout.Inset{Right: values.MarginPadding8}.Layout(gtx, func(gtx C) D {
-						return ticketCard(gtx, c, &tickets[index], c.theme.Tooltip())
+						return pg.liveClicks.layout(gtx, tickets, index, func(gtx C) D {
+							return ticketCard(gtx, c, &tickets[index], c.theme.Tooltip())
+						})
 					})
 				})
 			}),
@@ -297,6 +364,56 @@ func (pg *ticketPage) ticketsLiveSection(gtx layout.Context, c *pageCommon) layo
 	})
 }
 
+func (pg *ticketPage) unvotedSection(gtx layout.Context, c *pageCommon) layout.Dimensions {
+	if len(pg.unvoted) == 0 {
+		return layout.Dimensions{}
+	}
+
+	return pg.pageSections(gtx, func(gtx C) D {
+		children := []layout.FlexChild{
+			layout.Rigid(func(gtx C) D {
+				tit := c.theme.Label(values.TextSize14, values.String(values.StrUnvotedTickets))
+				tit.Color = c.theme.Color.Danger
+				return tit.Layout(gtx)
+			}),
+			layout.Rigid(func(gtx C) D {
+				return layout.Inset{Top: values.MarginPadding4, Bottom: values.MarginPadding8}.Layout(gtx, func(gtx C) D {
+					note := c.theme.Caption(values.String(values.StrUnvotedNote))
+					note.Color = c.theme.Color.Gray2
+					return note.Layout(gtx)
+				})
+			}),
+		}
+		for i := range pg.unvoted {
+			i, ticket := i, pg.unvoted[i]
+			children = append(children, layout.Rigid(func(gtx C) D {
+				return pg.unvotedClicks.layout(gtx, pg.unvoted, i, func(gtx C) D {
+					return layout.Inset{Top: values.MarginPadding8}.Layout(gtx, func(gtx C) D {
+						left := func(gtx C) D {
+							return layout.Flex{Axis: layout.Vertical}.Layout(gtx,
+								layout.Rigid(pg.th.Label(values.TextSize16, ticket.WalletName).Layout),
+								layout.Rigid(func(gtx C) D {
+									txt := pg.th.Label(values.TextSize14, strings.Title(strings.ToLower(ticket.Info.Status))+" · "+ticket.Amount)
+									txt.Color = pg.th.Color.Gray2
+									return txt.Layout(gtx)
+								}),
+							)
+						}
+						right := func(gtx C) D {
+							text, col, _ := vspStatusText(c, ticket)
+							txt := pg.th.Label(values.TextSize14, text)
+							txt.Color = col
+							return txt.Layout(gtx)
+						}
+						return endToEndRow(gtx, left, right)
+					})
+				})
+			}))
+		}
+		return layout.Flex{Axis: layout.Vertical}.Layout(gtx, children...)
+	})
+}
+
 func (pg *ticketPage) ticketsActivitySection(gtx layout.Context, c *pageCommon) layout.Dimensions {
 	tickets := (*pg.tickets).RecentActivity
 	if len(tickets) == 0 {
@@ -316,7 +433,28 @@ func (pg *ticketPage) ticketsActivitySection(gtx layout.Context, c *pageCommon)
 			}),
 			layout.Rigid(func(gtx C) D {
 				return pg.ticketsActivity.Layout(gtx, len(tickets), func(gtx C, index int) D {
-					return ticketActivityRow(gtx, c, tickets[index], index)
+					return pg.activityClicks.layout(gtx, tickets, index, func(gtx C) D {
+						return ticketActivityRow(gtx, c, tickets[index], index)
+					})
+				})
+			}),
+		)
+	})
+}
+
+func (pg *ticketPage) agendasSection(gtx layout.Context, c *pageCommon) layout.Dimensions {
+	return pg.pageSections(gtx, func(gtx C) D {
+		return layout.Flex{Axis: layout.Vertical}.Layout(gtx,
+			layout.Rigid(func(gtx C) D {
+				tit := c.theme.Label(values.TextSize14, values.String(values.StrConsensusVotes))
+				tit.Color = c.theme.Color.Gray2
+				return pg.titleRow(gtx, tit.Layout, pg.toAgendas.Layout)
+			}),
+			layout.Rigid(func(gtx C) D {
+				return layout.Inset{Top: values.MarginPadding4}.Layout(gtx, func(gtx C) D {
+					note := c.theme.Caption(values.String(values.StrConsensusVotesNote))
+					note.Color = c.theme.Color.Gray2
+					return note.Layout(gtx)
 				})
 			}),
 		)
@@ -332,7 +470,7 @@ func (pg *ticketPage) stackingRecordSection(gtx layout.Context, c *pageCommon) l
 				}.Layout(gtx, func(gtx C) D {
 					tit := c.theme.Label(values.TextSize14, "Staking Record")
 					tit.Color = c.theme.Color.Gray2
-					return pg.titleRow(gtx, tit.Layout, func(gtx C) D { return layout.Dimensions{} })
+					return pg.titleRow(gtx, tit.Layout, pg.toStakingRewards.Layout)
 				})
 			}),
 			layout.Rigid(func(gtx C) D {
@@ -403,7 +541,7 @@ func (pg *ticketPage) stackingRecordSection(gtx layout.Context, c *pageCommon) l
 											return ic.Layout(gtx)
 										}),
 										layout.Rigid(func(gtx C) D {
-											return c.layoutBalance(gtx, "16.5112316", false)
+											return c.layoutBalance(gtx, pg.rewardsEarned, false)
 										}),
 									)
 								}),
@@ -512,7 +650,7 @@ func (pg *ticketPage) confirmPurchaseModal(gtx layout.Context) layout.Dimensions
 					tleft := pg.th.Label(values.TextSize14, "Account")
 					tleft.Color = pg.th.Color.Gray2
 					selectedAccount := pg.purchaseAccountSelector.selectedAccount
-					selectedWallet := pg.common.multiWallet.WalletWithID(selectedAccount.WalletID)
+					selectedWallet := pg.common.wallet.WalletWithID(selectedAccount.WalletID)
 					tright := pg.th.Label(values.TextSize14, selectedWallet.Name)
 					return endToEndRow(gtx, tleft.Layout, tright.Layout)
 				}),
@@ -603,7 +741,7 @@ func (pg *ticketPage) vspHostSelectorLayout(gtx C, c *pageCommon) layout.Dimensi
 func (pg *ticketPage) vspHostModalLayout(gtx C, c *pageCommon) layout.Dimensions {
 	return pg.purchaseOptions.Layout(gtx, []layout.Widget{
 		func(gtx C) D {
-			return pg.th.Label(values.TextSize20, "Voting service provider").Layout(gtx)
+			return pg.titleRow(gtx, pg.th.Label(values.TextSize20, "Voting service provider").Layout, pg.toVSPs.Layout)
 		},
 		func(gtx C) D {
 			return layout.Flex{Axis: layout.Vertical}.Layout(gtx,
@@ -617,7 +755,7 @@ func (pg *ticketPage) vspHostModalLayout(gtx C, c *pageCommon) layout.Dimensions
 					})
 				}),
 				layout.Rigid(func(gtx C) D {
-					listVSP := (*pg.vspInfo).List
+					listVSP := (*pg.vspInfo).Usable()
 					return pg.vspHosts.Layout(gtx, len(listVSP), func(gtx C, i int) D {
 						click := pg.selectVSP[i]
 						pointer.Rect(image.Rectangle{Max: gtx.Constraints.Max}).Add(gtx.Ops)
@@ -704,8 +842,8 @@ func (pg *ticketPage) calculateAndValidCost(c *pageCommon) bool {
 	}
 
 	pg.reviewPurchase.Background = pg.th.Color.Primary
-	pg.totalCost = dcrutil.Amount(total).String()
-	pg.remainingBalance = dcrutil.Amount(remaining).String()
+	pg.totalCost = wallet.FormatAmount(total)
+	pg.remainingBalance = wallet.FormatAmount(remaining)
 	return true
 }
 
@@ -737,8 +875,8 @@ func (pg *ticketPage) handle() {
 		c.wallet.GetAllVSP()
 	}
 
-	if len((*pg.vspInfo).List) != len(pg.selectVSP) {
-		pg.selectVSP = createClickGestures(len((*pg.vspInfo).List))
+	if len((*pg.vspInfo).Usable()) != len(pg.selectVSP) {
+		pg.selectVSP = createClickGestures(len((*pg.vspInfo).Usable()))
 	}
 
 	for _, evt := range pg.ticketAmount.Editor.Events() {
@@ -750,7 +888,7 @@ func (pg *ticketPage) handle() {
 
 	if pg.purchaseTicket.Button.Clicked() {
 		if c.wallet.GetRememberVSP() != "" {
-			for _, vinfo := range (*pg.vspInfo).List {
+			for _, vinfo := range (*pg.vspInfo).Usable() {
 				if vinfo.Host == c.wallet.GetRememberVSP() {
 					pg.selectedVSP = vinfo
 					pg.rememberVSP.CheckBox.Value = true
@@ -816,6 +954,38 @@ func (pg *ticketPage) handle() {
 		c.changePage(PageTicketsActivity)
 	}
 
+	if pg.toStakingRewards.Button.Clicked() {
+		c.changePage(PageStakingRewards)
+	}
+
+	if pg.toAgendas.Button.Clicked() {
+		c.changePage(PageAgendas)
+	}
+
+	if pg.toTicketPrice.Button.Clicked() {
+		c.changePage(PageTicketPrice)
+	}
+
+	if pg.toVSPs.Button.Clicked() {
+		pg.showVSPHosts = false
+		c.changePage(PageVSPs)
+	}
+
+	pg.liveClicks.handle(c, PageTickets)
+	pg.activityClicks.handle(c, PageTickets)
+	pg.unvotedClicks.handle(c, PageTickets)
+
+	if pg.loadedTickets != *pg.tickets {
+		pg.loadedTickets = *pg.tickets
+		var tickets []wallet.Ticket
+		for _, walletTickets := range pg.loadedTickets.Confirmed {
+			tickets = append(tickets, walletTickets...)
+		}
+		pg.rewardsEarned = wallet.FormatAmount(computeStakingStats(tickets).rewards)
+
+		pg.unvoted = c.unvotedTickets(pg.loadedTickets)
+	}
+
 	select {
 	case err := <-pg.vspErrChan:
 		c.notify(err.Error(), false)
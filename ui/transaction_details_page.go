@@ -9,10 +9,10 @@ import (
 	"gioui.org/layout"
 	"gioui.org/widget"
 
-	"github.com/decred/dcrd/dcrutil"
 	"github.com/planetdecred/dcrlibwallet"
 	"github.com/planetdecred/godcr/ui/decredmaterial"
 	"github.com/planetdecred/godcr/ui/values"
+	"github.com/planetdecred/godcr/wallet"
 )
 
 const PageTransactionDetails = "TransactionDetails"
@@ -35,7 +35,7 @@ type transactionDetailsPage struct {
 	gtx                             *layout.Context
 
 	transaction *dcrlibwallet.Transaction
-	wallet      *dcrlibwallet.Wallet
+	wallet      *wallet.WalletSummary
 
 	txSourceAccount      string
 	txDestinationAddress string
@@ -64,7 +64,7 @@ func TransactionDetailsPage(common *pageCommon, transaction *dcrlibwallet.Transa
 		toDcrdata:            new(widget.Clickable),
 
 		transaction: transaction,
-		wallet:      common.multiWallet.WalletWithID(transaction.WalletID),
+		wallet:      common.wallet.WalletWithID(transaction.WalletID),
 	}
 
 	pg.backButton, pg.infoButton = common.SubPageHeaderButtons()
@@ -79,7 +79,7 @@ func TransactionDetailsPage(common *pageCommon, transaction *dcrlibwallet.Transa
 		transaction.Direction == dcrlibwallet.TxDirectionTransferred {
 		for _, input := range transaction.Inputs {
 			if input.AccountNumber != -1 {
-				accountName, err := pg.wallet.AccountName(input.AccountNumber)
+				accountName, err := pg.common.wallet.AccountName(pg.wallet.ID, input.AccountNumber)
 				if err != nil {
 					log.Error(err)
 				} else {
@@ -175,12 +175,12 @@ func (pg *transactionDetailsPage) txnBalanceAndStatus(gtx layout.Context, common
 			layout.Rigid(func(gtx C) D {
 				return layout.Flex{Axis: layout.Vertical}.Layout(gtx,
 					layout.Rigid(func(gtx C) D {
-						amount := strings.Split(dcrutil.Amount(pg.transaction.Amount).String(), " ")
+						amount := wallet.FormatAmountValue(pg.transaction.Amount)
 						return layout.Flex{Axis: layout.Horizontal, Alignment: layout.Baseline}.Layout(gtx,
 							layout.Rigid(func(gtx C) D {
-								return layout.Inset{Right: values.MarginPadding2}.Layout(gtx, common.theme.H4(amount[0]).Layout)
+								return layout.Inset{Right: values.MarginPadding2}.Layout(gtx, common.theme.H4(amount).Layout)
 							}),
-							layout.Rigid(common.theme.H6(amount[1]).Layout),
+							layout.Rigid(common.theme.H6(wallet.AmountUnit()).Layout),
 						)
 					}),
 					layout.Rigid(func(gtx C) D {
@@ -238,7 +238,8 @@ func (pg *transactionDetailsPage) txnBalanceAndStatus(gtx layout.Context, common
 func (pg *transactionDetailsPage) txConfirmations() int32 {
 	transaction := pg.transaction
 	if transaction.BlockHeight != -1 {
-		return (pg.common.multiWallet.WalletWithID(transaction.WalletID).GetBestBlock() - transaction.BlockHeight) + 1
+		bestBlock, _ := pg.common.wallet.WalletBestBlock(transaction.WalletID)
+		return (bestBlock - transaction.BlockHeight) + 1
 	}
 
 	return 0
@@ -262,7 +263,7 @@ func (pg *transactionDetailsPage) txnTypeAndID(gtx layout.Context) layout.Dimens
 			}),
 			layout.Rigid(func(gtx C) D {
 				return layout.Inset{Bottom: m, Top: m}.Layout(gtx, func(gtx C) D {
-					return pg.txnInfoSection(gtx, values.String(values.StrFee), dcrutil.Amount(transaction.Fee).String(), false, nil)
+					return pg.txnInfoSection(gtx, values.String(values.StrFee), wallet.FormatAmount(transaction.Fee), false, nil)
 				})
 			}),
 			layout.Rigid(func(gtx C) D {
@@ -385,7 +386,7 @@ func (pg *transactionDetailsPage) txnIORow(gtx layout.Context, amount int64, acc
 	accountName := "external"
 	walletName := ""
 	if acctNum != -1 {
-		name, err := pg.wallet.AccountName(acctNum)
+		name, err := pg.common.wallet.AccountName(pg.wallet.ID, acctNum)
 		if err == nil {
 			accountName = name
 			walletName = pg.wallet.Name
@@ -393,7 +394,7 @@ func (pg *transactionDetailsPage) txnIORow(gtx layout.Context, amount int64, acc
 	}
 
 	accountName = fmt.Sprintf("(%s)", accountName)
-	amt := dcrutil.Amount(amount).String()
+	amt := wallet.FormatAmount(amount)
 
 	return layout.Inset{Bottom: values.MarginPadding5}.Layout(gtx, func(gtx C) D {
 		card := pg.theme.Card()
@@ -37,7 +37,7 @@ type transactionsPage struct {
 	walletDropDown *decredmaterial.DropDown
 
 	transactions []dcrlibwallet.Transaction
-	wallets      []*dcrlibwallet.Wallet
+	wallets      []*wallet.WalletSummary
 }
 
 func TransactionsPage(common *pageCommon) Page {
@@ -95,7 +95,7 @@ func (pg *transactionsPage) loadTransactions() {
 		txFilter = dcrlibwallet.TxFilterStaking
 	}
 
-	wallTxs, err := selectedWallet.GetTransactionsRaw(0, 0, txFilter, newestFirst) //TODO
+	wallTxs, err := pg.wallet.GetWalletTransactionsRaw(selectedWallet.ID, 0, 0, txFilter, newestFirst) //TODO
 	if err != nil {
 		log.Error("Error loading transactions:", err)
 	} else {
@@ -231,10 +231,10 @@ func (pg *transactionsPage) onClose() {
 func initTxnWidgets(common *pageCommon, transaction *dcrlibwallet.Transaction) transactionWdg {
 
 	var txn transactionWdg
-	t := time.Unix(transaction.Timestamp, 0).UTC()
-	txn.time = common.theme.Body1(t.Format(time.UnixDate))
+	t := time.Unix(transaction.Timestamp, 0)
+	txn.time = common.theme.Body1(values.FormatDateTime(t))
 	txn.status = common.theme.Body1("")
-	txn.wallet = common.theme.Body2(common.multiWallet.WalletWithID(transaction.WalletID).Name)
+	txn.wallet = common.theme.Body2(common.wallet.WalletWithID(transaction.WalletID).Name)
 
 	if txConfirmations(common, *transaction) > 1 {
 		txn.status.Text = formatDateOrTime(transaction.Timestamp)
@@ -12,7 +12,6 @@ import (
 	"path"
 	"path/filepath"
 	"runtime"
-	"strconv"
 	"strings"
 	"time"
 
@@ -22,7 +21,6 @@ import (
 	"github.com/planetdecred/godcr/ui/decredmaterial"
 	"github.com/planetdecred/godcr/ui/values"
 	"github.com/planetdecred/godcr/wallet"
-	"golang.org/x/text/message"
 )
 
 const Uint32Size = 32 << (^uint32(0) >> 32 & 1) // 32 or 64
@@ -58,10 +56,10 @@ func generateRandomNumber() int {
 }
 
 // getLockWallet returns a list of locked wallets
-func getLockedWallets(wallets []*dcrlibwallet.Wallet) []*dcrlibwallet.Wallet {
-	var walletsLocked []*dcrlibwallet.Wallet
+func getLockedWallets(wal wallet.Backend, wallets []*wallet.WalletSummary) []*wallet.WalletSummary {
+	var walletsLocked []*wallet.WalletSummary
 	for _, wl := range wallets {
-		if !wl.HasDiscoveredAccounts && wl.IsLocked() {
+		if !wal.HasDiscoveredAccounts(wl.ID) && wal.IsLocked(wl.ID) {
 			walletsLocked = append(walletsLocked, wl)
 		}
 	}
@@ -70,17 +68,12 @@ func getLockedWallets(wallets []*dcrlibwallet.Wallet) []*dcrlibwallet.Wallet {
 }
 
 func formatDateOrTime(timestamp int64) string {
-	utcTime := time.Unix(timestamp, 0).UTC()
-	if time.Now().UTC().Sub(utcTime).Hours() < 168 {
-		return utcTime.Weekday().String()
+	t := time.Unix(timestamp, 0)
+	if time.Since(t).Hours() < 168 {
+		return values.FormatWeekday(t)
 	}
 
-	t := strings.Split(utcTime.Format(time.UnixDate), " ")
-	t2 := t[2]
-	if t[2] == "" {
-		t2 = t[3]
-	}
-	return fmt.Sprintf("%s %s", t[1], t2)
+	return values.FormatShortDate(t)
 }
 
 // createClickGestures returns a slice of click gestures
@@ -105,32 +98,32 @@ func showLabel(recentTransactions []wallet.Transaction) bool {
 	return false
 }
 
-// breakBalance takes the balance string and returns it in two slices
-func breakBalance(p *message.Printer, balance string) (b1, b2 string) {
-	var isDecimal = true
-	balanceParts := strings.Split(balance, ".")
-	if len(balanceParts) == 1 {
-		isDecimal = false
-		balanceParts = strings.Split(balance, " ")
+// breakBalance takes the balance string and returns it in two slices, the
+// first ending after the second decimal
+func breakBalance(balance string) (b1, b2 string) {
+	// the unit follows the last space, digit groups may be separated by
+	// spaces too
+	var unit string
+	if i := strings.LastIndex(balance, " "); i >= 0 {
+		balance, unit = balance[:i], balance[i:]
 	}
 
-	b1 = balanceParts[0]
-	if bal, err := strconv.Atoi(b1); err == nil {
-		b1 = p.Sprint(bal)
+	separator := values.DecimalSeparator()
+	balanceParts := strings.SplitN(balance, separator, 2)
+	if len(balanceParts) == 1 {
+		return balance, unit
 	}
 
-	b2 = balanceParts[1]
-	if isDecimal {
-		b1 = b1 + "." + b2[:2]
-		b2 = b2[2:]
-		return
+	fraction := balanceParts[1]
+	n := 2
+	if len(fraction) < n {
+		n = len(fraction)
 	}
-	b2 = " " + b2
-	return
+	return balanceParts[0] + separator + fraction[:n], fraction[n:] + unit
 }
 
-func formatUSDBalance(p *message.Printer, balance float64) string {
-	return p.Sprintf("$%.2f", balance)
+func formatUSDBalance(balance float64) string {
+	return values.FormatUSD(balance)
 }
 
 func goToURL(url string) {
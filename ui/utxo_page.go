@@ -2,14 +2,13 @@ package ui
 
 import (
 	"fmt"
+	"time"
 
 	"gioui.org/io/clipboard"
 	"gioui.org/layout"
 	"gioui.org/text"
 	"gioui.org/widget"
 
-	"github.com/decred/dcrd/dcrutil"
-	"github.com/planetdecred/dcrlibwallet"
 	"github.com/planetdecred/godcr/ui/decredmaterial"
 	"github.com/planetdecred/godcr/ui/values"
 	"github.com/planetdecred/godcr/wallet"
@@ -23,7 +22,7 @@ type utxoPage struct {
 	theme                  *decredmaterial.Theme
 	common                 *pageCommon
 	utxoListContainer      layout.List
-	txAuthor               *dcrlibwallet.TxAuthor
+	txAuthor               *wallet.TxAuthor
 	backButton             decredmaterial.IconButton
 	useUTXOButton          decredmaterial.Button
 	unspentOutputs         **wallet.UnspentOutputs
@@ -131,19 +130,22 @@ func (pg *utxoPage) calculateAmountAndFeeUTXO() {
 		utxoKeys = append(utxoKeys, utxoKey)
 		totalAmount += utxo.UTXO.Amount
 	}
-	err := pg.txAuthor.UseInputs(utxoKeys)
+	if *pg.txAuthor == nil {
+		return
+	}
+	err := (*pg.txAuthor).UseInputs(utxoKeys)
 	if err != nil {
 		log.Error(err)
 		return
 	}
-	feeAndSize, err := pg.txAuthor.EstimateFeeAndSize()
+	feeAndSize, err := (*pg.txAuthor).EstimateFeeAndSize()
 	if err != nil {
 		log.Error(err)
 		return
 	}
-	pg.txnAmount = dcrutil.Amount(totalAmount).String()
-	pg.txnFee = dcrutil.Amount(feeAndSize.Fee.AtomValue).String()
-	pg.txnAmountAfterFee = dcrutil.Amount(totalAmount - feeAndSize.Fee.AtomValue).String()
+	pg.txnAmount = wallet.FormatAmount(totalAmount)
+	pg.txnFee = wallet.FormatAmount(feeAndSize.Fee.AtomValue)
+	pg.txnAmountAfterFee = wallet.FormatAmount(totalAmount - feeAndSize.Fee.AtomValue)
 }
 
 func (pg *utxoPage) clearPageData() {
@@ -272,7 +274,7 @@ func (pg *utxoPage) utxoRow(gtx layout.Context, data *wallet.UnspentOutput, c *p
 			return txt.Layout(gtx)
 		}),
 		layout.Rigid(func(gtx C) D {
-			txt := c.theme.Body2(data.DateTime)
+			txt := c.theme.Body2(values.FormatDateTime(time.Unix(data.UTXO.ReceiveTime, 0)))
 			txt.MaxLines = 1
 			txt.Alignment = text.End
 			gtx.Constraints.Min.X = gtx.Px(values.MarginPadding100)
@@ -27,7 +27,7 @@ type validateAddressPage struct {
 	theme                 *decredmaterial.Theme
 	addressEditor         decredmaterial.Editor
 	clearBtn, validateBtn decredmaterial.Button
-	wallet                *wallet.Wallet
+	wallet                wallet.Backend
 	walletID              int
 	stateValidate         int
 	walletName            string
@@ -233,6 +233,10 @@ func (pg *validateAddressPage) pageSections(gtx layout.Context, body layout.Widg
 	})
 }
 
+func (pg *validateAddressPage) focusTargets() []focusTarget {
+	return append(editorTargets(pg.addressEditor.Editor), buttonTargets(pg.clearBtn.Button, pg.validateBtn.Button)...)
+}
+
 func (pg *validateAddressPage) handle() {
 	c := pg.common
 	pg.updateColors(c)
@@ -2,13 +2,39 @@ package values
 
 import "github.com/planetdecred/godcr/ui/values/localizable"
 
+// DefaultAmountPrecision is the amount precision preference that shows
+// amounts with as many decimals as they need.
+const DefaultAmountPrecision = "auto"
+
 var (
 	ArrLanguages          map[string]string
 	ArrExchangeCurrencies map[string]string
+	ArrAmountUnits        map[string]string
+	ArrAmountPrecisions   map[string]string
+
+	// ArrUIScales holds the selectable display and text scales, in percent of
+	// the platform default size, from smallest to largest.
+	ArrUIScales = []int{75, 80, 90, 100, 110, 125, 150, 175, 200}
 )
 
 func init() {
 	ArrLanguages = make(map[string]string)
 	ArrLanguages[localizable.ENGLISH] = StrEnglish
 	ArrLanguages[localizable.FRENCH] = StrFrench
+	ArrLanguages[localizable.CHINESE] = StrChinese
+
+	ArrAmountUnits = map[string]string{
+		"DCR":   StrUnitDCR,
+		"mDCR":  StrUnitMilliDCR,
+		"µDCR":  StrUnitMicroDCR,
+		"atoms": StrUnitAtoms,
+	}
+
+	ArrAmountPrecisions = map[string]string{
+		DefaultAmountPrecision: StrPrecisionAuto,
+		"0":                    StrPrecision0,
+		"2":                    StrPrecision2,
+		"4":                    StrPrecision4,
+		"8":                    StrPrecision8,
+	}
 }
@@ -0,0 +1,157 @@
+package values
+
+import (
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/text/language"
+	"golang.org/x/text/message"
+	"golang.org/x/text/number"
+)
+
+// monthToken is the layout element replaced with the translated month name
+// when formatting dates.
+const monthToken = "Jan"
+
+var (
+	printersMu sync.Mutex
+	printers   = make(map[string]*message.Printer)
+)
+
+// Printer returns a printer that formats numbers for the current user
+// language.
+func Printer() *message.Printer {
+	lang := UserLanguages[0]
+	printersMu.Lock()
+	defer printersMu.Unlock()
+	p, ok := printers[lang]
+	if !ok {
+		p = message.NewPrinter(language.Make(lang))
+		printers[lang] = p
+	}
+	return p
+}
+
+// plainSpaces replaces the non-breaking spaces some languages use for digit
+// grouping with plain spaces, which all of the app fonts can display.
+func plainSpaces(s string) string {
+	return strings.NewReplacer("\u00a0", " ", "\u202f", " ").Replace(s)
+}
+
+// FormatInteger returns n with the digit grouping of the current language.
+func FormatInteger(n int64) string {
+	return plainSpaces(Printer().Sprint(number.Decimal(n)))
+}
+
+// FormatDecimal returns f rounded to decimals fraction digits, with the digit
+// grouping and decimal separator of the current language.
+func FormatDecimal(f float64, decimals int) string {
+	return plainSpaces(Printer().Sprint(number.Decimal(f, number.MinFractionDigits(decimals),
+		number.MaxFractionDigits(decimals))))
+}
+
+// DecimalSeparator returns the decimal separator of the current language.
+func DecimalSeparator() string {
+	return strings.Trim(FormatDecimal(1.5, 1), "15")
+}
+
+// GroupSeparator returns the separator FormatInteger puts between groups of
+// digits in the current language.
+func GroupSeparator() string {
+	return strings.Trim(FormatInteger(1000), "10")
+}
+
+// FormatUSD returns a US dollar amount formatted for the current language.
+func FormatUSD(amount float64) string {
+	return StringF(StrUSDAmount, FormatDecimal(amount, 2))
+}
+
+// formatTime formats t with layout, using the translated month names in
+// place of the English ones.
+func formatTime(t time.Time, layout string) string {
+	parts := strings.Split(layout, monthToken)
+	month := t.Format(monthToken)
+	if names := strings.Split(String(StrMonthNames), ","); len(names) == 12 {
+		month = names[t.Month()-1]
+	}
+
+	for i, part := range parts {
+		if part != "" {
+			parts[i] = t.Format(part)
+		}
+	}
+	return strings.Join(parts, month)
+}
+
+// FormatDate returns the date of t formatted for the current language.
+func FormatDate(t time.Time) string {
+	return formatTime(t, String(StrDateLayout))
+}
+
+// FormatShortDate returns the month and day of t formatted for the current
+// language.
+func FormatShortDate(t time.Time) string {
+	return formatTime(t, String(StrShortDateLayout))
+}
+
+// FormatMonth returns the month and year of t formatted for the current
+// language.
+func FormatMonth(t time.Time) string {
+	return formatTime(t, String(StrMonthLayout))
+}
+
+// FormatTime returns the time of day of t in the 12 or 24 hour clock of the
+// current language.
+func FormatTime(t time.Time) string {
+	return formatTime(t, String(StrTimeLayout))
+}
+
+// FormatDateTime returns the date and time of t formatted for the current
+// language.
+func FormatDateTime(t time.Time) string {
+	return FormatDate(t) + " " + FormatTime(t)
+}
+
+// FormatWeekday returns the translated name of the day of the week of t.
+func FormatWeekday(t time.Time) string {
+	if names := strings.Split(String(StrWeekdayNames), ","); len(names) == 7 {
+		return names[t.Weekday()]
+	}
+	return t.Weekday().String()
+}
+
+// TimeAgo returns how long ago t was, in the current language. Times after
+// now, which come from a clock ahead of the local one, are just now.
+func TimeAgo(t time.Time) string {
+	elapsed := time.Since(t)
+	days := int(elapsed.Hours() / 24)
+	switch {
+	case elapsed < time.Minute:
+		return String(StrJustNow)
+	case elapsed < time.Hour:
+		minutes := int(elapsed.Minutes())
+		return StringP(StrMinutesAgo, minutes, minutes)
+	case days < 1:
+		hours := int(elapsed.Hours())
+		return StringP(StrHoursAgo, hours, hours)
+	case days < 7:
+		return StringP(StrDaysAgo, days, days)
+	case days < 30:
+		return StringP(StrWeeksAgo, days/7, days/7)
+	case days < 365:
+		return StringP(StrMonthsAgo, days/30, days/30)
+	default:
+		return StringP(StrYearsAgo, days/365, days/365)
+	}
+}
+
+// FormatDuration returns d in days and hours, or in hours and minutes if it
+// is under a day, in the current language.
+func FormatDuration(d time.Duration) string {
+	hours := int(d.Hours())
+	if hours >= 24 {
+		return StringF(StrDurationDays, hours/24, hours%24)
+	}
+	return StringF(StrDurationHours, hours, int(d.Minutes())%60)
+}
@@ -0,0 +1,124 @@
+package values
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/planetdecred/godcr/ui/values/localizable"
+)
+
+var _ = Describe("Formatting", func() {
+	date := time.Date(2021, time.February, 3, 16, 5, 9, 0, time.Local)
+
+	AfterEach(func() {
+		SetUserLanguage(DefaultLangauge)
+	})
+
+	It("groups the digits of integers", func() {
+		SetUserLanguage(localizable.ENGLISH)
+		Expect(FormatInteger(1234567)).To(Equal("1,234,567"))
+		Expect(FormatInteger(-1234)).To(Equal("-1,234"))
+		Expect(FormatInteger(999)).To(Equal("999"))
+
+		SetUserLanguage(localizable.FRENCH)
+		Expect(FormatInteger(1234567)).To(Equal("1 234 567"))
+		Expect(FormatInteger(-1234)).To(Equal("-1 234"))
+
+		SetUserLanguage(localizable.CHINESE)
+		Expect(FormatInteger(1234567)).To(Equal("1,234,567"))
+	})
+
+	It("rounds decimals with the separators of the language", func() {
+		SetUserLanguage(localizable.ENGLISH)
+		Expect(FormatDecimal(1234.5, 2)).To(Equal("1,234.50"))
+		Expect(FormatDecimal(0.126, 2)).To(Equal("0.13"))
+		Expect(FormatDecimal(12.6, 0)).To(Equal("13"))
+		Expect(DecimalSeparator()).To(Equal("."))
+		Expect(GroupSeparator()).To(Equal(","))
+
+		SetUserLanguage(localizable.FRENCH)
+		Expect(FormatDecimal(1234.5, 2)).To(Equal("1 234,50"))
+		Expect(FormatDecimal(0.125, 3)).To(Equal("0,125"))
+		Expect(DecimalSeparator()).To(Equal(","))
+		Expect(GroupSeparator()).To(Equal(" "))
+
+		SetUserLanguage(localizable.CHINESE)
+		Expect(FormatDecimal(1234.5, 2)).To(Equal("1,234.50"))
+		Expect(DecimalSeparator()).To(Equal("."))
+	})
+
+	It("formats US dollars", func() {
+		SetUserLanguage(localizable.ENGLISH)
+		Expect(FormatUSD(1234.5)).To(Equal("$1,234.50"))
+
+		SetUserLanguage(localizable.FRENCH)
+		Expect(FormatUSD(1234.5)).To(Equal("1 234,50 $US"))
+
+		SetUserLanguage(localizable.CHINESE)
+		Expect(FormatUSD(1234.5)).To(Equal("US$1,234.50"))
+	})
+
+	It("formats dates with the month names of the language", func() {
+		SetUserLanguage(localizable.ENGLISH)
+		Expect(FormatDate(date)).To(Equal("Feb 3, 2021"))
+		Expect(FormatShortDate(date)).To(Equal("Feb 3"))
+		Expect(FormatMonth(date)).To(Equal("Feb 2021"))
+		Expect(FormatDateTime(date)).To(Equal("Feb 3, 2021 4:05:09 PM"))
+		Expect(FormatWeekday(date)).To(Equal("Wednesday"))
+
+		SetUserLanguage(localizable.FRENCH)
+		Expect(FormatDate(date)).To(Equal("3 févr. 2021"))
+		Expect(FormatDate(date.AddDate(0, 5, 0))).To(Equal("3 juil. 2021"))
+		Expect(FormatMonth(date)).To(Equal("févr. 2021"))
+		Expect(FormatDateTime(date)).To(Equal("3 févr. 2021 16:05:09"))
+		Expect(FormatWeekday(date)).To(Equal("mercredi"))
+
+		SetUserLanguage(localizable.CHINESE)
+		Expect(FormatDate(date)).To(Equal("2021年2月3日"))
+		Expect(FormatShortDate(date)).To(Equal("2月3日"))
+		Expect(FormatMonth(date)).To(Equal("2021年2月"))
+		Expect(FormatWeekday(date)).To(Equal("星期三"))
+	})
+
+	It("formats layouts without a month", func() {
+		SetUserLanguage(localizable.FRENCH)
+		Expect(formatTime(date, "2006")).To(Equal("2021"))
+		Expect(formatTime(date, "Jan")).To(Equal("févr."))
+	})
+
+	It("says how long ago past times were", func() {
+		ago := func(d time.Duration) string {
+			return TimeAgo(time.Now().Add(-d))
+		}
+		day := 24 * time.Hour
+
+		SetUserLanguage(localizable.ENGLISH)
+		Expect(ago(10 * time.Second)).To(Equal("just now"))
+		Expect(ago(90 * time.Second)).To(Equal("1 minute ago"))
+		Expect(ago(3 * time.Hour)).To(Equal("3 hours ago"))
+		Expect(ago(2 * day)).To(Equal("2 days ago"))
+		Expect(ago(14 * day)).To(Equal("2 weeks ago"))
+		Expect(ago(61 * day)).To(Equal("2 months ago"))
+		Expect(ago(400 * day)).To(Equal("1 year ago"))
+
+		SetUserLanguage(localizable.FRENCH)
+		Expect(ago(90 * time.Second)).To(Equal("il y a 1 minute"))
+		Expect(ago(3 * time.Hour)).To(Equal("il y a 3 heures"))
+		Expect(ago(800 * day)).To(Equal("il y a 2 ans"))
+
+		SetUserLanguage(localizable.CHINESE)
+		Expect(ago(3 * time.Hour)).To(Equal("3小时前"))
+		Expect(ago(2 * day)).To(Equal("2天前"))
+	})
+
+	It("says future times are just now", func() {
+		SetUserLanguage(localizable.ENGLISH)
+		Expect(TimeAgo(time.Now().Add(30 * time.Second))).To(Equal("just now"))
+		Expect(TimeAgo(time.Now().Add(5 * time.Hour))).To(Equal("just now"))
+
+		SetUserLanguage(localizable.CHINESE)
+		Expect(TimeAgo(time.Now().Add(5 * time.Hour))).To(Equal("刚刚"))
+	})
+})
@@ -1,3 +1,17 @@
+// Command cmd is a tool for translators of godcr. It compares translation
+// files against the English strings and keeps them in sync.
+//
+// Usage:
+//
+//	go run ./ui/values/localizable/cmd export <lang>
+//	go run ./ui/values/localizable/cmd check <file>...
+//	go run ./ui/values/localizable/cmd sync <file> [output]
+//
+// Translation files use the `"key" = "value";` format of the built-in
+// languages and are named after their language, e.g. de.strings. The sync
+// command records a fingerprint of the English string after each translation
+// so that check can report translations of English strings that have since
+// changed as stale.
 package main
 
 import (
@@ -5,76 +19,281 @@ import (
 	"fmt"
 	"io/ioutil"
 	"os"
+	"path/filepath"
 	"regexp"
+	"sort"
 	"strings"
 
 	"github.com/planetdecred/godcr/ui/values/localizable"
 )
 
-var rex = regexp.MustCompile(`(?m)("(?:\\.|[^"\\])*")\s*=\s*("(?:\\.|[^"\\])*")`) // "key"="value"
-const commentPrefix = "/"
+const fingerprintPrefix = "// en:"
+
+var fingerprintRex = regexp.MustCompile(`//\s*en:([0-9a-f]{8})`)
+
+var builtin = map[string]string{
+	localizable.ENGLISH: localizable.EN,
+	localizable.FRENCH:  localizable.FR,
+	localizable.CHINESE: localizable.ZH,
+}
+
+// translation is a parsed translation file.
+type translation struct {
+	lang         string
+	strings      map[string]string
+	fingerprints map[string]string
+}
 
 func main() {
-	if len(os.Args) < 2 {
-		fmt.Println("Invalid arguments")
-		return
+	if len(os.Args) < 3 {
+		usage()
+		os.Exit(2)
 	}
 
-	readIntoMap := func(m map[string]string, localizableStrings string) {
-		scanner := bufio.NewScanner(strings.NewReader(localizableStrings))
-		for scanner.Scan() {
-			line := scanner.Text()
-			line = strings.TrimSpace(line)
-			if line == "" || strings.HasPrefix(line, commentPrefix) {
-				continue
+	var err error
+	switch os.Args[1] {
+	case "export":
+		err = export(os.Args[2])
+	case "check":
+		var ok bool
+		ok, err = check(os.Args[2:])
+		if err == nil && !ok {
+			os.Exit(1)
+		}
+	case "sync":
+		output := "translated.txt"
+		if len(os.Args) > 3 {
+			output = os.Args[3]
+		}
+		err = sync(os.Args[2], output)
+	default:
+		usage()
+		os.Exit(2)
+	}
+
+	if err != nil {
+		fmt.Println("Error:", err)
+		os.Exit(1)
+	}
+}
+
+func usage() {
+	fmt.Println("Usage:")
+	fmt.Println("  export <lang>            write a built-in language to <lang>" + localizable.FileExtension)
+	fmt.Println("  check <file>...          report missing, extra and stale keys, plural forms and format arguments")
+	fmt.Println("  sync <file> [output]     write the file in EN order with missing keys commented out (default output translated.txt)")
+}
+
+func readTranslation(path string) (*translation, error) {
+	content, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("error reading file: %v", err)
+	}
+
+	t := &translation{
+		lang:         strings.TrimSuffix(filepath.Base(path), filepath.Ext(path)),
+		strings:      localizable.Parse(string(content)),
+		fingerprints: make(map[string]string),
+	}
+
+	scanner := bufio.NewScanner(strings.NewReader(string(content)))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if strings.HasPrefix(line, "/") {
+			continue
+		}
+
+		key, _, ok := localizable.ParseLine(line)
+		if !ok {
+			continue
+		}
+
+		if match := fingerprintRex.FindStringSubmatch(line); match != nil {
+			t.fingerprints[key] = match[1]
+		}
+	}
+
+	return t, nil
+}
+
+// sortedKeys returns the keys of m in the order they appear in localizable
+// strings s, followed by any remaining keys in alphabetical order.
+func sortedKeys(m map[string]string, s string) []string {
+	var keys []string
+	seen := make(map[string]bool)
+	for _, line := range strings.Split(s, "\n") {
+		key, _, ok := localizable.ParseLine(strings.TrimSpace(line))
+		if ok && !seen[key] {
+			if _, exists := m[key]; exists {
+				keys = append(keys, key)
+				seen[key] = true
 			}
+		}
+	}
+
+	var rest []string
+	for k := range m {
+		if !seen[k] {
+			rest = append(rest, k)
+		}
+	}
+	sort.Strings(rest)
+
+	return append(keys, rest...)
+}
+
+// isPluralOf reports whether key is a plural form of a plural key in en.
+func isPluralOf(key string, en map[string]string) (string, bool) {
+	base, _, ok := localizable.SplitPluralKey(key)
+	if !ok {
+		return "", false
+	}
+	_, ok = en[base+localizable.PluralSeparator+localizable.PluralOther]
+	return base, ok
+}
+
+func export(lang string) error {
+	s, ok := builtin[lang]
+	if !ok {
+		return fmt.Errorf("unknown built-in language %q", lang)
+	}
 
-			matches := rex.FindAllStringSubmatch(line, -1)
-			if len(matches) == 0 {
+	en := localizable.Parse(localizable.EN)
+	strs := localizable.Parse(s)
+
+	var sb strings.Builder
+	for _, k := range sortedKeys(strs, localizable.EN) {
+		writeEntry(&sb, k, strs[k], en)
+	}
+
+	return ioutil.WriteFile(lang+localizable.FileExtension, []byte(sb.String()), 0644)
+}
+
+func writeEntry(sb *strings.Builder, key, value string, en map[string]string) {
+	sb.WriteString("\"" + key + "\" = \"" + value + "\";")
+	if enValue, ok := en[key]; ok {
+		sb.WriteString(" " + fingerprintPrefix + localizable.Fingerprint(enValue))
+	}
+	sb.WriteString("\n")
+}
+
+func check(paths []string) (bool, error) {
+	en := localizable.Parse(localizable.EN)
+	ok := true
+	for _, path := range paths {
+		t, err := readTranslation(path)
+		if err != nil {
+			return false, err
+		}
+
+		problems := checkTranslation(t, en)
+		if len(problems) == 0 {
+			fmt.Printf("%s: ok\n", path)
+			continue
+		}
+
+		ok = false
+		fmt.Printf("%s: %d problems\n", path, len(problems))
+		for _, p := range problems {
+			fmt.Println("  " + p)
+		}
+	}
+
+	return ok, nil
+}
+
+func checkTranslation(t *translation, en map[string]string) []string {
+	var problems []string
+
+	required := localizable.RequiredPluralForms(t.lang)
+	for _, k := range sortedKeys(en, localizable.EN) {
+		if base, ok := isPluralOf(k, en); ok {
+			// plural keys are checked once, against the forms of the target
+			// language rather than the forms used in English.
+			if !strings.HasSuffix(k, localizable.PluralSeparator+localizable.PluralOther) {
 				continue
 			}
+			for _, form := range required {
+				if _, ok := t.strings[base+localizable.PluralSeparator+form]; !ok {
+					problems = append(problems, fmt.Sprintf("missing plural: %q has no %q form", base, form))
+				}
+			}
+			continue
+		}
 
-			kv := matches[0]
-			key := trimQuotes(kv[1])
-			value := trimQuotes(kv[2])
+		if _, ok := t.strings[k]; !ok {
+			problems = append(problems, fmt.Sprintf("missing: %q", k))
+		}
+	}
+
+	for _, k := range sortedKeys(t.strings, localizable.EN) {
+		value := t.strings[k]
+		enValue, ok := en[k]
+		base, plural := isPluralOf(k, en)
+		switch {
+		case ok:
+		case plural:
+			enValue = en[base+localizable.PluralSeparator+localizable.PluralOther]
+		case k == localizable.LanguageNameKey:
+			continue
+		default:
+			problems = append(problems, fmt.Sprintf("extra: %q", k))
+			continue
+		}
+
+		if fp, ok := t.fingerprints[k]; ok && en[k] != "" && fp != localizable.Fingerprint(en[k]) {
+			problems = append(problems, fmt.Sprintf("stale: %q, English is now %q", k, en[k]))
+		}
 
-			m[key] = value
+		want, got := localizable.FormatVerbs(enValue), localizable.FormatVerbs(value)
+		if strings.Join(want, ",") != strings.Join(got, ",") {
+			problems = append(problems, fmt.Sprintf("format: %q has arguments %v, English has %v", k, got, want))
 		}
 	}
 
-	en := make(map[string]string)
-	readIntoMap(en, localizable.EN)
+	return problems
+}
 
-	fileBuff, err := ioutil.ReadFile(os.Args[1])
+func sync(path, output string) error {
+	t, err := readTranslation(path)
 	if err != nil {
-		fmt.Println("Error reading file:", err)
-		return
+		return err
 	}
 
-	translation := make(map[string]string)
-	readIntoMap(translation, string(fileBuff))
+	en := localizable.Parse(localizable.EN)
 
 	var sb strings.Builder
-	for k := range en {
-		translationValue, ok := translation[k]
-		if ok {
-			sb.WriteString("\"" + k + "\" = \"")
-			sb.WriteString(translationValue)
-			sb.WriteString("\";\n")
+	for _, k := range sortedKeys(en, localizable.EN) {
+		keys := []string{k}
+		if base, ok := isPluralOf(k, en); ok {
+			// plural keys are written once, in the forms of the target
+			// language rather than the forms used in English.
+			if !strings.HasSuffix(k, localizable.PluralSeparator+localizable.PluralOther) {
+				continue
+			}
+			keys = keys[:0]
+			for _, form := range localizable.RequiredPluralForms(t.lang) {
+				keys = append(keys, base+localizable.PluralSeparator+form)
+			}
 		}
-	}
 
-	err = ioutil.WriteFile("translated.txt", []byte(sb.String()), 0644)
-	if err != nil {
-		fmt.Println("Error writing file:", err)
-	}
-}
+		for _, key := range keys {
+			if value, ok := t.strings[key]; ok {
+				writeEntry(&sb, key, value, en)
+				continue
+			}
 
-func trimQuotes(s string) string {
-	if len(s) >= 2 {
-		if s[0] == '"' && s[len(s)-1] == '"' {
-			return s[1 : len(s)-1]
+			enValue, ok := en[key]
+			if !ok {
+				enValue = en[k]
+			}
+			sb.WriteString("// \"" + key + "\" = \"" + enValue + "\";\n")
 		}
 	}
-	return s
+
+	if name, ok := t.strings[localizable.LanguageNameKey]; ok {
+		sb.WriteString("\"" + localizable.LanguageNameKey + "\" = \"" + name + "\";\n")
+	}
+
+	return ioutil.WriteFile(output, []byte(sb.String()), 0644)
 }
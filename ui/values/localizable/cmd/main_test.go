@@ -0,0 +1,158 @@
+package main
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/planetdecred/godcr/ui/values/localizable"
+)
+
+// captureOutput returns what f prints.
+func captureOutput(f func()) string {
+	r, w, err := os.Pipe()
+	Expect(err).To(BeNil())
+
+	stdout := os.Stdout
+	os.Stdout = w
+	defer func() { os.Stdout = stdout }()
+
+	f()
+	w.Close()
+	out, err := ioutil.ReadAll(r)
+	Expect(err).To(BeNil())
+	return string(out)
+}
+
+var _ = Describe("Translator tool", func() {
+	var dir, wd string
+
+	BeforeEach(func() {
+		var err error
+		dir, err = ioutil.TempDir("", "godcr_translations")
+		Expect(err).To(BeNil())
+		wd, err = os.Getwd()
+		Expect(err).To(BeNil())
+		Expect(os.Chdir(dir)).To(Succeed())
+	})
+
+	AfterEach(func() {
+		Expect(os.Chdir(wd)).To(Succeed())
+		os.RemoveAll(dir)
+	})
+
+	writeFile := func(name, content string) string {
+		Expect(ioutil.WriteFile(name, []byte(content), 0644)).To(Succeed())
+		return filepath.Join(dir, name)
+	}
+
+	It("reads the language and fingerprints of a translation file", func() {
+		path := writeFile("fr.strings", `"sent" = "Envoyé"; // en:0a1b2c3d
+"received" = "Reçu";
+// "staking" = "Staking";
+`)
+		t, err := readTranslation(path)
+		Expect(err).To(BeNil())
+		Expect(t.lang).To(Equal("fr"))
+		Expect(t.strings).To(Equal(map[string]string{"sent": "Envoyé", "received": "Reçu"}))
+		Expect(t.fingerprints).To(Equal(map[string]string{"sent": "0a1b2c3d"}))
+	})
+
+	It("reports missing, extra, stale, plural and format problems", func() {
+		en := map[string]string{
+			"sent":         "Sent",
+			"received":     "Received",
+			"blocks.one":   "%d block",
+			"blocks.other": "%d blocks",
+			"syncing":      "Syncing %d%%",
+		}
+		t := &translation{
+			lang: "ru",
+			strings: map[string]string{
+				"sent":                      "Отправлено",
+				"blocks.one":                "%d блок",
+				"blocks.few":                "%d блока",
+				"syncing":                   "Синхронизация %s%%",
+				"unused":                    "Лишнее",
+				localizable.LanguageNameKey: "Русский",
+			},
+			fingerprints: map[string]string{"sent": "00000000"},
+		}
+
+		Expect(checkTranslation(t, en)).To(ConsistOf(
+			`missing: "received"`,
+			`missing plural: "blocks" has no "many" form`,
+			`stale: "sent", English is now "Sent"`,
+			`format: "syncing" has arguments [s], English has [d]`,
+			`extra: "unused"`,
+		))
+
+		t.strings["received"] = "Получено"
+		t.strings["blocks.many"] = "%d блоков"
+		t.strings["syncing"] = "Синхронизация %d%%"
+		t.fingerprints["sent"] = localizable.Fingerprint("Sent")
+		delete(t.strings, "unused")
+		Expect(checkTranslation(t, en)).To(BeEmpty())
+	})
+
+	It("checks exported built-in languages clean", func() {
+		Expect(export(localizable.ENGLISH)).To(Succeed())
+
+		var ok bool
+		out := captureOutput(func() {
+			var err error
+			ok, err = check([]string{localizable.ENGLISH + localizable.FileExtension})
+			Expect(err).To(BeNil())
+		})
+		Expect(ok).To(Equal(true))
+		Expect(out).To(Equal(localizable.ENGLISH + localizable.FileExtension + ": ok\n"))
+	})
+
+	It("prints the problems of each file it checks", func() {
+		path := writeFile("fr.strings", `"sent" = "Envoyé"; // en:00000000
+"appTitle" = "godcr (%d)";
+`)
+
+		var ok bool
+		out := captureOutput(func() {
+			var err error
+			ok, err = check([]string{path})
+			Expect(err).To(BeNil())
+		})
+		Expect(ok).To(Equal(false))
+		Expect(out).To(HavePrefix(path + ": "))
+		Expect(out).To(ContainSubstring("\n  " + `missing: "received"` + "\n"))
+		Expect(out).To(ContainSubstring("\n  " + `stale: "sent", English is now "Sent"` + "\n"))
+		Expect(out).To(ContainSubstring("\n  " + `format: "appTitle" has arguments [d], English has [s]` + "\n"))
+	})
+
+	It("syncs a file to the order and plural forms of its language", func() {
+		path := writeFile("ru.strings", `"languageName" = "Русский";
+"received" = "Получено";
+"sent" = "Отправлено";
+`)
+		Expect(sync(path, "synced.strings")).To(Succeed())
+
+		content, err := ioutil.ReadFile("synced.strings")
+		Expect(err).To(BeNil())
+		synced := string(content)
+
+		Expect(synced).To(ContainSubstring(`"sent" = "Отправлено"; ` + fingerprintPrefix + localizable.Fingerprint("Sent") + "\n"))
+		Expect(strings.Index(synced, `"sent"`)).To(BeNumerically("<", strings.Index(synced, `"received"`)))
+		Expect(synced).To(ContainSubstring(`// "appTitle" = "godcr (%s)";` + "\n"))
+		Expect(synced).To(ContainSubstring(`// "minutesAgo.one" = "%d minute ago";` + "\n"))
+		Expect(synced).To(ContainSubstring(`// "minutesAgo.few" = "%d minutes ago";` + "\n"))
+		Expect(synced).To(ContainSubstring(`// "minutesAgo.many" = "%d minutes ago";` + "\n"))
+		Expect(synced).NotTo(ContainSubstring(`"minutesAgo.other"`))
+		Expect(synced).To(HaveSuffix(`"languageName" = "Русский";` + "\n"))
+
+		t, err := readTranslation("synced.strings")
+		Expect(err).To(BeNil())
+		Expect(t.strings).To(HaveLen(3))
+		Expect(t.fingerprints).To(HaveKeyWithValue("received", localizable.Fingerprint("Received")))
+	})
+})
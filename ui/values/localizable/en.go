@@ -114,6 +114,226 @@ const EN = `
 "more" = "More";
 "english" = "English";
 "french" = "French";
+"chinese" = "Chinese";
 "usdBittrex" = "USD (Bittrex)";
 "none" = "None";
+"displayScale" = "Display scale";
+"textSize" = "Text size";
+"scalePercent" = "%d%%";
+"usdAmount" = "$%s";
+"dateLayout" = "Jan 2, 2006";
+"shortDateLayout" = "Jan 2";
+"monthLayout" = "Jan 2006";
+"timeLayout" = "3:04:05 PM";
+"monthNames" = "Jan,Feb,Mar,Apr,May,Jun,Jul,Aug,Sep,Oct,Nov,Dec";
+"weekdayNames" = "Sunday,Monday,Tuesday,Wednesday,Thursday,Friday,Saturday";
+"justNow" = "just now";
+"minutesAgo.one" = "%d minute ago";
+"minutesAgo.other" = "%d minutes ago";
+"hoursAgo.one" = "%d hour ago";
+"hoursAgo.other" = "%d hours ago";
+"daysAgo.one" = "%d day ago";
+"daysAgo.other" = "%d days ago";
+"weeksAgo.one" = "%d week ago";
+"weeksAgo.other" = "%d weeks ago";
+"monthsAgo.one" = "%d month ago";
+"monthsAgo.other" = "%d months ago";
+"yearsAgo.one" = "%d year ago";
+"yearsAgo.other" = "%d years ago";
+"amountUnit" = "Amount unit";
+"amountPrecision" = "Decimal places";
+"unitDCR" = "DCR";
+"unitMilliDCR" = "mDCR (0.001 DCR)";
+"unitMicroDCR" = "µDCR (0.000001 DCR)";
+"unitAtoms" = "atoms (0.00000001 DCR)";
+"precisionAuto" = "As needed";
+"precision0" = "None";
+"precision2" = "2";
+"precision4" = "4";
+"precision8" = "8";
+"proposals" = "Proposals";
+"goBack" = "Go back";
+"closeDialog" = "Close dialog";
+"nextField" = "Next field or button";
+"previousField" = "Previous field or button";
+"activateFocused" = "Press the focused button";
+"zoomIn" = "Zoom in";
+"zoomOut" = "Zoom out";
+"resetZoom" = "Reset zoom";
+"keyboardShortcuts" = "Keyboard shortcuts";
+"pressKeys" = "Press the new keys, or Esc to cancel";
+"resetDefaults" = "Reset to defaults";
+"close" = "Close";
+"searchLogs" = "Search logs";
+"followLog" = "Follow";
+"export" = "Export";
+"logExported" = "Exported %d entries to %s";
+"logExportFailed" = "Could not export log: %v";
+"noLogEntries" = "No log entries match the filters";
+"logLevels" = "Log levels";
+"debugLevelHint" = "SUBSYS=level, e.g. WALL=debug,SYNC=trace";
+"apply" = "Apply";
+"saveToConfigFile" = "Save to config file";
+"logLevelsSaveFailed" = "Could not save log levels: %v";
+"createDiagnosticsBundle" = "Create diagnostics bundle";
+"diagnosticsBundleSaved" = "Diagnostics bundle saved to %s";
+"diagnosticsBundleFailed" = "Could not create diagnostics bundle: %v";
+"crashTitle" = "godcr ran into a problem";
+"crashReportSaved" = "An error in %s was recovered. A crash report was saved to %s.";
+"crashReportNotSaved" = "An error in %s was recovered. The crash report could not be saved, see the log for details.";
+"restart" = "Restart";
+"quit" = "Quit";
+"dismiss" = "Dismiss";
+"restartFailed" = "Could not restart: %v";
+"crashReports" = "Crash reports";
+"noCrashReports" = "No crash reports";
+"balanceHistory" = "Balance history";
+"allWallets" = "All wallets";
+"oneWeek" = "1W";
+"oneMonth" = "1M";
+"oneYear" = "1Y";
+"allTime" = "All";
+"showFiatValue" = "Show USD value at current rate";
+"stakingRewards" = "Staking rewards";
+"rewardsEarned" = "Rewards earned";
+"txFeesPaid" = "Transaction fees";
+"vspFeesPaid" = "VSP fees";
+"netRewards" = "Net rewards";
+"votedTickets" = "Voted tickets";
+"missedTickets" = "Missed tickets";
+"expiredTickets" = "Expired tickets";
+"revokedTickets" = "Revoked tickets";
+"ticketsShare" = "%d (%s%%)";
+"averageTimeToVote" = "Average time to vote";
+"daysValue" = "%s days";
+"annualReturn" = "Annual return";
+"percentValue" = "%s%%";
+"rewardsPerMonth" = "Rewards per month";
+"noVotesYet" = "No votes yet";
+"vspFeeNote" = "VSP fees paid in a separate fee transaction are not linked to their ticket and are not included.";
+"ticketsExported" = "Exported %d tickets to %s";
+"ticketsExportFailed" = "Could not export tickets: %v";
+"ticketDetails" = "Ticket Details";
+"ticketStatus" = "Status";
+"ticketPrice" = "Price";
+"purchased" = "Purchased";
+"purchaseTx" = "Purchase transaction";
+"maturityHeight" = "Live at block";
+"expiryHeight" = "Expires at block";
+"notMinedYet" = "Not mined yet";
+"vsp" = "VSP";
+"vspFeeStatus" = "VSP fee";
+"feeTx" = "Fee transaction";
+"voteTx" = "Vote transaction";
+"revocationTx" = "Revocation transaction";
+"notSpentYet" = "Not spent yet";
+"reward" = "Reward";
+"timeLocked" = "Time locked";
+"voteChoices" = "Voting choices";
+"noVoteChoices" = "No vote choices are known for this ticket.";
+"unknown" = "Unknown";
+"feeUnpaid" = "Unpaid";
+"feePaid" = "Paid";
+"feeConfirmed" = "Confirmed";
+"feeErrored" = "Payment failed";
+"ticketDetailsFailed" = "Could not load the ticket details: %v";
+"vspHost" = "Host";
+"vote" = "Vote";
+"revocation" = "Revocation";
+"checkVSPStatus" = "Check VSP status";
+"checkVSPStatusOf" = "Check the VSP status of the tickets of %s";
+"vspTicketsChecked" = "%d tickets of %s checked with the VSPs";
+"retryFeePayment" = "Retry fee payment";
+"payVSPFee" = "Pay the VSP fee to %s";
+"pay" = "Pay";
+"vspFeeRetried" = "VSP fee paid to %s";
+"rememberVSPFirst" = "Remember a VSP on the purchase page to pay the fee to";
+"noVSP" = "No VSP";
+"vspFeeValue" = "VSP: %s";
+"lastChecked" = "Last checked";
+"notChecked" = "Not checked";
+"unvotedTickets" = "Tickets not voted by a VSP";
+"unvotedNote" = "No VSP knows these tickets or their fee was not paid, so they can miss their votes. Open a ticket to retry paying its fee.";
+"vsps" = "Voting service providers";
+"manageVSPs" = "Manage";
+"addVSP" = "Add VSP";
+"vspAddressHint" = "VSP address, e.g. https://vsp.example.org";
+"checkNow" = "Check now";
+"vspLatency" = "Online, %d ms";
+"unreachable" = "Unreachable";
+"keyChanged" = "Key changed";
+"keyChangedNote" = "This VSP signs with another public key than when it was first used. Tickets cannot be bought through it until you trust the new key, which you should only do if its operator announced the change.";
+"trustNewKey" = "Trust new key";
+"trustVSPKey" = "Trust the new key of %s?";
+"vspKeyTrusted" = "The new key of %s is trusted";
+"removeVSP" = "Remove %s?";
+"removeVSPNote" = "Its tickets are no longer checked unless it is added again.";
+"vspRemoved" = "%s removed";
+"noVSPs" = "No VSPs";
+"addedByYou" = "Added by you";
+"votingTickets" = "Voting tickets";
+"publicKey" = "Public key";
+"vspVersion" = "vspd version";
+"consensusVotes" = "Consensus votes";
+"setVotes" = "Set votes";
+"consensusVotesNote" = "Set how your tickets vote on consensus rule changes and treasury spends.";
+"sendToVSPs" = "Send to VSPs";
+"sendVoteChoicesOf" = "Send the vote choices of %s";
+"voteChoicesSent" = "The VSPs recorded the vote choices of %d tickets of %s";
+"recordedByVSPs" = "Recorded by VSPs";
+"recordedTickets" = "%d of %d tickets";
+"noTicketsAtVSP" = "No ticket of this wallet is known to be at a VSP. Check the VSP status of its tickets first.";
+"sendVoteChoicesNote" = "Send your choices to the VSPs so that your tickets vote them.";
+"treasurySpends" = "Treasury spends";
+"treasuryKeyValue" = "Signed by key %s";
+"votingPeriod" = "Voting period";
+"dateRange" = "%s – %s";
+"agendasFailed" = "Could not load the agendas: %v";
+"priceForecast" = "Forecast";
+"ticketPriceForecast" = "Ticket price forecast";
+"currentPrice" = "Current price";
+"priceChangesIn" = "Price changes in";
+"newPriceIn" = "New price in %s";
+"forecastNote" = "The price changes every %d blocks, depending on how many tickets were bought before. The history is of the prices this wallet saw while it was synced.";
+"ticketPriceHistory" = "Ticket price history";
+"noPriceHistory" = "No price history yet";
+"durationDays" = "%dd %dh";
+"durationHours" = "%dh %dm";
+"blocksLeft.one" = "%d block (about %s)";
+"blocksLeft.other" = "%d blocks (about %s)";
+"loadingForecast" = "Loading the ticket price forecast…";
+"anyOutcome" = "Any outcome";
+"passing" = "Passing";
+"failing" = "Failing";
+"noVotes" = "No votes";
+"anyTime" = "Any time";
+"pastWeek" = "Past week";
+"pastMonth" = "Past month";
+"pastYear" = "Past year";
+"mostVotes" = "Most votes";
+"endingSoonest" = "Ending soonest";
+"watching" = "Watching";
+"searchProposals" = "Search by title, author or token";
+"noMatchingProposals" = "No proposals match the search and filters";
+"noWatchedProposals" = "No watched proposals. Tap the star of a proposal to watch it";
+"versionHistory" = "Version history";
+"versionDate" = "Version %d · %s";
+"inline" = "Inline";
+"sideBySide" = "Side by side";
+"loadingVersions" = "Loading the versions of the proposal...";
+"versionsFailed" = "Could not load the versions of the proposal: %v";
+"retry" = "Retry";
+"oneVersion" = "This proposal has not been updated since it was published";
+"noChanges" = "No changes between these versions";
+"diffSummary" = "%d blocks added, %d removed";
+"attachments" = "Attachments";
+"proposal" = "Proposal";
+"commentsCount" = "Comments (%d)";
+"top" = "Top";
+"commentCount" = "%d comments";
+"loadingComments" = "Loading comments...";
+"commentsFailed" = "The comments could not be loaded: %v";
+"noComments" = "No comments yet";
+"commentScore" = "%d points";
+"commentCensored" = "This comment was censored";
 `
@@ -86,4 +86,224 @@ const FR = `
 "connectToSpecificPeer" = "Se connecter à un pair spécifique";
 "english" = "Anglais";
 "french" = "Français";
+"chinese" = "Chinois";
+"displayScale" = "Échelle d\'affichage";
+"textSize" = "Taille du texte";
+"scalePercent" = "%d %%";
+"usdAmount" = "%s $US";
+"dateLayout" = "2 Jan 2006";
+"shortDateLayout" = "2 Jan";
+"monthLayout" = "Jan 2006";
+"timeLayout" = "15:04:05";
+"monthNames" = "janv.,févr.,mars,avr.,mai,juin,juil.,août,sept.,oct.,nov.,déc.";
+"weekdayNames" = "dimanche,lundi,mardi,mercredi,jeudi,vendredi,samedi";
+"justNow" = "à l\'instant";
+"minutesAgo.one" = "il y a %d minute";
+"minutesAgo.other" = "il y a %d minutes";
+"hoursAgo.one" = "il y a %d heure";
+"hoursAgo.other" = "il y a %d heures";
+"daysAgo.one" = "il y a %d jour";
+"daysAgo.other" = "il y a %d jours";
+"weeksAgo.one" = "il y a %d semaine";
+"weeksAgo.other" = "il y a %d semaines";
+"monthsAgo.one" = "il y a %d mois";
+"monthsAgo.other" = "il y a %d mois";
+"yearsAgo.one" = "il y a %d an";
+"yearsAgo.other" = "il y a %d ans";
+"amountUnit" = "Unité des montants";
+"amountPrecision" = "Décimales";
+"unitDCR" = "DCR";
+"unitMilliDCR" = "mDCR (0,001 DCR)";
+"unitMicroDCR" = "µDCR (0,000001 DCR)";
+"unitAtoms" = "atoms (0,00000001 DCR)";
+"precisionAuto" = "Selon le montant";
+"precision0" = "Aucune";
+"precision2" = "2";
+"precision4" = "4";
+"precision8" = "8";
+"proposals" = "Propositions";
+"goBack" = "Retour";
+"closeDialog" = "Fermer la fenêtre";
+"nextField" = "Champ ou bouton suivant";
+"previousField" = "Champ ou bouton précédent";
+"activateFocused" = "Appuyer sur le bouton sélectionné";
+"zoomIn" = "Agrandir";
+"zoomOut" = "Réduire";
+"resetZoom" = "Réinitialiser le zoom";
+"keyboardShortcuts" = "Raccourcis clavier";
+"pressKeys" = "Appuyez sur les nouvelles touches, ou Échap pour annuler";
+"resetDefaults" = "Rétablir les valeurs par défaut";
+"close" = "Fermer";
+"searchLogs" = "Rechercher dans les journaux";
+"followLog" = "Suivre";
+"export" = "Exporter";
+"logExported" = "%d entrées exportées vers %s";
+"logExportFailed" = "Impossible d'exporter le journal : %v";
+"noLogEntries" = "Aucune entrée ne correspond aux filtres";
+"logLevels" = "Niveaux de journalisation";
+"debugLevelHint" = "SOUSSYS=niveau, ex. WALL=debug,SYNC=trace";
+"apply" = "Appliquer";
+"saveToConfigFile" = "Enregistrer dans le fichier de configuration";
+"logLevelsSaveFailed" = "Impossible d'enregistrer les niveaux de journalisation : %v";
+"createDiagnosticsBundle" = "Créer un paquet de diagnostic";
+"diagnosticsBundleSaved" = "Paquet de diagnostic enregistré dans %s";
+"diagnosticsBundleFailed" = "Impossible de créer le paquet de diagnostic : %v";
+"crashTitle" = "godcr a rencontré un problème";
+"crashReportSaved" = "Une erreur dans %s a été récupérée. Un rapport de plantage a été enregistré dans %s.";
+"crashReportNotSaved" = "Une erreur dans %s a été récupérée. Le rapport de plantage n'a pas pu être enregistré, consultez le journal.";
+"restart" = "Redémarrer";
+"quit" = "Quitter";
+"dismiss" = "Ignorer";
+"restartFailed" = "Impossible de redémarrer : %v";
+"crashReports" = "Rapports de plantage";
+"noCrashReports" = "Aucun rapport de plantage";
+"balanceHistory" = "Historique du solde";
+"allWallets" = "Tous les portefeuilles";
+"oneWeek" = "1S";
+"oneMonth" = "1M";
+"oneYear" = "1A";
+"allTime" = "Tout";
+"showFiatValue" = "Afficher la valeur en USD au cours actuel";
+"stakingRewards" = "Récompenses de staking";
+"rewardsEarned" = "Récompenses gagnées";
+"txFeesPaid" = "Frais de transaction";
+"vspFeesPaid" = "Frais VSP";
+"netRewards" = "Récompenses nettes";
+"votedTickets" = "Tickets votés";
+"missedTickets" = "Tickets manqués";
+"expiredTickets" = "Tickets expirés";
+"revokedTickets" = "Tickets révoqués";
+"ticketsShare" = "%d (%s %%)";
+"averageTimeToVote" = "Délai moyen de vote";
+"daysValue" = "%s jours";
+"annualReturn" = "Rendement annuel";
+"percentValue" = "%s %%";
+"rewardsPerMonth" = "Récompenses par mois";
+"noVotesYet" = "Aucun vote pour l'instant";
+"vspFeeNote" = "Les frais VSP payés dans une transaction de frais distincte ne sont pas liés à leur ticket et ne sont pas inclus.";
+"ticketsExported" = "%d tickets exportés vers %s";
+"ticketsExportFailed" = "Impossible d'exporter les tickets : %v";
+"ticketDetails" = "Détails du ticket";
+"ticketStatus" = "Statut";
+"ticketPrice" = "Prix";
+"purchased" = "Acheté";
+"purchaseTx" = "Transaction d'achat";
+"maturityHeight" = "Actif au bloc";
+"expiryHeight" = "Expire au bloc";
+"notMinedYet" = "Pas encore miné";
+"vsp" = "VSP";
+"vspFeeStatus" = "Frais du VSP";
+"feeTx" = "Transaction des frais";
+"voteTx" = "Transaction de vote";
+"revocationTx" = "Transaction de révocation";
+"notSpentYet" = "Pas encore dépensé";
+"reward" = "Récompense";
+"timeLocked" = "Durée de blocage";
+"voteChoices" = "Choix de vote";
+"noVoteChoices" = "Aucun choix de vote n'est connu pour ce ticket.";
+"unknown" = "Inconnu";
+"feeUnpaid" = "Non payés";
+"feePaid" = "Payés";
+"feeConfirmed" = "Confirmés";
+"feeErrored" = "Échec du paiement";
+"ticketDetailsFailed" = "Impossible de charger les détails du ticket : %v";
+"vspHost" = "Hôte";
+"vote" = "Vote";
+"revocation" = "Révocation";
+"checkVSPStatus" = "Vérifier le statut VSP";
+"checkVSPStatusOf" = "Vérifier le statut VSP des tickets de %s";
+"vspTicketsChecked" = "%d tickets de %s vérifiés auprès des VSP";
+"retryFeePayment" = "Réessayer le paiement";
+"payVSPFee" = "Payer les frais VSP à %s";
+"pay" = "Payer";
+"vspFeeRetried" = "Frais VSP payés à %s";
+"rememberVSPFirst" = "Mémorisez un VSP sur la page d'achat pour lui payer les frais";
+"noVSP" = "Aucun VSP";
+"vspFeeValue" = "VSP : %s";
+"lastChecked" = "Dernière vérification";
+"notChecked" = "Non vérifié";
+"unvotedTickets" = "Tickets non votés par un VSP";
+"unvotedNote" = "Aucun VSP ne connaît ces tickets ou leurs frais n'ont pas été payés, ils peuvent donc manquer leur vote. Ouvrez un ticket pour réessayer le paiement.";
+"vsps" = "Fournisseurs de services de vote";
+"manageVSPs" = "Gérer";
+"addVSP" = "Ajouter un VSP";
+"vspAddressHint" = "Adresse du VSP, ex. https://vsp.example.org";
+"checkNow" = "Vérifier";
+"vspLatency" = "En ligne, %d ms";
+"unreachable" = "Injoignable";
+"keyChanged" = "Clé modifiée";
+"keyChangedNote" = "Ce VSP signe avec une autre clé publique que lors de sa première utilisation. Aucun ticket ne peut être acheté par son intermédiaire tant que vous ne faites pas confiance à la nouvelle clé, ce que vous ne devriez faire que si son opérateur a annoncé le changement.";
+"trustNewKey" = "Faire confiance";
+"trustVSPKey" = "Faire confiance à la nouvelle clé de %s ?";
+"vspKeyTrusted" = "La nouvelle clé de %s est approuvée";
+"removeVSP" = "Supprimer %s ?";
+"removeVSPNote" = "Ses tickets ne sont plus vérifiés, sauf s'il est ajouté à nouveau.";
+"vspRemoved" = "%s supprimé";
+"noVSPs" = "Aucun VSP";
+"addedByYou" = "Ajouté par vous";
+"votingTickets" = "Tickets en vote";
+"publicKey" = "Clé publique";
+"vspVersion" = "Version de vspd";
+"consensusVotes" = "Votes de consensus";
+"setVotes" = "Définir les votes";
+"consensusVotesNote" = "Définissez comment vos tickets votent sur les changements de règles de consensus et les dépenses de la trésorerie.";
+"sendToVSPs" = "Envoyer aux VSP";
+"sendVoteChoicesOf" = "Envoyer les choix de vote de %s";
+"voteChoicesSent" = "Les VSP ont enregistré les choix de vote de %d tickets de %s";
+"recordedByVSPs" = "Enregistré par les VSP";
+"recordedTickets" = "%d sur %d tickets";
+"noTicketsAtVSP" = "Aucun ticket de ce portefeuille n'est connu d'un VSP. Vérifiez d'abord le statut VSP de ses tickets.";
+"sendVoteChoicesNote" = "Envoyez vos choix aux VSP pour que vos tickets les votent.";
+"treasurySpends" = "Dépenses de la trésorerie";
+"treasuryKeyValue" = "Signées par la clé %s";
+"votingPeriod" = "Période de vote";
+"dateRange" = "%s – %s";
+"agendasFailed" = "Impossible de charger les agendas : %v";
+"priceForecast" = "Prévision";
+"ticketPriceForecast" = "Prévision du prix des tickets";
+"currentPrice" = "Prix actuel";
+"priceChangesIn" = "Le prix change dans";
+"newPriceIn" = "Nouveau prix dans %s";
+"forecastNote" = "Le prix change tous les %d blocs, selon le nombre de tickets achetés auparavant. L'historique contient les prix que ce portefeuille a vus une fois synchronisé.";
+"ticketPriceHistory" = "Historique du prix des tickets";
+"noPriceHistory" = "Pas encore d'historique de prix";
+"durationDays" = "%d j %d h";
+"durationHours" = "%d h %d min";
+"blocksLeft.one" = "%d bloc (environ %s)";
+"blocksLeft.other" = "%d blocs (environ %s)";
+"loadingForecast" = "Chargement de la prévision du prix des tickets…";
+"anyOutcome" = "Tout résultat";
+"passing" = "Adoptée";
+"failing" = "Rejetée";
+"noVotes" = "Aucun vote";
+"anyTime" = "Toute date";
+"pastWeek" = "Semaine passée";
+"pastMonth" = "Mois passé";
+"pastYear" = "Année passée";
+"mostVotes" = "Plus de votes";
+"endingSoonest" = "Fin la plus proche";
+"watching" = "Suivies";
+"searchProposals" = "Rechercher par titre, auteur ou jeton";
+"noMatchingProposals" = "Aucune proposition ne correspond à la recherche et aux filtres";
+"noWatchedProposals" = "Aucune proposition suivie. Touchez l'étoile d'une proposition pour la suivre";
+"versionHistory" = "Historique des versions";
+"versionDate" = "Version %d · %s";
+"inline" = "En ligne";
+"sideBySide" = "Côte à côte";
+"loadingVersions" = "Chargement des versions de la proposition...";
+"versionsFailed" = "Impossible de charger les versions de la proposition : %v";
+"retry" = "Réessayer";
+"oneVersion" = "Cette proposition n'a pas été modifiée depuis sa publication";
+"noChanges" = "Aucune modification entre ces versions";
+"diffSummary" = "%d blocs ajoutés, %d supprimés";
+"attachments" = "Pièces jointes";
+"proposal" = "Proposition";
+"commentsCount" = "Commentaires (%d)";
+"top" = "Meilleurs";
+"commentCount" = "%d commentaires";
+"loadingComments" = "Chargement des commentaires...";
+"commentsFailed" = "Les commentaires n'ont pas pu être chargés : %v";
+"noComments" = "Aucun commentaire pour l'instant";
+"commentScore" = "%d points";
+"commentCensored" = "Ce commentaire a été censuré";
 `
@@ -0,0 +1,156 @@
+package localizable
+
+import (
+	"bufio"
+	"fmt"
+	"hash/fnv"
+	"regexp"
+	"strings"
+
+	"golang.org/x/text/feature/plural"
+	"golang.org/x/text/language"
+)
+
+const (
+	// FileExtension is the extension of translation files that are loaded at
+	// runtime, e.g. de.strings.
+	FileExtension = ".strings"
+
+	// LanguageNameKey is the key translation files use to give the name of
+	// their language, in that language.
+	LanguageNameKey = "languageName"
+
+	// PluralSeparator separates a key from its plural form, e.g. "blocks.one".
+	PluralSeparator = "."
+
+	// PluralOther is the plural form every plural key must define.
+	PluralOther = "other"
+
+	commentPrefix = "/"
+)
+
+var rex = regexp.MustCompile(`(?m)("(?:\\.|[^"\\])*")\s*=\s*("(?:\\.|[^"\\])*")`) // "key"="value"
+
+// formatVerbRex matches fmt verbs, including the escaped percent sign.
+var formatVerbRex = regexp.MustCompile(`%(?:\[\d+\])?[-+# 0]*(?:\d+|\*)?(?:\.(?:\d+|\*))?[a-zA-Z%]`)
+
+// PluralForms are the CLDR plural categories a plural key may be translated
+// into, in the order they are written out.
+var PluralForms = []string{"zero", "one", "two", "few", "many", PluralOther}
+
+var pluralFormNames = map[plural.Form]string{
+	plural.Zero:  "zero",
+	plural.One:   "one",
+	plural.Two:   "two",
+	plural.Few:   "few",
+	plural.Many:  "many",
+	plural.Other: PluralOther,
+}
+
+// Parse reads localizable strings, one `"key" = "value";` pair per line, into
+// a map. Empty lines and lines starting with a comment are ignored.
+func Parse(localizableStrings string) map[string]string {
+	m := make(map[string]string)
+	scanner := bufio.NewScanner(strings.NewReader(localizableStrings))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, commentPrefix) {
+			continue
+		}
+
+		key, value, ok := ParseLine(line)
+		if ok {
+			m[key] = value
+		}
+	}
+
+	return m
+}
+
+// ParseLine returns the key and value of a single localizable string line.
+func ParseLine(line string) (key, value string, ok bool) {
+	matches := rex.FindAllStringSubmatch(line, -1)
+	if len(matches) == 0 {
+		return "", "", false
+	}
+
+	kv := matches[0]
+	return trimQuotes(kv[1]), trimQuotes(kv[2]), true
+}
+
+func trimQuotes(s string) string {
+	if len(s) >= 2 {
+		if s[0] == '"' && s[len(s)-1] == '"' {
+			return s[1 : len(s)-1]
+		}
+	}
+	return s
+}
+
+// SplitPluralKey splits a plural key such as "blocks.one" into its base key
+// and plural form. ok is false if key does not end with a plural form.
+func SplitPluralKey(key string) (base, form string, ok bool) {
+	i := strings.LastIndex(key, PluralSeparator)
+	if i < 0 {
+		return key, "", false
+	}
+
+	base, form = key[:i], key[i+1:]
+	for _, f := range PluralForms {
+		if f == form {
+			return base, form, true
+		}
+	}
+
+	return key, "", false
+}
+
+// PluralForm returns the plural form lang uses for count items.
+func PluralForm(lang string, count int) string {
+	if count < 0 {
+		count = -count
+	}
+
+	form := plural.Cardinal.MatchPlural(language.Make(lang), count, 0, 0, 0, 0)
+	return pluralFormNames[form]
+}
+
+// RequiredPluralForms returns the plural forms lang uses for whole numbers,
+// in the order of PluralForms.
+func RequiredPluralForms(lang string) []string {
+	used := make(map[string]bool)
+	for n := 0; n <= 1000; n++ {
+		used[PluralForm(lang, n)] = true
+	}
+	used[PluralForm(lang, 1000000)] = true
+
+	var forms []string
+	for _, f := range PluralForms {
+		if used[f] {
+			forms = append(forms, f)
+		}
+	}
+
+	return forms
+}
+
+// FormatVerbs returns the fmt verbs in s, ignoring escaped percent signs.
+func FormatVerbs(s string) []string {
+	var verbs []string
+	for _, verb := range formatVerbRex.FindAllString(s, -1) {
+		if verb != "%%" {
+			verbs = append(verbs, verb[len(verb)-1:])
+		}
+	}
+
+	return verbs
+}
+
+// Fingerprint returns a short hash of an English string. Translation files
+// record it next to each translation so that translations of English strings
+// that have since changed can be reported as stale.
+func Fingerprint(s string) string {
+	h := fnv.New32a()
+	h.Write([]byte(s))
+	return fmt.Sprintf("%08x", h.Sum32())
+}
@@ -0,0 +1,13 @@
+package localizable
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+func TestLocalizable(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Localizable Suite")
+}
@@ -0,0 +1,109 @@
+package localizable
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Localizable strings", func() {
+	It("parses a line into its key and value", func() {
+		key, value, ok := ParseLine(`"appTitle" = "godcr (%s)";`)
+		Expect(ok).To(Equal(true))
+		Expect(key).To(Equal("appTitle"))
+		Expect(value).To(Equal("godcr (%s)"))
+
+		key, value, ok = ParseLine(`"quote"="say \"hi\"";`)
+		Expect(ok).To(Equal(true))
+		Expect(key).To(Equal("quote"))
+		Expect(value).To(Equal(`say \"hi\"`))
+
+		_, _, ok = ParseLine(`"appTitle" godcr`)
+		Expect(ok).To(Equal(false))
+	})
+
+	It("parses a file, skipping empty lines and comments", func() {
+		strs := Parse(`
+"sent" = "Sent";
+// "received" = "Received";
+/* "staking" = "Staking"; */
+  "blocks.one" = "%d block"; // en:0a1b2c3d
+"blocks.other" = "%d blocks";
+`)
+		Expect(strs).To(Equal(map[string]string{
+			"sent":         "Sent",
+			"blocks.one":   "%d block",
+			"blocks.other": "%d blocks",
+		}))
+	})
+
+	It("splits plural keys", func() {
+		base, form, ok := SplitPluralKey("daysAgo.few")
+		Expect(ok).To(Equal(true))
+		Expect(base).To(Equal("daysAgo"))
+		Expect(form).To(Equal("few"))
+
+		_, _, ok = SplitPluralKey("daysAgo")
+		Expect(ok).To(Equal(false))
+		_, _, ok = SplitPluralKey("version.major")
+		Expect(ok).To(Equal(false))
+	})
+
+	It("finds the plural form of a count in each language", func() {
+		Expect(PluralForm("en", 0)).To(Equal("other"))
+		Expect(PluralForm("en", 1)).To(Equal("one"))
+		Expect(PluralForm("en", -1)).To(Equal("one"))
+		Expect(PluralForm("en", 2)).To(Equal("other"))
+
+		Expect(PluralForm("fr", 0)).To(Equal("one"))
+		Expect(PluralForm("fr", 1)).To(Equal("one"))
+		Expect(PluralForm("fr", 2)).To(Equal("other"))
+
+		Expect(PluralForm("zh", 1)).To(Equal("other"))
+
+		Expect(PluralForm("ru", 1)).To(Equal("one"))
+		Expect(PluralForm("ru", 21)).To(Equal("one"))
+		Expect(PluralForm("ru", 2)).To(Equal("few"))
+		Expect(PluralForm("ru", 5)).To(Equal("many"))
+
+		Expect(PluralForm("ar", 0)).To(Equal("zero"))
+		Expect(PluralForm("ar", 2)).To(Equal("two"))
+		Expect(PluralForm("ar", 5)).To(Equal("few"))
+		Expect(PluralForm("ar", 11)).To(Equal("many"))
+		Expect(PluralForm("ar", 100)).To(Equal("other"))
+	})
+
+	It("requires the plural forms each language uses for whole numbers", func() {
+		Expect(RequiredPluralForms("en")).To(Equal([]string{"one", "other"}))
+		Expect(RequiredPluralForms("fr")).To(Equal([]string{"one", "other"}))
+		Expect(RequiredPluralForms("zh")).To(Equal([]string{"other"}))
+		Expect(RequiredPluralForms("ja")).To(Equal([]string{"other"}))
+		Expect(RequiredPluralForms("ru")).To(Equal([]string{"one", "few", "many"}))
+		Expect(RequiredPluralForms("pl")).To(Equal([]string{"one", "few", "many"}))
+		Expect(RequiredPluralForms("ar")).To(Equal([]string{"zero", "one", "two", "few", "many", "other"}))
+	})
+
+	It("finds the format verbs of a string", func() {
+		Expect(FormatVerbs("Fetching block headers · %v%%")).To(Equal([]string{"v"}))
+		Expect(FormatVerbs("%d of %5.2f %[1]s")).To(Equal([]string{"d", "f", "s"}))
+		Expect(FormatVerbs("100%% synced")).To(BeEmpty())
+		Expect(FormatVerbs("%d of %d")).NotTo(Equal(FormatVerbs("%d of %s")))
+	})
+
+	It("fingerprints English strings", func() {
+		Expect(Fingerprint("Sent")).To(HaveLen(8))
+		Expect(Fingerprint("Sent")).To(Equal(Fingerprint("Sent")))
+		Expect(Fingerprint("Sent")).NotTo(Equal(Fingerprint("Sent ")))
+	})
+
+	It("keeps the format verbs of English in the built-in languages", func() {
+		en := Parse(EN)
+		for lang, strs := range map[string]string{FRENCH: FR, CHINESE: ZH} {
+			parsed := Parse(strs)
+			for key := range parsed {
+				if _, ok := en[key]; ok {
+					Expect(FormatVerbs(parsed[key])).To(Equal(FormatVerbs(en[key])), "%s: %s", lang, key)
+				}
+			}
+		}
+	})
+})
@@ -25,4 +25,21 @@ const ZH = `
 "send" = "发送";
 "disconnect" = "断开连接";
 "blockHeaderFetchedCount" = "%d of %d";
+"english" = "英语";
+"french" = "法语";
+"chinese" = "中文";
+"usdAmount" = "US$%s";
+"dateLayout" = "2006年1月2日";
+"shortDateLayout" = "1月2日";
+"monthLayout" = "2006年1月";
+"timeLayout" = "15:04:05";
+"monthNames" = "1月,2月,3月,4月,5月,6月,7月,8月,9月,10月,11月,12月";
+"weekdayNames" = "星期日,星期一,星期二,星期三,星期四,星期五,星期六";
+"justNow" = "刚刚";
+"minutesAgo.other" = "%d分钟前";
+"hoursAgo.other" = "%d小时前";
+"daysAgo.other" = "%d天前";
+"weeksAgo.other" = "%d周前";
+"monthsAgo.other" = "%d个月前";
+"yearsAgo.other" = "%d年前";
 `
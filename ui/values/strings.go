@@ -1,9 +1,10 @@
 package values
 
 import (
-	"bufio"
 	"fmt"
-	"regexp"
+	"io/ioutil"
+	"os"
+	"path/filepath"
 	"strings"
 
 	"github.com/planetdecred/godcr/ui/values/localizable"
@@ -11,52 +12,80 @@ import (
 
 const (
 	DefaultLangauge = localizable.ENGLISH
-	commentPrefix   = "/"
+
+	// languageNamePrefix prefixes the string keys under which the names of
+	// languages loaded at runtime are registered.
+	languageNamePrefix = "languageName."
 )
 
-var rex = regexp.MustCompile(`(?m)("(?:\\.|[^"\\])*")\s*=\s*("(?:\\.|[^"\\])*")`) // "key"="value"
 var Languages = []string{localizable.ENGLISH, localizable.CHINESE, localizable.FRENCH}
 var UserLanguages = []string{DefaultLangauge} // order of preference
 
 var languageStrings map[string]map[string]string
 
 func init() {
+	languageStrings = make(map[string]map[string]string)
+	languageStrings[localizable.ENGLISH] = localizable.Parse(localizable.EN)
+	languageStrings[localizable.CHINESE] = localizable.Parse(localizable.ZH)
+	languageStrings[localizable.FRENCH] = localizable.Parse(localizable.FR)
+}
 
-	readIntoMap := func(m map[string]string, localizableStrings string) {
-		scanner := bufio.NewScanner(strings.NewReader(localizableStrings))
-		for scanner.Scan() {
-			line := scanner.Text()
-			line = strings.TrimSpace(line)
-			if line == "" || strings.HasPrefix(line, commentPrefix) {
-				continue
-			}
-
-			matches := rex.FindAllStringSubmatch(line, -1)
-			if len(matches) == 0 {
-				continue
-			}
-
-			kv := matches[0]
-			key := trimQuotes(kv[1])
-			value := trimQuotes(kv[2])
-
-			m[key] = value
+// LoadTranslations loads the translation files in dir, one <lang>.strings
+// file per language. Strings in a file for a built-in language override the
+// built-in strings, and files for other languages add those languages to
+// Languages and ArrLanguages. A missing dir is not an error.
+func LoadTranslations(dir string) error {
+	files, err := ioutil.ReadDir(dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
 		}
+		return err
 	}
 
-	en := make(map[string]string)
-	zh := make(map[string]string)
-	fr := make(map[string]string)
-	languageStrings = make(map[string]map[string]string)
+	for _, file := range files {
+		if file.IsDir() || filepath.Ext(file.Name()) != localizable.FileExtension {
+			continue
+		}
 
-	readIntoMap(en, localizable.EN)
-	languageStrings[localizable.ENGLISH] = en
+		lang := strings.TrimSuffix(file.Name(), localizable.FileExtension)
+		content, err := ioutil.ReadFile(filepath.Join(dir, file.Name()))
+		if err != nil {
+			return fmt.Errorf("error reading %s translation: %v", lang, err)
+		}
 
-	readIntoMap(zh, localizable.ZH)
-	languageStrings[localizable.CHINESE] = zh
+		addTranslation(lang, localizable.Parse(string(content)))
+	}
 
-	readIntoMap(fr, localizable.FR)
-	languageStrings[localizable.FRENCH] = fr
+	return nil
+}
+
+func addTranslation(lang string, translation map[string]string) {
+	languageMap, ok := languageStrings[lang]
+	if !ok {
+		languageMap = make(map[string]string)
+		languageStrings[lang] = languageMap
+	}
+
+	for key, value := range translation {
+		languageMap[key] = value
+	}
+
+	if hasLanguage(lang) {
+		return
+	}
+
+	// the language name is registered in the default language so that it
+	// shows in the language list whatever the current language is.
+	name := translation[localizable.LanguageNameKey]
+	if name == "" {
+		name = lang
+	}
+	nameKey := languageNamePrefix + lang
+	languageStrings[DefaultLangauge][nameKey] = name
+
+	Languages = append(Languages, lang)
+	ArrLanguages[lang] = nameKey
 }
 
 func hasLanguage(language string) bool {
@@ -80,15 +109,6 @@ func SetUserLanguage(lang string) {
 	}
 }
 
-func trimQuotes(s string) string {
-	if len(s) >= 2 {
-		if s[0] == '"' && s[len(s)-1] == '"' {
-			return s[1 : len(s)-1]
-		}
-	}
-	return s
-}
-
 func String(key string) string {
 	for _, lang := range UserLanguages {
 		languageMap := languageStrings[lang]
@@ -110,6 +130,24 @@ func StringF(key string, a ...interface{}) string {
 	return fmt.Sprintf(str, a...)
 }
 
+// StringP returns the plural form of key for count items, formatted with a.
+// Plural forms are stored under the key followed by the form name, e.g.
+// "blocks.one" and "blocks.other".
+func StringP(key string, count int, a ...interface{}) string {
+	for _, lang := range UserLanguages {
+		languageMap := languageStrings[lang]
+		str, ok := languageMap[key+localizable.PluralSeparator+localizable.PluralForm(lang, count)]
+		if !ok {
+			str, ok = languageMap[key+localizable.PluralSeparator+localizable.PluralOther]
+		}
+		if ok {
+			return fmt.Sprintf(str, a...)
+		}
+	}
+
+	return StringF(key, a...)
+}
+
 const (
 	StrAppName                     = "appName"
 	StrSend                        = "send"
@@ -220,6 +258,219 @@ const (
 	StrOverview                    = "overview"
 	StrEnglish                     = "english"
 	StrFrench                      = "french"
+	StrChinese                     = "chinese"
 	StrUsdBittrex                  = "usdBittrex"
 	StrNone                        = "none"
+	StrDisplayScale                = "displayScale"
+	StrTextSize                    = "textSize"
+	StrScalePercent                = "scalePercent"
+	StrUSDAmount                   = "usdAmount"
+	StrDateLayout                  = "dateLayout"
+	StrShortDateLayout             = "shortDateLayout"
+	StrMonthLayout                 = "monthLayout"
+	StrTimeLayout                  = "timeLayout"
+	StrMonthNames                  = "monthNames"
+	StrWeekdayNames                = "weekdayNames"
+	StrJustNow                     = "justNow"
+	StrMinutesAgo                  = "minutesAgo"
+	StrHoursAgo                    = "hoursAgo"
+	StrDaysAgo                     = "daysAgo"
+	StrWeeksAgo                    = "weeksAgo"
+	StrMonthsAgo                   = "monthsAgo"
+	StrYearsAgo                    = "yearsAgo"
+	StrAmountUnit                  = "amountUnit"
+	StrAmountPrecision             = "amountPrecision"
+	StrUnitDCR                     = "unitDCR"
+	StrUnitMilliDCR                = "unitMilliDCR"
+	StrUnitMicroDCR                = "unitMicroDCR"
+	StrUnitAtoms                   = "unitAtoms"
+	StrPrecisionAuto               = "precisionAuto"
+	StrPrecision0                  = "precision0"
+	StrPrecision2                  = "precision2"
+	StrPrecision4                  = "precision4"
+	StrPrecision8                  = "precision8"
+	StrProposals                   = "proposals"
+	StrGoBack                      = "goBack"
+	StrCloseDialog                 = "closeDialog"
+	StrNextField                   = "nextField"
+	StrPreviousField               = "previousField"
+	StrActivateFocused             = "activateFocused"
+	StrZoomIn                      = "zoomIn"
+	StrZoomOut                     = "zoomOut"
+	StrResetZoom                   = "resetZoom"
+	StrKeyboardShortcuts           = "keyboardShortcuts"
+	StrPressKeys                   = "pressKeys"
+	StrResetDefaults               = "resetDefaults"
+	StrClose                       = "close"
+	StrSearchLogs                  = "searchLogs"
+	StrFollowLog                   = "followLog"
+	StrExport                      = "export"
+	StrLogExported                 = "logExported"
+	StrLogExportFailed             = "logExportFailed"
+	StrNoLogEntries                = "noLogEntries"
+	StrLogLevels                   = "logLevels"
+	StrDebugLevelHint              = "debugLevelHint"
+	StrApply                       = "apply"
+	StrSaveToConfigFile            = "saveToConfigFile"
+	StrLogLevelsSaveFailed         = "logLevelsSaveFailed"
+	StrCreateDiagnosticsBundle     = "createDiagnosticsBundle"
+	StrDiagnosticsBundleSaved      = "diagnosticsBundleSaved"
+	StrDiagnosticsBundleFailed     = "diagnosticsBundleFailed"
+	StrCrashTitle                  = "crashTitle"
+	StrCrashReportSaved            = "crashReportSaved"
+	StrCrashReportNotSaved         = "crashReportNotSaved"
+	StrRestart                     = "restart"
+	StrQuit                        = "quit"
+	StrDismiss                     = "dismiss"
+	StrRestartFailed               = "restartFailed"
+	StrCrashReports                = "crashReports"
+	StrNoCrashReports              = "noCrashReports"
+	StrBalanceHistory              = "balanceHistory"
+	StrAllWallets                  = "allWallets"
+	StrOneWeek                     = "oneWeek"
+	StrOneMonth                    = "oneMonth"
+	StrOneYear                     = "oneYear"
+	StrAllTime                     = "allTime"
+	StrShowFiatValue               = "showFiatValue"
+	StrStakingRewards              = "stakingRewards"
+	StrRewardsEarned               = "rewardsEarned"
+	StrTxFeesPaid                  = "txFeesPaid"
+	StrVSPFeesPaid                 = "vspFeesPaid"
+	StrNetRewards                  = "netRewards"
+	StrVotedTickets                = "votedTickets"
+	StrMissedTickets               = "missedTickets"
+	StrExpiredTickets              = "expiredTickets"
+	StrRevokedTickets              = "revokedTickets"
+	StrTicketsShare                = "ticketsShare"
+	StrAverageTimeToVote           = "averageTimeToVote"
+	StrDaysValue                   = "daysValue"
+	StrAnnualReturn                = "annualReturn"
+	StrPercentValue                = "percentValue"
+	StrRewardsPerMonth             = "rewardsPerMonth"
+	StrNoVotesYet                  = "noVotesYet"
+	StrVSPFeeNote                  = "vspFeeNote"
+	StrTicketsExported             = "ticketsExported"
+	StrTicketsExportFailed         = "ticketsExportFailed"
+	StrTicketDetails               = "ticketDetails"
+	StrTicketStatus                = "ticketStatus"
+	StrTicketPrice                 = "ticketPrice"
+	StrPurchased                   = "purchased"
+	StrPurchaseTx                  = "purchaseTx"
+	StrMaturityHeight              = "maturityHeight"
+	StrExpiryHeight                = "expiryHeight"
+	StrNotMinedYet                 = "notMinedYet"
+	StrVSP                         = "vsp"
+	StrVSPFeeStatus                = "vspFeeStatus"
+	StrFeeTx                       = "feeTx"
+	StrVoteTx                      = "voteTx"
+	StrRevocationTx                = "revocationTx"
+	StrNotSpentYet                 = "notSpentYet"
+	StrReward                      = "reward"
+	StrTimeLocked                  = "timeLocked"
+	StrVoteChoices                 = "voteChoices"
+	StrNoVoteChoices               = "noVoteChoices"
+	StrUnknown                     = "unknown"
+	StrFeeUnpaid                   = "feeUnpaid"
+	StrFeePaid                     = "feePaid"
+	StrFeeConfirmed                = "feeConfirmed"
+	StrFeeErrored                  = "feeErrored"
+	StrTicketDetailsFailed         = "ticketDetailsFailed"
+	StrVSPHost                     = "vspHost"
+	StrVote                        = "vote"
+	StrRevocation                  = "revocation"
+	StrCheckVSPStatus              = "checkVSPStatus"
+	StrCheckVSPStatusOf            = "checkVSPStatusOf"
+	StrVSPTicketsChecked           = "vspTicketsChecked"
+	StrRetryFeePayment             = "retryFeePayment"
+	StrPayVSPFee                   = "payVSPFee"
+	StrPay                         = "pay"
+	StrVSPFeeRetried               = "vspFeeRetried"
+	StrRememberVSPFirst            = "rememberVSPFirst"
+	StrNoVSP                       = "noVSP"
+	StrVSPFeeValue                 = "vspFeeValue"
+	StrLastChecked                 = "lastChecked"
+	StrNotChecked                  = "notChecked"
+	StrUnvotedTickets              = "unvotedTickets"
+	StrUnvotedNote                 = "unvotedNote"
+	StrVSPs                        = "vsps"
+	StrManageVSPs                  = "manageVSPs"
+	StrAddVSP                      = "addVSP"
+	StrVSPAddressHint              = "vspAddressHint"
+	StrCheckNow                    = "checkNow"
+	StrVSPLatency                  = "vspLatency"
+	StrUnreachable                 = "unreachable"
+	StrKeyChanged                  = "keyChanged"
+	StrKeyChangedNote              = "keyChangedNote"
+	StrTrustNewKey                 = "trustNewKey"
+	StrTrustVSPKey                 = "trustVSPKey"
+	StrVSPKeyTrusted               = "vspKeyTrusted"
+	StrRemoveVSP                   = "removeVSP"
+	StrRemoveVSPNote               = "removeVSPNote"
+	StrVSPRemoved                  = "vspRemoved"
+	StrNoVSPs                      = "noVSPs"
+	StrAddedByYou                  = "addedByYou"
+	StrVotingTickets               = "votingTickets"
+	StrPublicKey                   = "publicKey"
+	StrVSPVersion                  = "vspVersion"
+	StrConsensusVotes              = "consensusVotes"
+	StrSetVotes                    = "setVotes"
+	StrConsensusVotesNote          = "consensusVotesNote"
+	StrSendToVSPs                  = "sendToVSPs"
+	StrSendVoteChoicesOf           = "sendVoteChoicesOf"
+	StrVoteChoicesSent             = "voteChoicesSent"
+	StrRecordedByVSPs              = "recordedByVSPs"
+	StrRecordedTickets             = "recordedTickets"
+	StrNoTicketsAtVSP              = "noTicketsAtVSP"
+	StrSendVoteChoicesNote         = "sendVoteChoicesNote"
+	StrTreasurySpends              = "treasurySpends"
+	StrTreasuryKeyValue            = "treasuryKeyValue"
+	StrVotingPeriod                = "votingPeriod"
+	StrDateRange                   = "dateRange"
+	StrAgendasFailed               = "agendasFailed"
+	StrPriceForecast               = "priceForecast"
+	StrTicketPriceForecast         = "ticketPriceForecast"
+	StrCurrentPrice                = "currentPrice"
+	StrPriceChangesIn              = "priceChangesIn"
+	StrNewPriceIn                  = "newPriceIn"
+	StrForecastNote                = "forecastNote"
+	StrTicketPriceHistory          = "ticketPriceHistory"
+	StrNoPriceHistory              = "noPriceHistory"
+	StrDurationDays                = "durationDays"
+	StrDurationHours               = "durationHours"
+	StrBlocksLeft                  = "blocksLeft"
+	StrLoadingForecast             = "loadingForecast"
+	StrAnyOutcome                  = "anyOutcome"
+	StrPassing                     = "passing"
+	StrFailing                     = "failing"
+	StrNoVotes                     = "noVotes"
+	StrAnyTime                     = "anyTime"
+	StrPastWeek                    = "pastWeek"
+	StrPastMonth                   = "pastMonth"
+	StrPastYear                    = "pastYear"
+	StrMostVotes                   = "mostVotes"
+	StrEndingSoonest               = "endingSoonest"
+	StrWatching                    = "watching"
+	StrSearchProposals             = "searchProposals"
+	StrNoMatchingProposals         = "noMatchingProposals"
+	StrNoWatchedProposals          = "noWatchedProposals"
+	StrVersionHistory              = "versionHistory"
+	StrVersionDate                 = "versionDate"
+	StrInline                      = "inline"
+	StrSideBySide                  = "sideBySide"
+	StrLoadingVersions             = "loadingVersions"
+	StrVersionsFailed              = "versionsFailed"
+	StrRetry                       = "retry"
+	StrOneVersion                  = "oneVersion"
+	StrNoChanges                   = "noChanges"
+	StrDiffSummary                 = "diffSummary"
+	StrAttachments                 = "attachments"
+	StrProposal                    = "proposal"
+	StrCommentsCount               = "commentsCount"
+	StrTop                         = "top"
+	StrCommentCount                = "commentCount"
+	StrLoadingComments             = "loadingComments"
+	StrCommentsFailed              = "commentsFailed"
+	StrNoComments                  = "noComments"
+	StrCommentScore                = "commentScore"
+	StrCommentCensored             = "commentCensored"
 )
@@ -144,6 +144,11 @@ func (pg *verifyMessagePage) verifyMessageResponse() layout.Widget {
 	}
 }
 
+func (pg *verifyMessagePage) focusTargets() []focusTarget {
+	return append(editorTargets(pg.addressInput.Editor, pg.messageInput.Editor, pg.signInput.Editor),
+		buttonTargets(pg.clearBtn.Button, pg.verifyBtn.Button)...)
+}
+
 func (pg *verifyMessagePage) handle() {
 	c := pg.common
 
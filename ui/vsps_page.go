@@ -0,0 +1,309 @@
+package ui
+
+import (
+	"encoding/base64"
+	"fmt"
+	"time"
+
+	"gioui.org/layout"
+	"gioui.org/op"
+	"gioui.org/widget"
+
+	"github.com/planetdecred/godcr/ui/decredmaterial"
+	"github.com/planetdecred/godcr/ui/values"
+	"github.com/planetdecred/godcr/wallet"
+)
+
+const PageVSPs = "VSPs"
+
+// vspHealthCheckInterval is how often the VSPs are asked for their info while
+// the page is shown.
+const vspHealthCheckInterval = 2 * time.Minute
+
+type vspsPage struct {
+	common  *pageCommon
+	vspInfo **wallet.VSP
+
+	container   layout.List
+	backButton  decredmaterial.IconButton
+	hostEditor  decredmaterial.Editor
+	addButton   decredmaterial.Button
+	checkButton decredmaterial.Button
+
+	// list are the VSPs being shown, with a remove and a trust button each.
+	list          []wallet.VSPInfo
+	removeButtons []decredmaterial.Button
+	trustButtons  []decredmaterial.Button
+
+	addErrChan chan error
+	// checkedAt is when the VSPs were last asked for their info.
+	checkedAt time.Time
+}
+
+func VSPsPage(common *pageCommon) Page {
+	pg := &vspsPage{
+		common:     common,
+		vspInfo:    common.vspInfo,
+		container:  layout.List{Axis: layout.Vertical},
+		hostEditor: common.theme.Editor(new(widget.Editor), values.String(values.StrVSPAddressHint)),
+		addErrChan: make(chan error),
+	}
+	pg.backButton, _ = common.SubPageHeaderButtons()
+	pg.hostEditor.Editor.SingleLine = true
+	pg.addButton = common.theme.Button(new(widget.Clickable), values.String(values.StrAddVSP))
+	pg.addButton.TextSize = values.TextSize14
+	pg.checkButton = common.theme.Button(new(widget.Clickable), values.String(values.StrCheckNow))
+	pg.checkButton.TextSize = values.TextSize14
+	return pg
+}
+
+// OnResume shows the cached VSPs; they are checked again after
+// vspHealthCheckInterval.
+func (pg *vspsPage) OnResume() {
+	pg.common.wallet.GetAllVSP()
+	pg.checkedAt = time.Now()
+}
+
+// checkVSPs asks every VSP for its info again.
+func (pg *vspsPage) checkVSPs() {
+	pg.common.wallet.CheckVSPs()
+	pg.checkedAt = time.Now()
+}
+
+func (pg *vspsPage) handle() {
+	common := pg.common
+	pg.list = (*pg.vspInfo).List
+	for len(pg.removeButtons) < len(pg.list) {
+		remove := common.theme.Button(new(widget.Clickable), values.String(values.StrRemove))
+		remove.TextSize = values.TextSize14
+		remove.Background = common.theme.Color.Danger
+		trust := common.theme.Button(new(widget.Clickable), values.String(values.StrTrustNewKey))
+		trust.TextSize = values.TextSize14
+		pg.removeButtons = append(pg.removeButtons, remove)
+		pg.trustButtons = append(pg.trustButtons, trust)
+	}
+
+	if time.Since(pg.checkedAt) >= vspHealthCheckInterval {
+		pg.checkVSPs()
+	}
+	for pg.checkButton.Button.Clicked() {
+		pg.checkVSPs()
+	}
+
+	for pg.addButton.Button.Clicked() {
+		host := pg.hostEditor.Editor.Text()
+		if host == "" {
+			continue
+		}
+		common.wallet.AddVSP(host, pg.addErrChan)
+		pg.hostEditor.Editor.SetText("")
+	}
+
+	for i, vsp := range pg.list {
+		vsp := vsp
+		for pg.removeButtons[i].Button.Clicked() {
+			newInfoModal(common).
+				title(values.StringF(values.StrRemoveVSP, vsp.Host)).
+				body(values.String(values.StrRemoveVSPNote)).
+				negativeButton(values.String(values.StrCancel), func() {}).
+				positiveButton(values.String(values.StrRemove), func() {
+					if err := common.wallet.RemoveVSP(vsp.Host); err != nil {
+						common.notify(err.Error(), false)
+						return
+					}
+					common.notify(values.StringF(values.StrVSPRemoved, vsp.Host), true)
+					common.wallet.GetAllVSP()
+				}).Show()
+		}
+		for pg.trustButtons[i].Button.Clicked() {
+			newInfoModal(common).
+				title(values.StringF(values.StrTrustVSPKey, vsp.Host)).
+				body(values.String(values.StrKeyChangedNote)).
+				negativeButton(values.String(values.StrCancel), func() {}).
+				positiveButton(values.String(values.StrTrustNewKey), func() {
+					if err := common.wallet.TrustVSPPubKey(vsp.Host); err != nil {
+						common.notify(err.Error(), false)
+						return
+					}
+					common.notify(values.StringF(values.StrVSPKeyTrusted, vsp.Host), true)
+					common.wallet.GetAllVSP()
+				}).Show()
+		}
+	}
+
+	select {
+	case err := <-pg.addErrChan:
+		common.notify(err.Error(), false)
+	default:
+	}
+}
+
+func (pg *vspsPage) onClose() {}
+
+// vspHealth returns a label of how a VSP answered when last checked.
+func vspHealth(common *pageCommon, vsp wallet.VSPInfo) decredmaterial.Label {
+	label := common.theme.Body2(values.String(values.StrNotChecked))
+	label.Color = common.theme.Color.Gray2
+	switch {
+	case vsp.Err != nil:
+		label.Text, label.Color = values.String(values.StrUnreachable), common.theme.Color.Danger
+	case vsp.PubKeyChanged:
+		label.Text, label.Color = values.String(values.StrKeyChanged), common.theme.Color.Danger
+	case vsp.Info != nil:
+		label.Text, label.Color = values.StringF(values.StrVSPLatency, vsp.Latency.Milliseconds()), common.theme.Color.Success
+	}
+	return label
+}
+
+// row lays out a detail of a VSP with its label on the left and value on the
+// right.
+func (pg *vspsPage) row(gtx C, label, value string) D {
+	return layout.Inset{Bottom: values.MarginPadding10}.Layout(gtx, func(gtx C) D {
+		left := pg.common.theme.Body2(label)
+		left.Color = pg.common.theme.Color.Gray3
+		return endToEndRow(gtx, left.Layout, pg.common.theme.Body1(value).Layout)
+	})
+}
+
+// note lays out a caption below the details of a VSP.
+func (pg *vspsPage) note(gtx C, text string, danger bool) D {
+	txt := pg.common.theme.Caption(text)
+	txt.Color = pg.common.theme.Color.Gray2
+	if danger {
+		txt.Color = pg.common.theme.Color.Danger
+	}
+	return layout.Inset{Bottom: values.MarginPadding10}.Layout(gtx, txt.Layout)
+}
+
+func (pg *vspsPage) addCard(gtx C) D {
+	return pg.common.theme.Card().Layout(gtx, func(gtx C) D {
+		gtx.Constraints.Min.X = gtx.Constraints.Max.X
+		return Container{layout.UniformInset(values.MarginPadding15)}.Layout(gtx, func(gtx C) D {
+			return layout.Flex{Alignment: layout.Middle}.Layout(gtx,
+				layout.Flexed(1, pg.hostEditor.Layout),
+				layout.Rigid(func(gtx C) D {
+					return layout.Inset{Left: values.MarginPadding10}.Layout(gtx, pg.addButton.Layout)
+				}),
+				layout.Rigid(func(gtx C) D {
+					return layout.Inset{Left: values.MarginPadding10}.Layout(gtx, pg.checkButton.Layout)
+				}),
+			)
+		})
+	})
+}
+
+func (pg *vspsPage) vspCard(gtx C, i int) D {
+	common, vsp := pg.common, pg.list[i]
+	rows := []layout.Widget{
+		func(gtx C) D {
+			return layout.Inset{Bottom: values.MarginPadding10}.Layout(gtx, func(gtx C) D {
+				return endToEndRow(gtx, common.theme.Body1(vsp.Host).Layout, vspHealth(common, vsp).Layout)
+			})
+		},
+	}
+	if vsp.Added {
+		rows = append(rows, func(gtx C) D {
+			return pg.note(gtx, values.String(values.StrAddedByYou), false)
+		})
+	}
+	if info := vsp.Info; info != nil {
+		details := []struct{ label, value string }{
+			{values.String(values.StrFee), values.StringF(values.StrPercentValue, values.FormatDecimal(info.FeePercentage, 2))},
+			{values.String(values.StrVotingTickets), fmt.Sprintf("%d", info.Voting)},
+			{values.String(values.StrVotedTickets), fmt.Sprintf("%d", info.Voted)},
+			{values.String(values.StrMissedTickets), fmt.Sprintf("%d", info.Revoked)},
+			{values.String(values.StrVSPVersion), info.VspdVersion},
+			{values.String(values.StrPublicKey), shortHash(base64.StdEncoding.EncodeToString(info.PubKey))},
+		}
+		for _, detail := range details {
+			detail := detail
+			rows = append(rows, func(gtx C) D {
+				return pg.row(gtx, detail.label, detail.value)
+			})
+		}
+	}
+	rows = append(rows, func(gtx C) D {
+		checked := values.String(values.StrNotChecked)
+		if !vsp.CheckedAt.IsZero() {
+			checked = values.FormatDateTime(vsp.CheckedAt)
+		}
+		return pg.row(gtx, values.String(values.StrLastChecked), checked)
+	})
+	if vsp.Err != nil {
+		rows = append(rows, func(gtx C) D {
+			return pg.note(gtx, vsp.Err.Error(), true)
+		})
+	}
+	if vsp.PubKeyChanged {
+		rows = append(rows, func(gtx C) D {
+			return pg.note(gtx, values.String(values.StrKeyChangedNote), true)
+		})
+	}
+	if vsp.PubKeyChanged || vsp.Added {
+		rows = append(rows, func(gtx C) D {
+			return layout.Flex{Alignment: layout.Middle}.Layout(gtx,
+				layout.Rigid(func(gtx C) D {
+					if !vsp.PubKeyChanged {
+						return layout.Dimensions{}
+					}
+					return layout.Inset{Right: values.MarginPadding10}.Layout(gtx, pg.trustButtons[i].Layout)
+				}),
+				layout.Rigid(func(gtx C) D {
+					if !vsp.Added {
+						return layout.Dimensions{}
+					}
+					return pg.removeButtons[i].Layout(gtx)
+				}),
+			)
+		})
+	}
+
+	return common.theme.Card().Layout(gtx, func(gtx C) D {
+		gtx.Constraints.Min.X = gtx.Constraints.Max.X
+		return Container{layout.UniformInset(values.MarginPadding15)}.Layout(gtx, func(gtx C) D {
+			children := make([]layout.FlexChild, len(rows))
+			for i, row := range rows {
+				children[i] = layout.Rigid(row)
+			}
+			return layout.Flex{Axis: layout.Vertical}.Layout(gtx, children...)
+		})
+	})
+}
+
+func (pg *vspsPage) Layout(gtx C) D {
+	common := pg.common
+	// wake up to check the VSPs again when they are due
+	op.InvalidateOp{At: pg.checkedAt.Add(vspHealthCheckInterval)}.Add(gtx.Ops)
+
+	body := func(gtx C) D {
+		page := SubPage{
+			title:      values.String(values.StrVSPs),
+			backButton: pg.backButton,
+			back: func() {
+				common.changePage(PageTickets)
+			},
+			body: func(gtx C) D {
+				sections := []layout.Widget{pg.addCard}
+				for i := range pg.list {
+					i := i
+					sections = append(sections, func(gtx C) D {
+						return pg.vspCard(gtx, i)
+					})
+				}
+				if len(pg.list) == 0 {
+					sections = append(sections, func(gtx C) D {
+						txt := common.theme.Body1(values.String(values.StrNoVSPs))
+						txt.Color = common.theme.Color.Gray2
+						return txt.Layout(gtx)
+					})
+				}
+				return pg.container.Layout(gtx, len(sections), func(gtx C, i int) D {
+					return layout.Inset{Bottom: values.MarginPadding10}.Layout(gtx, sections[i])
+				})
+			},
+		}
+		return common.SubPageLayout(gtx, page)
+	}
+
+	return common.UniformPadding(gtx, body)
+}
@@ -10,16 +10,16 @@ import (
 	"gioui.org/widget"
 	"gioui.org/widget/material"
 
-	"github.com/decred/dcrd/dcrutil"
 	"github.com/planetdecred/dcrlibwallet"
 	"github.com/planetdecred/godcr/ui/decredmaterial"
 	"github.com/planetdecred/godcr/ui/values"
+	"github.com/planetdecred/godcr/wallet"
 )
 
 const PageWallet = "Wallets"
 
 type walletListItem struct {
-	wal      *dcrlibwallet.Wallet
+	wal      *wallet.WalletSummary
 	accounts []*dcrlibwallet.Account
 
 	totalBalance string
@@ -44,8 +44,7 @@ type menuItem struct {
 }
 
 type walletPage struct {
-	multiWallet *dcrlibwallet.MultiWallet
-	listItems   []*walletListItem
+	listItems []*walletListItem
 
 	common *pageCommon
 	theme  *decredmaterial.Theme
@@ -73,7 +72,6 @@ type walletPage struct {
 func WalletPage(common *pageCommon) Page {
 	pg := &walletPage{
 		common:                   common,
-		multiWallet:              common.multiWallet,
 		container:                layout.List{Axis: layout.Vertical},
 		walletsList:              layout.List{Axis: layout.Vertical},
 		watchWalletsList:         common.theme.NewClickableList(layout.Vertical),
@@ -122,7 +120,7 @@ func (pg *walletPage) OnResume() {
 
 	pg.listItems = make([]*walletListItem, 0)
 	for _, wal := range wallets {
-		accountsResult, err := wal.GetAccountsRaw()
+		accountsResult, err := pg.common.wallet.GetAccountsRaw(wal.ID)
 		if err != nil {
 			continue
 		}
@@ -136,12 +134,12 @@ func (pg *walletPage) OnResume() {
 			wal:      wal,
 			accounts: accountsResult.Acc,
 
-			totalBalance: dcrutil.Amount(totalBalance).String(),
+			totalBalance: wallet.FormatAmount(totalBalance),
 			optionsMenu:  pg.getWalletMenu(wal),
 			accountsList: pg.theme.NewClickableList(layout.Vertical),
 		}
 
-		if wal.IsWatchingOnlyWallet() {
+		if wal.IsWatchingOnly {
 			moreBtn := decredmaterial.IconButton{
 				IconButtonStyle: material.IconButtonStyle{
 					Button:     new(widget.Clickable),
@@ -196,8 +194,8 @@ func (pg *walletPage) initializeFloatingMenu() {
 	}
 }
 
-func (pg *walletPage) getWalletMenu(wal *dcrlibwallet.Wallet) []menuItem {
-	if wal.IsWatchingOnlyWallet() {
+func (pg *walletPage) getWalletMenu(wal *wallet.WalletSummary) []menuItem {
+	if wal.IsWatchingOnly {
 		return pg.getWatchOnlyWalletMenu(wal)
 	}
 
@@ -229,7 +227,7 @@ func (pg *walletPage) getWalletMenu(wal *dcrlibwallet.Wallet) []menuItem {
 					hint("Wallet name").
 					positiveButton(values.String(values.StrRename), func(newName string, tim *textInputModal) bool {
 						// todo handle error
-						pg.multiWallet.RenameWallet(wal.ID, newName)
+						pg.common.wallet.RenameWallet(wal.ID, newName)
 						return true
 					})
 
@@ -246,7 +244,7 @@ func (pg *walletPage) getWalletMenu(wal *dcrlibwallet.Wallet) []menuItem {
 	}
 }
 
-func (pg *walletPage) getWatchOnlyWalletMenu(wal *dcrlibwallet.Wallet) []menuItem {
+func (pg *walletPage) getWatchOnlyWalletMenu(wal *wallet.WalletSummary) []menuItem {
 	return []menuItem{
 		{
 			text:   values.String(values.StrSettings),
@@ -261,7 +259,7 @@ func (pg *walletPage) getWatchOnlyWalletMenu(wal *dcrlibwallet.Wallet) []menuIte
 					hint("Wallet name").
 					positiveButton(values.String(values.StrRename), func(newName string, tim *textInputModal) bool {
 						//TODO
-						pg.multiWallet.RenameWallet(wal.ID, newName)
+						pg.common.wallet.RenameWallet(wal.ID, newName)
 						return true
 					})
 
@@ -279,7 +277,7 @@ func (pg *walletPage) showAddWalletModal(common *pageCommon) {
 		enableName(true).
 		passwordCreated(func(walletName, password string, m *createPasswordModal) bool {
 			go func() {
-				_, err := pg.multiWallet.CreateNewWallet(walletName, password, dcrlibwallet.PassphraseTypePass)
+				_, err := pg.common.wallet.CreateNewWallet(walletName, password)
 				if err != nil {
 					m.setError(err.Error())
 					m.setLoading(false)
@@ -295,7 +293,7 @@ func (pg *walletPage) showImportWatchOnlyWalletModal(common *pageCommon) {
 	newCreateWatchOnlyModal(common).
 		watchOnlyCreated(func(walletName, extPubKey string, m *createWatchOnlyModal) bool {
 			go func() {
-				_, err := pg.multiWallet.CreateWatchOnlyWallet(walletName, extPubKey)
+				err := pg.common.wallet.ImportWatchOnlyWallet(walletName, extPubKey)
 				if err != nil {
 					common.notify(err.Error(), false)
 					m.setError(err.Error())
@@ -359,7 +357,7 @@ func (pg *walletPage) layoutOptionsMenu(gtx layout.Context, optionsMenuIndex int
 	}
 
 	var leftInset float32
-	if listItem.wal.IsWatchingOnlyWallet() {
+	if listItem.wal.IsWatchingOnly {
 		leftInset = -35
 	} else {
 		leftInset = -120
@@ -406,7 +404,7 @@ func (pg *walletPage) layoutOptionsMenu(gtx layout.Context, optionsMenuIndex int
 func (pg *walletPage) walletSection(gtx layout.Context, common *pageCommon) layout.Dimensions {
 	return pg.walletsList.Layout(gtx, len(pg.listItems), func(gtx C, i int) D {
 		listItem := pg.listItems[i]
-		if listItem.wal.IsWatchingOnlyWallet() {
+		if listItem.wal.IsWatchingOnly {
 			return D{}
 		}
 
@@ -490,7 +488,7 @@ func (pg *walletPage) walletSection(gtx layout.Context, common *pageCommon) layo
 func (pg *walletPage) watchOnlyWalletSection(gtx layout.Context) layout.Dimensions {
 	hasWatchOnly := false
 	for _, listItem := range pg.listItems {
-		if listItem.wal.IsWatchingOnlyWallet() {
+		if listItem.wal.IsWatchingOnly {
 			hasWatchOnly = true
 			break
 		}
@@ -524,7 +522,7 @@ func (pg *walletPage) watchOnlyWalletSection(gtx layout.Context) layout.Dimensio
 func (pg *walletPage) layoutWatchOnlyWallets(gtx layout.Context) D {
 	return pg.watchWalletsList.Layout(gtx, len(pg.listItems), func(gtx C, i int) D {
 		listItem := pg.listItems[i]
-		if !listItem.wal.IsWatchingOnlyWallet() {
+		if !listItem.wal.IsWatchingOnly {
 			return D{}
 		}
 
@@ -664,7 +662,7 @@ func (pg *walletPage) walletAccountsLayout(gtx layout.Context, account *dcrlibwa
 										}),
 										layout.Flexed(1, func(gtx C) D {
 											return layout.E.Layout(gtx, func(gtx C) D {
-												totalBal := dcrutil.Amount(account.Balance.Spendable).String()
+												totalBal := wallet.FormatAmount(account.Balance.Spendable)
 												return common.layoutBalance(gtx, totalBal, true)
 											})
 										}),
@@ -679,7 +677,7 @@ func (pg *walletPage) walletAccountsLayout(gtx layout.Context, account *dcrlibwa
 									spendableLabel := pg.theme.Body2(values.String(values.StrLabelSpendable))
 									spendableLabel.Color = pg.theme.Color.Gray
 
-									spendableBal := dcrutil.Amount(account.Balance.Spendable).String()
+									spendableBal := wallet.FormatAmount(account.Balance.Spendable)
 									spendableBalLabel := pg.theme.Body2(spendableBal)
 									spendableBalLabel.Color = pg.theme.Color.Gray
 									return pg.tableLayout(gtx, spendableLabel, spendableBalLabel)
@@ -797,6 +795,26 @@ func (pg *walletPage) openPopup(index int) {
 	pg.openPopupIndex = index
 }
 
+// focusTargets returns the accounts of the expanded wallets and the watch
+// only wallets.
+func (pg *walletPage) focusTargets() []focusTarget {
+	var targets []focusTarget
+	for _, listItem := range pg.listItems {
+		if !listItem.wal.IsWatchingOnly && listItem.collapsible.IsExpanded() {
+			targets = append(targets, buttonTargets(listItem.accountsList.Clickables()...)...)
+		}
+	}
+
+	// the watch only wallets list has a hidden row for each other wallet
+	watchOnly := pg.watchWalletsList.Clickables()
+	for i, listItem := range pg.listItems {
+		if listItem.wal.IsWatchingOnly && i < len(watchOnly) {
+			targets = append(targets, buttonTargets(watchOnly[i])...)
+		}
+	}
+	return targets
+}
+
 func (pg *walletPage) handle() {
 	common := pg.common
 
@@ -817,7 +835,7 @@ func (pg *walletPage) handle() {
 			pg.common.changeFragment(AcctDetailsPage(common, listItem.accounts[selectedItem]), PageAccountDetails)
 		}
 
-		if listItem.wal.IsWatchingOnlyWallet() {
+		if listItem.wal.IsWatchingOnly {
 			for listItem.moreButton.Button.Clicked() {
 				pg.openPopup(index)
 			}
@@ -840,8 +858,7 @@ func (pg *walletPage) handle() {
 								positiveButton(values.String(values.StrConfirm), func(password string, pm *passwordModal) bool {
 									go func() {
 
-										wal := pg.multiWallet.WalletWithID(walletID)
-										wal.CreateNewAccount(accountName, []byte(password)) // TODO
+										pg.common.wallet.CreateNewAccount(walletID, accountName, []byte(password)) // TODO
 										pm.Dismiss()
 									}()
 
@@ -7,6 +7,7 @@ import (
 	"github.com/planetdecred/dcrlibwallet"
 	"github.com/planetdecred/godcr/ui/decredmaterial"
 	"github.com/planetdecred/godcr/ui/values"
+	"github.com/planetdecred/godcr/wallet"
 )
 
 const PageWalletSettings = "WalletSettings"
@@ -14,7 +15,7 @@ const PageWalletSettings = "WalletSettings"
 type walletSettingsPage struct {
 	theme  *decredmaterial.Theme
 	common *pageCommon
-	wallet *dcrlibwallet.Wallet
+	wallet *wallet.WalletSummary
 
 	changePass, rescan, deleteWallet *widget.Clickable
 
@@ -24,7 +25,7 @@ type walletSettingsPage struct {
 	backButton       decredmaterial.IconButton
 }
 
-func WalletSettingsPage(common *pageCommon, wal *dcrlibwallet.Wallet) Page {
+func WalletSettingsPage(common *pageCommon, wal *wallet.WalletSummary) Page {
 	pg := &walletSettingsPage{
 		theme:         common.theme,
 		common:        common,
@@ -50,7 +51,7 @@ func (pg *walletSettingsPage) OnResume() {
 func (pg *walletSettingsPage) Layout(gtx layout.Context) layout.Dimensions {
 	common := pg.common
 
-	beep := pg.wallet.ReadBoolConfigValueForKey(dcrlibwallet.BeepNewBlocksConfigKey, false)
+	beep := common.wallet.ReadWalletBoolConfigValueForKey(pg.wallet.ID, dcrlibwallet.BeepNewBlocksConfigKey, false)
 	pg.notificationW.Value = beep
 	if beep {
 		pg.notificationW.Value = true
@@ -184,13 +185,13 @@ func (pg *walletSettingsPage) handle() {
 			negativeButton(values.String(values.StrCancel), func() {}).
 			positiveButton(values.String(values.StrConfirm), func(password string, pm *passwordModal) bool {
 				go func() {
-					err := pg.wallet.UnlockWallet([]byte(password))
+					err := common.wallet.UnlockWallet(pg.wallet.ID, []byte(password))
 					if err != nil {
 						pm.setError(err.Error())
 						pm.setLoading(false)
 						return
 					}
-					pg.wallet.LockWallet()
+					common.wallet.LockWallet(pg.wallet.ID)
 					pm.Dismiss()
 
 					// change password
@@ -201,8 +202,7 @@ func (pg *walletSettingsPage) handle() {
 						confirmPasswordHint("Confirm new spending password").
 						passwordCreated(func(walletName, newPassword string, m *createPasswordModal) bool {
 							go func() {
-								err := pg.common.multiWallet.ChangePrivatePassphraseForWallet(pg.wallet.ID, []byte(password),
-									[]byte(newPassword), dcrlibwallet.PassphraseTypePass)
+								err := common.wallet.ChangeWalletPassphrase(pg.wallet.ID, []byte(password), []byte(newPassword))
 								if err != nil {
 									m.setError(err.Error())
 									m.setLoading(false)
@@ -228,7 +228,7 @@ func (pg *walletSettingsPage) handle() {
 					" blockchain for transactions").
 				negativeButton(values.String(values.StrCancel), func() {}).
 				positiveButton(values.String(values.StrRescan), func() {
-					err := pg.common.multiWallet.RescanBlocks(pg.wallet.ID)
+					err := common.wallet.RescanBlocks(pg.wallet.ID)
 					if err != nil {
 						if err.Error() == dcrlibwallet.ErrNotConnected {
 							common.notify(values.String(values.StrNotConnected), false)
@@ -247,7 +247,7 @@ func (pg *walletSettingsPage) handle() {
 	}
 
 	if pg.notificationW.Changed() {
-		pg.wallet.SetBoolConfigValueForKey(dcrlibwallet.BeepNewBlocksConfigKey, pg.notificationW.Value)
+		common.wallet.SetWalletBoolConfigValueForKey(pg.wallet.ID, dcrlibwallet.BeepNewBlocksConfigKey, pg.notificationW.Value)
 	}
 
 	for pg.deleteWallet.Clicked() {
@@ -263,7 +263,7 @@ func (pg *walletSettingsPage) handle() {
 					positiveButtonStyle(common.theme.Color.Surface, common.theme.Color.Danger).
 					positiveButton(values.String(values.StrConfirm), func(password string, pm *passwordModal) bool {
 						go func() {
-							err := pg.common.multiWallet.DeleteWallet(pg.wallet.ID, []byte(password))
+							err := common.wallet.DeleteWallet(pg.wallet.ID, []byte(password))
 							if err != nil {
 								pm.setError(err.Error())
 								pm.setLoading(false)
@@ -12,8 +12,10 @@ import (
 	"gioui.org/layout"
 	"gioui.org/op"
 	"gioui.org/text"
+	"gioui.org/widget"
 
 	"github.com/planetdecred/dcrlibwallet"
+	"github.com/planetdecred/godcr/crash"
 	"github.com/planetdecred/godcr/ui/decredmaterial"
 	"github.com/planetdecred/godcr/ui/values"
 	"github.com/planetdecred/godcr/wallet"
@@ -26,7 +28,7 @@ type Window struct {
 	ops        *op.Ops
 	invalidate chan struct{}
 
-	wallet               *wallet.Wallet
+	wallet               wallet.Backend
 	walletInfo           *wallet.MultiWalletInfo
 	walletSyncStatus     *wallet.SyncStatus
 	walletTransactions   *wallet.Transactions
@@ -34,7 +36,10 @@ type Window struct {
 	walletAccount        *wallet.Account
 	walletTickets        *wallet.Tickets
 	vspInfo              *wallet.VSP
+	ticketPriceForecast  *wallet.TicketPriceForecast
 	proposals            *wallet.Proposals
+	proposalVersions     *wallet.ProposalVersions
+	proposalComments     *wallet.ProposalComments
 	selectedProposal     *dcrlibwallet.Proposal
 	proposal             chan *wallet.Proposal
 	walletUnspentOutputs *wallet.UnspentOutputs
@@ -47,10 +52,8 @@ type Window struct {
 	currentPage   Page
 	pageBackStack []Page
 
-	signatureResult *wallet.Signature
-
 	selectedAccount int
-	txAuthor        dcrlibwallet.TxAuthor
+	txAuthor        wallet.TxAuthor
 	broadcastResult wallet.Broadcast
 
 	selected int
@@ -63,6 +66,18 @@ type Window struct {
 	sysDestroyWithSync    bool
 	walletAcctMixerStatus chan *wallet.AccountMixer
 	internalLog           chan string
+
+	scale            uiScale
+	shortcuts        *shortcuts
+	pendingShortcuts []shortcutAction
+	// focusedEditor is the editor that had the keyboard focus in the last
+	// frame.
+	focusedEditor *widget.Editor
+
+	// crashReports receives the reports of recovered panics. crash is the
+	// dialog shown for the last one.
+	crashReports chan crash.Report
+	crash        *crashDialog
 }
 
 type WriteClipboard struct {
@@ -74,18 +89,29 @@ type WriteClipboard struct {
 // Should never be called more than once as it calls
 // app.NewWindow() which does not support being called more
 // than once.
-func CreateWindow(wal *wallet.Wallet, decredIcons map[string]image.Image, collection []text.FontFace, internalLog chan string) (*Window, *app.Window, error) {
-	win := new(Window)
+func CreateWindow(wal wallet.Backend, decredIcons map[string]image.Image, collection []text.FontFace, internalLog chan string) (*Window, *app.Window, error) {
 	var netType string
-	if wal.Net == "testnet3" {
+	if wal.Network() == "testnet3" {
 		netType = "testnet"
 	} else {
-		netType = wal.Net
+		netType = wal.Network()
 	}
 	appWindow := app.NewWindow(app.Size(values.AppWidth, values.AppHeight), app.Title(values.StringF(values.StrAppTitle, netType)))
+	win, err := newWindow(wal, decredIcons, collection, internalLog)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return win, appWindow, nil
+}
+
+// newWindow initializes the window state and pages without creating an
+// app.Window, so that pages can also be rendered offscreen.
+func newWindow(wal wallet.Backend, decredIcons map[string]image.Image, collection []text.FontFace, internalLog chan string) (*Window, error) {
+	win := new(Window)
 	theme := decredmaterial.NewTheme(collection, decredIcons, false)
 	if theme == nil {
-		return nil, nil, errors.New("Unexpected error while loading theme")
+		return nil, errors.New("Unexpected error while loading theme")
 	}
 	win.theme = theme
 	win.ops = &op.Ops{}
@@ -97,7 +123,10 @@ func CreateWindow(wal *wallet.Wallet, decredIcons map[string]image.Image, collec
 	win.walletAcctMixerStatus = make(chan *wallet.AccountMixer)
 	win.walletTickets = new(wallet.Tickets)
 	win.vspInfo = new(wallet.VSP)
+	win.ticketPriceForecast = new(wallet.TicketPriceForecast)
 	win.proposals = new(wallet.Proposals)
+	win.proposalVersions = new(wallet.ProposalVersions)
+	win.proposalComments = new(wallet.ProposalComments)
 	win.proposal = make(chan *wallet.Proposal)
 	win.invalidate = make(chan struct{}, 2)
 
@@ -105,12 +134,22 @@ func CreateWindow(wal *wallet.Wallet, decredIcons map[string]image.Image, collec
 	win.states.loading = false
 
 	win.keyEvents = make(chan *key.Event)
+	win.scale = defaultUIScale()
+	win.shortcuts = newShortcuts()
 
 	win.internalLog = internalLog
 
+	win.crashReports = make(chan crash.Report, 4)
+	crash.Handle(func(report crash.Report) {
+		select {
+		case win.crashReports <- report:
+		default:
+		}
+	})
+
 	win.common = win.newPageCommon(decredIcons)
 
-	return win, appWindow, nil
+	return win, nil
 }
 
 func (win *Window) Start() {
@@ -181,6 +220,7 @@ func (win *Window) unloaded(w *app.Window) {
 		case system.DestroyEvent:
 			return
 		case system.FrameEvent:
+			evt.Metric = win.scale.metric(evt.Metric)
 			gtx := layout.NewContext(win.ops, evt)
 			lbl.Layout(gtx)
 			evt.Frame(win.ops)
@@ -196,6 +236,7 @@ func (win *Window) layoutPage(gtx C, page Page) {
 			return decredmaterial.Fill(gtx, win.theme.Color.LightGray)
 		}),
 		layout.Stacked(func(gtx C) D {
+			win.handleShortcuts(gtx)
 			page.handle()
 			return page.Layout(gtx)
 		}),
@@ -213,13 +254,33 @@ func (win *Window) layoutPage(gtx C, page Page) {
 	)
 }
 
-// Loop runs main event handling and page rendering loop
+// Loop runs main event handling and page rendering loop. A panic while
+// handling an event is recovered and reported, and the loop continues with
+// the crash dialog shown instead of the page.
 func (win *Window) Loop(w *app.Window, shutdown chan int) {
+	for !win.loop(w, shutdown) {
+		w.Invalidate()
+	}
+}
+
+// loop handles events until the window is closed and reports whether it
+// returned without a panic.
+func (win *Window) loop(w *app.Window, shutdown chan int) (done bool) {
+	defer func() {
+		if r := recover(); r != nil {
+			win.recoverPanic(r)
+			done = false
+		}
+	}()
+
 	for {
 		select {
+		case report := <-win.crashReports:
+			win.showCrash(report, false)
+			w.Invalidate()
 		case <-win.invalidate:
 			w.Invalidate()
-		case e := <-win.wallet.Send:
+		case e := <-win.wallet.Responses():
 			if e.Err != nil {
 				err := e.Err.Error()
 				log.Error("Wallet Error: " + err)
@@ -240,7 +301,7 @@ func (win *Window) Loop(w *app.Window, shutdown chan int) {
 
 			win.updateStates(e.Resp)
 
-		case update := <-win.wallet.Sync:
+		case update := <-win.wallet.SyncUpdates():
 			switch update.Stage {
 			case wallet.SyncCompleted:
 				if win.sysDestroyWithSync {
@@ -271,6 +332,7 @@ func (win *Window) Loop(w *app.Window, shutdown chan int) {
 			case wallet.BlockAttached:
 				if win.walletInfo.Synced {
 					win.wallet.GetAllTickets()
+					win.wallet.GetTicketPriceForecast()
 					win.wallet.GetMultiWalletInfo()
 					win.updateSyncProgress(update.BlockInfo)
 				}
@@ -282,6 +344,9 @@ func (win *Window) Loop(w *app.Window, shutdown chan int) {
 				}()
 			case wallet.ProposalAdded, wallet.ProposalVoteFinished, wallet.ProposalVoteStarted, wallet.ProposalSynced:
 				win.wallet.GetAllProposals()
+				if update.Stage == wallet.ProposalSynced {
+					win.wallet.RefreshProposalCache()
+				}
 				go func() {
 					win.proposal <- &update.Proposal
 				}()
@@ -305,18 +370,35 @@ func (win *Window) Loop(w *app.Window, shutdown chan int) {
 					close(shutdown)
 				}
 			case system.FrameEvent:
+				evt.Metric = win.scale.metric(evt.Metric)
 				gtx := layout.NewContext(win.ops, evt)
 				ts := int64(time.Since(time.Unix(win.walletInfo.BestBlockTime, 0)).Seconds())
 				win.walletInfo.LastSyncTime = wallet.SecondsToDays(ts)
 
-				if win.currentPage != nil {
+				switch {
+				case win.crash != nil && win.crash.fatal:
+					win.layoutCrash(gtx, w)
+				case win.currentPage != nil:
 					win.layoutPage(gtx, win.currentPage)
-				} else {
+					if win.crash != nil {
+						win.layoutCrash(gtx, w)
+					}
+				default:
 					win.Loading(gtx)
 				}
 
 				evt.Frame(gtx.Ops)
 			case key.Event:
+				if win.shortcuts.capture != nil && evt.State == key.Press {
+					win.shortcuts.capture(evt)
+					w.Invalidate()
+					break
+				}
+				if action, ok := win.shortcuts.match(evt); ok && win.acceptShortcut(action) {
+					win.pendingShortcuts = append(win.pendingShortcuts, action)
+					w.Invalidate()
+					break
+				}
 				go func() {
 					win.keyEvents <- &evt
 				}()
@@ -0,0 +1,236 @@
+package wallet
+
+import (
+	"errors"
+	"math"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/decred/dcrd/dcrutil"
+)
+
+const (
+	// AmountUnitConfigKey and AmountPrecisionConfigKey are the config keys of
+	// the amount display preferences.
+	AmountUnitConfigKey      = "amount_unit"
+	AmountPrecisionConfigKey = "amount_precision"
+
+	// AmountPrecisionAuto shows amounts with as many decimals as they need.
+	AmountPrecisionAuto = -1
+)
+
+// Amount display units, named after their labels.
+const (
+	UnitDCR      = "DCR"
+	UnitMilliDCR = "mDCR"
+	UnitMicroDCR = "µDCR"
+	UnitAtoms    = "atoms"
+)
+
+var amountUnits = map[string]dcrutil.AmountUnit{
+	UnitDCR:      dcrutil.AmountCoin,
+	UnitMilliDCR: dcrutil.AmountMilliCoin,
+	UnitMicroDCR: dcrutil.AmountMicroCoin,
+	UnitAtoms:    dcrutil.AmountAtom,
+}
+
+// AmountLocale formats and parses amounts for a language. Its functions are
+// called each time an amount is formatted or parsed so that they follow
+// changes of language. Nil functions format amounts as strconv does.
+type AmountLocale struct {
+	// GroupDigits returns n with digit grouping.
+	GroupDigits func(n int64) string
+	// GroupSeparator returns the separator GroupDigits puts between groups
+	// of three digits.
+	GroupSeparator func() string
+	// DecimalSeparator returns the separator of the whole and fractional
+	// parts of amounts.
+	DecimalSeparator func() string
+}
+
+var (
+	amountDisplayMu sync.RWMutex
+	amountUnit      = UnitDCR
+	amountPrecision = AmountPrecisionAuto
+	amountLocale    AmountLocale
+)
+
+var errInvalidAmount = errors.New("invalid amount")
+
+// SetAmountDisplay sets the unit and maximum number of decimals amounts are
+// formatted and parsed with. An unknown unit selects DCR.
+func SetAmountDisplay(unit string, precision int) {
+	if _, ok := amountUnits[unit]; !ok {
+		unit = UnitDCR
+	}
+	if precision < 0 {
+		precision = AmountPrecisionAuto
+	}
+
+	amountDisplayMu.Lock()
+	amountUnit, amountPrecision = unit, precision
+	amountDisplayMu.Unlock()
+}
+
+// SetAmountLocale sets the language amounts are formatted and parsed for.
+func SetAmountLocale(locale AmountLocale) {
+	amountDisplayMu.Lock()
+	amountLocale = locale
+	amountDisplayMu.Unlock()
+}
+
+// separators returns the digit group and decimal separators of the amount
+// locale.
+func separators() (group, decimal string) {
+	amountDisplayMu.RLock()
+	locale := amountLocale
+	amountDisplayMu.RUnlock()
+
+	decimal = "."
+	if locale.GroupSeparator != nil {
+		group = locale.GroupSeparator()
+	}
+	if locale.DecimalSeparator != nil {
+		decimal = locale.DecimalSeparator()
+	}
+	return group, decimal
+}
+
+// AmountUnit returns the label of the unit amounts are displayed in.
+func AmountUnit() string {
+	amountDisplayMu.RLock()
+	defer amountDisplayMu.RUnlock()
+	return amountUnit
+}
+
+// unitDecimals returns the number of decimals needed to show all atoms of an
+// amount in u.
+func unitDecimals(u dcrutil.AmountUnit) int {
+	return int(u) + 8
+}
+
+// FormatAmountValue returns atoms in the display unit, rounded to the
+// display precision, with the digit grouping and decimal separator of the
+// amount locale and without the unit label.
+func FormatAmountValue(atoms int64) string {
+	amountDisplayMu.RLock()
+	precision := amountPrecision
+	amountDisplayMu.RUnlock()
+
+	return localizeAmount(formatAmountValue(atoms, precision))
+}
+
+// AmountInputValue returns atoms in the display unit with full precision and
+// without digit grouping, for amount editors. ParseAmount returns the same
+// amount for its result.
+func AmountInputValue(atoms int64) string {
+	_, decimal := separators()
+	return strings.Replace(formatAmountValue(atoms, AmountPrecisionAuto), ".", decimal, 1)
+}
+
+func formatAmountValue(atoms int64, precision int) string {
+	amountDisplayMu.RLock()
+	u := amountUnits[amountUnit]
+	amountDisplayMu.RUnlock()
+
+	decimals := unitDecimals(u)
+	value := float64(atoms) / math.Pow10(decimals)
+	if precision == AmountPrecisionAuto || precision > decimals {
+		return strconv.FormatFloat(value, 'f', -1, 64)
+	}
+	return strconv.FormatFloat(value, 'f', precision, 64)
+}
+
+// localizeAmount returns a decimal amount formatted by strconv with the digit
+// grouping and decimal separator of the amount locale.
+func localizeAmount(amount string) string {
+	amountDisplayMu.RLock()
+	groupDigits := amountLocale.GroupDigits
+	amountDisplayMu.RUnlock()
+	_, decimal := separators()
+
+	var sign string
+	if strings.HasPrefix(amount, "-") {
+		sign, amount = "-", amount[1:]
+	}
+	whole, fraction := amount, ""
+	if i := strings.IndexByte(amount, '.'); i >= 0 {
+		whole, fraction = amount[:i], amount[i+1:]
+	}
+	if n, err := strconv.ParseInt(whole, 10, 64); err == nil && groupDigits != nil {
+		whole = groupDigits(n)
+	}
+	if fraction == "" {
+		return sign + whole
+	}
+	return sign + whole + decimal + fraction
+}
+
+// FormatAmount returns atoms in the display unit, followed by the unit label.
+// It is used in place of dcrutil.Amount.String for all displayed amounts.
+func FormatAmount(atoms int64) string {
+	return FormatAmountValue(atoms) + " " + AmountUnit()
+}
+
+// ParseAmount parses an amount entered in the display unit and returns it in
+// atoms. The whole part may group its digits in threes with the group
+// separator of the amount locale, and the decimal separator of the locale or
+// a period separates the fractional part.
+func ParseAmount(s string) (int64, error) {
+	group, decimal := separators()
+	s = strings.TrimSpace(s)
+
+	whole, fraction := s, ""
+	if i := strings.LastIndex(s, decimal); i >= 0 {
+		whole, fraction = s[:i], s[i+len(decimal):]
+	} else if i := strings.LastIndex(s, "."); i >= 0 && group != "." {
+		whole, fraction = s[:i], s[i+1:]
+	}
+	if whole == "" && fraction == "" {
+		return 0, errInvalidAmount
+	}
+
+	whole, ok := ungroupDigits(whole, group)
+	if !ok {
+		return 0, errInvalidAmount
+	}
+
+	amountDisplayMu.RLock()
+	u := amountUnits[amountUnit]
+	amountDisplayMu.RUnlock()
+
+	decimals := unitDecimals(u)
+	if len(fraction) > decimals {
+		return 0, errInvalidAmount
+	}
+	digits := whole + fraction + strings.Repeat("0", decimals-len(fraction))
+	if digits == "" || strings.TrimLeft(digits, "0123456789") != "" {
+		return 0, errInvalidAmount
+	}
+
+	atoms, err := strconv.ParseInt(digits, 10, 64)
+	if err != nil || atoms > dcrutil.MaxAmount {
+		return 0, errInvalidAmount
+	}
+	return atoms, nil
+}
+
+// ungroupDigits removes the group separators of the whole part of an amount.
+// ok is false if the digits are not grouped in threes.
+func ungroupDigits(whole, group string) (string, bool) {
+	if group == "" || !strings.Contains(whole, group) {
+		return whole, true
+	}
+
+	groups := strings.Split(whole, group)
+	if n := len(groups[0]); n < 1 || n > 3 {
+		return "", false
+	}
+	for _, g := range groups[1:] {
+		if len(g) != 3 {
+			return "", false
+		}
+	}
+	return strings.Join(groups, ""), true
+}
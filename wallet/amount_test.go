@@ -0,0 +1,129 @@
+package wallet_test
+
+import (
+	"strconv"
+	"strings"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	. "github.com/planetdecred/godcr/wallet"
+)
+
+// testLocale groups digits in threes with group and separates decimals with
+// decimal.
+func testLocale(group, decimal string) AmountLocale {
+	return AmountLocale{
+		GroupDigits: func(n int64) string {
+			digits := strconv.FormatInt(n, 10)
+			var sign string
+			if n < 0 {
+				sign, digits = "-", digits[1:]
+			}
+			for i := len(digits) - 3; i > 0; i -= 3 {
+				digits = digits[:i] + group + digits[i:]
+			}
+			return sign + digits
+		},
+		GroupSeparator:   func() string { return group },
+		DecimalSeparator: func() string { return decimal },
+	}
+}
+
+var _ = Describe("Amount display", func() {
+	BeforeEach(func() {
+		SetAmountLocale(testLocale(",", "."))
+	})
+
+	AfterEach(func() {
+		SetAmountDisplay(UnitDCR, AmountPrecisionAuto)
+		SetAmountLocale(AmountLocale{})
+	})
+
+	It("formats amounts in DCR by default", func() {
+		Expect(FormatAmount(150000000)).To(Equal("1.5 DCR"))
+	})
+	It("formats amounts in the selected unit and precision", func() {
+		SetAmountDisplay(UnitMilliDCR, 2)
+		Expect(FormatAmount(123456789)).To(Equal("1,234.57 mDCR"))
+		Expect(AmountInputValue(123456789)).To(Equal("1234.56789"))
+
+		SetAmountDisplay(UnitAtoms, 2)
+		Expect(FormatAmount(42)).To(Equal("42 atoms"))
+	})
+	It("formats amounts for the amount locale", func() {
+		SetAmountLocale(testLocale(" ", ","))
+		SetAmountDisplay(UnitMilliDCR, 2)
+		Expect(FormatAmount(123456789)).To(Equal("1 234,57 mDCR"))
+		Expect(FormatAmount(-50000)).To(Equal("-0,50 mDCR"))
+		Expect(AmountInputValue(123456789)).To(Equal("1234,56789"))
+
+		SetAmountLocale(AmountLocale{})
+		Expect(FormatAmount(123456789)).To(Equal("1234.57 mDCR"))
+	})
+	It("parses amounts in the selected unit", func() {
+		SetAmountDisplay(UnitMicroDCR, AmountPrecisionAuto)
+		atoms, err := ParseAmount(" 1.5 ")
+		Expect(err).To(BeNil())
+		Expect(atoms).To(BeEquivalentTo(150))
+
+		SetAmountDisplay(UnitDCR, AmountPrecisionAuto)
+		atoms, err = ParseAmount("0.29")
+		Expect(err).To(BeNil())
+		Expect(atoms).To(BeEquivalentTo(29000000))
+		atoms, err = ParseAmount(".00000001")
+		Expect(err).To(BeNil())
+		Expect(atoms).To(BeEquivalentTo(1))
+		atoms, err = ParseAmount("21000000")
+		Expect(err).To(BeNil())
+		Expect(atoms).To(BeEquivalentTo(2100000000000000))
+
+		for _, invalid := range []string{"", ".", "-1", "+1", "1e3", "0.000000001", "21000000.00000001", "99999999999"} {
+			_, err = ParseAmount(invalid)
+			Expect(err).NotTo(BeNil(), invalid)
+		}
+
+		SetAmountDisplay(UnitAtoms, AmountPrecisionAuto)
+		_, err = ParseAmount("1.5")
+		Expect(err).NotTo(BeNil())
+	})
+	It("parses digits grouped with the group separator of the locale", func() {
+		atoms, err := ParseAmount("1,234.5")
+		Expect(err).To(BeNil())
+		Expect(atoms).To(BeEquivalentTo(123450000000))
+
+		for _, invalid := range []string{"1,5", "1,2345", ",123", "1234,567", "1.234,5"} {
+			_, err = ParseAmount(invalid)
+			Expect(err).NotTo(BeNil(), invalid)
+		}
+
+		SetAmountLocale(testLocale(" ", ","))
+		atoms, err = ParseAmount("1 234,5")
+		Expect(err).To(BeNil())
+		Expect(atoms).To(BeEquivalentTo(123450000000))
+		atoms, err = ParseAmount("1,5")
+		Expect(err).To(BeNil())
+		Expect(atoms).To(BeEquivalentTo(150000000))
+		atoms, err = ParseAmount("1.5")
+		Expect(err).To(BeNil())
+		Expect(atoms).To(BeEquivalentTo(150000000))
+
+		SetAmountLocale(testLocale(".", ","))
+		atoms, err = ParseAmount("1.234")
+		Expect(err).To(BeNil())
+		Expect(atoms).To(BeEquivalentTo(123400000000))
+	})
+	It("parses the amounts it formats for editors", func() {
+		SetAmountLocale(testLocale(".", ","))
+		for _, unit := range []string{UnitDCR, UnitMilliDCR, UnitMicroDCR, UnitAtoms} {
+			SetAmountDisplay(unit, 2)
+			atoms, err := ParseAmount(AmountInputValue(123456789))
+			Expect(err).To(BeNil())
+			Expect(atoms).To(BeEquivalentTo(123456789), unit)
+			Expect(strings.Contains(AmountInputValue(123456789), ".")).To(Equal(false))
+		}
+	})
+	It("falls back to DCR for an unknown unit", func() {
+		SetAmountDisplay("bogus", AmountPrecisionAuto)
+		Expect(AmountUnit()).To(Equal(UnitDCR))
+	})
+})
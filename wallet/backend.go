@@ -0,0 +1,400 @@
+package wallet
+
+import (
+	"github.com/planetdecred/dcrlibwallet"
+)
+
+// Backend is the wallet API used by the UI. It is implemented by Wallet, and
+// by FakeWallet for running the UI without any chain data.
+//
+// Methods without a return value are non-blocking and send their result or
+// any error to the Responses channel.
+type Backend interface {
+	// Network returns the name of the network the wallets are on.
+	Network() string
+	// Responses returns the channel results of non-blocking methods are sent
+	// to.
+	Responses() chan Response
+	// SyncUpdates returns the channel sync and notification updates are sent
+	// to once SetupListeners has been called.
+	SyncUpdates() chan SyncStatusUpdate
+
+	InitMultiWallet() error
+	// IsConfigLoaded reports whether InitMultiWallet loaded the wallets and
+	// their config.
+	IsConfigLoaded() bool
+	SetupListeners()
+	Shutdown()
+
+	// Startup passphrase
+	IsStartupSecuritySet() bool
+	VerifyStartupPassphrase(passphrase []byte) error
+	SetStartupPassphrase(passphrase []byte) error
+	ChangeStartupPassphrase(oldPassphrase, newPassphrase []byte) error
+	RemoveStartupPassphrase(passphrase []byte) error
+
+	// Notifications
+	AddTxAndBlockNotificationListener(listener dcrlibwallet.TxAndBlockNotificationListener, uniqueIdentifier string) error
+	RemoveTxAndBlockNotificationListener(uniqueIdentifier string)
+	AddAccountMixerNotificationListener(listener dcrlibwallet.AccountMixerNotificationListener, uniqueIdentifier string) error
+	RemoveAccountMixerNotificationListener(uniqueIdentifier string)
+	AddProposalNotificationListener(listener dcrlibwallet.ProposalNotificationListener, uniqueIdentifier string) error
+	RemoveProposalNotificationListener(uniqueIdentifier string)
+
+	// Sync
+	StartSync() error
+	CancelSync()
+	IsSynced() bool
+	IsSyncing() bool
+	IsConnectedToDecredNetwork() bool
+	ConnectedPeers() int32
+	PeerInfo() ([]dcrlibwallet.PeerInfo, error)
+	GetBestBlock() *dcrlibwallet.BlockInfo
+	SetOverallBlockHeight(height int32)
+	AddSyncProgressListener(listener dcrlibwallet.SyncProgressListener, uniqueIdentifier string) error
+	RemoveSyncProgressListener(uniqueIdentifier string)
+
+	// Wallets
+	// LoadedWalletsCount returns how many wallets are loaded, whether they
+	// are opened or not.
+	LoadedWalletsCount() int32
+	// OpenWallets opens the loaded wallets, with the startup passphrase if
+	// one is set.
+	OpenWallets(startupPassphrase []byte) error
+	// AllWallets returns the loaded wallets, sorted by ID.
+	AllWallets() []*WalletSummary
+	// WalletWithID returns the loaded wallet with walletID, or nil if there
+	// is none.
+	WalletWithID(walletID int) *WalletSummary
+	CreateNewWallet(name, passphrase string) (*WalletSummary, error)
+	RestoreWallet(name, seed, passphrase string) (*WalletSummary, error)
+	ImportWatchOnlyWallet(name, extendedPublicKey string) error
+	RenameWallet(walletID int, name string) error
+	DeleteWallet(walletID int, passphrase []byte) error
+	ChangeWalletPassphrase(walletID int, oldPrivatePassphrase, newPrivatePassphrase []byte) error
+	UnlockWallet(walletID int, passphrase []byte) error
+	LockWallet(walletID int)
+	IsLocked(walletID int) bool
+	// HasDiscoveredAccounts reports whether the accounts of a wallet were
+	// discovered, which needs the wallet to be unlocked on its first sync.
+	HasDiscoveredAccounts(walletID int) bool
+	// IsWaiting reports whether a wallet waits for the other wallets to sync.
+	IsWaiting(walletID int) bool
+	// WalletBestBlock returns the height and timestamp of the best block of
+	// a wallet.
+	WalletBestBlock(walletID int) (height int32, timestamp int64)
+	RescanBlocks(walletID int) error
+	ReadWalletBoolConfigValueForKey(walletID int, key string, defaultValue bool) bool
+	SetWalletBoolConfigValueForKey(walletID int, key string, value bool)
+
+	// Accounts and addresses
+	GetAccountsRaw(walletID int) (*dcrlibwallet.Accounts, error)
+	AccountName(walletID int, accountNumber int32) (string, error)
+	CreateNewAccount(walletID int, name string, passphrase []byte) (int32, error)
+	RenameAccount(walletID int, accountNumber int32, name string) error
+	CurrentAddress(walletID int, accountNumber int32) (string, error)
+	NextAddress(walletID int, accountNumber int32) (string, error)
+	// HaveWalletAddress reports whether address belongs to the wallet
+	// identified by walletID.
+	HaveWalletAddress(walletID int, address string) bool
+	SignMessage(walletID int, passphrase []byte, address, message string) ([]byte, error)
+
+	// Account mixer
+	IsAccountMixerConfigSet(walletID int) bool
+	IsAccountMixerActive(walletID int) bool
+	CreateMixerAccounts(walletID int, mixedAccount, unmixedAccount, passphrase string) error
+	MixedAccountNumber(walletID int) int32
+	UnmixedAccountNumber(walletID int) int32
+	StartAccountMixer(walletID int, walletPassphrase string) error
+	StopAccountMixer(walletID int) error
+
+	// Transactions
+	GetMultiWalletInfo()
+	GetAllTransactions(offset, limit, txfilter int32)
+	GetTransaction(walletID int, txnHash string)
+	GetTransactionsRaw(offset, limit, txFilter int32, newestFirst bool) ([]dcrlibwallet.Transaction, error)
+	GetWalletTransactionsRaw(walletID int, offset, limit, txFilter int32, newestFirst bool) ([]dcrlibwallet.Transaction, error)
+	CreateTransaction(walletID int, accountID int32, errChan chan error)
+	BroadcastTransaction(txAuthor TxAuthor, passphrase []byte, errChan chan error)
+	IsAddressValid(address string) (bool, error)
+	HaveAddress(address string) (bool, string)
+	VerifyMessage(address string, message string, signature string) (bool, error)
+	GetWalletSeedPhrase(walletID int, password []byte) (string, error)
+	VerifyWalletSeedPhrase(walletID int, seedPhrase string, privpass []byte) error
+	GetBlockExplorerURL(txnHash string) string
+	GetUSDExchangeValues(target interface{}) error
+	WalletDirectory() string
+	DataSize() string
+
+	// Staking
+	GetAllTickets()
+	TicketPrice() (int64, string)
+	// TicketPriceForecast returns the next ticket price with when it changes
+	// and the price history. Auto-buying should use it to decide when to
+	// buy.
+	TicketPriceForecast() (*TicketPriceForecast, error)
+	// GetTicketPriceForecast is the non-blocking TicketPriceForecast, sending
+	// its result to the Send channel.
+	GetTicketPriceForecast()
+	NewVSPD(host string, walletID int, accountID int32) (*dcrlibwallet.VSP, error)
+	PurchaseTicket(walletID int, accountID int32, tickets uint32, passphrase []byte, vspd *dcrlibwallet.VSP, errChan chan error)
+	// TicketDetails returns the transactions, VSP and heights of a ticket.
+	TicketDetails(walletID int, ticket Ticket) (*TicketDetails, error)
+	// CheckVSPTickets asks the VSPs in use about the unspent tickets of a
+	// wallet and returns how many tickets were checked.
+	CheckVSPTickets(walletID int, passphrase []byte) (int, error)
+	// RetryVSPFee pays the fee of a ticket to the VSP at host again.
+	RetryVSPFee(walletID int, ticket Ticket, host string, passphrase []byte) error
+	// Agendas returns the consensus agendas and treasury keys tickets vote
+	// on.
+	Agendas() ([]Agenda, error)
+	// VoteChoices returns the vote choices of a wallet by agenda ID.
+	VoteChoices(walletID int) (map[string]string, error)
+	// SetVoteChoice saves the choice of a wallet on an agenda.
+	SetVoteChoice(walletID int, agendaID, choiceID string) error
+	// PushVoteChoices sends the vote choices of a wallet to the VSPs of its
+	// tickets and returns how many tickets they were recorded for.
+	PushVoteChoices(walletID int, passphrase []byte) (int, error)
+	AddVSP(host string, errChan chan error)
+	GetAllVSP()
+	// CheckVSPs asks every VSP for its info again, ignoring the cache.
+	CheckVSPs()
+	// RemoveVSP removes a VSP added by the user.
+	RemoveVSP(host string) error
+	// TrustVSPPubKey accepts the changed public key of a VSP.
+	TrustVSPPubKey(host string) error
+	RememberVSP(host string)
+	GetRememberVSP() string
+
+	// Governance
+	GetAllProposals()
+	SyncProposals()
+	IsSyncingProposals() bool
+	// FetchProposalContent returns the description and attachments of the
+	// latest version of a proposal, from the cache when offline.
+	FetchProposalContent(token string) (*ProposalContent, error)
+	// RefreshProposalCache caches the updates of the cached proposals.
+	RefreshProposalCache()
+	// GetProposalComments sends the comments on a proposal on Send.
+	GetProposalComments(token string)
+	// GetProposalVersions sends every version of a proposal on Send.
+	GetProposalVersions(token string)
+	// WatchedProposals returns the tokens of the proposals the user watches.
+	WatchedProposals() map[string]bool
+	// WatchProposal adds or removes a proposal from the watched proposals.
+	WatchProposal(token string, watch bool)
+
+	// Config
+	SaveConfigValueForKey(key string, value interface{})
+	ReadBoolConfigValueForKey(key string) bool
+	ReadStringConfigValueForKey(key string) string
+	ReadIntConfigValueForKey(key string, defaultValue int) int
+	RemoveUserConfigValueForKey(key string)
+	ReadUserConfigValues(keys []string) map[string]interface{}
+}
+
+// Network returns the name of the network the wallets are on.
+func (wal *Wallet) Network() string {
+	return wal.Net
+}
+
+// Responses returns the channel results of non-blocking methods are sent to.
+func (wal *Wallet) Responses() chan Response {
+	return wal.Send
+}
+
+// SyncUpdates returns the channel sync and notification updates are sent to.
+func (wal *Wallet) SyncUpdates() chan SyncStatusUpdate {
+	return wal.Sync
+}
+
+// IsConfigLoaded reports whether InitMultiWallet loaded the wallets and their
+// config.
+func (wal *Wallet) IsConfigLoaded() bool {
+	return wal.multi != nil
+}
+
+// SetOverallBlockHeight sets the height wallets are synced against when
+// reporting their sync status.
+func (wal *Wallet) SetOverallBlockHeight(height int32) {
+	wal.OverallBlockHeight = height
+}
+
+func (wal *Wallet) IsSynced() bool {
+	return wal.multi.IsSynced()
+}
+
+func (wal *Wallet) IsSyncing() bool {
+	return wal.multi.IsSyncing()
+}
+
+func (wal *Wallet) IsConnectedToDecredNetwork() bool {
+	return wal.multi.IsConnectedToDecredNetwork()
+}
+
+func (wal *Wallet) ConnectedPeers() int32 {
+	return wal.multi.ConnectedPeers()
+}
+
+// PeerInfo returns the peers the multiwallet is connected to.
+func (wal *Wallet) PeerInfo() ([]dcrlibwallet.PeerInfo, error) {
+	return wal.multi.PeerInfoRaw()
+}
+
+func (wal *Wallet) GetBestBlock() *dcrlibwallet.BlockInfo {
+	return wal.multi.GetBestBlock()
+}
+
+func (wal *Wallet) AddSyncProgressListener(listener dcrlibwallet.SyncProgressListener, uniqueIdentifier string) error {
+	return wal.multi.AddSyncProgressListener(listener, uniqueIdentifier)
+}
+
+func (wal *Wallet) RemoveSyncProgressListener(uniqueIdentifier string) {
+	wal.multi.RemoveSyncProgressListener(uniqueIdentifier)
+}
+
+// GetTransactionsRaw returns the transactions of all wallets.
+func (wal *Wallet) GetTransactionsRaw(offset, limit, txFilter int32, newestFirst bool) ([]dcrlibwallet.Transaction, error) {
+	return wal.multi.GetTransactionsRaw(offset, limit, txFilter, newestFirst)
+}
+
+// GetWalletTransactionsRaw returns the transactions of a single wallet.
+func (wal *Wallet) GetWalletTransactionsRaw(walletID int, offset, limit, txFilter int32, newestFirst bool) ([]dcrlibwallet.Transaction, error) {
+	wall := wal.multi.WalletWithID(walletID)
+	if wall == nil {
+		return nil, ErrIDNotExist
+	}
+	return wall.GetTransactionsRaw(offset, limit, txFilter, newestFirst)
+}
+
+func (wal *Wallet) AddTxAndBlockNotificationListener(listener dcrlibwallet.TxAndBlockNotificationListener, uniqueIdentifier string) error {
+	return wal.multi.AddTxAndBlockNotificationListener(listener, uniqueIdentifier)
+}
+
+func (wal *Wallet) RemoveTxAndBlockNotificationListener(uniqueIdentifier string) {
+	wal.multi.RemoveTxAndBlockNotificationListener(uniqueIdentifier)
+}
+
+func (wal *Wallet) AddAccountMixerNotificationListener(listener dcrlibwallet.AccountMixerNotificationListener, uniqueIdentifier string) error {
+	return wal.multi.AddAccountMixerNotificationListener(listener, uniqueIdentifier)
+}
+
+func (wal *Wallet) RemoveAccountMixerNotificationListener(uniqueIdentifier string) {
+	wal.multi.RemoveAccountMixerNotificationListener(uniqueIdentifier)
+}
+
+func (wal *Wallet) AddProposalNotificationListener(listener dcrlibwallet.ProposalNotificationListener, uniqueIdentifier string) error {
+	return wal.multi.Politeia.AddNotificationListener(listener, uniqueIdentifier)
+}
+
+func (wal *Wallet) RemoveProposalNotificationListener(uniqueIdentifier string) {
+	wal.multi.Politeia.RemoveNotificationListener(uniqueIdentifier)
+}
+
+func (wal *Wallet) LockWallet(walletID int) {
+	if wall := wal.multi.WalletWithID(walletID); wall != nil {
+		wall.LockWallet()
+	}
+}
+
+func (wal *Wallet) IsLocked(walletID int) bool {
+	wall := wal.multi.WalletWithID(walletID)
+	return wall != nil && wall.IsLocked()
+}
+
+// HasDiscoveredAccounts reports whether the accounts of a wallet were
+// discovered.
+func (wal *Wallet) HasDiscoveredAccounts(walletID int) bool {
+	wall := wal.multi.WalletWithID(walletID)
+	return wall != nil && wall.HasDiscoveredAccounts
+}
+
+// IsWaiting reports whether a wallet waits for the other wallets to sync.
+func (wal *Wallet) IsWaiting(walletID int) bool {
+	wall := wal.multi.WalletWithID(walletID)
+	return wall != nil && wall.IsWaiting()
+}
+
+// WalletBestBlock returns the height and timestamp of the best block of a
+// wallet.
+func (wal *Wallet) WalletBestBlock(walletID int) (height int32, timestamp int64) {
+	wall := wal.multi.WalletWithID(walletID)
+	if wall == nil {
+		return 0, 0
+	}
+	return wall.GetBestBlock(), wall.GetBestBlockTimeStamp()
+}
+
+func (wal *Wallet) ReadWalletBoolConfigValueForKey(walletID int, key string, defaultValue bool) bool {
+	wall := wal.multi.WalletWithID(walletID)
+	if wall == nil {
+		return defaultValue
+	}
+	return wall.ReadBoolConfigValueForKey(key, defaultValue)
+}
+
+func (wal *Wallet) SetWalletBoolConfigValueForKey(walletID int, key string, value bool) {
+	if wall := wal.multi.WalletWithID(walletID); wall != nil {
+		wall.SetBoolConfigValueForKey(key, value)
+	}
+}
+
+// GetAccountsRaw returns the accounts of a wallet.
+func (wal *Wallet) GetAccountsRaw(walletID int) (*dcrlibwallet.Accounts, error) {
+	wall := wal.multi.WalletWithID(walletID)
+	if wall == nil {
+		return nil, ErrIDNotExist
+	}
+	return wall.GetAccountsRaw()
+}
+
+func (wal *Wallet) AccountName(walletID int, accountNumber int32) (string, error) {
+	wall := wal.multi.WalletWithID(walletID)
+	if wall == nil {
+		return "", ErrIDNotExist
+	}
+	return wall.AccountName(accountNumber)
+}
+
+// CreateNewAccount adds an account named name to a wallet and returns its
+// number.
+func (wal *Wallet) CreateNewAccount(walletID int, name string, passphrase []byte) (int32, error) {
+	wall := wal.multi.WalletWithID(walletID)
+	if wall == nil {
+		return 0, ErrIDNotExist
+	}
+	return wall.CreateNewAccount(name, passphrase)
+}
+
+// HaveWalletAddress reports whether address belongs to the wallet identified
+// by walletID.
+func (wal *Wallet) HaveWalletAddress(walletID int, address string) bool {
+	wall := wal.multi.WalletWithID(walletID)
+	return wall != nil && wall.HaveAddress(address)
+}
+
+// CreateMixerAccounts creates the accounts the mixer of a wallet mixes from
+// and to, and sets them as its config.
+func (wal *Wallet) CreateMixerAccounts(walletID int, mixedAccount, unmixedAccount, passphrase string) error {
+	wall := wal.multi.WalletWithID(walletID)
+	if wall == nil {
+		return ErrIDNotExist
+	}
+	return wall.CreateMixerAccounts(mixedAccount, unmixedAccount, passphrase)
+}
+
+func (wal *Wallet) MixedAccountNumber(walletID int) int32 {
+	wall := wal.multi.WalletWithID(walletID)
+	if wall == nil {
+		return -1
+	}
+	return wall.MixedAccountNumber()
+}
+
+func (wal *Wallet) UnmixedAccountNumber(walletID int) int32 {
+	wall := wal.multi.WalletWithID(walletID)
+	if wall == nil {
+		return -1
+	}
+	return wall.UnmixedAccountNumber()
+}
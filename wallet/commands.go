@@ -1,9 +1,11 @@
 package wallet
 
 import (
+	"bytes"
 	"context"
 	"crypto/ed25519"
 	"encoding/base64"
+	"encoding/hex"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -13,19 +15,24 @@ import (
 	"sort"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	"golang.org/x/sync/errgroup"
 
 	"github.com/decred/dcrd/chaincfg/chainhash"
 	"github.com/decred/dcrd/dcrutil/v3"
+	"github.com/decred/dcrd/wire"
 	"github.com/planetdecred/dcrlibwallet"
+	"github.com/planetdecred/dcrlibwallet/utils"
+	"github.com/planetdecred/godcr/crash"
 )
 
 // CreateWallet creates a new wallet with the given parameters.
 // It is non-blocking and sends its result or any error to wal.Send.
 func (wal *Wallet) CreateWallet(name, passphrase string, errChan chan error) {
 	go func() {
+		defer crash.Recover("wallet CreateWallet")
 		var resp Response
 		wall, err := wal.multi.CreateNewWallet(name, passphrase, dcrlibwallet.PassphraseTypePass)
 		sendErr := func(err error) {
@@ -55,59 +62,35 @@ func (wal *Wallet) CreateWallet(name, passphrase string, errChan chan error) {
 	}()
 }
 
-// RestoreWallet restores a wallet with the given parameters.
-// It is non-blocking and sends its result or any error to wal.Send.
-func (wal *Wallet) RestoreWallet(seed, passphrase string, errChan chan error) {
-	go func() {
-		var resp Response
-		_, err := wal.multi.RestoreWallet("wallet", seed, passphrase, dcrlibwallet.PassphraseTypePass)
-		if err != nil {
-			go func() {
-				errChan <- err
-			}()
-			resp.Err = err
-			wal.Send <- ResponseError(MultiWalletError{
-				Message: "Could not restore wallet",
-				Err:     err,
-			})
-			return
-		}
-		resp.Resp = Restored{}
-		wal.Send <- resp
-	}()
+// CreateNewWallet creates a wallet named name with a new seed.
+func (wal *Wallet) CreateNewWallet(name, passphrase string) (*WalletSummary, error) {
+	wall, err := wal.multi.CreateNewWallet(name, passphrase, dcrlibwallet.PassphraseTypePass)
+	if err != nil {
+		return nil, err
+	}
+	return walletSummary(wall), nil
 }
 
-// DeleteWallet deletes a wallet.
-// It is non-blocking and sends its result or any error to wal.Send.
-func (wal *Wallet) DeleteWallet(walletID int, passphrase []byte, errChan chan error) {
-	log.Debug("Deleting Wallet")
-	go func() {
-		var resp Response
-		log.Debugf("Wallet %d: %+v", walletID, wal.multi.WalletWithID(walletID))
-		err := wal.multi.DeleteWallet(walletID, passphrase)
-		if err != nil {
-			go func() {
-				errChan <- err
-			}()
-			resp.Err = err
-			wal.Send <- ResponseError(InternalWalletError{
-				Message:  "Could not delete wallet",
-				Affected: []int{walletID},
-				Err:      err,
-			})
-			return
-		}
-		resp.Resp = DeletedWallet{
-			ID: walletID,
-		}
-		wal.Send <- resp
-	}()
+// RestoreWallet restores a wallet named name from its seed.
+func (wal *Wallet) RestoreWallet(name, seed, passphrase string) (*WalletSummary, error) {
+	wall, err := wal.multi.RestoreWallet(name, seed, passphrase, dcrlibwallet.PassphraseTypePass)
+	if err != nil {
+		return nil, err
+	}
+	return walletSummary(wall), nil
+}
+
+// DeleteWallet deletes the wallet identified by walletID.
+func (wal *Wallet) DeleteWallet(walletID int, passphrase []byte) error {
+	log.Debugf("Deleting wallet %d", walletID)
+	return wal.multi.DeleteWallet(walletID, passphrase)
 }
 
 // AddAccount adds an account to a wallet.
 // It is non-blocking and sends its result or any error to wal.Send.
 func (wal *Wallet) AddAccount(walletID int, name string, pass []byte, errChan chan error, onCreate func(*dcrlibwallet.Account)) {
 	go func() {
+		defer crash.Recover("wallet AddAccount")
 		var resp Response
 		wall := wal.multi.WalletWithID(walletID)
 		if wall == nil {
@@ -163,6 +146,7 @@ func (wal *Wallet) AddAccount(walletID int, name string, pass []byte, errChan ch
 // It is non-blocking and sends its result or any error to wal.Send.
 func (wal *Wallet) CreateTransaction(walletID int, accountID int32, errChan chan error) {
 	go func() {
+		defer crash.Recover("wallet CreateTransaction")
 		var resp Response
 		txAuthor, err := wal.multi.NewUnsignedTx(walletID, accountID)
 		if err != nil {
@@ -186,11 +170,17 @@ func transactionStatus(bestBlockHeight, txnBlockHeight int32) (string, int32) {
 
 // BroadcastTransaction broadcasts the transaction built with txAuthor to the network.
 // It is non-blocking and sends its result or any error to wal.Send.
-func (wal *Wallet) BroadcastTransaction(txAuthor *dcrlibwallet.TxAuthor, passphrase []byte, errChan chan error) {
+func (wal *Wallet) BroadcastTransaction(txAuthor TxAuthor, passphrase []byte, errChan chan error) {
 	go func() {
+		defer crash.Recover("wallet BroadcastTransaction")
 		var resp Response
 
-		txHash, err := txAuthor.Broadcast(passphrase)
+		author, ok := txAuthor.(*dcrlibwallet.TxAuthor)
+		if !ok {
+			errChan <- fmt.Errorf("error broadcasting transaction: %T was not created by the wallet", txAuthor)
+			return
+		}
+		txHash, err := author.Broadcast(passphrase)
 		if err != nil {
 			errChan <- fmt.Errorf("error broadcasting transaction: %s", err.Error())
 			return
@@ -213,6 +203,7 @@ func (wal *Wallet) BroadcastTransaction(txAuthor *dcrlibwallet.TxAuthor, passphr
 // It is non-blocking and sends its result or any error to wal.Send.
 func (wal *Wallet) GetAllTransactions(offset, limit, txfilter int32) {
 	go func() {
+		defer crash.Recover("wallet GetAllTransactions")
 		var resp Response
 		wallets, err := wal.wallets()
 		if err != nil {
@@ -241,10 +232,9 @@ func (wal *Wallet) GetAllTransactions(offset, limit, txfilter int32) {
 				txn := Transaction{
 					Txn:           txnRaw,
 					Status:        status,
-					Balance:       dcrutil.Amount(txnRaw.Amount).String(),
+					Balance:       FormatAmount(txnRaw.Amount),
 					WalletName:    wall.Name,
 					Confirmations: confirmations,
-					DateTime:      dcrlibwallet.ExtractDateOrTime(txnRaw.Timestamp),
 				}
 				recentTxs = append(recentTxs, txn)
 				if txn.Txn.Type == dcrlibwallet.TxTypeTicketPurchase {
@@ -279,6 +269,7 @@ func (wal *Wallet) GetAllTransactions(offset, limit, txfilter int32) {
 // It is non-blocking and sends its result or any error to wal.Send.
 func (wal *Wallet) GetTransaction(walletID int, txnHash string) {
 	go func() {
+		defer crash.Recover("wallet GetTransaction")
 		var resp Response
 		wall := wal.multi.WalletWithID(walletID)
 
@@ -306,10 +297,9 @@ func (wal *Wallet) GetTransaction(walletID int, txnHash string) {
 		resp.Resp = &Transaction{
 			Txn:           *txn,
 			Status:        status,
-			Balance:       dcrutil.Amount(txn.Amount).String(),
+			Balance:       FormatAmount(txn.Amount),
 			WalletName:    wall.Name,
 			Confirmations: confirmations,
-			DateTime:      dcrlibwallet.ExtractDateOrTime(txn.Timestamp),
 			AccountName:   acct.Name,
 		}
 		wal.Send <- resp
@@ -334,6 +324,7 @@ func walletSyncStatus(isWaiting bool, walletBestBlock, bestBlockHeight int32) st
 // It is non-blocking and sends its result or any error to wal.Send.
 func (wal *Wallet) GetMultiWalletInfo() {
 	go func() {
+		defer crash.Recover("wallet GetMultiWalletInfo")
 		log.Debug("Getting multiwallet info")
 		var resp Response
 		wallets, err := wal.wallets()
@@ -367,7 +358,7 @@ func (wal *Wallet) GetMultiWalletInfo() {
 				accts = append(accts, Account{
 					Number:           acct.Number,
 					Name:             acct.Name,
-					TotalBalance:     dcrutil.Amount(acct.TotalBalance).String(),
+					TotalBalance:     FormatAmount(acct.TotalBalance),
 					SpendableBalance: acct.Balance.Spendable,
 					Balance: Balance{
 						Total:                   acct.Balance.Total,
@@ -385,7 +376,7 @@ func (wal *Wallet) GetMultiWalletInfo() {
 						External: strconv.Itoa(int(acct.ExternalKeyCount)),
 						Imported: strconv.Itoa(int(acct.ImportedKeyCount)),
 					},
-					HDPath:         wal.hdPrefix() + strconv.Itoa(int(acct.Number)) + "'",
+					HDPath:         hdPrefix(wal.Net) + strconv.Itoa(int(acct.Number)) + "'",
 					CurrentAddress: addr,
 				})
 				acctBalance += acct.TotalBalance
@@ -396,7 +387,7 @@ func (wal *Wallet) GetMultiWalletInfo() {
 			infos[i] = InfoShort{
 				ID:               wall.ID,
 				Name:             wall.Name,
-				Balance:          dcrutil.Amount(acctBalance).String(),
+				Balance:          FormatAmount(acctBalance),
 				SpendableBalance: spendableBalance,
 				Accounts:         accts,
 				BestBlockHeight:  wall.GetBestBlock(),
@@ -426,7 +417,7 @@ func (wal *Wallet) GetMultiWalletInfo() {
 		lastSyncTime := int64(time.Since(time.Unix(best.Timestamp, 0)).Seconds())
 		resp.Resp = MultiWalletInfo{
 			LoadedWallets:   len(wallets),
-			TotalBalance:    dcrutil.Amount(completeTotal).String(),
+			TotalBalance:    FormatAmount(completeTotal),
 			TotalBalanceRaw: GetRawBalance(completeTotal, 0),
 			BestBlockHeight: best.Height,
 			BestBlockTime:   best.Timestamp,
@@ -439,59 +430,19 @@ func (wal *Wallet) GetMultiWalletInfo() {
 	}()
 }
 
-func (wal *Wallet) GetMultiWallet() *dcrlibwallet.MultiWallet {
-	return wal.multi
-}
-
-func (wal *Wallet) SignMessage(walletID int, passphrase []byte, address, message string, errChan chan error) {
-	go func() {
-		var resp Response
-
-		wall := wal.multi.WalletWithID(walletID)
-		if wall == nil {
-			resp.Err = ErrIDNotExist
-			wal.Send <- resp
-			return
-		}
-
-		signedMessageBytes, err := wall.SignMessage(passphrase, address, message)
-		if err != nil {
-			go func() {
-				errChan <- err
-			}()
-			wal.Send <- resp
-			return
-		}
-
-		resp.Resp = &Signature{
-			Signature: base64.StdEncoding.EncodeToString(signedMessageBytes),
-		}
-
-		wal.Send <- resp
-	}()
+// SignMessage signs message with the private key of address, which must be
+// an address of the wallet identified by walletID.
+func (wal *Wallet) SignMessage(walletID int, passphrase []byte, address, message string) ([]byte, error) {
+	wall := wal.multi.WalletWithID(walletID)
+	if wall == nil {
+		return nil, ErrIDNotExist
+	}
+	return wall.SignMessage(passphrase, address, message)
 }
 
 // RenameWallet renames the wallet identified by walletID.
-func (wal *Wallet) RenameWallet(walletID int, name string, errChan chan error) {
-	go func() {
-		var resp Response
-		err := wal.multi.RenameWallet(walletID, name)
-		if err != nil {
-			go func() {
-				errChan <- err
-			}()
-			resp.Err = err
-			wal.Send <- ResponseError(MultiWalletError{
-				Message: "Could not rename wallet",
-				Err:     err,
-			})
-			return
-		}
-		resp.Resp = Renamed{
-			ID: walletID,
-		}
-		wal.Send <- resp
-	}()
+func (wal *Wallet) RenameWallet(walletID int, name string) error {
+	return wal.multi.RenameWallet(walletID, name)
 }
 
 // ImportWatchOnlyWallet imports a watch only wallet with the given parameters.
@@ -514,115 +465,31 @@ func (wal *Wallet) ImportWatchOnlyWallet(name, extendedPublicKey string) error {
 }
 
 // ChangeWalletPassphrase changes the spending passphrase of the wallet identified by walletID.
-func (wal *Wallet) ChangeWalletPassphrase(walletID int, oldPrivatePassphrase, newPrivatePassphrase string, errChan chan error) {
-	go func() {
-		var resp Response
-		err := wal.multi.ChangePrivatePassphraseForWallet(walletID, []byte(oldPrivatePassphrase), []byte(newPrivatePassphrase), dcrlibwallet.PassphraseTypePass)
-		if err != nil {
-			go func() {
-				errChan <- err
-			}()
-			resp.Err = err
-			wal.Send <- ResponseError(InternalWalletError{
-				Message:  "Could not change password",
-				Affected: []int{walletID},
-				Err:      err,
-			})
-			return
-		}
-
-		resp.Resp = &ChangePassword{
-			ID: walletID,
-		}
-	}()
+func (wal *Wallet) ChangeWalletPassphrase(walletID int, oldPrivatePassphrase, newPrivatePassphrase []byte) error {
+	return wal.multi.ChangePrivatePassphraseForWallet(walletID, oldPrivatePassphrase, newPrivatePassphrase, dcrlibwallet.PassphraseTypePass)
 }
 
-func (wal *Wallet) OpenWallets(passphrase string, errChan chan error) {
-	go func() {
-		var resp Response
-		err := wal.multi.OpenWallets([]byte(passphrase))
-		if err != nil {
-			go func() {
-				errChan <- err
-			}()
-			resp.Err = err
-			wal.Send <- ResponseError(MultiWalletError{
-				Message: "Could not open wallets",
-				Err:     err,
-			})
-			return
-		}
-
-		resp.Resp = OpenWallet{}
-		wal.Send <- resp
-	}()
+// OpenWallets opens the loaded wallets, with the startup passphrase if one is
+// set.
+func (wal *Wallet) OpenWallets(startupPassphrase []byte) error {
+	return wal.multi.OpenWallets(startupPassphrase)
 }
 
-func (wal *Wallet) SetStartupPassphrase(passphrase string, errChan chan error) {
-	go func() {
-		var resp Response
-		err := wal.multi.SetStartupPassphrase([]byte(passphrase), dcrlibwallet.PassphraseTypePass)
-		if err != nil {
-			go func() {
-				errChan <- err
-			}()
-			resp.Err = err
-			wal.Send <- ResponseError(MultiWalletError{
-				Message: "Could not set up startup passphrase",
-				Err:     err,
-			})
-			return
-		}
-		resp.Resp = &StartupPassphrase{
-			Msg: "Startup password set",
-		}
-		wal.Send <- resp
-	}()
+// VerifyStartupPassphrase checks that passphrase is the startup passphrase.
+func (wal *Wallet) VerifyStartupPassphrase(passphrase []byte) error {
+	return wal.multi.VerifyStartupPassphrase(passphrase)
 }
 
-func (wal *Wallet) ChangeStartupPassphrase(oldPrivatePassphrase, newPrivatePassphrase string, errChan chan error) {
-	go func() {
-		var resp Response
-		err := wal.multi.ChangeStartupPassphrase([]byte(oldPrivatePassphrase), []byte(newPrivatePassphrase), dcrlibwallet.PassphraseTypePass)
-		if err != nil {
-			go func() {
-				errChan <- err
-			}()
-			resp.Err = err
-			wal.Send <- ResponseError(MultiWalletError{
-				Message: "Could not change startup passphrase",
-				Err:     err,
-			})
-			return
-		}
+func (wal *Wallet) SetStartupPassphrase(passphrase []byte) error {
+	return wal.multi.SetStartupPassphrase(passphrase, dcrlibwallet.PassphraseTypePass)
+}
 
-		resp.Resp = &StartupPassphrase{
-			Msg: "Startup password changed",
-		}
-		wal.Send <- resp
-	}()
+func (wal *Wallet) ChangeStartupPassphrase(oldPassphrase, newPassphrase []byte) error {
+	return wal.multi.ChangeStartupPassphrase(oldPassphrase, newPassphrase, dcrlibwallet.PassphraseTypePass)
 }
 
-func (wal *Wallet) RemoveStartupPassphrase(passphrase string, errChan chan error) {
-	go func() {
-		var resp Response
-		err := wal.multi.RemoveStartupPassphrase([]byte(passphrase))
-		if err != nil {
-			go func() {
-				errChan <- err
-			}()
-			resp.Err = err
-			wal.Send <- ResponseError(MultiWalletError{
-				Message: "Could not remove startup passphrase",
-				Err:     err,
-			})
-			return
-		}
-		resp.Resp = &StartupPassphrase{
-			Msg: "Startup password removed",
-		}
-		wal.Send <- resp
-	}()
+func (wal *Wallet) RemoveStartupPassphrase(passphrase []byte) error {
+	return wal.multi.RemoveStartupPassphrase(passphrase)
 }
 
 // IsStartupSecuritySet checks if start up password is set
@@ -631,45 +498,18 @@ func (wal *Wallet) IsStartupSecuritySet() bool {
 }
 
 // RenameAccount renames the acct of wallet with id walletID.
-func (wal *Wallet) RenameAccount(walletID int, acct int32, name string, errChan chan<- error) {
-	go func() {
-		var resp Response
-		wall := wal.multi.WalletWithID(walletID)
-		if wall == nil {
-			go func() {
-				errChan <- ErrIDNotExist
-			}()
-			resp.Err = ErrIDNotExist
-			wal.Send <- Response{
-				Resp: UpdatedAccount{},
-				Err:  ErrIDNotExist,
-			}
-			return
-		}
-
-		err := wall.RenameAccount(acct, name)
-		if err != nil {
-			go func() {
-				errChan <- err
-			}()
-			resp.Err = err
-			wal.Send <- ResponseError(InternalWalletError{
-				Message:  "Could not rename account",
-				Affected: []int{walletID},
-				Err:      err,
-			})
-			return
-		}
-		resp.Resp = UpdatedAccount{
-			ID: acct,
-		}
-		wal.Send <- resp
-	}()
+func (wal *Wallet) RenameAccount(walletID int, acct int32, name string) error {
+	wall := wal.multi.WalletWithID(walletID)
+	if wall == nil {
+		return ErrIDNotExist
+	}
+	return wall.RenameAccount(acct, name)
 }
 
 func (wal *Wallet) GetAllProposals() {
 	var resp Response
 	go func() {
+		defer crash.Recover("wallet GetAllProposals")
 		proposals, err := wal.multi.Politeia.GetProposalsRaw(dcrlibwallet.ProposalCategoryAll, 0, 0, true)
 		if err != nil {
 			resp.Err = err
@@ -683,8 +523,40 @@ func (wal *Wallet) GetAllProposals() {
 	}()
 }
 
-func (wal *Wallet) FetchProposalDescription(token string) (string, error) {
-	return wal.multi.Politeia.FetchProposalDescription(dcrlibwallet.PoliteiaMainnetHost, token)
+// watchedProposalsConfigKey is the config key of the tokens of the proposals
+// the user watches.
+const watchedProposalsConfigKey = "watched_proposals"
+
+// WatchedProposals returns the tokens of the proposals the user watches.
+func (wal *Wallet) WatchedProposals() map[string]bool {
+	var tokens []string
+	wal.multi.ReadUserConfigValue(watchedProposalsConfigKey, &tokens)
+	watched := make(map[string]bool, len(tokens))
+	for _, token := range tokens {
+		watched[token] = true
+	}
+	return watched
+}
+
+// WatchProposal adds the proposal with token to the watched proposals, or
+// removes it if watch is false.
+func (wal *Wallet) WatchProposal(token string, watch bool) {
+	watched := wal.WatchedProposals()
+	if watched[token] == watch {
+		return
+	}
+	if watch {
+		watched[token] = true
+	} else {
+		delete(watched, token)
+	}
+
+	tokens := make([]string, 0, len(watched))
+	for token := range watched {
+		tokens = append(tokens, token)
+	}
+	sort.Strings(tokens)
+	wal.multi.SaveUserConfigValue(watchedProposalsConfigKey, tokens)
 }
 
 func (wal *Wallet) UnlockWallet(walletID int, passphrase []byte) error {
@@ -774,10 +646,27 @@ func (wal *Wallet) ReadStringConfigValueForKey(key string) string {
 	return wal.multi.ReadStringConfigValueForKey(key)
 }
 
+func (wal *Wallet) ReadIntConfigValueForKey(key string, defaultValue int) int {
+	return wal.multi.ReadIntConfigValueForKey(key, defaultValue)
+}
+
 func (wal *Wallet) RemoveUserConfigValueForKey(key string) {
 	wal.multi.DeleteUserConfigValueForKey(key)
 }
 
+// ReadUserConfigValues returns the values saved for keys, whatever their
+// type. Keys without a saved value are left out.
+func (wal *Wallet) ReadUserConfigValues(keys []string) map[string]interface{} {
+	values := make(map[string]interface{})
+	for _, key := range keys {
+		var value interface{}
+		if wal.multi.ReadUserConfigValue(key, &value) == nil {
+			values[key] = value
+		}
+	}
+	return values
+}
+
 func (wal *Wallet) LoadedWalletsCount() int32 {
 	return wal.multi.LoadedWalletsCount()
 }
@@ -819,6 +708,7 @@ func divMod(numerator, denominator int64) (quotient, remainder int64) {
 // AllUnspentOutputs get all unspent outputs by walletID and acct
 func (wal *Wallet) AllUnspentOutputs(walletID int, acct int32) {
 	go func() {
+		defer crash.Recover("wallet AllUnspentOutputs")
 		var resp Response
 		wall := wal.multi.WalletWithID(walletID)
 		if wall == nil {
@@ -843,9 +733,8 @@ func (wal *Wallet) AllUnspentOutputs(walletID int, acct int32) {
 		var list []*UnspentOutput
 		for _, utxo := range utxos {
 			item := UnspentOutput{
-				UTXO:     *utxo,
-				Amount:   dcrutil.Amount(utxo.Amount).String(),
-				DateTime: dcrlibwallet.ExtractDateOrTime(utxo.ReceiveTime),
+				UTXO:   *utxo,
+				Amount: FormatAmount(utxo.Amount),
 			}
 			list = append(list, &item)
 		}
@@ -869,6 +758,7 @@ func (wal *Wallet) IsAccountMixerConfigSet(walletID int) bool {
 // It is non-blocking and sends its result or any error to wal.Send.
 func (wal *Wallet) SetupAccountMixer(walletID int, walletPassphrase string, errChan chan error) {
 	go func() {
+		defer crash.Recover("wallet SetupAccountMixer")
 		var resp Response
 
 		wall := wal.multi.WalletWithID(walletID)
@@ -940,18 +830,50 @@ func (wal *Wallet) SetupAccountMixer(walletID int, walletPassphrase string, errC
 
 // TicketPrice get ticket price
 func (wal *Wallet) TicketPrice() (int64, string) {
-	pr, err := wal.multi.WalletsIterator().Next().TicketPrice()
+	pr, err := wal.ticketPrice()
 	if err != nil {
 		log.Error(err)
 		return 0, ""
 	}
-	return pr.TicketPrice, dcrutil.Amount(pr.TicketPrice).String()
+	return pr.TicketPrice, FormatAmount(pr.TicketPrice)
+}
+
+// ticketPrice returns the next ticket price seen by the wallet furthest along
+// the chain, since the others may still be syncing.
+func (wal *Wallet) ticketPrice() (*dcrlibwallet.TicketPriceResponse, error) {
+	var (
+		best *dcrlibwallet.TicketPriceResponse
+		err  error
+	)
+	for _, wall := range wal.multi.AllWallets() {
+		price, priceErr := wall.TicketPrice()
+		if priceErr != nil {
+			err = priceErr
+			continue
+		}
+		if best == nil || price.Height > best.Height {
+			best = price
+		}
+	}
+	if best == nil {
+		if err == nil {
+			err = errors.New("no wallet to get the ticket price from")
+		}
+		return nil, err
+	}
+	return best, nil
 }
 
+// NewVSPD returns a client of the VSP at host that pays fees from an account.
+// It refuses a VSP whose public key changed since it was pinned, so no ticket
+// is bought from or fee paid to it until the new key is trusted.
 func (wal *Wallet) NewVSPD(host string, walletID int, accountID int32) (*dcrlibwallet.VSP, error) {
 	if host == "" {
 		return nil, fmt.Errorf("Host is required")
 	}
+	if wal.fetchVSPInfo(host).PubKeyChanged {
+		return nil, ErrVSPPubKeyChanged
+	}
 	wall := wal.multi.WalletWithID(walletID)
 	if wall == nil {
 		return nil, ErrIDNotExist
@@ -966,6 +888,7 @@ func (wal *Wallet) NewVSPD(host string, walletID int, accountID int32) (*dcrlibw
 // PurchaseTicket buy a ticket with given parameters
 func (wal *Wallet) PurchaseTicket(walletID int, accountID int32, tickets uint32, passphrase []byte, vspd *dcrlibwallet.VSP, errChan chan error) {
 	go func() {
+		defer crash.Recover("wallet PurchaseTicket")
 		var resp Response
 		wall := wal.multi.WalletWithID(walletID)
 		if wall == nil {
@@ -975,6 +898,14 @@ func (wal *Wallet) PurchaseTicket(walletID int, accountID int32, tickets uint32,
 			return
 		}
 
+		// NewVSPD returns no client for a VSP it refuses
+		if vspd == nil {
+			go func() {
+				errChan <- errors.New("no VSP to pay the ticket fee to")
+			}()
+			return
+		}
+
 		_, err := vspd.GetInfo(context.Background())
 		if err != nil {
 			go func() {
@@ -1004,6 +935,7 @@ func (wal *Wallet) PurchaseTicket(walletID int, accountID int32, tickets uint32,
 // It is non-blocking and sends its result or any error to wal.Send.
 func (wal *Wallet) GetAllTickets() {
 	go func() {
+		defer crash.Recover("wallet GetAllTickets")
 		var resp Response
 		wallets, err := wal.wallets()
 		if err != nil {
@@ -1012,33 +944,10 @@ func (wal *Wallet) GetAllTickets() {
 			return
 		}
 
-		var liveRecentTickets []Ticket
-		var recentActivity []Ticket
-
+		walletIDs := make([]int, 0, len(wallets))
 		tickets := make(map[int][]Ticket)
 		unconfirmedTickets := make(map[int][]UnconfirmedPurchase)
-
-		stackingRecordCounter := []struct {
-			Status string
-			Count  int
-		}{
-			{"UNMINED", 0},
-			{"IMMATURE", 0},
-			{"LIVE", 0},
-			{"VOTED", 0},
-			{"MISSED", 0},
-			{"EXPIRED", 0},
-			{"REVOKED", 0},
-		}
-
-		liveCounter := []struct {
-			Status string
-			Count  int
-		}{
-			{"UNMINED", 0},
-			{"IMMATURE", 0},
-			{"LIVE", 0},
-		}
+		statuses := wal.vspTicketStatuses()
 
 		for _, wall := range wallets {
 			ticketsInfo, err := wall.GetTicketsForBlockHeightRange(0, wall.GetBestBlock(), math.MaxInt32)
@@ -1048,6 +957,7 @@ func (wal *Wallet) GetAllTickets() {
 				return
 			}
 
+			walletIDs = append(walletIDs, wall.ID)
 			for _, tinfo := range ticketsInfo {
 				if tinfo.Status == "UNKNOWN" {
 					continue
@@ -1057,34 +967,16 @@ func (wal *Wallet) GetAllTickets() {
 				for _, output := range tinfo.Ticket.MyOutputs {
 					amount += output.Amount
 				}
-				info := Ticket{
+				status := statuses[tinfo.Ticket.Hash.String()]
+				tickets[wall.ID] = append(tickets[wall.ID], Ticket{
 					Info:       *tinfo,
-					DateTime:   time.Unix(tinfo.Ticket.Timestamp, 0).Format("Jan 2, 2006 03:04:05 PM"),
-					MonthDay:   time.Unix(tinfo.Ticket.Timestamp, 0).Format("Jan 2"),
 					DaysBehind: calculateDaysBehind(tinfo.Ticket.Timestamp),
-					Amount:     amount.String(),
-					Fee:        tinfo.Ticket.Fee.String(),
+					Amount:     FormatAmount(int64(amount)),
+					Fee:        FormatAmount(int64(tinfo.Ticket.Fee)),
 					WalletName: wall.Name,
-				}
-				tickets[wall.ID] = append(tickets[wall.ID], info)
-
-				for i := range liveCounter {
-					if liveCounter[i].Status == tinfo.Status {
-						liveCounter[i].Count++
-					}
-				}
-
-				if tinfo.Status == "UNMINED" || tinfo.Status == "IMMATURE" || tinfo.Status == "LIVE" {
-					liveRecentTickets = append(liveRecentTickets, info)
-				}
-
-				recentActivity = append(recentActivity, info)
-
-				for i := range stackingRecordCounter {
-					if stackingRecordCounter[i].Status == tinfo.Status {
-						stackingRecordCounter[i].Count++
-					}
-				}
+					VSP:        status,
+					FeeTx:      vspFeeTx(&wall, status),
+				})
 			}
 
 			sort.SliceStable(tickets[wall.ID], func(i, j int) bool {
@@ -1102,37 +994,596 @@ func (wal *Wallet) GetAllTickets() {
 			unconfirmedTickets[wall.ID] = unconfirmedTicketPurchases
 		}
 
-		sort.SliceStable(liveRecentTickets, func(i, j int) bool {
-			backTime := time.Unix(liveRecentTickets[j].Info.Ticket.Timestamp, 0)
-			frontTime := time.Unix(liveRecentTickets[i].Info.Ticket.Timestamp, 0)
-			return backTime.Before(frontTime)
-		})
-
-		recentLimit := 5
-		if len(liveRecentTickets) > recentLimit {
-			liveRecentTickets = liveRecentTickets[:recentLimit]
-		}
+		resp.Resp = summarizeTickets(walletIDs, tickets, unconfirmedTickets)
+		wal.Send <- resp
+	}()
+}
 
-		sort.SliceStable(recentActivity, func(i, j int) bool {
-			backTime := time.Unix(recentActivity[j].Info.Ticket.Timestamp, 0)
-			frontTime := time.Unix(recentActivity[i].Info.Ticket.Timestamp, 0)
-			return backTime.Before(frontTime)
-		})
+// canVote returns whether a ticket of status can still be chosen to vote.
+func canVote(status string) bool {
+	return status == "UNMINED" || status == "IMMATURE" || status == "LIVE"
+}
 
-		if len(recentActivity) > recentLimit {
-			recentActivity = recentActivity[:recentLimit]
-		}
+// IsUnvoted returns whether an immature or live ticket was found to not be
+// voted by any VSP, because no VSP knows it or its fee was not paid.
+func IsUnvoted(ticket Ticket) bool {
+	if ticket.Info.Status != "IMMATURE" && ticket.Info.Status != "LIVE" {
+		return false
+	}
+	return ticket.VSP != nil && !ticket.VSP.Voting()
+}
 
-		resp.Resp = &Tickets{
-			Confirmed:             tickets,
-			Unconfirmed:           unconfirmedTickets,
-			RecentActivity:        recentActivity,
-			StackingRecordCounter: stackingRecordCounter,
-			LiveRecent:            liveRecentTickets,
-			LiveCounter:           liveCounter,
+// UnvotedTickets returns the tickets that are unvoted.
+func UnvotedTickets(tickets []Ticket) []Ticket {
+	var unvoted []Ticket
+	for _, ticket := range tickets {
+		if IsUnvoted(ticket) {
+			unvoted = append(unvoted, ticket)
 		}
-		wal.Send <- resp
-	}()
+	}
+	return unvoted
+}
+
+// ticketHeights returns the heights a ticket mined at height becomes live and
+// expires at on net.
+func ticketHeights(net string, height int32) (maturity, expiry int32, err error) {
+	params, err := utils.ChainParams(net)
+	if err != nil {
+		return 0, 0, err
+	}
+	maturity = height + int32(params.TicketMaturity)
+	return maturity, maturity + int32(params.TicketExpiry), nil
+}
+
+// TicketDetails looks up the purchase and spender transactions of a ticket.
+// dcrlibwallet does not record which VSP a ticket was bought through, so the
+// VSP fields are only known once the VSPs were asked about the ticket.
+func (wal *Wallet) TicketDetails(walletID int, ticket Ticket) (*TicketDetails, error) {
+	wall := wal.multi.WalletWithID(walletID)
+	if wall == nil {
+		return nil, ErrIDNotExist
+	}
+
+	var err error
+	details := &TicketDetails{Ticket: ticket}
+	details.Purchase, err = wall.GetTransactionRaw(ticket.Info.Ticket.Hash[:])
+	if err != nil {
+		return nil, err
+	}
+	if ticket.Info.Spender != nil {
+		details.Spender, err = wall.GetTransactionRaw(ticket.Info.Spender.Hash[:])
+		if err != nil {
+			return nil, err
+		}
+	}
+	if status := wal.vspTicketStatuses()[ticket.Info.Ticket.Hash.String()]; status != nil {
+		details.setVSPStatus(status)
+		details.FeeTx = vspFeeTx(wall, status)
+	}
+	if ticket.Info.BlockHeight > 0 {
+		details.MaturityHeight, details.ExpiryHeight, err = ticketHeights(wal.Net, ticket.Info.BlockHeight)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return details, nil
+}
+
+// vspFeeTx returns the transaction paying the VSP fee of a ticket with
+// status. The fee transaction is only in the wallet if it was paid from it,
+// otherwise nil is returned.
+func vspFeeTx(wall *dcrlibwallet.Wallet, status *VSPTicketStatus) *dcrlibwallet.Transaction {
+	if status == nil || status.FeeTxHash == "" {
+		return nil
+	}
+	hash, err := chainhash.NewHashFromStr(status.FeeTxHash)
+	if err != nil {
+		return nil
+	}
+	txn, err := wall.GetTransactionRaw(hash[:])
+	if err != nil {
+		return nil
+	}
+	return txn
+}
+
+// setVSPStatus fills in the VSP fields of the details from the VSP status of
+// the ticket.
+func (details *TicketDetails) setVSPStatus(status *VSPTicketStatus) {
+	details.VSPHost = status.Host
+	details.FeeStatus = status.FeeStatus
+	details.VoteChoices = status.VoteChoices
+}
+
+// vspTicketStatusConfigKey is the config key of the VSP statuses of the
+// tickets of every wallet, by ticket hash.
+const vspTicketStatusConfigKey = "vsp_ticket_statuses"
+
+// vspTicketStatuses returns the saved VSP statuses of the tickets.
+func (wal *Wallet) vspTicketStatuses() map[string]*VSPTicketStatus {
+	wal.vspStatusMu.Lock()
+	defer wal.vspStatusMu.Unlock()
+	statuses := make(map[string]*VSPTicketStatus)
+	wal.multi.ReadUserConfigValue(vspTicketStatusConfigKey, &statuses)
+	return statuses
+}
+
+// saveVSPTicketStatuses saves statuses over the saved statuses of the same
+// tickets.
+func (wal *Wallet) saveVSPTicketStatuses(statuses map[string]*VSPTicketStatus) {
+	wal.vspStatusMu.Lock()
+	defer wal.vspStatusMu.Unlock()
+	saved := make(map[string]*VSPTicketStatus)
+	wal.multi.ReadUserConfigValue(vspTicketStatusConfigKey, &saved)
+	for hash, status := range statuses {
+		saved[hash] = status
+	}
+	wal.multi.SaveUserConfigValue(vspTicketStatusConfigKey, saved)
+}
+
+// vspHosts returns the remembered VSP and the VSPs added by the user. Only
+// these are asked about tickets, as asking a VSP about a ticket tells it who
+// owns the ticket.
+func (wal *Wallet) vspHosts() []string {
+	config := wal.vspConfig()
+
+	var hosts []string
+	if config.Remember != "" {
+		hosts = append(hosts, config.Remember)
+	}
+	for _, host := range config.List {
+		if host != config.Remember {
+			hosts = append(hosts, host)
+		}
+	}
+	return hosts
+}
+
+// CheckVSPTickets asks the VSPs in use about each ticket of a wallet that can
+// still vote and saves what the first VSP that knows the ticket reports.
+// Tickets no VSP knows are saved with no host. The requests are signed with
+// the commitment address of the ticket, which needs the spending passphrase.
+func (wal *Wallet) CheckVSPTickets(walletID int, passphrase []byte) (int, error) {
+	wall := wal.multi.WalletWithID(walletID)
+	if wall == nil {
+		return 0, ErrIDNotExist
+	}
+
+	type vsp struct {
+		host   string
+		pubKey []byte
+	}
+	var vsps []vsp
+	for _, host := range wal.vspHosts() {
+		info := wal.fetchVSPInfo(host)
+		if info.Err != nil {
+			log.Warnf("Could not reach VSP %s: %v", host, info.Err)
+			continue
+		}
+		if info.PubKeyChanged {
+			log.Warnf("Not asking VSP %s, its public key changed", host)
+			continue
+		}
+		vsps = append(vsps, vsp{host: host, pubKey: info.Info.PubKey})
+	}
+	if len(vsps) == 0 {
+		return 0, ErrNoVSP
+	}
+
+	ticketsInfo, err := wall.GetTicketsForBlockHeightRange(0, wall.GetBestBlock(), math.MaxInt32)
+	if err != nil {
+		return 0, err
+	}
+
+	statuses := make(map[string]*VSPTicketStatus)
+	for _, tinfo := range ticketsInfo {
+		if !canVote(tinfo.Status) {
+			continue
+		}
+		commitment, err := commitmentAddress(wall, tinfo.Ticket.Hash)
+		if err != nil {
+			return 0, err
+		}
+		if commitment == "" {
+			continue
+		}
+
+		hash := tinfo.Ticket.Hash.String()
+		body, err := json.Marshal(struct {
+			TicketHash string `json:"tickethash"`
+		}{hash})
+		if err != nil {
+			return 0, err
+		}
+		sig, err := wall.SignMessage(passphrase, commitment, string(body))
+		if err != nil {
+			return 0, err
+		}
+
+		status := &VSPTicketStatus{CheckedAt: time.Now().Unix()}
+		for _, v := range vsps {
+			resp, err := getVSPTicketStatus(v.host, v.pubKey, body, sig)
+			if err != nil {
+				log.Debugf("VSP %s has no status for ticket %s: %v", v.host, hash, err)
+				continue
+			}
+			status.Host = v.host
+			status.FeeStatus = vspFeeStatus(resp.FeeTxStatus)
+			status.FeeTxHash = resp.FeeTxHash
+			status.VoteChoices = make(map[string]string)
+			for id, choice := range resp.VoteChoices {
+				status.VoteChoices[id] = choice
+			}
+			for key, policy := range resp.TreasuryPolicy {
+				status.VoteChoices[key] = policy
+			}
+			break
+		}
+		statuses[hash] = status
+	}
+
+	wal.saveVSPTicketStatuses(statuses)
+	return len(statuses), nil
+}
+
+// RetryVSPFee pays the fee of a ticket to the VSP at host from the account
+// that bought the ticket, and saves the fee of the ticket as paid. Like
+// NewVSPD, it refuses a VSP whose public key changed.
+func (wal *Wallet) RetryVSPFee(walletID int, ticket Ticket, host string, passphrase []byte) error {
+	var account int32
+	if inputs := ticket.Info.Ticket.MyInputs; len(inputs) > 0 {
+		account = int32(inputs[0].PreviousAccount)
+	}
+	vspd, err := wal.NewVSPD(host, walletID, account)
+	if err != nil {
+		return err
+	}
+	wall := wal.multi.WalletWithID(walletID)
+
+	err = wall.UnlockWallet(passphrase)
+	if err != nil {
+		return err
+	}
+	defer wall.LockWallet()
+
+	// An empty fee transaction makes the VSP client pick the inputs to pay
+	// the fee with.
+	feeTx := wire.NewMsgTx()
+	err = vspd.ProcessFee(context.Background(), ticket.Info.Ticket.Hash, feeTx)
+	if err != nil {
+		return err
+	}
+
+	wal.saveVSPTicketStatuses(map[string]*VSPTicketStatus{
+		ticket.Info.Ticket.Hash.String(): {
+			Host:      host,
+			FeeStatus: VSPFeePaid,
+			FeeTxHash: feeTx.TxHash().String(),
+			CheckedAt: time.Now().Unix(),
+		},
+	})
+	return nil
+}
+
+// commitmentAddress returns the commitment address of a ticket, which signs
+// the requests about the ticket to VSPs, or empty if the ticket has none.
+func commitmentAddress(wall *dcrlibwallet.Wallet, hash *chainhash.Hash) (string, error) {
+	purchase, err := wall.GetTransactionRaw(hash[:])
+	if err != nil {
+		return "", err
+	}
+	// The commitment of a ticket is its second output.
+	if len(purchase.Outputs) < 2 {
+		return "", nil
+	}
+	return purchase.Outputs[1].Address, nil
+}
+
+// voteChoicesConfigKey is the wallet config key of the vote choices of the
+// wallet.
+const voteChoicesConfigKey = "vote_choices"
+
+// Agendas returns the agendas of the latest vote version of the network,
+// followed by the treasury keys whose spends tickets vote on.
+func (wal *Wallet) Agendas() ([]Agenda, error) {
+	params, err := utils.ChainParams(wal.Net)
+	if err != nil {
+		return nil, err
+	}
+
+	var version uint32
+	for v := range params.Deployments {
+		if v > version {
+			version = v
+		}
+	}
+
+	var agendas []Agenda
+	for _, deployment := range params.Deployments[version] {
+		agenda := Agenda{
+			ID:          deployment.Vote.Id,
+			Description: deployment.Vote.Description,
+			StartTime:   time.Unix(int64(deployment.StartTime), 0),
+			ExpireTime:  time.Unix(int64(deployment.ExpireTime), 0),
+		}
+		for _, choice := range deployment.Vote.Choices {
+			agenda.Choices = append(agenda.Choices, AgendaChoice{
+				ID:          choice.Id,
+				Description: choice.Description,
+				IsAbstain:   choice.IsAbstain,
+				IsNo:        choice.IsNo,
+			})
+		}
+		agendas = append(agendas, agenda)
+	}
+	for _, key := range params.PiKeys {
+		agendas = append(agendas, treasuryKeyAgenda(hex.EncodeToString(key)))
+	}
+	return agendas, nil
+}
+
+// treasuryKeyAgenda returns the agenda of the treasury spends signed by key.
+func treasuryKeyAgenda(key string) Agenda {
+	return Agenda{
+		ID:          key,
+		Description: "Treasury spends signed by this Politeia key",
+		TreasuryKey: true,
+		Choices: []AgendaChoice{
+			{ID: "abstain", Description: "abstain from voting on the spends", IsAbstain: true},
+			{ID: "no", Description: "vote against the spends", IsNo: true},
+			{ID: "yes", Description: "vote for the spends"},
+		},
+	}
+}
+
+// isTreasuryKey returns whether an agenda ID is the hex public key of a
+// treasury key.
+func isTreasuryKey(id string) bool {
+	key, err := hex.DecodeString(id)
+	return err == nil && len(key) == 33
+}
+
+// VoteChoices returns the choices of a wallet by agenda ID or treasury key.
+// Agendas without a choice are abstained from.
+func (wal *Wallet) VoteChoices(walletID int) (map[string]string, error) {
+	wall := wal.multi.WalletWithID(walletID)
+	if wall == nil {
+		return nil, ErrIDNotExist
+	}
+	choices := make(map[string]string)
+	wall.ReadUserConfigValue(voteChoicesConfigKey, &choices)
+	return choices, nil
+}
+
+// SetVoteChoice saves the choice of a wallet on an agenda. The choice is sent
+// to the VSPs of the tickets by PushVoteChoices.
+func (wal *Wallet) SetVoteChoice(walletID int, agendaID, choiceID string) error {
+	wall := wal.multi.WalletWithID(walletID)
+	if wall == nil {
+		return ErrIDNotExist
+	}
+	choices := make(map[string]string)
+	wall.ReadUserConfigValue(voteChoicesConfigKey, &choices)
+	choices[agendaID] = choiceID
+	wall.SaveUserConfigValue(voteChoicesConfigKey, choices)
+	return nil
+}
+
+// PushVoteChoices sends the vote choices of a wallet to the VSP of each of its
+// tickets that can still vote, and returns how many tickets the VSPs
+// recorded the choices for. The VSP of a ticket is only known once the VSPs
+// were asked about it with CheckVSPTickets.
+func (wal *Wallet) PushVoteChoices(walletID int, passphrase []byte) (int, error) {
+	wall := wal.multi.WalletWithID(walletID)
+	if wall == nil {
+		return 0, ErrIDNotExist
+	}
+	choices, err := wal.VoteChoices(walletID)
+	if err != nil {
+		return 0, err
+	}
+	voteChoices, treasuryPolicy := make(map[string]string), make(map[string]string)
+	for id, choice := range choices {
+		if isTreasuryKey(id) {
+			treasuryPolicy[id] = choice
+		} else {
+			voteChoices[id] = choice
+		}
+	}
+
+	ticketsInfo, err := wall.GetTicketsForBlockHeightRange(0, wall.GetBestBlock(), math.MaxInt32)
+	if err != nil {
+		return 0, err
+	}
+
+	saved := wal.vspTicketStatuses()
+	pubKeys := make(map[string][]byte)
+	statuses := make(map[string]*VSPTicketStatus)
+	for _, tinfo := range ticketsInfo {
+		hash := tinfo.Ticket.Hash.String()
+		status := saved[hash]
+		if !canVote(tinfo.Status) || status == nil || status.Host == "" {
+			continue
+		}
+
+		pubKey, ok := pubKeys[status.Host]
+		if !ok {
+			info := wal.fetchVSPInfo(status.Host)
+			switch {
+			case info.Err != nil:
+				log.Warnf("Could not reach VSP %s: %v", status.Host, info.Err)
+			case info.PubKeyChanged:
+				log.Warnf("Not sending vote choices to VSP %s, its public key changed", status.Host)
+			default:
+				pubKey = info.Info.PubKey
+			}
+			pubKeys[status.Host] = pubKey
+		}
+		if pubKey == nil {
+			continue
+		}
+
+		commitment, err := commitmentAddress(wall, tinfo.Ticket.Hash)
+		if err != nil {
+			return 0, err
+		}
+		if commitment == "" {
+			continue
+		}
+		body, err := json.Marshal(struct {
+			Timestamp      int64             `json:"timestamp"`
+			TicketHash     string            `json:"tickethash"`
+			VoteChoices    map[string]string `json:"votechoices"`
+			TreasuryPolicy map[string]string `json:"treasurypolicy"`
+		}{time.Now().Unix(), hash, voteChoices, treasuryPolicy})
+		if err != nil {
+			return 0, err
+		}
+		sig, err := wall.SignMessage(passphrase, commitment, string(body))
+		if err != nil {
+			return 0, err
+		}
+
+		err = setVSPVoteChoices(status.Host, pubKey, body, sig)
+		if err != nil {
+			log.Warnf("VSP %s did not record the vote choices of ticket %s: %v", status.Host, hash, err)
+			continue
+		}
+		recorded := *status
+		recorded.VoteChoices = choices
+		recorded.CheckedAt = time.Now().Unix()
+		statuses[hash] = &recorded
+	}
+
+	wal.saveVSPTicketStatuses(statuses)
+	return len(statuses), nil
+}
+
+// choicesRecorded returns whether a VSP recorded the choices for a ticket.
+// Agendas without a recorded choice are abstained from.
+func choicesRecorded(status *VSPTicketStatus, choices map[string]string) bool {
+	for id, choice := range choices {
+		recorded, ok := status.VoteChoices[id]
+		if !ok {
+			recorded = "abstain"
+		}
+		if recorded != choice {
+			return false
+		}
+	}
+	return true
+}
+
+// VoteStatuses returns, for each VSP voting any of tickets, how many of its
+// tickets that can still vote it recorded the choices for, sorted by host.
+func VoteStatuses(tickets []Ticket, choices map[string]string) []VSPVoteStatus {
+	byHost := make(map[string]*VSPVoteStatus)
+	var hosts []string
+	for _, ticket := range tickets {
+		if !canVote(ticket.Info.Status) || ticket.VSP == nil || ticket.VSP.Host == "" {
+			continue
+		}
+		status, ok := byHost[ticket.VSP.Host]
+		if !ok {
+			status = &VSPVoteStatus{Host: ticket.VSP.Host}
+			byHost[ticket.VSP.Host] = status
+			hosts = append(hosts, ticket.VSP.Host)
+		}
+		status.Tickets++
+		if choicesRecorded(ticket.VSP, choices) {
+			status.Recorded++
+		}
+	}
+
+	sort.Strings(hosts)
+	statuses := make([]VSPVoteStatus, len(hosts))
+	for i, host := range hosts {
+		statuses[i] = *byHost[host]
+	}
+	return statuses
+}
+
+// summarizeTickets counts the tickets of each status and collects the most
+// recent live tickets and ticket activity across the wallets in walletIDs.
+// The tickets of each wallet must be sorted newest first.
+func summarizeTickets(walletIDs []int, tickets map[int][]Ticket, unconfirmed map[int][]UnconfirmedPurchase) *Tickets {
+	var liveRecentTickets []Ticket
+	var recentActivity []Ticket
+
+	stackingRecordCounter := []struct {
+		Status string
+		Count  int
+	}{
+		{"UNMINED", 0},
+		{"IMMATURE", 0},
+		{"LIVE", 0},
+		{"VOTED", 0},
+		{"MISSED", 0},
+		{"EXPIRED", 0},
+		{"REVOKED", 0},
+	}
+
+	liveCounter := []struct {
+		Status string
+		Count  int
+	}{
+		{"UNMINED", 0},
+		{"IMMATURE", 0},
+		{"LIVE", 0},
+	}
+
+	for _, id := range walletIDs {
+		for _, info := range tickets[id] {
+			status := info.Info.Status
+			for i := range liveCounter {
+				if liveCounter[i].Status == status {
+					liveCounter[i].Count++
+				}
+			}
+
+			if status == "UNMINED" || status == "IMMATURE" || status == "LIVE" {
+				liveRecentTickets = append(liveRecentTickets, info)
+			}
+
+			recentActivity = append(recentActivity, info)
+
+			for i := range stackingRecordCounter {
+				if stackingRecordCounter[i].Status == status {
+					stackingRecordCounter[i].Count++
+				}
+			}
+		}
+	}
+
+	sort.SliceStable(liveRecentTickets, func(i, j int) bool {
+		backTime := time.Unix(liveRecentTickets[j].Info.Ticket.Timestamp, 0)
+		frontTime := time.Unix(liveRecentTickets[i].Info.Ticket.Timestamp, 0)
+		return backTime.Before(frontTime)
+	})
+
+	recentLimit := 5
+	if len(liveRecentTickets) > recentLimit {
+		liveRecentTickets = liveRecentTickets[:recentLimit]
+	}
+
+	sort.SliceStable(recentActivity, func(i, j int) bool {
+		backTime := time.Unix(recentActivity[j].Info.Ticket.Timestamp, 0)
+		frontTime := time.Unix(recentActivity[i].Info.Ticket.Timestamp, 0)
+		return backTime.Before(frontTime)
+	})
+
+	if len(recentActivity) > recentLimit {
+		recentActivity = recentActivity[:recentLimit]
+	}
+
+	return &Tickets{
+		Confirmed:             tickets,
+		Unconfirmed:           unconfirmed,
+		RecentActivity:        recentActivity,
+		StackingRecordCounter: stackingRecordCounter,
+		LiveRecent:            liveRecentTickets,
+		LiveCounter:           liveCounter,
+	}
 }
 
 func getUnconfirmedPurchases(wall dcrlibwallet.Wallet, tickets []Ticket) (unconfirmed []UnconfirmedPurchase, err error) {
@@ -1172,9 +1623,9 @@ func getUnconfirmedPurchases(wall dcrlibwallet.Wallet, tickets []Ticket) (unconf
 			unconfirmed = append(unconfirmed, UnconfirmedPurchase{
 				Hash:        txn.Hash,
 				Status:      "UNCONFIRMED",
-				DateTime:    dcrlibwallet.ExtractDateOrTime(txn.Timestamp),
+				Timestamp:   txn.Timestamp,
 				BlockHeight: txn.BlockHeight,
-				Amount:      dcrutil.Amount(amount).String(),
+				Amount:      FormatAmount(amount),
 			})
 		}
 	}
@@ -1182,22 +1633,12 @@ func getUnconfirmedPurchases(wall dcrlibwallet.Wallet, tickets []Ticket) (unconf
 	return
 }
 
-func (wal *Wallet) StartAccountMixer(walletID int, walletPassphrase string, errChan chan error) {
-	err := wal.multi.StartAccountMixer(walletID, walletPassphrase)
-	if err != nil {
-		go func() {
-			errChan <- err
-		}()
-	}
+func (wal *Wallet) StartAccountMixer(walletID int, walletPassphrase string) error {
+	return wal.multi.StartAccountMixer(walletID, walletPassphrase)
 }
 
-func (wal *Wallet) StopAccountMixer(walletID int, errChan chan error) {
-	err := wal.multi.StopAccountMixer(walletID)
-	if err != nil {
-		go func() {
-			errChan <- err
-		}()
-	}
+func (wal *Wallet) StopAccountMixer(walletID int) error {
+	return wal.multi.StopAccountMixer(walletID)
 }
 
 func (wal *Wallet) IsAccountMixerActive(walletID int) bool {
@@ -1208,8 +1649,37 @@ func (wal *Wallet) IsAccountMixerActive(walletID int) bool {
 	return wall.IsAccountMixerActive()
 }
 
-func (wal *Wallet) AllWallets() []*dcrlibwallet.Wallet {
-	return wal.multi.AllWallets()
+// AllWallets returns the loaded wallets, sorted by ID.
+func (wal *Wallet) AllWallets() []*WalletSummary {
+	wallets := wal.multi.AllWallets()
+	summaries := make([]*WalletSummary, len(wallets))
+	for i, wall := range wallets {
+		summaries[i] = walletSummary(wall)
+	}
+	sort.Slice(summaries, func(i, j int) bool {
+		return summaries[i].ID < summaries[j].ID
+	})
+	return summaries
+}
+
+// WalletWithID returns the loaded wallet with walletID, or nil if there is
+// none.
+func (wal *Wallet) WalletWithID(walletID int) *WalletSummary {
+	wall := wal.multi.WalletWithID(walletID)
+	if wall == nil {
+		return nil
+	}
+	return walletSummary(wall)
+}
+
+// walletSummary copies the identity of wall.
+func walletSummary(wall *dcrlibwallet.Wallet) *WalletSummary {
+	return &WalletSummary{
+		ID:             wall.ID,
+		Name:           wall.Name,
+		EncryptedSeed:  wall.EncryptedSeed,
+		IsWatchingOnly: wall.IsWatchingOnlyWallet(),
+	}
 }
 
 func (wal *Wallet) ReadMixerConfigValueForKey(key string, walletID int) int32 {
@@ -1220,102 +1690,327 @@ func (wal *Wallet) ReadMixerConfigValueForKey(key string, walletID int) int32 {
 	return 0
 }
 
-func (wal *Wallet) AddVSP(host string, errChan chan error) {
-	// wal.multi.DeleteUserConfigValueForKey(dcrlibwallet.VSPHostConfigKey)
-	go func() {
-		var resp Response
-		var valueOut struct {
-			Remember string
-			List     []string
-		}
+const (
+	// vspInfoTTL is how long the info of a VSP is used before it is asked
+	// again.
+	vspInfoTTL = 10 * time.Minute
+	// vspListTTL is how long the list of VSPs from api.decred.org is used
+	// before it is fetched again.
+	vspListTTL = time.Hour
 
-		wal.multi.ReadUserConfigValue(dcrlibwallet.VSPHostConfigKey, &valueOut)
+	// vspPubKeyConfigKey is the config key of the public keys pinned for
+	// each VSP host.
+	vspPubKeyConfigKey = "vsp_pubkeys"
+)
 
-		for _, v := range valueOut.List {
-			if v == host {
-				go func() {
-					errChan <- fmt.Errorf("Existing host %s", host)
-				}()
-				return
+// vspListURL lists the VSPs of every network.
+var vspListURL = "https://api.decred.org/?c=vsp"
+
+// vspDirectory caches the info of the VSPs so that they are not asked every
+// time the list is shown.
+type vspDirectory struct {
+	mu       sync.Mutex
+	infos    map[string]VSPInfo
+	listed   []string
+	listedAt time.Time
+
+	// keyMu guards the public keys pinned in the config.
+	keyMu sync.Mutex
+}
+
+// vspConfig returns the remembered VSP and the VSPs added by the user.
+func (wal *Wallet) vspConfig() (config struct {
+	Remember string
+	List     []string
+}) {
+	wal.multi.ReadUserConfigValue(dcrlibwallet.VSPHostConfigKey, &config)
+	return config
+}
+
+// fetchVSPInfo asks a VSP for its info and times the request. The public key
+// the VSP signs with is pinned the first time it answers, and compared with
+// the pinned key after.
+func (wal *Wallet) fetchVSPInfo(host string) VSPInfo {
+	start := time.Now()
+	info, err := getVSPInfo(host)
+	vsp := VSPInfo{
+		Host:      host,
+		Latency:   time.Since(start),
+		CheckedAt: time.Now(),
+	}
+	switch {
+	case err != nil:
+		vsp.Err = err
+	case !strings.Contains(wal.Net, info.Network):
+		vsp.Err = fmt.Errorf("Invalid net %s", info.Network)
+	default:
+		vsp.Info = info
+		vsp.PubKeyChanged = !wal.pinVSPPubKey(host, info.PubKey, false)
+	}
+	return vsp
+}
+
+// pinVSPPubKey pins pubKey as the public key of the VSP at host if none is
+// pinned yet, or replaces the pinned key if replace is set. It returns whether
+// pubKey is the pinned key.
+func (wal *Wallet) pinVSPPubKey(host string, pubKey []byte, replace bool) bool {
+	wal.vspDir.keyMu.Lock()
+	defer wal.vspDir.keyMu.Unlock()
+	keys := make(map[string][]byte)
+	wal.multi.ReadUserConfigValue(vspPubKeyConfigKey, &keys)
+	if pinned, ok := keys[host]; ok && !replace {
+		return bytes.Equal(pinned, pubKey)
+	}
+	keys[host] = pubKey
+	wal.multi.SaveUserConfigValue(vspPubKeyConfigKey, keys)
+	return true
+}
+
+// unpinVSPPubKey forgets the public key pinned for the VSP at host.
+func (wal *Wallet) unpinVSPPubKey(host string) {
+	wal.vspDir.keyMu.Lock()
+	defer wal.vspDir.keyMu.Unlock()
+	keys := make(map[string][]byte)
+	wal.multi.ReadUserConfigValue(vspPubKeyConfigKey, &keys)
+	delete(keys, host)
+	wal.multi.SaveUserConfigValue(vspPubKeyConfigKey, keys)
+}
+
+// listedVSPHosts returns the hosts api.decred.org lists for the network,
+// fetching the list again if it is older than vspListTTL or force is set. The
+// last list is kept if api.decred.org cannot be reached. wal.vspDir.mu is
+// not held while the list is fetched.
+func (wal *Wallet) listedVSPHosts(force bool) []string {
+	dir := &wal.vspDir
+	dir.mu.Lock()
+	listed, listedAt := dir.listed, dir.listedAt
+	dir.mu.Unlock()
+	if !force && listed != nil && time.Since(listedAt) < vspListTTL {
+		return listed
+	}
+
+	list, err := getInitVSPInfo(vspListURL)
+	if err != nil {
+		log.Warnf("Could not fetch the VSP list: %v", err)
+		return listed
+	}
+	listed = make([]string, 0, len(list))
+	for h, v := range list {
+		if strings.Contains(wal.Net, v.Network) {
+			listed = append(listed, fmt.Sprintf("https://%s", h))
+		}
+	}
+	sort.Strings(listed)
+
+	dir.mu.Lock()
+	dir.listed, dir.listedAt = listed, time.Now()
+	dir.mu.Unlock()
+	return listed
+}
+
+// vspList returns the VSPs added by the user followed by the listed VSPs.
+// The VSPs are asked for their info at once if it is older than vspInfoTTL or
+// force is set. A VSP that cannot be reached keeps the info it last sent.
+// wal.vspDir.mu is only held to read and merge the cached info, so that a
+// slow VSP doesn't hold up adding, removing or trusting VSPs.
+func (wal *Wallet) vspList(force bool) []VSPInfo {
+	listed := wal.listedVSPHosts(force)
+
+	dir := &wal.vspDir
+	dir.mu.Lock()
+	config := wal.vspConfig()
+	added := make(map[string]bool)
+	hosts := append([]string(nil), config.List...)
+	for _, host := range config.List {
+		added[host] = true
+	}
+	for _, host := range listed {
+		if !added[host] {
+			hosts = append(hosts, host)
+		}
+	}
+	var stale []int
+	for i, host := range hosts {
+		cached, ok := dir.infos[host]
+		if !ok || force || time.Since(cached.CheckedAt) >= vspInfoTTL {
+			stale = append(stale, i)
+		}
+	}
+	dir.mu.Unlock()
+
+	var wg sync.WaitGroup
+	fetched := make([]*VSPInfo, len(hosts))
+	for _, i := range stale {
+		wg.Add(1)
+		go func(i int, host string) {
+			defer wg.Done()
+			defer crash.Recover("wallet vspList")
+			vsp := wal.fetchVSPInfo(host)
+			fetched[i] = &vsp
+		}(i, hosts[i])
+	}
+	wg.Wait()
+
+	dir.mu.Lock()
+	defer dir.mu.Unlock()
+	if dir.infos == nil {
+		dir.infos = make(map[string]VSPInfo)
+	}
+	// VSPs the user removed while the others were asked are left out.
+	kept := make(map[string]bool)
+	for _, host := range wal.vspConfig().List {
+		kept[host] = true
+	}
+	list := make([]VSPInfo, 0, len(hosts))
+	for i, host := range hosts {
+		if added[host] && !kept[host] {
+			continue
+		}
+		if vsp := fetched[i]; vsp != nil {
+			if cached, ok := dir.infos[host]; ok && vsp.Err != nil {
+				vsp.Info, vsp.PubKeyChanged = cached.Info, cached.PubKeyChanged
 			}
+			dir.infos[host] = *vsp
 		}
+		vsp := dir.infos[host]
+		vsp.Added = added[host]
+		list = append(list, vsp)
+	}
+	return list
+}
 
-		info, err := getVSPInfo(host)
-		if err != nil {
+// hasVSP returns whether host is added by the user or listed.
+// wal.vspDir.mu must be held.
+func (wal *Wallet) hasVSP(host string) bool {
+	for _, v := range append(append([]string(nil), wal.vspConfig().List...), wal.vspDir.listed...) {
+		if v == host {
+			return true
+		}
+	}
+	return false
+}
+
+// AddVSP asks the VSP at host for its info and adds it to the VSPs of the
+// user. wal.vspDir.mu is not held while the VSP is asked.
+// It is non-blocking and sends its result or any error to wal.Send.
+func (wal *Wallet) AddVSP(host string, errChan chan error) {
+	go func() {
+		defer crash.Recover("wallet AddVSP")
+		sendErr := func(err error) {
 			go func() {
 				errChan <- err
 			}()
-			resp.Err = err
+		}
+
+		dir := &wal.vspDir
+		dir.mu.Lock()
+		exists := wal.hasVSP(host)
+		dir.mu.Unlock()
+		if exists {
+			sendErr(fmt.Errorf("Existing host %s", host))
+			return
+		}
+
+		vsp := wal.fetchVSPInfo(host)
+		if vsp.Err != nil {
+			sendErr(vsp.Err)
 			wal.Send <- ResponseError(MultiWalletError{
 				Message: "Could not create vsp",
-				Err:     err,
+				Err:     vsp.Err,
 			})
 			return
 		}
 
-		if info.Network != wal.Net {
-			go func() {
-				errChan <- fmt.Errorf("Invalid net %s", info.Network)
-			}()
+		dir.mu.Lock()
+		// the VSP may have been added while it was asked
+		if wal.hasVSP(host) {
+			dir.mu.Unlock()
+			sendErr(fmt.Errorf("Existing host %s", host))
 			return
 		}
-
-		valueOut.List = append(valueOut.List, host)
-		wal.multi.SaveUserConfigValue(dcrlibwallet.VSPHostConfigKey, valueOut)
-		resp.Resp = &VSPInfo{
-			Host: host,
-			Info: info,
+		if dir.infos == nil {
+			dir.infos = make(map[string]VSPInfo)
 		}
-		wal.Send <- resp
+		config := wal.vspConfig()
+		config.List = append(config.List, host)
+		wal.multi.SaveUserConfigValue(dcrlibwallet.VSPHostConfigKey, config)
+		dir.infos[host] = vsp
+		dir.mu.Unlock()
+
+		vsp.Added = true
+		wal.Send <- ResponseResp(&vsp)
 	}()
 }
 
+// GetAllVSP lists the VSPs with the info they last sent, asking those whose
+// info is older than vspInfoTTL again.
+// It is non-blocking and sends its result to wal.Send.
 func (wal *Wallet) GetAllVSP() {
 	go func() {
-		var valueOut struct {
-			Remember string
-			List     []string
-		}
+		defer crash.Recover("wallet GetAllVSP")
+		wal.Send <- ResponseResp(&VSP{List: wal.vspList(false)})
+	}()
+}
 
-		wal.multi.ReadUserConfigValue(dcrlibwallet.VSPHostConfigKey, &valueOut)
-		var loadedVSP []VSPInfo
+// CheckVSPs asks every VSP for its info again to check that it is up, how
+// fast it answers and whether its public key changed.
+// It is non-blocking and sends its result to wal.Send.
+func (wal *Wallet) CheckVSPs() {
+	go func() {
+		defer crash.Recover("wallet CheckVSPs")
+		wal.Send <- ResponseResp(&VSP{List: wal.vspList(true)})
+	}()
+}
 
-		for _, host := range valueOut.List {
-			v, err := getVSPInfo(host)
-			if err == nil {
-				loadedVSP = append(loadedVSP, VSPInfo{
-					Host: host,
-					Info: v,
-				})
-			}
-		}
+// RemoveVSP removes a VSP added by the user and forgets its public key. The
+// VSP is no longer remembered if it was.
+func (wal *Wallet) RemoveVSP(host string) error {
+	dir := &wal.vspDir
+	dir.mu.Lock()
+	defer dir.mu.Unlock()
 
-		l, _ := getInitVSPInfo("https://api.decred.org/?c=vsp")
-		for h, v := range l {
-			if strings.Contains(wal.Net, v.Network) {
-				loadedVSP = append(loadedVSP, VSPInfo{
-					Host: fmt.Sprintf("https://%s", h),
-					Info: v,
-				})
-			}
+	config := wal.vspConfig()
+	index := -1
+	for i, v := range config.List {
+		if v == host {
+			index = i
 		}
+	}
+	if index < 0 {
+		return fmt.Errorf("Unknown host %s", host)
+	}
 
-		var resp Response
-		resp.Resp = &VSP{
-			List: loadedVSP,
-		}
-		wal.Send <- resp
-	}()
+	config.List = append(config.List[:index], config.List[index+1:]...)
+	if config.Remember == host {
+		config.Remember = ""
+	}
+	wal.multi.SaveUserConfigValue(dcrlibwallet.VSPHostConfigKey, config)
+	wal.unpinVSPPubKey(host)
+	delete(dir.infos, host)
+	return nil
 }
 
-func (wal *Wallet) RememberVSP(host string) {
-	var valueOut struct {
-		Remember string
-		List     []string
+// TrustVSPPubKey pins the public key the VSP at host last signed with, after
+// the user checked that the VSP changed its key.
+func (wal *Wallet) TrustVSPPubKey(host string) error {
+	dir := &wal.vspDir
+	dir.mu.Lock()
+	defer dir.mu.Unlock()
+
+	vsp, ok := dir.infos[host]
+	if !ok || vsp.Info == nil {
+		return fmt.Errorf("Unknown host %s", host)
 	}
-	wal.multi.ReadUserConfigValue(dcrlibwallet.VSPHostConfigKey, &valueOut)
-	valueOut.Remember = host
-	wal.multi.SaveUserConfigValue(dcrlibwallet.VSPHostConfigKey, valueOut)
+	wal.pinVSPPubKey(host, vsp.Info.PubKey, true)
+	vsp.PubKeyChanged = false
+	dir.infos[host] = vsp
+	return nil
+}
+
+func (wal *Wallet) RememberVSP(host string) {
+	config := wal.vspConfig()
+	config.Remember = host
+	wal.multi.SaveUserConfigValue(dcrlibwallet.VSPHostConfigKey, config)
 }
 
 func (wal *Wallet) GetRememberVSP() string {
@@ -1329,8 +2024,7 @@ func (wal *Wallet) GetRememberVSP() string {
 
 // getVSPInfo returns the information of the specified VSP base URL
 func getVSPInfo(url string) (*dcrlibwallet.VspInfoResponse, error) {
-	rq := new(http.Client)
-	resp, err := rq.Get((url + "/api/v3/vspinfo"))
+	resp, err := vspHTTPClient.Get((url + "/api/v3/vspinfo"))
 
 	if err != nil {
 		return nil, err
@@ -1359,10 +2053,96 @@ func getVSPInfo(url string) (*dcrlibwallet.VspInfoResponse, error) {
 	return &vspInfoResponse, nil
 }
 
+// vspHTTPClient is the client of the requests to VSPs. It times out so that
+// an unreachable VSP does not hold up the others.
+var vspHTTPClient = &http.Client{Timeout: 10 * time.Second}
+
+// vspTicketStatusResponse is the status of a ticket reported by a VSP.
+type vspTicketStatusResponse struct {
+	TicketConfirmed bool              `json:"ticketconfirmed"`
+	FeeTxStatus     string            `json:"feetxstatus"`
+	FeeTxHash       string            `json:"feetxhash"`
+	VoteChoices     map[string]string `json:"votechoices"`
+	TreasuryPolicy  map[string]string `json:"treasurypolicy"`
+}
+
+// getVSPTicketStatus asks the VSP at url for the status of a ticket. body is
+// the request and sig its signature by the commitment address of the ticket.
+func getVSPTicketStatus(url string, pubKey, body, sig []byte) (*vspTicketStatusResponse, error) {
+	var status vspTicketStatusResponse
+	err := postToVSP(url+"/api/v3/ticketstatus", pubKey, body, sig, &status)
+	if err != nil {
+		return nil, err
+	}
+	return &status, nil
+}
+
+// setVSPVoteChoices sends the vote choices of a ticket to the VSP at url. body
+// is the request and sig its signature by the commitment address of the
+// ticket.
+func setVSPVoteChoices(url string, pubKey, body, sig []byte) error {
+	var resp struct {
+		Request []byte `json:"request"`
+	}
+	err := postToVSP(url+"/api/v3/setvotechoices", pubKey, body, sig, &resp)
+	if err != nil {
+		return err
+	}
+	if !bytes.Equal(resp.Request, body) {
+		return errors.New("server response contains differing request")
+	}
+	return nil
+}
+
+// postToVSP posts a signed request to a VSP and decodes its response into
+// resp once the signature of the VSP is validated against pubKey.
+func postToVSP(url string, pubKey, body, sig []byte, resp interface{}) error {
+	req, err := http.NewRequest(http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("VSP-Client-Signature", base64.StdEncoding.EncodeToString(sig))
+
+	res, err := vspHTTPClient.Do(req)
+	if err != nil {
+		return err
+	}
+
+	b, err := ioutil.ReadAll(res.Body)
+	res.Body.Close()
+	if err != nil {
+		return err
+	}
+
+	if res.StatusCode != http.StatusOK {
+		return fmt.Errorf("non 200 response from server: %v", string(b))
+	}
+
+	err = validateVSPServerSignature(res, pubKey, b)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(b, resp)
+}
+
+// vspFeeStatus converts the fee transaction status reported by a VSP.
+func vspFeeStatus(feeTxStatus string) VSPFeeStatus {
+	switch feeTxStatus {
+	case "none":
+		return VSPFeeUnpaid
+	case "received", "broadcast":
+		return VSPFeePaid
+	case "confirmed":
+		return VSPFeeConfirmed
+	case "error":
+		return VSPFeeErrored
+	}
+	return VSPFeeUnknown
+}
+
 // getInitVSPInfo returns the list information of the VSP
 func getInitVSPInfo(url string) (map[string]*dcrlibwallet.VspInfoResponse, error) {
-	rq := new(http.Client)
-	resp, err := rq.Get((url))
+	resp, err := vspHTTPClient.Get((url))
 	if err != nil {
 		return nil, err
 	}
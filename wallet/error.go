@@ -12,6 +12,13 @@ var (
 
 	// ErrBadPass wraps dcrlibwallet.ErrInvalidPassphrase
 	ErrBadPass = errors.New(dcrlibwallet.ErrInvalidPassphrase)
+
+	// ErrNoVSP is returned when none of the VSPs in use can be reached.
+	ErrNoVSP = errors.New("none of the VSPs in use could be reached")
+
+	// ErrVSPPubKeyChanged is returned when a VSP signs with another public key
+	// than the one pinned for it, until the new key is trusted.
+	ErrVSPPubKeyChanged = errors.New("the VSP signs with another public key than before")
 )
 
 // InternalWalletError wraps errors encountered with individual Wallets and Accounts
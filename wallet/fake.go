@@ -0,0 +1,1323 @@
+package wallet
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"decred.org/dcrwallet/wallet"
+	"github.com/decred/dcrd/chaincfg/chainhash"
+	"github.com/decred/dcrd/chaincfg/v3"
+	"github.com/decred/dcrd/dcrutil/v3"
+	"github.com/planetdecred/dcrlibwallet"
+	"github.com/planetdecred/dcrlibwallet/utils"
+	"github.com/planetdecred/godcr/crash"
+)
+
+// FakeWallet is a Backend that serves scripted wallets, accounts,
+// transactions, tickets, proposals and sync events instead of chain data.
+//
+// Everything is kept in memory, so nothing is written to disk and the
+// scripted wallets are as they were every time the app starts.
+type FakeWallet struct {
+	Net                string
+	Send               chan Response
+	Sync               chan SyncStatusUpdate
+	OverallBlockHeight int32
+
+	params *chaincfg.Params
+
+	mu            sync.Mutex
+	syncListeners map[string]dcrlibwallet.SyncProgressListener
+	cancelSync    chan struct{}
+	syncing       bool
+	synced        bool
+	peers         int32
+	broadcasts    int
+
+	balances    map[int]Balance
+	txs         map[int][]dcrlibwallet.Transaction
+	tickets     map[int][]dcrlibwallet.TicketInfo
+	vspStatuses map[string]*VSPTicketStatus
+	vsps        []VSPInfo
+	proposals   []dcrlibwallet.Proposal
+
+	// walletMu guards the wallets, the config and the listeners other than
+	// the sync listeners.
+	walletMu          sync.Mutex
+	wallets           map[int]*fakeWallet
+	opened            bool
+	startupPassphrase []byte
+	config            map[string]json.RawMessage
+	txListeners       map[string]dcrlibwallet.TxAndBlockNotificationListener
+	mixerListeners    map[string]dcrlibwallet.AccountMixerNotificationListener
+	proposalListeners map[string]dcrlibwallet.ProposalNotificationListener
+}
+
+var (
+	_ Backend = (*Wallet)(nil)
+	_ Backend = (*FakeWallet)(nil)
+)
+
+// NewFakeWallet creates a FakeWallet for net. Like NewWallet, the wallets are
+// not loaded until InitMultiWallet is called.
+func NewFakeWallet(net string, send chan Response) (*FakeWallet, error) {
+	params, err := utils.ChainParams(net)
+	if err != nil {
+		return nil, err
+	}
+
+	return &FakeWallet{
+		Net:               net,
+		Send:              send,
+		Sync:              make(chan SyncStatusUpdate, 2),
+		params:            params,
+		syncListeners:     make(map[string]dcrlibwallet.SyncProgressListener),
+		balances:          make(map[int]Balance),
+		txs:               make(map[int][]dcrlibwallet.Transaction),
+		tickets:           make(map[int][]dcrlibwallet.TicketInfo),
+		vspStatuses:       make(map[string]*VSPTicketStatus),
+		vsps:              append([]VSPInfo(nil), fakeVSPDirectory...),
+		config:            make(map[string]json.RawMessage),
+		txListeners:       make(map[string]dcrlibwallet.TxAndBlockNotificationListener),
+		mixerListeners:    make(map[string]dcrlibwallet.AccountMixerNotificationListener),
+		proposalListeners: make(map[string]dcrlibwallet.ProposalNotificationListener),
+	}, nil
+}
+
+// InitMultiWallet loads the scripted wallets, which are opened by
+// OpenWallets. It does nothing once they are loaded.
+func (fw *FakeWallet) InitMultiWallet() error {
+	fw.walletMu.Lock()
+	defer fw.walletMu.Unlock()
+	if fw.wallets != nil {
+		return nil
+	}
+
+	fw.wallets = make(map[int]*fakeWallet)
+	for i, script := range fakeWallets {
+		wall := newFakeWallet(i+1, script.name, script.seed, FakePassphrase)
+		for agenda, choice := range fakeWalletVoteChoices {
+			wall.voteChoices[agenda] = choice
+		}
+		fw.wallets[wall.ID] = wall
+		fw.loadScript(wall.ID, script)
+	}
+
+	for _, p := range fakeProposals {
+		proposal := p.Proposal
+		proposal.Timestamp = fakeBestBlockTime.Add(-p.age).Unix()
+		proposal.PublishedAt = proposal.Timestamp
+		fw.proposals = append(fw.proposals, proposal)
+	}
+	return nil
+}
+
+// Network returns the name of the network the wallets are on.
+func (fw *FakeWallet) Network() string {
+	return fw.Net
+}
+
+// Responses returns the channel results of non-blocking methods are sent to.
+func (fw *FakeWallet) Responses() chan Response {
+	return fw.Send
+}
+
+// SyncUpdates returns the channel sync and notification updates are sent to.
+func (fw *FakeWallet) SyncUpdates() chan SyncStatusUpdate {
+	return fw.Sync
+}
+
+// SetOverallBlockHeight sets the height wallets are synced against when
+// reporting their sync status.
+func (fw *FakeWallet) SetOverallBlockHeight(height int32) {
+	fw.OverallBlockHeight = height
+}
+
+// loadScript converts the scripted transactions and tickets of a wallet.
+func (fw *FakeWallet) loadScript(walletID int, script fakeWalletScript) {
+	fw.balances[walletID] = script.balance
+
+	for i, tx := range script.txs {
+		txn := newFakeTransaction(walletID, fakeHash(script.name, "tx", i), fakeHash(script.name, "prev", i), tx)
+		fw.txs[walletID] = append(fw.txs[walletID], txn)
+	}
+
+	for i, t := range script.tickets {
+		height := fakeBestBlockHeight - t.blocksAgo
+		info := dcrlibwallet.TicketInfo{
+			Status: t.status,
+			Ticket: &wallet.TransactionSummary{
+				Hash:      fakeHash(script.name, "ticket", i),
+				Fee:       dcrutil.Amount(t.fee),
+				Timestamp: fakeBlockTime(height).Unix(),
+				Type:      wallet.TransactionTypeTicketPurchase,
+				MyInputs: []wallet.TransactionSummaryInput{{
+					PreviousAmount: dcrutil.Amount(fakeTicketPrice + t.fee + t.vspFee),
+				}},
+				MyOutputs: []wallet.TransactionSummaryOutput{{
+					Amount: dcrutil.Amount(fakeTicketPrice),
+				}},
+			},
+		}
+		if t.status != "UNMINED" {
+			info.BlockHeight = height
+		}
+		if t.spentBlocksAgo > 0 {
+			spender := &wallet.TransactionSummary{
+				Hash:      fakeHash(script.name, "spender", i),
+				Timestamp: fakeBlockTime(fakeBestBlockHeight - t.spentBlocksAgo).Unix(),
+				Type:      wallet.TransactionTypeVote,
+				MyOutputs: []wallet.TransactionSummaryOutput{{
+					Amount: dcrutil.Amount(t.returned),
+				}},
+			}
+			if t.status == "REVOKED" {
+				spender.Type = wallet.TransactionTypeRevocation
+				spender.Fee = dcrutil.Amount(fakeTicketPrice - t.returned)
+			}
+			info.Spender = spender
+		}
+		fw.tickets[walletID] = append(fw.tickets[walletID], info)
+		if t.vspFee == 0 {
+			fw.vspStatuses[info.Ticket.Hash.String()] = fakeVSPStatus(info)
+		}
+	}
+}
+
+// fakeVSPStatus returns the status of a scripted ticket that paid no pool fee
+// in its purchase. Such tickets were bought through one of the scripted VSPs,
+// picked by their hash, and paid it in a separate fee transaction.
+func fakeVSPStatus(info dcrlibwallet.TicketInfo) *VSPTicketStatus {
+	hash := info.Ticket.Hash
+	if hash.IsEqual(fakeNoVSPTicket) {
+		return &VSPTicketStatus{CheckedAt: fakeBestBlockTime.Unix()}
+	}
+
+	status := fakePaidStatus(fakeVSPs[int(hash[0])%len(fakeVSPs)].Host, hash.String(), 0)
+	switch {
+	case hash.IsEqual(fakeFeeErroredTicket):
+		status.FeeStatus = VSPFeeErrored
+	case info.Status != "UNMINED":
+		status.FeeStatus = VSPFeeConfirmed
+	}
+	return status
+}
+
+// newFakeTransaction converts a scripted transaction of a wallet.
+func newFakeTransaction(walletID int, hash, prevHash *chainhash.Hash, tx fakeTx) dcrlibwallet.Transaction {
+	height := fakeBestBlockHeight - tx.blocksAgo
+	txn := dcrlibwallet.Transaction{
+		WalletID:    walletID,
+		Hash:        hash.String(),
+		Type:        tx.txType,
+		Timestamp:   fakeBlockTime(height).Unix(),
+		BlockHeight: height,
+		Version:     1,
+		Fee:         tx.fee,
+		FeeRate:     tx.fee * 1000 / int64(tx.size),
+		Size:        tx.size,
+		Direction:   tx.direction,
+		Amount:      tx.amount,
+		Inputs: []*dcrlibwallet.TxInput{{
+			PreviousTransactionHash: prevHash.String(),
+			Amount:                  tx.amount + tx.fee,
+			AccountNumber:           0,
+		}},
+		Outputs: []*dcrlibwallet.TxOutput{{
+			Amount:     tx.amount,
+			Version:    0,
+			ScriptType: "pubkeyhash",
+		}},
+	}
+	switch tx.direction {
+	case dcrlibwallet.TxDirectionReceived:
+		txn.Inputs[0].AccountNumber = -1
+	case dcrlibwallet.TxDirectionSent:
+		txn.Outputs[0].AccountNumber = -1
+	}
+	if tx.txType == dcrlibwallet.TxTypeMixed {
+		txn.MixDenomination = tx.amount / 4
+		txn.MixCount = 4
+	}
+	if tx.txType == dcrlibwallet.TxTypeVote {
+		txn.VoteReward = tx.amount - fakeTicketPrice
+	}
+	return txn
+}
+
+// fakeHash returns a stable hash for the nth scripted item of a kind.
+func fakeHash(walletName, kind string, n int) *chainhash.Hash {
+	hash := chainhash.HashH([]byte(walletName + "/" + kind + "/" + strconv.Itoa(n)))
+	return &hash
+}
+
+// fakeBlockTime returns the timestamp of the block at height.
+func fakeBlockTime(height int32) time.Time {
+	return fakeBestBlockTime.Add(-time.Duration(fakeBestBlockHeight-height) * fakeBlockInterval)
+}
+
+// fakeBlocksAgo returns how many blocks before the best block a timestamp was
+// mined.
+func fakeBlocksAgo(timestamp int64) int32 {
+	return int32(fakeBestBlockTime.Sub(time.Unix(timestamp, 0)) / fakeBlockInterval)
+}
+
+func (fw *FakeWallet) SetupListeners() {
+	l := &listener{Send: fw.Sync}
+	err := fw.AddSyncProgressListener(l, syncID)
+	if err != nil {
+		fw.Send <- ResponseError(err)
+		return
+	}
+	fw.AddTxAndBlockNotificationListener(l, syncID)
+	fw.AddAccountMixerNotificationListener(l, syncID)
+	fw.AddProposalNotificationListener(l, syncID)
+
+	fw.Send <- Response{
+		Resp: LoadedWallets{
+			Count:              fw.LoadedWalletsCount(),
+			StartUpSecuritySet: fw.IsStartupSecuritySet(),
+		},
+	}
+}
+
+func (fw *FakeWallet) Shutdown() {
+	fw.CancelSync()
+}
+
+func (fw *FakeWallet) AddSyncProgressListener(listener dcrlibwallet.SyncProgressListener, uniqueIdentifier string) error {
+	fw.mu.Lock()
+	defer fw.mu.Unlock()
+	if _, ok := fw.syncListeners[uniqueIdentifier]; ok {
+		return errors.New(dcrlibwallet.ErrListenerAlreadyExist)
+	}
+	fw.syncListeners[uniqueIdentifier] = listener
+	return nil
+}
+
+func (fw *FakeWallet) RemoveSyncProgressListener(uniqueIdentifier string) {
+	fw.mu.Lock()
+	defer fw.mu.Unlock()
+	delete(fw.syncListeners, uniqueIdentifier)
+}
+
+// notify calls f with every sync listener outside the lock.
+func (fw *FakeWallet) notify(f func(dcrlibwallet.SyncProgressListener)) {
+	fw.mu.Lock()
+	listeners := make([]dcrlibwallet.SyncProgressListener, 0, len(fw.syncListeners))
+	for _, l := range fw.syncListeners {
+		listeners = append(listeners, l)
+	}
+	fw.mu.Unlock()
+
+	for _, l := range listeners {
+		f(l)
+	}
+}
+
+// StartSync plays the scripted sync: peers connect, then headers are
+// fetched, addresses discovered and blocks rescanned before the sync
+// completes.
+func (fw *FakeWallet) StartSync() error {
+	fw.mu.Lock()
+	defer fw.mu.Unlock()
+	if fw.syncing {
+		return errors.New(dcrlibwallet.ErrSyncAlreadyInProgress)
+	}
+	fw.syncing, fw.synced = true, false
+	fw.cancelSync = make(chan struct{})
+	go fw.playSync(fw.cancelSync)
+	return nil
+}
+
+// CancelSync stops the scripted sync.
+func (fw *FakeWallet) CancelSync() {
+	fw.mu.Lock()
+	defer fw.mu.Unlock()
+	if fw.cancelSync != nil {
+		close(fw.cancelSync)
+		fw.cancelSync = nil
+	}
+}
+
+func (fw *FakeWallet) playSync(cancel chan struct{}) {
+	defer crash.Recover("wallet playSync")
+
+	wait := func() bool {
+		select {
+		case <-cancel:
+			fw.mu.Lock()
+			fw.syncing, fw.peers = false, 0
+			fw.mu.Unlock()
+			fw.notify(func(l dcrlibwallet.SyncProgressListener) { l.OnSyncCanceled(false) })
+			return false
+		case <-time.After(fakeSyncStepInterval):
+			return true
+		}
+	}
+
+	fw.notify(func(l dcrlibwallet.SyncProgressListener) { l.OnSyncStarted(false) })
+	if !wait() {
+		return
+	}
+
+	fw.mu.Lock()
+	fw.peers = fakeConnectedPeers
+	fw.mu.Unlock()
+	fw.notify(func(l dcrlibwallet.SyncProgressListener) { l.OnPeerConnectedOrDisconnected(fakeConnectedPeers) })
+
+	// each stage is a third of the overall progress
+	totalSteps := int32(3 * fakeSyncSteps)
+	general := func(step int32) *dcrlibwallet.GeneralSyncProgress {
+		return &dcrlibwallet.GeneralSyncProgress{
+			TotalSyncProgress:         step * 100 / totalSteps,
+			TotalTimeRemainingSeconds: int64(totalSteps-step) * int64(fakeSyncStepInterval/time.Second+1),
+		}
+	}
+
+	const headersToFetch int32 = 2880
+	for i := int32(1); i <= fakeSyncSteps; i++ {
+		if !wait() {
+			return
+		}
+		height := fakeBestBlockHeight - headersToFetch + headersToFetch*i/fakeSyncSteps
+		report := &dcrlibwallet.HeadersFetchProgressReport{
+			GeneralSyncProgress:    general(i),
+			TotalHeadersToFetch:    headersToFetch,
+			CurrentHeaderHeight:    height,
+			CurrentHeaderTimestamp: fakeBlockTime(height).Unix(),
+			HeadersFetchProgress:   i * 100 / fakeSyncSteps,
+		}
+		fw.notify(func(l dcrlibwallet.SyncProgressListener) { l.OnHeadersFetchProgress(report) })
+	}
+
+	var ids []int
+	for _, wall := range fw.openedWallets() {
+		ids = append(ids, wall.ID)
+	}
+	for i := int32(1); i <= fakeSyncSteps; i++ {
+		if !wait() {
+			return
+		}
+		report := &dcrlibwallet.AddressDiscoveryProgressReport{
+			GeneralSyncProgress:      general(fakeSyncSteps + i),
+			AddressDiscoveryProgress: i * 100 / fakeSyncSteps,
+		}
+		if len(ids) > 0 {
+			report.WalletID = ids[0]
+		}
+		fw.notify(func(l dcrlibwallet.SyncProgressListener) { l.OnAddressDiscoveryProgress(report) })
+	}
+
+	for i := int32(1); i <= fakeSyncSteps; i++ {
+		if !wait() {
+			return
+		}
+		report := &dcrlibwallet.HeadersRescanProgressReport{
+			GeneralSyncProgress: general(2*fakeSyncSteps + i),
+			TotalHeadersToScan:  fakeBestBlockHeight,
+			CurrentRescanHeight: fakeBestBlockHeight * i / fakeSyncSteps,
+			RescanProgress:      i * 100 / fakeSyncSteps,
+		}
+		if len(ids) > 0 {
+			report.WalletID = ids[len(ids)-1]
+		}
+		fw.notify(func(l dcrlibwallet.SyncProgressListener) { l.OnHeadersRescanProgress(report) })
+	}
+
+	fw.mu.Lock()
+	if fw.cancelSync == cancel {
+		fw.cancelSync = nil
+	}
+	fw.syncing, fw.synced = false, true
+	fw.mu.Unlock()
+	fw.discoverAccounts()
+	fw.notify(func(l dcrlibwallet.SyncProgressListener) { l.OnSyncCompleted() })
+}
+
+func (fw *FakeWallet) IsSynced() bool {
+	fw.mu.Lock()
+	defer fw.mu.Unlock()
+	return fw.synced
+}
+
+func (fw *FakeWallet) IsSyncing() bool {
+	fw.mu.Lock()
+	defer fw.mu.Unlock()
+	return fw.syncing
+}
+
+func (fw *FakeWallet) IsConnectedToDecredNetwork() bool {
+	fw.mu.Lock()
+	defer fw.mu.Unlock()
+	return fw.syncing || fw.synced
+}
+
+func (fw *FakeWallet) ConnectedPeers() int32 {
+	fw.mu.Lock()
+	defer fw.mu.Unlock()
+	return fw.peers
+}
+
+// PeerInfo returns a scripted peer for each connected peer.
+func (fw *FakeWallet) PeerInfo() ([]dcrlibwallet.PeerInfo, error) {
+	port := 19108
+	if fw.Net == "mainnet" {
+		port = 9108
+	}
+
+	peers := make([]dcrlibwallet.PeerInfo, fw.ConnectedPeers())
+	for i := range peers {
+		peers[i] = dcrlibwallet.PeerInfo{
+			ID:             int32(i + 1),
+			Addr:           fmt.Sprintf("192.0.2.%d:%d", i+1, port),
+			Version:        6,
+			SubVer:         "/dcrwire:0.4.0/dcrd:1.6.0/",
+			StartingHeight: int64(fakeBestBlockHeight),
+		}
+	}
+	return peers, nil
+}
+
+func (fw *FakeWallet) GetBestBlock() *dcrlibwallet.BlockInfo {
+	return &dcrlibwallet.BlockInfo{
+		Height:    fakeBestBlockHeight,
+		Timestamp: fakeBestBlockTime.Unix(),
+	}
+}
+
+// GetMultiWalletInfo reports the scripted balances of the opened wallets.
+// It is non-blocking and sends its result or any error to fw.Send.
+func (fw *FakeWallet) GetMultiWalletInfo() {
+	go func() {
+		defer crash.Recover("wallet GetMultiWalletInfo")
+		var resp Response
+		wallets := fw.openedWallets()
+		best := fw.GetBestBlock()
+		var completeTotal int64
+		infos := make([]InfoShort, len(wallets))
+		for i, wall := range wallets {
+			accounts, err := fw.GetAccountsRaw(wall.ID)
+			if err != nil {
+				resp.Err = err
+				fw.Send <- resp
+				return
+			}
+
+			balance := fw.balance(wall.ID)
+			accts := make([]Account, 0)
+			for _, acct := range accounts.Acc {
+				account := Account{
+					Number:           acct.Number,
+					Name:             acct.Name,
+					TotalBalance:     FormatAmount(acct.TotalBalance),
+					SpendableBalance: acct.Balance.Spendable,
+					Balance:          Balance(*acct.Balance),
+					HDPath:           hdPrefix(fw.Net) + strconv.Itoa(int(acct.Number)) + "'",
+				}
+				if acct.Number != math.MaxInt32 {
+					account.CurrentAddress, _ = fw.CurrentAddress(wall.ID, acct.Number)
+				}
+				accts = append(accts, account)
+			}
+			completeTotal += balance.Total
+
+			infos[i] = InfoShort{
+				ID:               wall.ID,
+				Name:             wall.Name,
+				Balance:          FormatAmount(balance.Total),
+				SpendableBalance: balance.Spendable,
+				Accounts:         accts,
+				BestBlockHeight:  best.Height,
+				BlockTimestamp:   best.Timestamp,
+				DaysBehind:       fmt.Sprintf("%s behind", calculateDaysBehind(best.Timestamp)),
+				Status:           walletSyncStatus(false, best.Height, fw.OverallBlockHeight),
+				Seed:             wall.EncryptedSeed,
+				IsWatchingOnly:   wall.IsWatchingOnly,
+			}
+		}
+
+		resp.Resp = MultiWalletInfo{
+			LoadedWallets:   len(wallets),
+			TotalBalance:    FormatAmount(completeTotal),
+			TotalBalanceRaw: GetRawBalance(completeTotal, 0),
+			BestBlockHeight: best.Height,
+			BestBlockTime:   best.Timestamp,
+			LastSyncTime:    SecondsToDays(int64(time.Since(fakeBestBlockTime).Seconds())),
+			Wallets:         infos,
+			Synced:          fw.IsSynced(),
+			Syncing:         fw.IsSyncing(),
+		}
+		fw.Send <- resp
+	}()
+}
+
+// filterTransactions returns the transactions matching txFilter, newest or
+// oldest first, starting at offset. A limit of 0 returns every transaction.
+func filterTransactions(txs []dcrlibwallet.Transaction, offset, limit, txFilter int32, newestFirst bool) []dcrlibwallet.Transaction {
+	matches := func(tx dcrlibwallet.Transaction) bool {
+		switch txFilter {
+		case dcrlibwallet.TxFilterSent:
+			return tx.Type == dcrlibwallet.TxTypeRegular && tx.Direction == dcrlibwallet.TxDirectionSent
+		case dcrlibwallet.TxFilterReceived:
+			return tx.Type == dcrlibwallet.TxTypeRegular && tx.Direction == dcrlibwallet.TxDirectionReceived
+		case dcrlibwallet.TxFilterTransferred:
+			return tx.Type == dcrlibwallet.TxTypeRegular && tx.Direction == dcrlibwallet.TxDirectionTransferred
+		case dcrlibwallet.TxFilterStaking:
+			return tx.Type == dcrlibwallet.TxTypeTicketPurchase || tx.Type == dcrlibwallet.TxTypeVote ||
+				tx.Type == dcrlibwallet.TxTypeRevocation
+		case dcrlibwallet.TxFilterCoinBase:
+			return tx.Type == dcrlibwallet.TxTypeCoinBase
+		case dcrlibwallet.TxFilterRegular:
+			return tx.Type == dcrlibwallet.TxTypeRegular
+		case dcrlibwallet.TxFilterMixed:
+			return tx.Type == dcrlibwallet.TxTypeMixed
+		default:
+			return true
+		}
+	}
+
+	var filtered []dcrlibwallet.Transaction
+	for _, tx := range txs {
+		if matches(tx) {
+			filtered = append(filtered, tx)
+		}
+	}
+
+	sort.SliceStable(filtered, func(i, j int) bool {
+		if newestFirst {
+			return filtered[i].Timestamp > filtered[j].Timestamp
+		}
+		return filtered[i].Timestamp < filtered[j].Timestamp
+	})
+
+	if int(offset) >= len(filtered) {
+		return nil
+	}
+	filtered = filtered[offset:]
+	if limit > 0 && int(limit) < len(filtered) {
+		filtered = filtered[:limit]
+	}
+	return filtered
+}
+
+// walletTxs returns a copy of the transactions of a wallet.
+func (fw *FakeWallet) walletTxs(walletID int) []dcrlibwallet.Transaction {
+	fw.mu.Lock()
+	defer fw.mu.Unlock()
+	return append([]dcrlibwallet.Transaction(nil), fw.txs[walletID]...)
+}
+
+func (fw *FakeWallet) GetTransactionsRaw(offset, limit, txFilter int32, newestFirst bool) ([]dcrlibwallet.Transaction, error) {
+	var all []dcrlibwallet.Transaction
+	for _, wall := range fw.openedWallets() {
+		all = append(all, fw.walletTxs(wall.ID)...)
+	}
+	return filterTransactions(all, offset, limit, txFilter, newestFirst), nil
+}
+
+func (fw *FakeWallet) GetWalletTransactionsRaw(walletID int, offset, limit, txFilter int32, newestFirst bool) ([]dcrlibwallet.Transaction, error) {
+	if fw.WalletWithID(walletID) == nil {
+		return nil, ErrIDNotExist
+	}
+	return filterTransactions(fw.walletTxs(walletID), offset, limit, txFilter, newestFirst), nil
+}
+
+// GetAllTransactions collects the scripted transactions of every wallet.
+// It is non-blocking and sends its result or any error to fw.Send.
+func (fw *FakeWallet) GetAllTransactions(offset, limit, txfilter int32) {
+	go func() {
+		defer crash.Recover("wallet GetAllTransactions")
+		var resp Response
+		wallets := fw.openedWallets()
+		var recentTxs []Transaction
+		transactions := make(map[int][]Transaction)
+		ticketTxs := make(map[int][]Transaction)
+		totalTxn := 0
+
+		for _, wall := range wallets {
+			for _, txnRaw := range filterTransactions(fw.walletTxs(wall.ID), offset, limit, txfilter, true) {
+				totalTxn++
+				status, confirmations := transactionStatus(fakeBestBlockHeight, txnRaw.BlockHeight)
+				txn := Transaction{
+					Txn:           txnRaw,
+					Status:        status,
+					Balance:       FormatAmount(txnRaw.Amount),
+					WalletName:    wall.Name,
+					Confirmations: confirmations,
+				}
+				recentTxs = append(recentTxs, txn)
+				if txn.Txn.Type == dcrlibwallet.TxTypeTicketPurchase {
+					ticketTxs[wall.ID] = append(ticketTxs[wall.ID], txn)
+				}
+				transactions[wall.ID] = append(transactions[wall.ID], txn)
+			}
+		}
+
+		sort.SliceStable(recentTxs, func(i, j int) bool {
+			return recentTxs[i].Txn.Timestamp > recentTxs[j].Txn.Timestamp
+		})
+
+		recentTxsLimit := 5
+		if len(recentTxs) > recentTxsLimit {
+			recentTxs = recentTxs[:recentTxsLimit]
+		}
+
+		resp.Resp = &Transactions{
+			Total:   totalTxn,
+			Txs:     transactions,
+			Recent:  recentTxs,
+			Tickets: ticketTxs,
+		}
+		fw.Send <- resp
+	}()
+}
+
+// GetTransaction looks up a scripted transaction by wallet ID and hash.
+// It is non-blocking and sends its result or any error to fw.Send.
+func (fw *FakeWallet) GetTransaction(walletID int, txnHash string) {
+	go func() {
+		defer crash.Recover("wallet GetTransaction")
+		wall := fw.WalletWithID(walletID)
+		if wall == nil {
+			fw.Send <- ResponseError(ErrIDNotExist)
+			return
+		}
+
+		for _, txn := range fw.walletTxs(walletID) {
+			if txn.Hash != txnHash {
+				continue
+			}
+			status, confirmations := transactionStatus(fakeBestBlockHeight, txn.BlockHeight)
+			fw.Send <- ResponseResp(&Transaction{
+				Txn:           txn,
+				Status:        status,
+				Balance:       FormatAmount(txn.Amount),
+				WalletName:    wall.Name,
+				Confirmations: confirmations,
+				AccountName:   fw.accountName(walletID, txn.Inputs[0].AccountNumber),
+			})
+			return
+		}
+
+		fw.Send <- ResponseError(errors.New(dcrlibwallet.ErrNotExist))
+	}()
+}
+
+// BroadcastTransaction pretends to publish the transaction built with a
+// txAuthor of CreateTransaction once the passphrase of its wallet is given.
+// It is non-blocking and sends its result or any error to fw.Send.
+func (fw *FakeWallet) BroadcastTransaction(txAuthor TxAuthor, passphrase []byte, errChan chan error) {
+	go func() {
+		defer crash.Recover("wallet BroadcastTransaction")
+		author, ok := txAuthor.(*fakeTxAuthor)
+		if !ok {
+			errChan <- fmt.Errorf("error broadcasting transaction: %T was not created by the wallet", txAuthor)
+			return
+		}
+		if _, err := author.EstimateFeeAndSize(); err != nil {
+			errChan <- fmt.Errorf("error broadcasting transaction: %s", err.Error())
+			return
+		}
+		if err := fw.checkPassphrase(author.walletID, passphrase); err != nil {
+			errChan <- fmt.Errorf("error broadcasting transaction: %s", err.Error())
+			return
+		}
+
+		fw.mu.Lock()
+		fw.broadcasts++
+		hash := fakeHash("broadcast", "tx", fw.broadcasts)
+		fw.mu.Unlock()
+
+		fw.Send <- ResponseResp(&Broadcast{
+			TxHash: hash.String(),
+		})
+	}()
+}
+
+// GetAllTickets collects the scripted tickets of every wallet.
+// It is non-blocking and sends its result or any error to fw.Send.
+func (fw *FakeWallet) GetAllTickets() {
+	go func() {
+		defer crash.Recover("wallet GetAllTickets")
+		wallets := fw.openedWallets()
+		fw.mu.Lock()
+		walletIDs := make([]int, 0, len(wallets))
+		tickets := make(map[int][]Ticket)
+		unconfirmed := make(map[int][]UnconfirmedPurchase)
+		for _, wall := range wallets {
+			walletIDs = append(walletIDs, wall.ID)
+			for _, tinfo := range fw.tickets[wall.ID] {
+				var amount dcrutil.Amount
+				for _, output := range tinfo.Ticket.MyOutputs {
+					amount += output.Amount
+				}
+				feeTx, _ := fw.feeTx(wall.ID, tinfo)
+				tickets[wall.ID] = append(tickets[wall.ID], Ticket{
+					Info:       tinfo,
+					DaysBehind: calculateDaysBehind(tinfo.Ticket.Timestamp),
+					Amount:     FormatAmount(int64(amount)),
+					Fee:        FormatAmount(int64(tinfo.Ticket.Fee)),
+					WalletName: wall.Name,
+					VSP:        fw.vspStatuses[tinfo.Ticket.Hash.String()],
+					FeeTx:      feeTx,
+				})
+			}
+
+			sort.SliceStable(tickets[wall.ID], func(i, j int) bool {
+				return tickets[wall.ID][i].Info.Ticket.Timestamp > tickets[wall.ID][j].Info.Ticket.Timestamp
+			})
+		}
+		fw.mu.Unlock()
+
+		fw.Send <- ResponseResp(summarizeTickets(walletIDs, tickets, unconfirmed))
+	}()
+}
+
+func (fw *FakeWallet) TicketPrice() (int64, string) {
+	return fakeTicketPrice, FormatAmount(fakeTicketPrice)
+}
+
+// TicketPriceForecast returns the scripted ticket price with a scripted
+// history.
+func (fw *FakeWallet) TicketPriceForecast() (*TicketPriceForecast, error) {
+	forecast, err := newTicketPriceForecast(fw.Net, fakeTicketPrice, fakeBestBlockHeight, fakeBestBlockTime)
+	if err != nil {
+		return nil, err
+	}
+	forecast.History = fakeTicketPriceHistory(forecast.WindowSize)
+	return forecast, nil
+}
+
+func (fw *FakeWallet) GetTicketPriceForecast() {
+	go func() {
+		defer crash.Recover("wallet GetTicketPriceForecast")
+		forecast, err := fw.TicketPriceForecast()
+		if err != nil {
+			fw.Send <- ResponseError(err)
+			return
+		}
+		fw.Send <- ResponseResp(forecast)
+	}()
+}
+
+// fakeTicketPriceHistory returns a ticket price for each of the last
+// fakeTicketPriceWindows windows that rises over time in waves, ending at
+// fakeTicketPrice.
+func fakeTicketPriceHistory(windowSize int32) []TicketPricePoint {
+	current := (fakeBestBlockHeight + 1) / windowSize
+	history := make([]TicketPricePoint, fakeTicketPriceWindows)
+	for i := range history {
+		ago := float64(fakeTicketPriceWindows - 1 - i)
+		change := 0.15*math.Sin(ago/40) + 0.05*math.Sin(ago/7) - 0.4*ago/fakeTicketPriceWindows
+		history[i] = TicketPricePoint{
+			Time:  fakeBlockTime((current - int32(ago)) * windowSize),
+			Price: fakeTicketPrice + int64(float64(fakeTicketPrice)*change),
+		}
+	}
+	return history
+}
+
+// NewVSPD returns an empty VSP client; FakeWallet never contacts the VSP.
+// Like Wallet, it refuses a VSP whose public key changed.
+func (fw *FakeWallet) NewVSPD(host string, walletID int, accountID int32) (*dcrlibwallet.VSP, error) {
+	if host == "" {
+		return nil, fmt.Errorf("Host is required")
+	}
+	if fw.vspPubKeyChanged(host) {
+		return nil, ErrVSPPubKeyChanged
+	}
+	if fw.WalletWithID(walletID) == nil {
+		return nil, ErrIDNotExist
+	}
+	return new(dcrlibwallet.VSP), nil
+}
+
+// PurchaseTicket adds unmined tickets to the wallet once the scripted
+// passphrase is given.
+func (fw *FakeWallet) PurchaseTicket(walletID int, accountID int32, tickets uint32, passphrase []byte, vspd *dcrlibwallet.VSP, errChan chan error) {
+	go func() {
+		defer crash.Recover("wallet PurchaseTicket")
+		if err := fw.checkPassphrase(walletID, passphrase); err != nil {
+			errChan <- err
+			return
+		}
+
+		fw.mu.Lock()
+		for i := uint32(0); i < tickets; i++ {
+			n := len(fw.tickets[walletID])
+			fw.tickets[walletID] = append(fw.tickets[walletID], dcrlibwallet.TicketInfo{
+				Status: "UNMINED",
+				Ticket: &wallet.TransactionSummary{
+					Hash:      fakeHash(strconv.Itoa(walletID), "purchase", n),
+					Fee:       dcrutil.Amount(2980),
+					Timestamp: fakeBestBlockTime.Unix(),
+					Type:      wallet.TransactionTypeTicketPurchase,
+					MyOutputs: []wallet.TransactionSummaryOutput{{
+						Amount: dcrutil.Amount(fakeTicketPrice),
+					}},
+				},
+			})
+		}
+		fw.mu.Unlock()
+
+		errChan <- nil
+		fw.Send <- ResponseResp(&TicketPurchase{})
+	}()
+}
+
+// TicketDetails builds the transactions of a scripted ticket, and the fee
+// transaction of the tickets a VSP has a fee for.
+func (fw *FakeWallet) TicketDetails(walletID int, ticket Ticket) (*TicketDetails, error) {
+	if fw.WalletWithID(walletID) == nil {
+		return nil, ErrIDNotExist
+	}
+
+	info := ticket.Info
+	hash := info.Ticket.Hash
+	var blocksAgo int32
+	if info.BlockHeight > 0 {
+		blocksAgo = fakeBestBlockHeight - info.BlockHeight
+	}
+	unmined := func(txn *dcrlibwallet.Transaction) *dcrlibwallet.Transaction {
+		if info.BlockHeight == 0 {
+			txn.BlockHeight = -1
+		}
+		return txn
+	}
+
+	var price int64
+	for _, output := range info.Ticket.MyOutputs {
+		price += int64(output.Amount)
+	}
+	purchase := newFakeTransaction(walletID, hash, fakeHash(hash.String(), "prev", 0), fakeTx{
+		blocksAgo: blocksAgo,
+		txType:    dcrlibwallet.TxTypeTicketPurchase,
+		direction: dcrlibwallet.TxDirectionSent,
+		amount:    price,
+		fee:       int64(info.Ticket.Fee),
+		size:      298,
+	})
+	details := &TicketDetails{
+		Ticket:   ticket,
+		Purchase: unmined(&purchase),
+	}
+
+	if info.Spender != nil {
+		spender := fakeTx{
+			blocksAgo: fakeBlocksAgo(info.Spender.Timestamp),
+			txType:    dcrlibwallet.TxTypeVote,
+			direction: dcrlibwallet.TxDirectionReceived,
+			fee:       int64(info.Spender.Fee),
+			size:      344,
+		}
+		for _, output := range info.Spender.MyOutputs {
+			spender.amount += int64(output.Amount)
+		}
+		if info.Spender.Type == wallet.TransactionTypeRevocation {
+			spender.txType, spender.size = dcrlibwallet.TxTypeRevocation, 297
+		}
+		txn := newFakeTransaction(walletID, info.Spender.Hash, hash, spender)
+		details.Spender = &txn
+	}
+
+	if info.BlockHeight > 0 {
+		var err error
+		details.MaturityHeight, details.ExpiryHeight, err = ticketHeights(fw.Net, info.BlockHeight)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	fw.mu.Lock()
+	defer fw.mu.Unlock()
+	status := fw.vspStatuses[hash.String()]
+	if status == nil {
+		return details, nil
+	}
+	details.setVSPStatus(status)
+	var err error
+	details.FeeTx, err = fw.feeTx(walletID, info)
+	if err != nil {
+		return nil, err
+	}
+	return details, nil
+}
+
+// feeTx returns the scripted transaction paying the VSP fee of the ticket
+// with info, or nil if no fee was paid. The caller must hold mu.
+func (fw *FakeWallet) feeTx(walletID int, info dcrlibwallet.TicketInfo) (*dcrlibwallet.Transaction, error) {
+	hash := info.Ticket.Hash
+	status := fw.vspStatuses[hash.String()]
+	if status == nil || status.FeeTxHash == "" {
+		return nil, nil
+	}
+	feeHash, err := chainhash.NewHashFromStr(status.FeeTxHash)
+	if err != nil {
+		return nil, err
+	}
+
+	var blocksAgo int32
+	if info.BlockHeight > 0 {
+		blocksAgo = fakeBestBlockHeight - info.BlockHeight
+	}
+	var price int64
+	for _, output := range info.Ticket.MyOutputs {
+		price += int64(output.Amount)
+	}
+	var feePercentage float64
+	for _, vsp := range fw.vsps {
+		if vsp.Host == status.Host && vsp.Info != nil {
+			feePercentage = vsp.Info.FeePercentage
+		}
+	}
+	feeTx := newFakeTransaction(walletID, feeHash, fakeHash(hash.String(), "prev", 1), fakeTx{
+		blocksAgo: blocksAgo,
+		txType:    dcrlibwallet.TxTypeRegular,
+		direction: dcrlibwallet.TxDirectionSent,
+		amount:    int64(float64(price) * feePercentage / 100),
+		fee:       2530,
+		size:      217,
+	})
+	if status.FeeStatus != VSPFeeConfirmed {
+		feeTx.BlockHeight = -1
+	}
+	return &feeTx, nil
+}
+
+// CheckVSPTickets finds the tickets bought since the VSPs were last asked
+// about to be paid to the remembered VSP, or the first scripted VSP, and the
+// fees paid for mined tickets to be confirmed.
+func (fw *FakeWallet) CheckVSPTickets(walletID int, passphrase []byte) (int, error) {
+	if err := fw.checkPassphrase(walletID, passphrase); err != nil {
+		return 0, err
+	}
+	host := fw.GetRememberVSP()
+	if host == "" {
+		host = fakeVSPs[0].Host
+	}
+
+	fw.mu.Lock()
+	defer fw.mu.Unlock()
+	var checked int
+	for _, info := range fw.tickets[walletID] {
+		if !canVote(info.Status) {
+			continue
+		}
+		hash := info.Ticket.Hash.String()
+		status := fw.vspStatuses[hash]
+		switch {
+		case status == nil:
+			fw.vspStatuses[hash] = fakePaidStatus(host, hash, 0)
+		case status.FeeStatus == VSPFeePaid && info.Status != "UNMINED":
+			confirmed := *status
+			confirmed.FeeStatus = VSPFeeConfirmed
+			fw.vspStatuses[hash] = &confirmed
+		}
+		checked++
+	}
+	return checked, nil
+}
+
+// RetryVSPFee finds the fee of a ticket to be paid to host once the scripted
+// passphrase is given.
+func (fw *FakeWallet) RetryVSPFee(walletID int, ticket Ticket, host string, passphrase []byte) error {
+	if host == "" {
+		return fmt.Errorf("Host is required")
+	}
+	if fw.vspPubKeyChanged(host) {
+		return ErrVSPPubKeyChanged
+	}
+	if err := fw.checkPassphrase(walletID, passphrase); err != nil {
+		return err
+	}
+
+	fw.mu.Lock()
+	defer fw.mu.Unlock()
+	hash := ticket.Info.Ticket.Hash.String()
+	for _, info := range fw.tickets[walletID] {
+		if info.Ticket.Hash.String() == hash {
+			fw.vspStatuses[hash] = fakePaidStatus(host, hash, 1)
+			return nil
+		}
+	}
+	return ErrIDNotExist
+}
+
+// fakePaidStatus returns the status of a ticket whose nth fee was paid to
+// host.
+func fakePaidStatus(host, hash string, n int) *VSPTicketStatus {
+	status := &VSPTicketStatus{
+		Host:        host,
+		FeeStatus:   VSPFeePaid,
+		FeeTxHash:   fakeHash(hash, "fee", n).String(),
+		VoteChoices: make(map[string]string, len(fakeVoteChoices)),
+		CheckedAt:   fakeBestBlockTime.Unix(),
+	}
+	for agenda, choice := range fakeVoteChoices {
+		status.VoteChoices[agenda] = choice
+	}
+	return status
+}
+
+// Agendas returns the scripted consensus agendas followed by the treasury keys
+// of the network.
+func (fw *FakeWallet) Agendas() ([]Agenda, error) {
+	params, err := utils.ChainParams(fw.Net)
+	if err != nil {
+		return nil, err
+	}
+	agendas := append([]Agenda(nil), fakeAgendas...)
+	for _, key := range params.PiKeys {
+		agendas = append(agendas, treasuryKeyAgenda(hex.EncodeToString(key)))
+	}
+	return agendas, nil
+}
+
+// PushVoteChoices records the vote choices of a wallet for each of its
+// tickets at a VSP that can still vote.
+func (fw *FakeWallet) PushVoteChoices(walletID int, passphrase []byte) (int, error) {
+	if err := fw.checkPassphrase(walletID, passphrase); err != nil {
+		return 0, err
+	}
+	choices, err := fw.VoteChoices(walletID)
+	if err != nil {
+		return 0, err
+	}
+
+	fw.mu.Lock()
+	defer fw.mu.Unlock()
+	var pushed int
+	for _, info := range fw.tickets[walletID] {
+		hash := info.Ticket.Hash.String()
+		status := fw.vspStatuses[hash]
+		if !canVote(info.Status) || status == nil || status.Host == "" {
+			continue
+		}
+		recorded := *status
+		recorded.VoteChoices = choices
+		fw.vspStatuses[hash] = &recorded
+		pushed++
+	}
+	return pushed, nil
+}
+
+// AddVSP adds a VSP with scripted info to the list.
+// It is non-blocking and sends its result or any error to fw.Send.
+func (fw *FakeWallet) AddVSP(host string, errChan chan error) {
+	go func() {
+		defer crash.Recover("wallet AddVSP")
+		fw.mu.Lock()
+		for _, v := range fw.vsps {
+			if v.Host == host {
+				fw.mu.Unlock()
+				errChan <- fmt.Errorf("Existing host %s", host)
+				return
+			}
+		}
+		info := VSPInfo{
+			Host: host,
+			Info: &dcrlibwallet.VspInfoResponse{
+				APIVersions:   []int64{3},
+				FeePercentage: 1,
+				Network:       fw.Net,
+				VspdVersion:   "1.0.0",
+				PubKey:        fakeHash(host, "pubkey", 0)[:],
+			},
+			Added:     true,
+			Latency:   120 * time.Millisecond,
+			CheckedAt: fakeBestBlockTime,
+		}
+		fw.vsps = append(fw.vsps, info)
+		fw.mu.Unlock()
+
+		fw.Send <- ResponseResp(&info)
+	}()
+}
+
+// GetAllVSP lists the scripted VSPs and any added with AddVSP.
+// It is non-blocking and sends its result to fw.Send.
+func (fw *FakeWallet) GetAllVSP() {
+	go func() {
+		defer crash.Recover("wallet GetAllVSP")
+		fw.Send <- ResponseResp(&VSP{List: fw.vspList()})
+	}()
+}
+
+// CheckVSPs lists the VSPs like GetAllVSP; the scripted VSPs answer the same
+// on every check.
+// It is non-blocking and sends its result to fw.Send.
+func (fw *FakeWallet) CheckVSPs() {
+	go func() {
+		defer crash.Recover("wallet CheckVSPs")
+		fw.Send <- ResponseResp(&VSP{List: fw.vspList()})
+	}()
+}
+
+// vspList returns copies of the VSPs, checked at the scripted time.
+func (fw *FakeWallet) vspList() []VSPInfo {
+	fw.mu.Lock()
+	defer fw.mu.Unlock()
+	list := make([]VSPInfo, len(fw.vsps))
+	for i, v := range fw.vsps {
+		list[i] = v
+		list[i].CheckedAt = fakeBestBlockTime
+		if v.Info != nil {
+			info := *v.Info
+			info.Network = fw.Net
+			list[i].Info = &info
+		}
+	}
+	return list
+}
+
+// RemoveVSP removes a VSP added by the user from the list.
+func (fw *FakeWallet) RemoveVSP(host string) error {
+	fw.mu.Lock()
+	defer fw.mu.Unlock()
+	for i, v := range fw.vsps {
+		if v.Host == host && v.Added {
+			fw.vsps = append(fw.vsps[:i], fw.vsps[i+1:]...)
+			if fw.GetRememberVSP() == host {
+				fw.RememberVSP("")
+			}
+			return nil
+		}
+	}
+	return fmt.Errorf("Unknown host %s", host)
+}
+
+// vspPubKeyChanged returns whether the scripted VSP at host signs with
+// another public key than the pinned one.
+func (fw *FakeWallet) vspPubKeyChanged(host string) bool {
+	fw.mu.Lock()
+	defer fw.mu.Unlock()
+	for _, v := range fw.vsps {
+		if v.Host == host {
+			return v.PubKeyChanged
+		}
+	}
+	return false
+}
+
+// TrustVSPPubKey accepts the changed public key of a scripted VSP.
+func (fw *FakeWallet) TrustVSPPubKey(host string) error {
+	fw.mu.Lock()
+	defer fw.mu.Unlock()
+	for i, v := range fw.vsps {
+		if v.Host == host && v.Info != nil {
+			fw.vsps[i].PubKeyChanged = false
+			return nil
+		}
+	}
+	return fmt.Errorf("Unknown host %s", host)
+}
+
+// GetAllProposals sends the scripted proposals, newest first.
+// It is non-blocking and sends its result to fw.Send.
+func (fw *FakeWallet) GetAllProposals() {
+	go func() {
+		defer crash.Recover("wallet GetAllProposals")
+		proposals := append([]dcrlibwallet.Proposal(nil), fw.proposals...)
+		sort.SliceStable(proposals, func(i, j int) bool {
+			return proposals[i].Timestamp > proposals[j].Timestamp
+		})
+		fw.Send <- ResponseResp(&Proposals{Proposals: proposals})
+	}()
+}
+
+func (fw *FakeWallet) SyncProposals() {}
+
+func (fw *FakeWallet) IsSyncingProposals() bool {
+	return false
+}
+
+// FetchProposalContent returns the scripted description and attachments of a
+// proposal, at the time of the proposal.
+func (fw *FakeWallet) FetchProposalContent(token string) (*ProposalContent, error) {
+	for _, p := range fakeProposals {
+		if p.Token == token {
+			return &ProposalContent{
+				Token:       token,
+				Version:     len(p.earlier) + 1,
+				Timestamp:   fakeBestBlockTime.Add(-p.age),
+				Description: p.description,
+				Attachments: p.attachments,
+			}, nil
+		}
+	}
+	return nil, errors.New(dcrlibwallet.ErrNotExist)
+}
+
+// RefreshProposalCache does nothing as the fake proposals are never updated.
+func (fw *FakeWallet) RefreshProposalCache() {}
+
+// GetProposalComments sends the scripted comments on a proposal.
+// It is non-blocking and sends its result to fw.Send.
+func (fw *FakeWallet) GetProposalComments(token string) {
+	go func() {
+		defer crash.Recover("wallet GetProposalComments")
+		for _, p := range fakeProposals {
+			if p.Token != token {
+				continue
+			}
+			comments := make([]*ProposalComment, len(p.comments))
+			for i := range p.comments {
+				comment := p.comments[i]
+				comments[i] = &comment
+			}
+			fw.Send <- ResponseResp(&ProposalComments{
+				Token:    token,
+				Comments: commentTree(comments),
+				Count:    len(comments),
+			})
+			return
+		}
+		fw.Send <- ResponseResp(&ProposalComments{Token: token, Err: errors.New(dcrlibwallet.ErrNotExist)})
+	}()
+}
+
+// GetProposalVersions sends the scripted versions of a proposal, a week
+// apart, with the latest at the time of the proposal.
+// It is non-blocking and sends its result to fw.Send.
+func (fw *FakeWallet) GetProposalVersions(token string) {
+	go func() {
+		defer crash.Recover("wallet GetProposalVersions")
+		for _, p := range fakeProposals {
+			if p.Token != token {
+				continue
+			}
+			latest := fakeBestBlockTime.Add(-p.age)
+			descriptions := append(append([]string(nil), p.earlier...), p.description)
+			versions := make([]ProposalVersion, len(descriptions))
+			for i, description := range descriptions {
+				versions[i] = ProposalVersion{
+					Version:     i + 1,
+					Timestamp:   latest.Add(-time.Duration(len(descriptions)-1-i) * 7 * 24 * time.Hour),
+					Description: description,
+				}
+			}
+			fw.Send <- ResponseResp(&ProposalVersions{Token: token, Versions: versions})
+			return
+		}
+		fw.Send <- ResponseResp(&ProposalVersions{Token: token, Err: errors.New(dcrlibwallet.ErrNotExist)})
+	}()
+}
+
+// GetUSDExchangeValues decodes a scripted DCR-USDT ticker into target.
+func (fw *FakeWallet) GetUSDExchangeValues(target interface{}) error {
+	ticker, err := json.Marshal(struct {
+		LastTradeRate string `json:"lastTradeRate"`
+	}{fakeExchangeRate})
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(ticker, target)
+}
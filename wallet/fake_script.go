@@ -0,0 +1,397 @@
+package wallet
+
+import (
+	"bytes"
+	"errors"
+	"image"
+	"image/color"
+	"image/png"
+	"time"
+
+	"github.com/planetdecred/dcrlibwallet"
+)
+
+// The scripted chain state of FakeWallet. Every timestamp is derived from
+// fakeBestBlockTime so that the UI renders the same data on every run.
+const (
+	fakeBestBlockHeight int32 = 540000
+	fakeBlockInterval         = 5 * time.Minute
+	fakeConnectedPeers  int32 = 8
+	fakeTicketPrice     int64 = 14532000000
+
+	// fakeTicketPriceWindows is how many windows of ticket price history
+	// there are.
+	fakeTicketPriceWindows = 1460
+
+	// FakePassphrase is the spending passphrase of every scripted wallet.
+	FakePassphrase = "password"
+
+	// fakeExchangeRate is the DCR-USDT rate reported by the fake ticker.
+	fakeExchangeRate = "128.45"
+)
+
+var fakeBestBlockTime = time.Date(2021, time.April, 1, 12, 0, 0, 0, time.UTC)
+
+// fakeSyncSteps is the number of progress reports sent in each sync stage.
+const fakeSyncSteps = 5
+
+// fakeSyncStepInterval is the delay between scripted sync events.
+var fakeSyncStepInterval = 300 * time.Millisecond
+
+type fakeTx struct {
+	blocksAgo int32
+	txType    string
+	direction int32
+	amount    int64
+	fee       int64
+	size      int
+}
+
+type fakeTicket struct {
+	blocksAgo int32
+	status    string
+	fee       int64
+	// vspFee is paid to the stake pool in the ticket purchase.
+	vspFee int64
+	// spentBlocksAgo is the age of the vote or revocation of the ticket and
+	// returned what it paid to the wallet.
+	spentBlocksAgo int32
+	returned       int64
+}
+
+type fakeWalletScript struct {
+	name    string
+	seed    string
+	balance Balance
+	txs     []fakeTx
+	tickets []fakeTicket
+}
+
+var fakeWallets = []fakeWalletScript{
+	{
+		name: "Personal",
+		seed: "puppy yesteryear Vulcan almighty crackdown torpedo allow exodus befriend typewriter " +
+			"Neptune cannonball newborn monument stopwatch visitor minnow bodyguard fallout examine indulge " +
+			"megaton sterling paragraph pupil impetus ammo politeness befriend photograph merit paragon unearth",
+		balance: Balance{
+			Total:           95623411200,
+			Spendable:       51979411200,
+			LockedByTickets: 43596000000,
+			UnConfirmed:     48000000,
+		},
+		txs: []fakeTx{
+			{0, dcrlibwallet.TxTypeRegular, dcrlibwallet.TxDirectionReceived, 48000000, 2530, 217},
+			{3, dcrlibwallet.TxTypeRegular, dcrlibwallet.TxDirectionSent, 1250000000, 2980, 251},
+			{40, dcrlibwallet.TxTypeTicketPurchase, dcrlibwallet.TxDirectionSent, fakeTicketPrice, 2980, 298},
+			{320, dcrlibwallet.TxTypeRegular, dcrlibwallet.TxDirectionTransferred, 500000000, 2530, 217},
+			{910, dcrlibwallet.TxTypeVote, dcrlibwallet.TxDirectionReceived, 14621300000, 0, 344},
+			{2200, dcrlibwallet.TxTypeRegular, dcrlibwallet.TxDirectionReceived, 30000000000, 2530, 217},
+			{4100, dcrlibwallet.TxTypeTicketPurchase, dcrlibwallet.TxDirectionSent, 14320000000, 2980, 298},
+			{8640, dcrlibwallet.TxTypeRevocation, dcrlibwallet.TxDirectionReceived, 14318000000, 0, 297},
+			{12000, dcrlibwallet.TxTypeRegular, dcrlibwallet.TxDirectionReceived, 70000000000, 2530, 217},
+		},
+		tickets: []fakeTicket{
+			{0, "UNMINED", 2980, 0, 0, 0},
+			{40, "IMMATURE", 2980, 0, 0, 0},
+			{4100, "LIVE", 2980, 0, 0, 0},
+			{5300, "LIVE", 2980, 0, 0, 0},
+			{6900, "VOTED", 2980, 0, 910, 14621300000},
+			{9400, "REVOKED", 2980, 0, 8640, fakeTicketPrice - 2980},
+		},
+	},
+	{
+		name: "Savings",
+		seed: "snowcap guitarist flytrap caravan peachy photograph pheasant bookseller seabird cellulose " +
+			"enlist hydraulic eating equation locale indigo wayside narrative spindle revenue glitter informant " +
+			"waffle examine escape gadgetry Geiger surrender eating Wichita facial getaway Trojan",
+		balance: Balance{
+			Total:     250000000000,
+			Spendable: 250000000000,
+		},
+		txs: []fakeTx{
+			{150, dcrlibwallet.TxTypeMixed, dcrlibwallet.TxDirectionTransferred, 26843545600, 5730, 1846},
+			{1800, dcrlibwallet.TxTypeRegular, dcrlibwallet.TxDirectionReceived, 125000000000, 2530, 217},
+			{7200, dcrlibwallet.TxTypeRegular, dcrlibwallet.TxDirectionReceived, 125000000000, 2530, 217},
+		},
+		tickets: []fakeTicket{
+			{8100, "MISSED", 2980, 0, 0, 0},
+			{10500, "EXPIRED", 2980, 0, 0, 0},
+			{18000, "VOTED", 2980, 4470000, 10000, 14620100000},
+			{26000, "VOTED", 2980, 4470000, 20500, 14619400000},
+		},
+	},
+}
+
+var fakeVSPs = []VSPInfo{
+	{
+		Host: "https://teststakepool.decred.org",
+		Info: &dcrlibwallet.VspInfoResponse{
+			APIVersions:   []int64{3},
+			FeePercentage: 2,
+			VspdVersion:   "1.0.0",
+			Voting:        512,
+			Voted:         20434,
+			Revoked:       31,
+			PubKey:        fakeHash("vsp", "pubkey", 0)[:],
+		},
+		Latency: 180 * time.Millisecond,
+	},
+	{
+		Host: "https://testnet-vsp.jholdstock.uk",
+		Info: &dcrlibwallet.VspInfoResponse{
+			APIVersions:   []int64{3},
+			FeePercentage: 0.5,
+			VspdVersion:   "1.0.0",
+			Voting:        143,
+			Voted:         5230,
+			Revoked:       4,
+			PubKey:        fakeHash("vsp", "pubkey", 1)[:],
+		},
+		Latency: 95 * time.Millisecond,
+	},
+}
+
+// fakeVSPDirectory are the VSPs listed by FakeWallet: the scripted VSPs the
+// tickets were bought through, a listed VSP whose public key changed since it
+// was pinned and a VSP added by the user that does not answer.
+var fakeVSPDirectory = append(append([]VSPInfo(nil), fakeVSPs...),
+	VSPInfo{
+		Host: "https://vsp.stakey.example",
+		Info: &dcrlibwallet.VspInfoResponse{
+			APIVersions:   []int64{3},
+			FeePercentage: 1,
+			VspdVersion:   "1.0.0",
+			Voting:        37,
+			Voted:         912,
+			Revoked:       2,
+			PubKey:        fakeHash("vsp", "pubkey", 2)[:],
+		},
+		Latency:       240 * time.Millisecond,
+		PubKeyChanged: true,
+	},
+	VSPInfo{
+		Host:  "https://vsp.unreachable.example",
+		Added: true,
+		Err:   errors.New("Get \"https://vsp.unreachable.example/api/v3/vspinfo\": context deadline exceeded"),
+	},
+)
+
+// The fee of one live ticket could not be paid to its VSP and no VSP knows
+// another live ticket, so that the tickets not being voted are warned about.
+var (
+	fakeFeeErroredTicket = fakeHash("Personal", "ticket", 2)
+	fakeNoVSPTicket      = fakeHash("Personal", "ticket", 3)
+)
+
+// fakeVoteChoices are the agenda choices of the tickets bought through a
+// scripted VSP.
+var fakeVoteChoices = map[string]string{
+	"reverttreasurypolicy": "yes",
+	"changesubsidysplit":   "abstain",
+}
+
+// fakeWalletVoteChoices are the vote choices of every scripted wallet. The
+// VSPs recorded another choice on changesubsidysplit, so that the choices are
+// shown as not sent yet.
+var fakeWalletVoteChoices = map[string]string{
+	"reverttreasurypolicy": "yes",
+	"changesubsidysplit":   "yes",
+}
+
+// fakeAgendas are the consensus agendas of the scripted vote version, voted on
+// from a month before the best block for a year.
+var fakeAgendas = []Agenda{
+	{
+		ID:          "reverttreasurypolicy",
+		Description: "Change maximum treasury expenditure policy as defined in DCP0007",
+		Choices:     fakeAgendaChoices,
+		StartTime:   fakeBestBlockTime.AddDate(0, -1, 0),
+		ExpireTime:  fakeBestBlockTime.AddDate(0, 11, 0),
+	},
+	{
+		ID:          "changesubsidysplit",
+		Description: "Change block reward subsidy split to 10/80/10 as defined in DCP0010",
+		Choices:     fakeAgendaChoices,
+		StartTime:   fakeBestBlockTime.AddDate(0, -1, 0),
+		ExpireTime:  fakeBestBlockTime.AddDate(0, 11, 0),
+	},
+}
+
+var fakeAgendaChoices = []AgendaChoice{
+	{ID: "abstain", Description: "abstain voting for change", IsAbstain: true},
+	{ID: "no", Description: "keep the existing consensus rules", IsNo: true},
+	{ID: "yes", Description: "change to the new consensus rules"},
+}
+
+type fakeProposal struct {
+	dcrlibwallet.Proposal
+	// age is how long before the best block the proposal was published.
+	age         time.Duration
+	description string
+	// earlier are the descriptions of the versions before the latest, oldest
+	// first.
+	earlier     []string
+	attachments []ProposalAttachment
+	// comments are the comments on the proposal, without their replies.
+	comments []ProposalComment
+}
+
+var fakeProposals = []fakeProposal{
+	{
+		Proposal: dcrlibwallet.Proposal{
+			Token:            "a3def199af812b796887f4eae22e11e45f112b50c2e17252c60ed190933ec14f",
+			Category:         dcrlibwallet.ProposalCategoryActive,
+			Name:             "Decred Integration in Wallet Apps",
+			State:            2,
+			Status:           4,
+			Username:         "atom",
+			NumComments:      27,
+			Version:          "3",
+			VoteStatus:       3,
+			YesVotes:         8120,
+			NoVotes:          1904,
+			EligibleTickets:  40960,
+			QuorumPercentage: 20,
+			PassPercentage:   60,
+		},
+		age: 9 * 24 * time.Hour,
+		description: "# Decred Integration in Wallet Apps\n\nThis proposal funds native DCR support in three " +
+			"popular multi-currency wallets over the next six months.\n\n## Budget\n\n* Development: $60,000\n" +
+			"* Audits: $15,000\n",
+		earlier: []string{
+			"# Decred Integration in Wallet Apps\n\nThis proposal funds native DCR support in two " +
+				"popular multi-currency wallets.\n\n## Budget\n\n* Development: $40,000\n",
+			"# Decred Integration in Wallet Apps\n\nThis proposal funds native DCR support in three " +
+				"popular multi-currency wallets.\n\n## Budget\n\n* Development: $60,000\n",
+		},
+		comments: []ProposalComment{
+			{ID: "1", Author: "zeta", Timestamp: fakeBestBlockTime.Add(-8 * 24 * time.Hour), Score: 12,
+				Text: "Which wallets are these? The proposal should name them before the vote starts."},
+			{ID: "2", ParentID: "1", Author: "atom", Timestamp: fakeBestBlockTime.Add(-7 * 24 * time.Hour), Score: 9,
+				Text: "Fair point, version 3 lists them and adds an audit of each integration."},
+			{ID: "3", ParentID: "2", Author: "zeta", Timestamp: fakeBestBlockTime.Add(-6 * 24 * time.Hour), Score: 4,
+				Text: "Thanks, that answers it."},
+			{ID: "4", Author: "lotus", Timestamp: fakeBestBlockTime.Add(-5 * 24 * time.Hour), Score: -3,
+				Text: "$75,000 seems high for three integrations."},
+			{ID: "5", ParentID: "4", Author: "mirage", Timestamp: fakeBestBlockTime.Add(-4 * 24 * time.Hour), Score: 6,
+				Text: "Audits alone are $15,000, see the [budget](https://proposals.decred.org)."},
+			{ID: "6", Author: "kite", Timestamp: fakeBestBlockTime.Add(-2 * 24 * time.Hour), Score: 2,
+				Text: "Looking forward to paying with DCR from my phone."},
+			{ID: "7", Author: "nomad", Timestamp: fakeBestBlockTime.Add(-24 * time.Hour), Censored: true},
+		},
+	},
+	{
+		Proposal: dcrlibwallet.Proposal{
+			Token:            "fa38a3593d9a3f6cb2478a24c25114f5097c572f6dadf24c78bb521ed10992a4",
+			Category:         dcrlibwallet.ProposalCategoryPre,
+			Name:             "Marketing Campaign for Q3",
+			State:            2,
+			Status:           4,
+			Username:         "lotus",
+			NumComments:      5,
+			Version:          "1",
+			VoteStatus:       1,
+			EligibleTickets:  40960,
+			QuorumPercentage: 20,
+			PassPercentage:   60,
+		},
+		age: 2 * 24 * time.Hour,
+		description: "# Marketing Campaign for Q3\n\nA series of conference appearances and sponsored content " +
+			"aimed at exchanges and merchants.\n\n![Expected reach](reach.png)\n\nThe budget is attached.\n",
+		attachments: []ProposalAttachment{
+			{Name: "reach.png", MIME: "image/png", Data: fakeChartPNG([]int{3, 5, 4, 8, 9, 12})},
+			{Name: "budget.csv", MIME: "text/plain; charset=utf-8", Data: []byte("Item,Months,USD\n" +
+				"Conferences,3,24000\nSponsored content,3,12000\nTotal,,36000\n")},
+		},
+	},
+	{
+		Proposal: dcrlibwallet.Proposal{
+			Token:            "27f87171d98b7923a1bd2bee6affed929fa2d2a6e178b5c80a9971a92a5c7f50",
+			Category:         dcrlibwallet.ProposalCategoryApproved,
+			Name:             "Research Grant: Privacy Improvements",
+			State:            2,
+			Status:           4,
+			Username:         "mirage",
+			NumComments:      64,
+			Version:          "2",
+			VoteStatus:       4,
+			VoteApproved:     true,
+			YesVotes:         11842,
+			NoVotes:          1203,
+			EligibleTickets:  40960,
+			QuorumPercentage: 20,
+			PassPercentage:   60,
+		},
+		age: 70 * 24 * time.Hour,
+		description: "# Research Grant: Privacy Improvements\n\nFunding for a year of research into improving " +
+			"the privacy of mixed outputs.\n",
+		earlier: []string{
+			"# Research Grant: Privacy Improvements\n\nFunding for research into improving privacy.\n",
+		},
+	},
+	{
+		Proposal: dcrlibwallet.Proposal{
+			Token:            "2eb1ddce7d0d68b6a5e6b2f72ea2fe4e4df2d3ad4f63f8a4fa4ba3aa8f4b0b5b",
+			Category:         dcrlibwallet.ProposalCategoryRejected,
+			Name:             "Sponsor a Racing Team",
+			State:            2,
+			Status:           4,
+			Username:         "velocity",
+			NumComments:      112,
+			Version:          "1",
+			VoteStatus:       4,
+			YesVotes:         2011,
+			NoVotes:          13088,
+			EligibleTickets:  40960,
+			QuorumPercentage: 20,
+			PassPercentage:   60,
+		},
+		age:         45 * 24 * time.Hour,
+		description: "# Sponsor a Racing Team\n\nPlace the Decred logo on a racing team's cars for one season.\n",
+	},
+	{
+		Proposal: dcrlibwallet.Proposal{
+			Token:            "c96290a2478d0a1916284438ea2c59a1215fe768a87648d04d45f6b7ecb82c3f",
+			Category:         dcrlibwallet.ProposalCategoryAbandoned,
+			Name:             "Community Podcast",
+			State:            2,
+			Status:           6,
+			Username:         "echo",
+			NumComments:      3,
+			Version:          "1",
+			VoteStatus:       1,
+			EligibleTickets:  40960,
+			QuorumPercentage: 20,
+			PassPercentage:   60,
+		},
+		age:         120 * 24 * time.Hour,
+		description: "# Community Podcast\n\nA weekly podcast covering Decred development and governance.\n",
+	},
+}
+
+// fakeChartPNG returns a PNG of a bar chart of values.
+func fakeChartPNG(values []int) []byte {
+	const barWidth, unit = 20, 8
+	var highest int
+	for _, v := range values {
+		if v > highest {
+			highest = v
+		}
+	}
+
+	img := image.NewNRGBA(image.Rect(0, 0, len(values)*barWidth, highest*unit))
+	bar := color.NRGBA{R: 0x29, G: 0x70, B: 0xff, A: 0xff}
+	for i, v := range values {
+		for x := i*barWidth + 2; x < (i+1)*barWidth-2; x++ {
+			for y := (highest - v) * unit; y < highest*unit; y++ {
+				img.SetNRGBA(x, y, bar)
+			}
+		}
+	}
+
+	var b bytes.Buffer
+	if err := png.Encode(&b, img); err != nil {
+		panic(err)
+	}
+	return b.Bytes()
+}
@@ -0,0 +1,278 @@
+package wallet_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/planetdecred/dcrlibwallet"
+	. "github.com/planetdecred/godcr/wallet"
+)
+
+var _ = Describe("FakeWallet", func() {
+	var fake *FakeWallet
+
+	BeforeEach(func() {
+		var err error
+		fake, err = NewFakeWallet(testnet, make(chan Response, 3))
+		Expect(err).To(BeNil())
+		Expect(fake.InitMultiWallet()).To(BeNil())
+		Expect(fake.OpenWallets(nil)).To(BeNil())
+	})
+
+	AfterEach(func() {
+		fake.Shutdown()
+	})
+
+	It("loads the scripted wallets", func() {
+		fake.SetupListeners()
+		resp := <-fake.Send
+		Expect(resp.Resp).To(BeAssignableToTypeOf(LoadedWallets{}))
+		Expect(resp.Resp.(LoadedWallets).Count).To(BeEquivalentTo(2))
+
+		fake.GetMultiWalletInfo()
+		resp = <-fake.Send
+		Expect(resp.Err).To(BeNil())
+		info := resp.Resp.(MultiWalletInfo)
+		Expect(info.LoadedWallets).To(Equal(2))
+		Expect(info.Wallets[0].Name).To(Equal("Personal"))
+	})
+
+	It("filters the scripted transactions", func() {
+		all, err := fake.GetTransactionsRaw(0, 0, dcrlibwallet.TxFilterAll, true)
+		Expect(err).To(BeNil())
+		staking, err := fake.GetTransactionsRaw(0, 0, dcrlibwallet.TxFilterStaking, true)
+		Expect(err).To(BeNil())
+		Expect(len(staking)).To(BeNumerically("<", len(all)))
+		Expect(all[0].Timestamp).To(BeNumerically(">=", all[len(all)-1].Timestamp))
+	})
+
+	It("builds the details of the scripted tickets", func() {
+		fake.GetAllTickets()
+		resp := <-fake.Send
+		Expect(resp.Err).To(BeNil())
+		for walletID, tickets := range resp.Resp.(*Tickets).Confirmed {
+			for _, ticket := range tickets {
+				details, err := fake.TicketDetails(walletID, ticket)
+				Expect(err).To(BeNil())
+				Expect(details.Purchase.Hash).To(Equal(ticket.Info.Ticket.Hash.String()))
+				Expect(details.Spender == nil).To(Equal(ticket.Info.Spender == nil))
+				if ticket.Info.BlockHeight > 0 {
+					Expect(details.MaturityHeight).To(BeNumerically(">", ticket.Info.BlockHeight))
+					Expect(details.ExpiryHeight).To(BeNumerically(">", details.MaturityHeight))
+				}
+				if details.VSPHost != "" {
+					Expect(details.FeeTx).NotTo(BeNil())
+					Expect(details.FeeStatus).NotTo(Equal(VSPFeeUnknown))
+				}
+			}
+		}
+	})
+
+	It("checks and retries the VSP fees of the scripted tickets", func() {
+		var personal *WalletSummary
+		for _, wal := range fake.AllWallets() {
+			if wal.Name == "Personal" {
+				personal = wal
+			}
+		}
+		Expect(personal).NotTo(BeNil())
+
+		fake.GetAllTickets()
+		resp := <-fake.Send
+		Expect(resp.Err).To(BeNil())
+		unvoted := UnvotedTickets(resp.Resp.(*Tickets).Confirmed[personal.ID])
+		Expect(unvoted).To(HaveLen(2))
+
+		const host = "https://teststakepool.decred.org"
+		Expect(fake.RetryVSPFee(personal.ID, unvoted[0], host, []byte("wrong"))).NotTo(BeNil())
+		for _, ticket := range unvoted {
+			Expect(fake.RetryVSPFee(personal.ID, ticket, host, []byte(FakePassphrase))).To(BeNil())
+		}
+		checked, err := fake.CheckVSPTickets(personal.ID, []byte(FakePassphrase))
+		Expect(err).To(BeNil())
+		Expect(checked).To(Equal(4))
+
+		fake.GetAllTickets()
+		resp = <-fake.Send
+		Expect(resp.Err).To(BeNil())
+		for _, ticket := range resp.Resp.(*Tickets).Confirmed[personal.ID] {
+			Expect(IsUnvoted(ticket)).To(Equal(false))
+			if ticket.Info.Status == "LIVE" {
+				Expect(ticket.VSP.FeeStatus).To(Equal(VSPFeeConfirmed))
+			}
+		}
+	})
+
+	It("sends the vote choices of a wallet to the VSPs", func() {
+		var personal *WalletSummary
+		for _, wal := range fake.AllWallets() {
+			if wal.Name == "Personal" {
+				personal = wal
+			}
+		}
+		Expect(personal).NotTo(BeNil())
+
+		agendas, err := fake.Agendas()
+		Expect(err).To(BeNil())
+		Expect(agendas).NotTo(BeEmpty())
+		Expect(fake.SetVoteChoice(personal.ID, agendas[0].ID, "no")).To(BeNil())
+		choices, err := fake.VoteChoices(personal.ID)
+		Expect(err).To(BeNil())
+		Expect(choices[agendas[0].ID]).To(Equal("no"))
+
+		fake.GetAllTickets()
+		resp := <-fake.Send
+		Expect(resp.Err).To(BeNil())
+		for _, status := range VoteStatuses(resp.Resp.(*Tickets).Confirmed[personal.ID], choices) {
+			Expect(status.Recorded).To(BeNumerically("<", status.Tickets))
+		}
+
+		_, err = fake.PushVoteChoices(personal.ID, []byte("wrong"))
+		Expect(err).NotTo(BeNil())
+		pushed, err := fake.PushVoteChoices(personal.ID, []byte(FakePassphrase))
+		Expect(err).To(BeNil())
+		Expect(pushed).To(BeNumerically(">", 0))
+
+		fake.GetAllTickets()
+		resp = <-fake.Send
+		Expect(resp.Err).To(BeNil())
+		statuses := VoteStatuses(resp.Resp.(*Tickets).Confirmed[personal.ID], choices)
+		Expect(statuses).NotTo(BeEmpty())
+		for _, status := range statuses {
+			Expect(status.Recorded).To(Equal(status.Tickets))
+		}
+	})
+
+	It("removes VSPs and trusts changed VSP keys", func() {
+		fake.GetAllVSP()
+		resp := <-fake.Send
+		Expect(resp.Err).To(BeNil())
+		vsps := resp.Resp.(*VSP)
+		Expect(vsps.List).To(HaveLen(4))
+		Expect(vsps.Usable()).To(HaveLen(2))
+
+		var changed, unreachable VSPInfo
+		for _, vsp := range vsps.List {
+			switch {
+			case vsp.PubKeyChanged:
+				changed = vsp
+			case vsp.Err != nil:
+				unreachable = vsp
+			}
+		}
+		Expect(changed.Added).To(Equal(false))
+		Expect(unreachable.Added).To(Equal(true))
+
+		walletID := fake.AllWallets()[0].ID
+		_, err := fake.NewVSPD(changed.Host, walletID, 0)
+		Expect(err).To(Equal(ErrVSPPubKeyChanged))
+
+		Expect(fake.RemoveVSP(changed.Host)).NotTo(BeNil())
+		Expect(fake.RemoveVSP(unreachable.Host)).To(BeNil())
+		Expect(fake.TrustVSPPubKey(unreachable.Host)).NotTo(BeNil())
+		Expect(fake.TrustVSPPubKey(changed.Host)).To(BeNil())
+		_, err = fake.NewVSPD(changed.Host, walletID, 0)
+		Expect(err).To(BeNil())
+
+		fake.CheckVSPs()
+		resp = <-fake.Send
+		Expect(resp.Err).To(BeNil())
+		vsps = resp.Resp.(*VSP)
+		Expect(vsps.List).To(HaveLen(3))
+		Expect(vsps.Usable()).To(HaveLen(3))
+	})
+
+	It("forecasts the scripted ticket price", func() {
+		fake.GetTicketPriceForecast()
+		resp := <-fake.Send
+		Expect(resp.Err).To(BeNil())
+		forecast := resp.Resp.(*TicketPriceForecast)
+		Expect(forecast.WindowSize).To(BeEquivalentTo(144))
+		Expect(forecast.BlocksLeft).To(BeNumerically(">", 0))
+		Expect(forecast.BlocksLeft).To(BeNumerically("<=", forecast.WindowSize))
+
+		history := forecast.History
+		Expect(history).NotTo(BeEmpty())
+		Expect(history[len(history)-1].Price).To(Equal(forecast.Price))
+		Expect(history[0].Time.Before(history[len(history)-1].Time)).To(Equal(true))
+	})
+
+	It("sends every scripted version of a proposal", func() {
+		fake.GetProposalVersions("a3def199af812b796887f4eae22e11e45f112b50c2e17252c60ed190933ec14f")
+		resp := <-fake.Send
+		Expect(resp.Resp.(*ProposalVersions).Err).To(BeNil())
+		versions := resp.Resp.(*ProposalVersions).Versions
+		Expect(versions).To(HaveLen(3))
+		for i, v := range versions {
+			Expect(v.Version).To(Equal(i + 1))
+			Expect(v.Description).NotTo(BeEmpty())
+		}
+		Expect(versions[0].Timestamp.Before(versions[2].Timestamp)).To(Equal(true))
+
+		fake.GetProposalVersions("unknown")
+		resp = <-fake.Send
+		Expect(resp.Resp.(*ProposalVersions).Err).NotTo(BeNil())
+	})
+
+	It("returns the scripted content of a proposal", func() {
+		content, err := fake.FetchProposalContent("a3def199af812b796887f4eae22e11e45f112b50c2e17252c60ed190933ec14f")
+		Expect(err).To(BeNil())
+		Expect(content.Version).To(Equal(3))
+		Expect(content.Description).NotTo(BeEmpty())
+
+		content, err = fake.FetchProposalContent("fa38a3593d9a3f6cb2478a24c25114f5097c572f6dadf24c78bb521ed10992a4")
+		Expect(err).To(BeNil())
+		Expect(content.Attachments).To(HaveLen(2))
+		Expect(content.Attachments[0].Data).NotTo(BeEmpty())
+
+		_, err = fake.FetchProposalContent("unknown")
+		Expect(err).NotTo(BeNil())
+	})
+
+	It("sends the scripted comments on a proposal as a tree", func() {
+		fake.GetProposalComments("a3def199af812b796887f4eae22e11e45f112b50c2e17252c60ed190933ec14f")
+		resp := <-fake.Send
+		comments := resp.Resp.(*ProposalComments)
+		Expect(comments.Err).To(BeNil())
+		Expect(comments.Count).To(Equal(7))
+		Expect(comments.Comments).To(HaveLen(4))
+		Expect(comments.Comments[0].Replies).To(HaveLen(1))
+		Expect(comments.Comments[0].Replies[0].Replies).To(HaveLen(1))
+		for i := 1; i < len(comments.Comments); i++ {
+			Expect(comments.Comments[i-1].Timestamp.Before(comments.Comments[i].Timestamp)).To(Equal(true))
+		}
+
+		fake.GetProposalComments("unknown")
+		resp = <-fake.Send
+		Expect(resp.Resp.(*ProposalComments).Err).NotTo(BeNil())
+	})
+
+	It("remembers the watched proposals", func() {
+		token := "a3def199af812b796887f4eae22e11e45f112b50c2e17252c60ed190933ec14f"
+		Expect(fake.WatchedProposals()).To(BeEmpty())
+
+		fake.WatchProposal(token, true)
+		fake.WatchProposal(token, true)
+		Expect(fake.WatchedProposals()).To(Equal(map[string]bool{token: true}))
+
+		fake.WatchProposal(token, false)
+		Expect(fake.WatchedProposals()).To(BeEmpty())
+	})
+
+	It("plays the scripted sync", func() {
+		fake.SetupListeners()
+		<-fake.Send
+		Expect(fake.StartSync()).To(BeNil())
+		Expect(fake.IsSyncing()).To(Equal(true))
+		for update := range fake.Sync {
+			if update.Stage == SyncCompleted {
+				break
+			}
+		}
+		Expect(fake.IsSynced()).To(Equal(true))
+		Expect(fake.ConnectedPeers()).To(BeNumerically(">", 0))
+
+		peers, err := fake.PeerInfo()
+		Expect(err).To(BeNil())
+		Expect(peers).To(HaveLen(int(fake.ConnectedPeers())))
+	})
+})
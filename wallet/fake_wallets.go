@@ -0,0 +1,1112 @@
+package wallet
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"os"
+	"sort"
+
+	"decred.org/dcrwallet/wallet"
+	"decred.org/dcrwallet/wallet/txrules"
+	"github.com/decred/dcrd/chaincfg/chainhash"
+	"github.com/decred/dcrd/chaincfg/v3"
+	"github.com/decred/dcrd/dcrec"
+	"github.com/decred/dcrd/dcrec/secp256k1/v3"
+	"github.com/decred/dcrd/dcrec/secp256k1/v3/ecdsa"
+	"github.com/decred/dcrd/dcrutil/v3"
+	"github.com/decred/dcrd/wire"
+	"github.com/planetdecred/dcrlibwallet"
+	"github.com/planetdecred/godcr/crash"
+)
+
+// fakeWallet is a wallet of FakeWallet. It is guarded by FakeWallet.walletMu.
+type fakeWallet struct {
+	WalletSummary
+	passphrase string
+	// seed derives the keys of the addresses of the wallet. It is the
+	// extended public key of watching only wallets.
+	seed string
+
+	accounts []fakeAccount
+	// keys are the private keys of the addresses given out, by address.
+	keys map[string]*secp256k1.PrivateKey
+	// nextAddress is the index of the current address of each account.
+	nextAddress map[int32]uint32
+
+	locked     bool
+	discovered bool
+	config     map[string]bool
+
+	voteChoices    map[string]string
+	mixedAccount   int32
+	unmixedAccount int32
+	mixing         bool
+}
+
+type fakeAccount struct {
+	number int32
+	name   string
+}
+
+func newFakeWallet(id int, name, seed, passphrase string) *fakeWallet {
+	return &fakeWallet{
+		WalletSummary: WalletSummary{ID: id, Name: name},
+		passphrase:    passphrase,
+		seed:          seed,
+		accounts: []fakeAccount{
+			{number: 0, name: "default"},
+			{number: math.MaxInt32, name: "imported"},
+		},
+		keys:           make(map[string]*secp256k1.PrivateKey),
+		nextAddress:    make(map[int32]uint32),
+		locked:         true,
+		config:         make(map[string]bool),
+		voteChoices:    make(map[string]string),
+		mixedAccount:   -1,
+		unmixedAccount: -1,
+	}
+}
+
+// account returns the account of the wallet with number, or nil if there is
+// none.
+func (wall *fakeWallet) account(number int32) *fakeAccount {
+	for i := range wall.accounts {
+		if wall.accounts[i].number == number {
+			return &wall.accounts[i]
+		}
+	}
+	return nil
+}
+
+// address returns the address at index of an account of the wallet,
+// remembering its key.
+func (wall *fakeWallet) address(params *chaincfg.Params, account int32, index uint32) (string, error) {
+	key := secp256k1.PrivKeyFromBytes(chainhash.HashB([]byte(fmt.Sprintf("%s/%d/%d", wall.seed, account, index))))
+	hash := dcrutil.Hash160(key.PubKey().SerializeCompressed())
+	addr, err := dcrutil.NewAddressPubKeyHash(hash, params, dcrec.STEcdsaSecp256k1)
+	if err != nil {
+		return "", err
+	}
+	wall.keys[addr.Address()] = key
+	return addr.Address(), nil
+}
+
+// wallet returns the wallet with walletID. The caller must hold walletMu.
+func (fw *FakeWallet) wallet(walletID int) (*fakeWallet, error) {
+	wall, ok := fw.wallets[walletID]
+	if !ok {
+		return nil, ErrIDNotExist
+	}
+	return wall, nil
+}
+
+// checkPassphrase checks that passphrase is the spending passphrase of the
+// wallet with walletID.
+func (fw *FakeWallet) checkPassphrase(walletID int, passphrase []byte) error {
+	fw.walletMu.Lock()
+	defer fw.walletMu.Unlock()
+	wall, err := fw.wallet(walletID)
+	if err != nil {
+		return err
+	}
+	if wall.IsWatchingOnly || string(passphrase) != wall.passphrase {
+		return errors.New(dcrlibwallet.ErrInvalidPassphrase)
+	}
+	return nil
+}
+
+// openedWallets returns the wallets once OpenWallets was called, sorted by ID.
+func (fw *FakeWallet) openedWallets() []*WalletSummary {
+	fw.walletMu.Lock()
+	opened := fw.opened
+	fw.walletMu.Unlock()
+	if !opened {
+		return nil
+	}
+	return fw.AllWallets()
+}
+
+// discoverAccounts marks the accounts of the opened wallets as discovered and
+// locks the wallets unlocked for the discovery, as the first sync does.
+func (fw *FakeWallet) discoverAccounts() {
+	fw.walletMu.Lock()
+	defer fw.walletMu.Unlock()
+	if !fw.opened {
+		return
+	}
+	for _, wall := range fw.wallets {
+		if !wall.discovered {
+			wall.discovered = true
+			wall.locked = true
+		}
+	}
+}
+
+// balance returns the scripted balance of a wallet.
+func (fw *FakeWallet) balance(walletID int) Balance {
+	fw.mu.Lock()
+	defer fw.mu.Unlock()
+	return fw.balances[walletID]
+}
+
+// IsConfigLoaded reports whether the wallets were loaded.
+func (fw *FakeWallet) IsConfigLoaded() bool {
+	fw.walletMu.Lock()
+	defer fw.walletMu.Unlock()
+	return fw.wallets != nil
+}
+
+func (fw *FakeWallet) LoadedWalletsCount() int32 {
+	fw.walletMu.Lock()
+	defer fw.walletMu.Unlock()
+	return int32(len(fw.wallets))
+}
+
+// OpenWallets opens the wallets, with the startup passphrase if one is set.
+func (fw *FakeWallet) OpenWallets(startupPassphrase []byte) error {
+	fw.walletMu.Lock()
+	defer fw.walletMu.Unlock()
+	if fw.startupPassphrase != nil && !bytes.Equal(startupPassphrase, fw.startupPassphrase) {
+		return errors.New(dcrlibwallet.ErrInvalidPassphrase)
+	}
+	fw.opened = true
+	return nil
+}
+
+// AllWallets returns the loaded wallets, sorted by ID.
+func (fw *FakeWallet) AllWallets() []*WalletSummary {
+	fw.walletMu.Lock()
+	defer fw.walletMu.Unlock()
+	summaries := make([]*WalletSummary, 0, len(fw.wallets))
+	for _, wall := range fw.wallets {
+		summary := wall.WalletSummary
+		summaries = append(summaries, &summary)
+	}
+	sort.Slice(summaries, func(i, j int) bool {
+		return summaries[i].ID < summaries[j].ID
+	})
+	return summaries
+}
+
+// WalletWithID returns the loaded wallet with walletID, or nil if there is
+// none.
+func (fw *FakeWallet) WalletWithID(walletID int) *WalletSummary {
+	fw.walletMu.Lock()
+	defer fw.walletMu.Unlock()
+	wall, err := fw.wallet(walletID)
+	if err != nil {
+		return nil
+	}
+	summary := wall.WalletSummary
+	return &summary
+}
+
+// addWallet adds a wallet named name with the next ID, unless a wallet has
+// that name.
+func (fw *FakeWallet) addWallet(name, seed, passphrase string) (*fakeWallet, error) {
+	id := 1
+	for _, wall := range fw.wallets {
+		if wall.Name == name {
+			return nil, errors.New(dcrlibwallet.ErrExist)
+		}
+		if wall.ID >= id {
+			id = wall.ID + 1
+		}
+	}
+	wall := newFakeWallet(id, name, seed, passphrase)
+	fw.wallets[id] = wall
+	return wall, nil
+}
+
+// CreateNewWallet adds a wallet with a new seed, kept until it is backed up.
+func (fw *FakeWallet) CreateNewWallet(name, passphrase string) (*WalletSummary, error) {
+	seed, err := dcrlibwallet.GenerateSeed()
+	if err != nil {
+		return nil, err
+	}
+
+	fw.walletMu.Lock()
+	defer fw.walletMu.Unlock()
+	wall, err := fw.addWallet(name, seed, passphrase)
+	if err != nil {
+		return nil, err
+	}
+	wall.EncryptedSeed = fakeHash(seed, "encrypted", 0)[:]
+	wall.discovered = true
+	summary := wall.WalletSummary
+	return &summary, nil
+}
+
+// RestoreWallet adds a wallet with seed. Its accounts are discovered on the
+// next sync.
+func (fw *FakeWallet) RestoreWallet(name, seed, passphrase string) (*WalletSummary, error) {
+	if !dcrlibwallet.VerifySeed(seed) {
+		return nil, errors.New(dcrlibwallet.ErrInvalid)
+	}
+
+	fw.walletMu.Lock()
+	defer fw.walletMu.Unlock()
+	wall, err := fw.addWallet(name, seed, passphrase)
+	if err != nil {
+		return nil, err
+	}
+	summary := wall.WalletSummary
+	return &summary, nil
+}
+
+// ImportWatchOnlyWallet adds a wallet that watches the addresses of
+// extendedPublicKey. The key itself is not checked.
+func (fw *FakeWallet) ImportWatchOnlyWallet(name, extendedPublicKey string) error {
+	if extendedPublicKey == "" {
+		return fmt.Errorf("error importing watch only wallet: %s", dcrlibwallet.ErrInvalid)
+	}
+
+	fw.walletMu.Lock()
+	defer fw.walletMu.Unlock()
+	wall, err := fw.addWallet(name, extendedPublicKey, "")
+	if err != nil {
+		return fmt.Errorf("error importing watch only wallet: %s", err.Error())
+	}
+	wall.IsWatchingOnly = true
+	wall.discovered = true
+	wall.accounts = wall.accounts[:1]
+	return nil
+}
+
+func (fw *FakeWallet) RenameWallet(walletID int, name string) error {
+	fw.walletMu.Lock()
+	defer fw.walletMu.Unlock()
+	wall, err := fw.wallet(walletID)
+	if err != nil {
+		return err
+	}
+	for _, w := range fw.wallets {
+		if w.Name == name && w != wall {
+			return errors.New(dcrlibwallet.ErrExist)
+		}
+	}
+	wall.Name = name
+	return nil
+}
+
+// DeleteWallet removes a wallet with its transactions and tickets.
+func (fw *FakeWallet) DeleteWallet(walletID int, passphrase []byte) error {
+	fw.walletMu.Lock()
+	wall, err := fw.wallet(walletID)
+	if err == nil && !wall.IsWatchingOnly && string(passphrase) != wall.passphrase {
+		err = errors.New(dcrlibwallet.ErrInvalidPassphrase)
+	}
+	if err != nil {
+		fw.walletMu.Unlock()
+		return err
+	}
+	delete(fw.wallets, walletID)
+	fw.walletMu.Unlock()
+
+	fw.mu.Lock()
+	defer fw.mu.Unlock()
+	delete(fw.balances, walletID)
+	delete(fw.txs, walletID)
+	delete(fw.tickets, walletID)
+	return nil
+}
+
+func (fw *FakeWallet) ChangeWalletPassphrase(walletID int, oldPrivatePassphrase, newPrivatePassphrase []byte) error {
+	if err := fw.checkPassphrase(walletID, oldPrivatePassphrase); err != nil {
+		return err
+	}
+	fw.walletMu.Lock()
+	defer fw.walletMu.Unlock()
+	if wall, err := fw.wallet(walletID); err == nil {
+		wall.passphrase = string(newPrivatePassphrase)
+	}
+	return nil
+}
+
+func (fw *FakeWallet) UnlockWallet(walletID int, passphrase []byte) error {
+	if err := fw.checkPassphrase(walletID, passphrase); err != nil {
+		return err
+	}
+	fw.walletMu.Lock()
+	defer fw.walletMu.Unlock()
+	if wall, err := fw.wallet(walletID); err == nil {
+		wall.locked = false
+	}
+	return nil
+}
+
+func (fw *FakeWallet) LockWallet(walletID int) {
+	fw.walletMu.Lock()
+	defer fw.walletMu.Unlock()
+	if wall, err := fw.wallet(walletID); err == nil {
+		wall.locked = true
+	}
+}
+
+func (fw *FakeWallet) IsLocked(walletID int) bool {
+	fw.walletMu.Lock()
+	defer fw.walletMu.Unlock()
+	wall, err := fw.wallet(walletID)
+	return err == nil && wall.locked
+}
+
+// HasDiscoveredAccounts reports whether the accounts of a wallet were
+// discovered.
+func (fw *FakeWallet) HasDiscoveredAccounts(walletID int) bool {
+	fw.walletMu.Lock()
+	defer fw.walletMu.Unlock()
+	wall, err := fw.wallet(walletID)
+	return err == nil && wall.discovered
+}
+
+// IsWaiting reports whether a wallet waits for the other wallets to sync. The
+// scripted wallets sync together, so none waits.
+func (fw *FakeWallet) IsWaiting(walletID int) bool {
+	return false
+}
+
+// WalletBestBlock returns the scripted best block, which every wallet is
+// synced to.
+func (fw *FakeWallet) WalletBestBlock(walletID int) (height int32, timestamp int64) {
+	if fw.WalletWithID(walletID) == nil {
+		return 0, 0
+	}
+	return fakeBestBlockHeight, fakeBestBlockTime.Unix()
+}
+
+// RescanBlocks does nothing as the scripted transactions never change.
+func (fw *FakeWallet) RescanBlocks(walletID int) error {
+	if fw.WalletWithID(walletID) == nil {
+		return ErrIDNotExist
+	}
+	return nil
+}
+
+func (fw *FakeWallet) ReadWalletBoolConfigValueForKey(walletID int, key string, defaultValue bool) bool {
+	fw.walletMu.Lock()
+	defer fw.walletMu.Unlock()
+	wall, err := fw.wallet(walletID)
+	if err != nil {
+		return defaultValue
+	}
+	value, ok := wall.config[key]
+	if !ok {
+		return defaultValue
+	}
+	return value
+}
+
+func (fw *FakeWallet) SetWalletBoolConfigValueForKey(walletID int, key string, value bool) {
+	fw.walletMu.Lock()
+	defer fw.walletMu.Unlock()
+	if wall, err := fw.wallet(walletID); err == nil {
+		wall.config[key] = value
+	}
+}
+
+// GetAccountsRaw returns the accounts of a wallet. The scripted balance of
+// the wallet is in its default account.
+func (fw *FakeWallet) GetAccountsRaw(walletID int) (*dcrlibwallet.Accounts, error) {
+	fw.walletMu.Lock()
+	wall, err := fw.wallet(walletID)
+	if err != nil {
+		fw.walletMu.Unlock()
+		return nil, err
+	}
+	accounts := append([]fakeAccount(nil), wall.accounts...)
+	fw.walletMu.Unlock()
+
+	balance := fw.balance(walletID)
+	accts := &dcrlibwallet.Accounts{
+		Count:              len(accounts),
+		CurrentBlockHash:   fakeHash("", "block", int(fakeBestBlockHeight))[:],
+		CurrentBlockHeight: fakeBestBlockHeight,
+	}
+	for _, acct := range accounts {
+		account := &dcrlibwallet.Account{
+			WalletID: walletID,
+			Number:   acct.number,
+			Name:     acct.name,
+			Balance:  new(dcrlibwallet.Balance),
+		}
+		if acct.number == 0 {
+			*account.Balance = dcrlibwallet.Balance(balance)
+			account.TotalBalance = balance.Total
+		}
+		accts.Acc = append(accts.Acc, account)
+	}
+	return accts, nil
+}
+
+func (fw *FakeWallet) AccountName(walletID int, accountNumber int32) (string, error) {
+	fw.walletMu.Lock()
+	defer fw.walletMu.Unlock()
+	wall, err := fw.wallet(walletID)
+	if err != nil {
+		return "", err
+	}
+	acct := wall.account(accountNumber)
+	if acct == nil {
+		return "", errors.New(dcrlibwallet.ErrNotExist)
+	}
+	return acct.name, nil
+}
+
+// accountName returns the name of an account, or "external" if it is not an
+// account of the wallet.
+func (fw *FakeWallet) accountName(walletID int, accountNumber int32) string {
+	name, err := fw.AccountName(walletID, accountNumber)
+	if err != nil {
+		return "external"
+	}
+	return name
+}
+
+// CreateNewAccount adds an account named name to a wallet and returns its
+// number.
+func (fw *FakeWallet) CreateNewAccount(walletID int, name string, passphrase []byte) (int32, error) {
+	if err := fw.checkPassphrase(walletID, passphrase); err != nil {
+		return 0, err
+	}
+
+	fw.walletMu.Lock()
+	defer fw.walletMu.Unlock()
+	wall, err := fw.wallet(walletID)
+	if err != nil {
+		return 0, err
+	}
+	var number int32
+	for _, acct := range wall.accounts {
+		if acct.name == name {
+			return 0, errors.New(dcrlibwallet.ErrExist)
+		}
+		if acct.number != math.MaxInt32 && acct.number >= number {
+			number = acct.number + 1
+		}
+	}
+	// the imported account stays last
+	last := len(wall.accounts) - 1
+	wall.accounts = append(wall.accounts[:last], fakeAccount{number: number, name: name}, wall.accounts[last])
+	return number, nil
+}
+
+func (fw *FakeWallet) RenameAccount(walletID int, accountNumber int32, name string) error {
+	fw.walletMu.Lock()
+	defer fw.walletMu.Unlock()
+	wall, err := fw.wallet(walletID)
+	if err != nil {
+		return err
+	}
+	acct := wall.account(accountNumber)
+	if acct == nil || acct.number == math.MaxInt32 {
+		return errors.New(dcrlibwallet.ErrInvalid)
+	}
+	for _, a := range wall.accounts {
+		if a.name == name && a.number != accountNumber {
+			return errors.New(dcrlibwallet.ErrExist)
+		}
+	}
+	acct.name = name
+	return nil
+}
+
+// CurrentAddress returns the current address of an account of a wallet.
+func (fw *FakeWallet) CurrentAddress(walletID int, accountID int32) (string, error) {
+	fw.walletMu.Lock()
+	defer fw.walletMu.Unlock()
+	wall, err := fw.wallet(walletID)
+	if err != nil {
+		return "", err
+	}
+	if acct := wall.account(accountID); acct == nil || acct.number == math.MaxInt32 {
+		return "", errors.New(dcrlibwallet.ErrNotExist)
+	}
+	return wall.address(fw.params, accountID, wall.nextAddress[accountID])
+}
+
+// NextAddress moves an account of a wallet to its next address and returns
+// it.
+func (fw *FakeWallet) NextAddress(walletID int, accountID int32) (string, error) {
+	fw.walletMu.Lock()
+	defer fw.walletMu.Unlock()
+	wall, err := fw.wallet(walletID)
+	if err != nil {
+		return "", err
+	}
+	if acct := wall.account(accountID); acct == nil || acct.number == math.MaxInt32 {
+		return "", errors.New(dcrlibwallet.ErrNotExist)
+	}
+	wall.nextAddress[accountID]++
+	return wall.address(fw.params, accountID, wall.nextAddress[accountID])
+}
+
+// HaveWalletAddress reports whether address was given out by the wallet
+// identified by walletID.
+func (fw *FakeWallet) HaveWalletAddress(walletID int, address string) bool {
+	fw.walletMu.Lock()
+	defer fw.walletMu.Unlock()
+	wall, err := fw.wallet(walletID)
+	if err != nil {
+		return false
+	}
+	_, ok := wall.keys[address]
+	return ok
+}
+
+// HaveAddress reports whether address was given out by a wallet, and the name
+// of the wallet.
+func (fw *FakeWallet) HaveAddress(address string) (bool, string) {
+	for _, wall := range fw.AllWallets() {
+		if fw.HaveWalletAddress(wall.ID, address) {
+			return true, wall.Name
+		}
+	}
+	return false, ""
+}
+
+// IsAddressValid checks if address is valid on the network of the wallets.
+func (fw *FakeWallet) IsAddressValid(address string) (bool, error) {
+	_, err := dcrutil.DecodeAddress(address, fw.params)
+	return err == nil, nil
+}
+
+// SignMessage signs message with the key of address, which must have been
+// given out by the wallet identified by walletID.
+func (fw *FakeWallet) SignMessage(walletID int, passphrase []byte, address, message string) ([]byte, error) {
+	if err := fw.checkPassphrase(walletID, passphrase); err != nil {
+		return nil, err
+	}
+
+	fw.walletMu.Lock()
+	defer fw.walletMu.Unlock()
+	wall, err := fw.wallet(walletID)
+	if err != nil {
+		return nil, err
+	}
+	key, ok := wall.keys[address]
+	if !ok {
+		return nil, errors.New(dcrlibwallet.ErrInvalidAddress)
+	}
+
+	var buf bytes.Buffer
+	wire.WriteVarString(&buf, 0, "Decred Signed Message:\n")
+	wire.WriteVarString(&buf, 0, message)
+	return ecdsa.SignCompact(key, chainhash.HashB(buf.Bytes()), true), nil
+}
+
+// VerifyMessage checks if the given message matches the signature for the
+// address.
+func (fw *FakeWallet) VerifyMessage(address string, message string, signature string) (bool, error) {
+	addr, err := dcrutil.DecodeAddress(address, fw.params)
+	if err != nil {
+		return false, errors.New(dcrlibwallet.ErrInvalidAddress)
+	}
+	sig, err := dcrlibwallet.DecodeBase64(signature)
+	if err != nil {
+		return false, err
+	}
+	return wallet.VerifyMessage(message, addr, sig, fw.params)
+}
+
+// GetWalletSeedPhrase returns the seed of a wallet until it is backed up.
+func (fw *FakeWallet) GetWalletSeedPhrase(walletID int, password []byte) (string, error) {
+	if err := fw.checkPassphrase(walletID, password); err != nil {
+		return "", err
+	}
+	fw.walletMu.Lock()
+	defer fw.walletMu.Unlock()
+	wall, err := fw.wallet(walletID)
+	if err != nil {
+		return "", err
+	}
+	if wall.EncryptedSeed == nil {
+		return "", errors.New(dcrlibwallet.ErrInvalid)
+	}
+	return wall.seed, nil
+}
+
+// VerifyWalletSeedPhrase marks the seed of a wallet as backed up if seedPhrase
+// is its seed.
+func (fw *FakeWallet) VerifyWalletSeedPhrase(walletID int, seedPhrase string, privpass []byte) error {
+	if err := fw.checkPassphrase(walletID, privpass); err != nil {
+		return err
+	}
+	fw.walletMu.Lock()
+	defer fw.walletMu.Unlock()
+	wall, err := fw.wallet(walletID)
+	if err != nil {
+		return err
+	}
+	if wall.EncryptedSeed == nil || seedPhrase != wall.seed {
+		return errors.New(dcrlibwallet.ErrInvalid)
+	}
+	wall.EncryptedSeed = nil
+	return nil
+}
+
+func (fw *FakeWallet) IsAccountMixerConfigSet(walletID int) bool {
+	return fw.ReadWalletBoolConfigValueForKey(walletID, dcrlibwallet.AccountMixerConfigSet, false)
+}
+
+func (fw *FakeWallet) IsAccountMixerActive(walletID int) bool {
+	fw.walletMu.Lock()
+	defer fw.walletMu.Unlock()
+	wall, err := fw.wallet(walletID)
+	return err == nil && wall.mixing
+}
+
+// CreateMixerAccounts adds the accounts the mixer of a wallet mixes from and
+// to, and sets them as its config.
+func (fw *FakeWallet) CreateMixerAccounts(walletID int, mixedAccount, unmixedAccount, passphrase string) error {
+	if fw.IsAccountMixerConfigSet(walletID) {
+		return errors.New(dcrlibwallet.ErrInvalid)
+	}
+	fw.walletMu.Lock()
+	wall, err := fw.wallet(walletID)
+	if err == nil && (accountNamed(wall, mixedAccount) || accountNamed(wall, unmixedAccount)) {
+		err = errors.New(dcrlibwallet.ErrExist)
+	}
+	fw.walletMu.Unlock()
+	if err != nil {
+		return err
+	}
+
+	mixed, err := fw.CreateNewAccount(walletID, mixedAccount, []byte(passphrase))
+	if err != nil {
+		return err
+	}
+	unmixed, err := fw.CreateNewAccount(walletID, unmixedAccount, []byte(passphrase))
+	if err != nil {
+		return err
+	}
+
+	fw.walletMu.Lock()
+	defer fw.walletMu.Unlock()
+	if wall, err := fw.wallet(walletID); err == nil {
+		wall.mixedAccount, wall.unmixedAccount = mixed, unmixed
+		wall.config[dcrlibwallet.AccountMixerConfigSet] = true
+	}
+	return nil
+}
+
+// accountNamed reports whether wall has an account named name.
+func accountNamed(wall *fakeWallet, name string) bool {
+	for _, acct := range wall.accounts {
+		if acct.name == name {
+			return true
+		}
+	}
+	return false
+}
+
+func (fw *FakeWallet) MixedAccountNumber(walletID int) int32 {
+	fw.walletMu.Lock()
+	defer fw.walletMu.Unlock()
+	wall, err := fw.wallet(walletID)
+	if err != nil {
+		return -1
+	}
+	return wall.mixedAccount
+}
+
+func (fw *FakeWallet) UnmixedAccountNumber(walletID int) int32 {
+	fw.walletMu.Lock()
+	defer fw.walletMu.Unlock()
+	wall, err := fw.wallet(walletID)
+	if err != nil {
+		return -1
+	}
+	return wall.unmixedAccount
+}
+
+// StartAccountMixer marks the mixer of a wallet as running. Nothing is mixed.
+func (fw *FakeWallet) StartAccountMixer(walletID int, walletPassphrase string) error {
+	if err := fw.checkPassphrase(walletID, []byte(walletPassphrase)); err != nil {
+		return err
+	}
+	if !fw.IsAccountMixerConfigSet(walletID) {
+		return errors.New(dcrlibwallet.ErrInvalid)
+	}
+	if err := fw.setMixing(walletID, true); err != nil {
+		return err
+	}
+	fw.notifyMixer(func(l dcrlibwallet.AccountMixerNotificationListener) { l.OnAccountMixerStarted(walletID) })
+	return nil
+}
+
+func (fw *FakeWallet) StopAccountMixer(walletID int) error {
+	if err := fw.setMixing(walletID, false); err != nil {
+		return err
+	}
+	fw.notifyMixer(func(l dcrlibwallet.AccountMixerNotificationListener) { l.OnAccountMixerEnded(walletID) })
+	return nil
+}
+
+// setMixing starts or stops the mixer of a wallet, failing if it already is.
+func (fw *FakeWallet) setMixing(walletID int, mixing bool) error {
+	fw.walletMu.Lock()
+	defer fw.walletMu.Unlock()
+	wall, err := fw.wallet(walletID)
+	if err != nil {
+		return err
+	}
+	if wall.mixing == mixing {
+		return errors.New(dcrlibwallet.ErrInvalid)
+	}
+	wall.mixing = mixing
+	return nil
+}
+
+// notifyMixer calls f with every account mixer listener outside the lock.
+func (fw *FakeWallet) notifyMixer(f func(dcrlibwallet.AccountMixerNotificationListener)) {
+	fw.walletMu.Lock()
+	listeners := make([]dcrlibwallet.AccountMixerNotificationListener, 0, len(fw.mixerListeners))
+	for _, l := range fw.mixerListeners {
+		listeners = append(listeners, l)
+	}
+	fw.walletMu.Unlock()
+
+	for _, l := range listeners {
+		f(l)
+	}
+}
+
+func (fw *FakeWallet) AddTxAndBlockNotificationListener(listener dcrlibwallet.TxAndBlockNotificationListener, uniqueIdentifier string) error {
+	fw.walletMu.Lock()
+	defer fw.walletMu.Unlock()
+	if _, ok := fw.txListeners[uniqueIdentifier]; ok {
+		return errors.New(dcrlibwallet.ErrListenerAlreadyExist)
+	}
+	fw.txListeners[uniqueIdentifier] = listener
+	return nil
+}
+
+func (fw *FakeWallet) RemoveTxAndBlockNotificationListener(uniqueIdentifier string) {
+	fw.walletMu.Lock()
+	defer fw.walletMu.Unlock()
+	delete(fw.txListeners, uniqueIdentifier)
+}
+
+func (fw *FakeWallet) AddAccountMixerNotificationListener(listener dcrlibwallet.AccountMixerNotificationListener, uniqueIdentifier string) error {
+	fw.walletMu.Lock()
+	defer fw.walletMu.Unlock()
+	if _, ok := fw.mixerListeners[uniqueIdentifier]; ok {
+		return errors.New(dcrlibwallet.ErrListenerAlreadyExist)
+	}
+	fw.mixerListeners[uniqueIdentifier] = listener
+	return nil
+}
+
+func (fw *FakeWallet) RemoveAccountMixerNotificationListener(uniqueIdentifier string) {
+	fw.walletMu.Lock()
+	defer fw.walletMu.Unlock()
+	delete(fw.mixerListeners, uniqueIdentifier)
+}
+
+func (fw *FakeWallet) AddProposalNotificationListener(listener dcrlibwallet.ProposalNotificationListener, uniqueIdentifier string) error {
+	fw.walletMu.Lock()
+	defer fw.walletMu.Unlock()
+	if _, ok := fw.proposalListeners[uniqueIdentifier]; ok {
+		return errors.New(dcrlibwallet.ErrListenerAlreadyExist)
+	}
+	fw.proposalListeners[uniqueIdentifier] = listener
+	return nil
+}
+
+func (fw *FakeWallet) RemoveProposalNotificationListener(uniqueIdentifier string) {
+	fw.walletMu.Lock()
+	defer fw.walletMu.Unlock()
+	delete(fw.proposalListeners, uniqueIdentifier)
+}
+
+// IsStartupSecuritySet checks if start up password is set
+func (fw *FakeWallet) IsStartupSecuritySet() bool {
+	fw.walletMu.Lock()
+	defer fw.walletMu.Unlock()
+	return fw.startupPassphrase != nil
+}
+
+// VerifyStartupPassphrase checks that passphrase is the startup passphrase.
+func (fw *FakeWallet) VerifyStartupPassphrase(passphrase []byte) error {
+	fw.walletMu.Lock()
+	defer fw.walletMu.Unlock()
+	if fw.startupPassphrase == nil || !bytes.Equal(passphrase, fw.startupPassphrase) {
+		return errors.New(dcrlibwallet.ErrInvalidPassphrase)
+	}
+	return nil
+}
+
+func (fw *FakeWallet) SetStartupPassphrase(passphrase []byte) error {
+	fw.walletMu.Lock()
+	defer fw.walletMu.Unlock()
+	if fw.startupPassphrase != nil {
+		return errors.New(dcrlibwallet.ErrInvalid)
+	}
+	fw.startupPassphrase = append([]byte{}, passphrase...)
+	return nil
+}
+
+func (fw *FakeWallet) ChangeStartupPassphrase(oldPassphrase, newPassphrase []byte) error {
+	if err := fw.VerifyStartupPassphrase(oldPassphrase); err != nil {
+		return err
+	}
+	fw.walletMu.Lock()
+	defer fw.walletMu.Unlock()
+	fw.startupPassphrase = append([]byte{}, newPassphrase...)
+	return nil
+}
+
+func (fw *FakeWallet) RemoveStartupPassphrase(passphrase []byte) error {
+	if err := fw.VerifyStartupPassphrase(passphrase); err != nil {
+		return err
+	}
+	fw.walletMu.Lock()
+	defer fw.walletMu.Unlock()
+	fw.startupPassphrase = nil
+	return nil
+}
+
+func (fw *FakeWallet) SaveConfigValueForKey(key string, value interface{}) {
+	b, err := json.Marshal(value)
+	if err != nil {
+		log.Errorf("Error saving config value for %s: %v", key, err)
+		return
+	}
+	fw.walletMu.Lock()
+	defer fw.walletMu.Unlock()
+	fw.config[key] = b
+}
+
+// readConfigValue reads the value saved for key into valueOut, reporting
+// whether there is one.
+func (fw *FakeWallet) readConfigValue(key string, valueOut interface{}) bool {
+	fw.walletMu.Lock()
+	defer fw.walletMu.Unlock()
+	b, ok := fw.config[key]
+	return ok && json.Unmarshal(b, valueOut) == nil
+}
+
+func (fw *FakeWallet) ReadBoolConfigValueForKey(key string) bool {
+	var value bool
+	fw.readConfigValue(key, &value)
+	return value
+}
+
+func (fw *FakeWallet) ReadStringConfigValueForKey(key string) string {
+	var value string
+	fw.readConfigValue(key, &value)
+	return value
+}
+
+func (fw *FakeWallet) ReadIntConfigValueForKey(key string, defaultValue int) int {
+	value := defaultValue
+	fw.readConfigValue(key, &value)
+	return value
+}
+
+func (fw *FakeWallet) RemoveUserConfigValueForKey(key string) {
+	fw.walletMu.Lock()
+	defer fw.walletMu.Unlock()
+	delete(fw.config, key)
+}
+
+// ReadUserConfigValues returns the values saved for keys, whatever their
+// type. Keys without a saved value are left out.
+func (fw *FakeWallet) ReadUserConfigValues(keys []string) map[string]interface{} {
+	values := make(map[string]interface{})
+	for _, key := range keys {
+		var value interface{}
+		if fw.readConfigValue(key, &value) {
+			values[key] = value
+		}
+	}
+	return values
+}
+
+// VoteChoices returns the choices of a wallet by agenda ID or treasury key.
+func (fw *FakeWallet) VoteChoices(walletID int) (map[string]string, error) {
+	fw.walletMu.Lock()
+	defer fw.walletMu.Unlock()
+	wall, err := fw.wallet(walletID)
+	if err != nil {
+		return nil, err
+	}
+	choices := make(map[string]string, len(wall.voteChoices))
+	for agenda, choice := range wall.voteChoices {
+		choices[agenda] = choice
+	}
+	return choices, nil
+}
+
+// SetVoteChoice saves the choice of a wallet on an agenda.
+func (fw *FakeWallet) SetVoteChoice(walletID int, agendaID, choiceID string) error {
+	fw.walletMu.Lock()
+	defer fw.walletMu.Unlock()
+	wall, err := fw.wallet(walletID)
+	if err != nil {
+		return err
+	}
+	wall.voteChoices[agendaID] = choiceID
+	return nil
+}
+
+func (fw *FakeWallet) RememberVSP(host string) {
+	fw.SaveConfigValueForKey(dcrlibwallet.VSPHostConfigKey, host)
+}
+
+func (fw *FakeWallet) GetRememberVSP() string {
+	return fw.ReadStringConfigValueForKey(dcrlibwallet.VSPHostConfigKey)
+}
+
+// WatchedProposals returns the tokens of the proposals the user watches.
+func (fw *FakeWallet) WatchedProposals() map[string]bool {
+	watched := make(map[string]bool)
+	fw.readConfigValue(watchedProposalsConfigKey, &watched)
+	return watched
+}
+
+// WatchProposal adds the proposal with token to the watched proposals, or
+// removes it if watch is false.
+func (fw *FakeWallet) WatchProposal(token string, watch bool) {
+	watched := fw.WatchedProposals()
+	if watch {
+		watched[token] = true
+	} else {
+		delete(watched, token)
+	}
+	fw.SaveConfigValueForKey(watchedProposalsConfigKey, watched)
+}
+
+// CreateTransaction sends a TxAuthor that spends from an account of a wallet.
+// It is non-blocking and sends its result or any error to fw.Send.
+func (fw *FakeWallet) CreateTransaction(walletID int, accountID int32, errChan chan error) {
+	go func() {
+		defer crash.Recover("wallet CreateTransaction")
+		if _, err := fw.AccountName(walletID, accountID); err != nil {
+			errChan <- err
+			return
+		}
+		fw.Send <- Response{
+			Resp: &fakeTxAuthor{fw: fw, walletID: walletID, account: accountID},
+		}
+	}()
+}
+
+func (fw *FakeWallet) GetBlockExplorerURL(txnHash string) string {
+	switch fw.Net {
+	case "testnet3":
+		return "https://testnet.dcrdata.org/tx/" + txnHash
+	case "mainnet":
+		return "https://explorer.dcrdata.org/tx/" + txnHash
+	default:
+		return ""
+	}
+}
+
+// WalletDirectory returns the temporary directory, as nothing is written to
+// disk.
+func (fw *FakeWallet) WalletDirectory() string {
+	return os.TempDir()
+}
+
+func (fw *FakeWallet) DataSize() string {
+	return fmt.Sprintf("%f GB", 0.0)
+}
+
+// The sizes of the parts of a fake transaction, used to estimate its fee.
+const (
+	fakeTxBaseSize   = 12
+	fakeTxInputSize  = 166
+	fakeTxOutputSize = 36
+)
+
+// fakeTxAuthor is the TxAuthor of FakeWallet. Its fee is the relay fee on the
+// estimated size.
+type fakeTxAuthor struct {
+	fw       *FakeWallet
+	walletID int
+	account  int32
+
+	destinations []fakeDestination
+	inputs       []string
+}
+
+type fakeDestination struct {
+	address string
+	amount  int64
+	sendMax bool
+}
+
+func (author *fakeTxAuthor) AddSendDestination(address string, atomAmount int64, sendMax bool) error {
+	if valid, _ := author.fw.IsAddressValid(address); !valid {
+		return errors.New(dcrlibwallet.ErrInvalidAddress)
+	}
+	author.destinations = append(author.destinations, fakeDestination{address, atomAmount, sendMax})
+	return nil
+}
+
+func (author *fakeTxAuthor) RemoveSendDestination(index int) {
+	if index >= 0 && index < len(author.destinations) {
+		author.destinations = append(author.destinations[:index], author.destinations[index+1:]...)
+	}
+}
+
+func (author *fakeTxAuthor) UseInputs(utxoKeys []string) error {
+	author.inputs = append([]string(nil), utxoKeys...)
+	return nil
+}
+
+// spendable returns the spendable balance of the account.
+func (author *fakeTxAuthor) spendable() int64 {
+	if author.account != 0 {
+		return 0
+	}
+	return author.fw.balance(author.walletID).Spendable
+}
+
+// fee returns the relay fee of the transaction with its change output.
+func (author *fakeTxAuthor) fee() (fee int64, size int) {
+	inputs := len(author.inputs)
+	if inputs == 0 {
+		inputs = 1
+	}
+	size = fakeTxBaseSize + inputs*fakeTxInputSize + (len(author.destinations)+1)*fakeTxOutputSize
+	return int64(txrules.FeeForSerializeSize(txrules.DefaultRelayFeePerKb, size)), size
+}
+
+func (author *fakeTxAuthor) EstimateFeeAndSize() (*dcrlibwallet.TxFeeAndSize, error) {
+	fee, size := author.fee()
+	var amount int64
+	for _, dest := range author.destinations {
+		if dest.sendMax {
+			amount = author.spendable() - fee
+			break
+		}
+		amount += dest.amount
+	}
+	change := author.spendable() - amount - fee
+	if change < 0 {
+		return nil, errors.New(dcrlibwallet.ErrInsufficientBalance)
+	}
+	return &dcrlibwallet.TxFeeAndSize{
+		Fee:                 fakeAmount(fee),
+		Change:              fakeAmount(change),
+		EstimatedSignedSize: size,
+	}, nil
+}
+
+func (author *fakeTxAuthor) EstimateMaxSendAmount() (*dcrlibwallet.Amount, error) {
+	fee, _ := author.fee()
+	max := author.spendable() - fee
+	if max < 0 {
+		return nil, errors.New(dcrlibwallet.ErrInsufficientBalance)
+	}
+	return fakeAmount(max), nil
+}
+
+func fakeAmount(atoms int64) *dcrlibwallet.Amount {
+	return &dcrlibwallet.Amount{AtomValue: atoms, DcrValue: dcrutil.Amount(atoms).ToCoin()}
+}
@@ -0,0 +1,238 @@
+package wallet
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// proposalCacheLimit is the most bytes of proposals kept on disk. The
+// proposal versions read least recently are removed past it.
+const proposalCacheLimit = 64 << 20
+
+// proposalCache keeps the versions of proposals and the comments on them
+// fetched from Politeia on disk, as Politeia sent them, so that they can be
+// read offline. A published version of a proposal never changes, unlike its
+// comments.
+type proposalCache struct {
+	mu  sync.Mutex
+	dir string
+	// limit is the most bytes the cached versions may take.
+	limit int64
+}
+
+// path returns the path of the file of a version of the proposal with token.
+func (cache *proposalCache) path(token string, version int) string {
+	return filepath.Join(cache.dir, token, strconv.Itoa(version)+".json")
+}
+
+// commentsPath returns the path of the file of the comments on the proposal
+// with token.
+func (cache *proposalCache) commentsPath(token string) string {
+	return filepath.Join(cache.dir, token, "comments.json")
+}
+
+// get returns the cached version of the proposal with token, marking it as
+// just read.
+func (cache *proposalCache) get(token string, version int) (*ProposalContent, bool) {
+	cache.mu.Lock()
+	defer cache.mu.Unlock()
+
+	path := cache.path(token, version)
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, false
+	}
+	content, err := parseProposalDetails(token, version, b)
+	if err != nil {
+		log.Errorf("Removing unreadable cached proposal %s: %v", path, err)
+		os.Remove(path)
+		return nil, false
+	}
+	now := time.Now()
+	os.Chtimes(path, now, now)
+	return content, true
+}
+
+// latest returns the latest cached version of the proposal with token.
+func (cache *proposalCache) latest(token string) (*ProposalContent, bool) {
+	versions := cache.versions(token)
+	if len(versions) == 0 {
+		return nil, false
+	}
+	return cache.get(token, versions[len(versions)-1])
+}
+
+// versions returns the cached versions of the proposal with token, in order.
+func (cache *proposalCache) versions(token string) []int {
+	cache.mu.Lock()
+	defer cache.mu.Unlock()
+
+	files, _ := ioutil.ReadDir(filepath.Join(cache.dir, token))
+	var versions []int
+	for _, file := range files {
+		name := file.Name()
+		if version, err := strconv.Atoi(name[:len(name)-len(filepath.Ext(name))]); err == nil {
+			versions = append(versions, version)
+		}
+	}
+	sort.Ints(versions)
+	return versions
+}
+
+// tokens returns the tokens of the proposals with cached versions.
+func (cache *proposalCache) tokens() []string {
+	cache.mu.Lock()
+	defer cache.mu.Unlock()
+
+	dirs, _ := ioutil.ReadDir(cache.dir)
+	var tokens []string
+	for _, dir := range dirs {
+		if dir.IsDir() {
+			tokens = append(tokens, dir.Name())
+		}
+	}
+	return tokens
+}
+
+// put caches a version of the proposal with token as Politeia sent it and
+// removes the versions read least recently past the limit.
+func (cache *proposalCache) put(token string, version int, details []byte) error {
+	cache.mu.Lock()
+	defer cache.mu.Unlock()
+
+	return cache.write(cache.path(token, version), details)
+}
+
+// comments returns the cached comments on the proposal with token as Politeia
+// sent them, marking them as just read.
+func (cache *proposalCache) comments(token string) ([]byte, bool) {
+	cache.mu.Lock()
+	defer cache.mu.Unlock()
+
+	path := cache.commentsPath(token)
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, false
+	}
+	now := time.Now()
+	os.Chtimes(path, now, now)
+	return b, true
+}
+
+// putComments caches the comments on the proposal with token as Politeia sent
+// them, replacing those cached before.
+func (cache *proposalCache) putComments(token string, comments []byte) error {
+	cache.mu.Lock()
+	defer cache.mu.Unlock()
+	return cache.write(cache.commentsPath(token), comments)
+}
+
+// write writes b to the file at path and removes the files read least
+// recently past the limit.
+func (cache *proposalCache) write(path string, b []byte) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return err
+	}
+	// write to a temporary file first so that a file is never read half
+	// written
+	tmp := path + ".tmp"
+	if err := ioutil.WriteFile(tmp, b, 0600); err != nil {
+		return err
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		return err
+	}
+	return cache.evict(path)
+}
+
+// evict removes the files read least recently until the cache is within its
+// limit, keeping the file at keep.
+func (cache *proposalCache) evict(keep string) error {
+	type cached struct {
+		path    string
+		size    int64
+		modTime time.Time
+	}
+	var files []cached
+	var size int64
+	err := filepath.Walk(cache.dir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if !info.IsDir() {
+			files = append(files, cached{path, info.Size(), info.ModTime()})
+			size += info.Size()
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	sort.Slice(files, func(i, j int) bool {
+		return files[i].modTime.Before(files[j].modTime)
+	})
+	for _, file := range files {
+		if size <= cache.limit {
+			break
+		}
+		if file.path == keep {
+			continue
+		}
+		if err := os.Remove(file.path); err != nil {
+			return err
+		}
+		size -= file.size
+		// the directory of a proposal is only removed once it is empty
+		os.Remove(filepath.Dir(file.path))
+	}
+	return nil
+}
+
+// parseProposalDetails returns the content of a version of the proposal with
+// token from the details Politeia sent.
+func parseProposalDetails(token string, version int, b []byte) (*ProposalContent, error) {
+	var details politeiaProposalDetails
+	if err := json.Unmarshal(b, &details); err != nil {
+		return nil, err
+	}
+	if details.Proposal.Version != strconv.Itoa(version) {
+		return nil, fmt.Errorf("Politeia sent version %s of proposal %s instead of %d",
+			details.Proposal.Version, token, version)
+	}
+
+	content := &ProposalContent{
+		Token:     token,
+		Version:   version,
+		Timestamp: time.Unix(details.Proposal.Timestamp, 0),
+	}
+	var described bool
+	for _, file := range details.Proposal.Files {
+		data, err := base64.StdEncoding.DecodeString(file.Payload)
+		if err != nil {
+			return nil, err
+		}
+		if file.Name == proposalIndexFile {
+			content.Description = string(data)
+			described = true
+			continue
+		}
+		content.Attachments = append(content.Attachments, ProposalAttachment{
+			Name: file.Name,
+			MIME: file.MIME,
+			Data: data,
+		})
+	}
+	if !described {
+		return nil, fmt.Errorf("version %d of proposal %s has no %s", version, token, proposalIndexFile)
+	}
+	return content, nil
+}
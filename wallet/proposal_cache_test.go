@@ -0,0 +1,172 @@
+package wallet
+
+import (
+	"encoding/base64"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+// testToken is the token of a proposal in the specs.
+const testToken = "27f87171d98b7923a1bd2bee6affed929fa2d2a6e178b5c80a9971a92a5c7f50"
+
+// proposalDetails returns the details Politeia sends for a version of a
+// proposal with files.
+func proposalDetails(version string, files ...string) []byte {
+	var reported []string
+	for i := 0; i+1 < len(files); i += 2 {
+		reported = append(reported, fmt.Sprintf(`{"name":%q,"mime":"text/plain","payload":%q}`,
+			files[i], base64.StdEncoding.EncodeToString([]byte(files[i+1]))))
+	}
+	return []byte(fmt.Sprintf(`{"proposal":{"version":%q,"timestamp":1600000000,"files":[%s]}}`,
+		version, strings.Join(reported, ",")))
+}
+
+// newTestCacheDir returns a directory for a proposal cache.
+func newTestCacheDir() string {
+	dir, err := ioutil.TempDir("", "godcr-proposals")
+	Expect(err).To(BeNil())
+	return dir
+}
+
+var _ = Describe("Proposal details", func() {
+	It("reads the description and attachments", func() {
+		content, err := parseProposalDetails(testToken, 2,
+			proposalDetails("2", "budget.csv", "1,2", proposalIndexFile, "# Proposal", "plan.txt", "plan"))
+		Expect(err).To(BeNil())
+		Expect(content.Token).To(Equal(testToken))
+		Expect(content.Version).To(Equal(2))
+		Expect(content.Timestamp.Unix()).To(BeEquivalentTo(1600000000))
+		Expect(content.Description).To(Equal("# Proposal"))
+		Expect(content.Attachments).To(HaveLen(2))
+		Expect(content.Attachments[0].Name).To(Equal("budget.csv"))
+		Expect(content.Attachments[1].Name).To(Equal("plan.txt"))
+	})
+
+	It("rejects another version", func() {
+		_, err := parseProposalDetails(testToken, 2, proposalDetails("1", proposalIndexFile, "# Proposal"))
+		Expect(err).NotTo(BeNil())
+	})
+
+	It("rejects a proposal without a description", func() {
+		_, err := parseProposalDetails(testToken, 2, proposalDetails("2", "budget.csv", "1,2"))
+		Expect(err).NotTo(BeNil())
+	})
+
+	It("rejects malformed details", func() {
+		_, err := parseProposalDetails(testToken, 2, []byte(`{"proposal":{"version":"2","files":[{"name":"index.md","payload":"!"}]}}`))
+		Expect(err).NotTo(BeNil())
+		_, err = parseProposalDetails(testToken, 2, []byte(`{"proposal":`))
+		Expect(err).NotTo(BeNil())
+	})
+})
+
+var _ = Describe("Proposal tokens", func() {
+	It("accepts a hex censorship token", func() {
+		Expect(checkProposalToken(testToken)).To(Succeed())
+	})
+
+	It("rejects anything else", func() {
+		for _, token := range []string{"", ".", "..", "../" + testToken, testToken[:62], testToken + "00", "zz" + testToken[2:]} {
+			Expect(checkProposalToken(token)).NotTo(Succeed(), token)
+		}
+	})
+})
+
+var _ = Describe("Proposal cache", func() {
+	var cache *proposalCache
+
+	BeforeEach(func() {
+		cache = &proposalCache{dir: newTestCacheDir(), limit: proposalCacheLimit}
+	})
+
+	AfterEach(func() {
+		os.RemoveAll(cache.dir)
+	})
+
+	// cacheRead puts details in the cache as version 2 of testToken, read an
+	// hour ago.
+	cacheRead := func(details []byte) string {
+		Expect(cache.put(testToken, 2, details)).To(BeNil())
+		path := cache.path(testToken, 2)
+		old := time.Now().Add(-time.Hour)
+		Expect(os.Chtimes(path, old, old)).To(BeNil())
+		return path
+	}
+
+	It("reads a cached version and marks it as just read", func() {
+		path := cacheRead(proposalDetails("2", proposalIndexFile, "# Proposal"))
+		content, found := cache.get(testToken, 2)
+		Expect(found).To(BeTrue())
+		Expect(content.Description).To(Equal("# Proposal"))
+		info, err := os.Stat(path)
+		Expect(err).To(BeNil())
+		Expect(info.ModTime()).To(BeTemporally(">", time.Now().Add(-time.Minute)))
+	})
+
+	It("misses a version that is not cached", func() {
+		_, found := cache.get(testToken, 2)
+		Expect(found).To(BeFalse())
+	})
+
+	It("removes a cached version it cannot read", func() {
+		path := cacheRead([]byte(`{"proposal":`))
+		_, found := cache.get(testToken, 2)
+		Expect(found).To(BeFalse())
+		_, err := os.Stat(path)
+		Expect(os.IsNotExist(err)).To(BeTrue())
+	})
+
+	It("removes a cached version that is not the one asked for", func() {
+		path := cacheRead(proposalDetails("1", proposalIndexFile, "# Proposal"))
+		_, found := cache.get(testToken, 2)
+		Expect(found).To(BeFalse())
+		_, err := os.Stat(path)
+		Expect(os.IsNotExist(err)).To(BeTrue())
+	})
+
+	Describe("over its limit", func() {
+		// the files are named by their token and are each 10 bytes, read
+		// in the order a, b, c
+		BeforeEach(func() {
+			read := time.Now().Add(-time.Hour)
+			for _, token := range []string{"a", "b", "c"} {
+				path := cache.path(token, 1)
+				Expect(os.MkdirAll(filepath.Dir(path), 0700)).To(BeNil())
+				Expect(ioutil.WriteFile(path, make([]byte, 10), 0600)).To(BeNil())
+				read = read.Add(time.Minute)
+				Expect(os.Chtimes(path, read, read)).To(BeNil())
+			}
+		})
+
+		It("keeps everything within the limit", func() {
+			cache.limit = 30
+			Expect(cache.evict("")).To(BeNil())
+			Expect(cache.tokens()).To(Equal([]string{"a", "b", "c"}))
+		})
+
+		It("removes the files read least recently", func() {
+			cache.limit = 15
+			Expect(cache.evict("")).To(BeNil())
+			Expect(cache.tokens()).To(Equal([]string{"c"}))
+		})
+
+		It("keeps the file just written", func() {
+			cache.limit = 15
+			Expect(cache.evict(cache.path("a", 1))).To(BeNil())
+			Expect(cache.tokens()).To(Equal([]string{"a"}))
+		})
+
+		It("removes no more than it needs to", func() {
+			cache.limit = 25
+			Expect(cache.evict(cache.path("b", 1))).To(BeNil())
+			Expect(cache.tokens()).To(Equal([]string{"b", "c"}))
+		})
+	})
+})
@@ -0,0 +1,139 @@
+package wallet
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/planetdecred/godcr/crash"
+)
+
+// politeiaComments are the comments on a proposal reported by Politeia.
+type politeiaComments struct {
+	Comments []struct {
+		CommentID   string `json:"commentid"`
+		ParentID    string `json:"parentid"`
+		Comment     string `json:"comment"`
+		Username    string `json:"username"`
+		Timestamp   int64  `json:"timestamp"`
+		ResultVotes int64  `json:"resultvotes"`
+		Censored    bool   `json:"censored"`
+	} `json:"comments"`
+}
+
+// parseProposalComments returns the comments Politeia sent, without their
+// replies.
+func parseProposalComments(b []byte) ([]*ProposalComment, error) {
+	var reported politeiaComments
+	if err := json.Unmarshal(b, &reported); err != nil {
+		return nil, err
+	}
+
+	comments := make([]*ProposalComment, len(reported.Comments))
+	for i, c := range reported.Comments {
+		comments[i] = &ProposalComment{
+			ID:        c.CommentID,
+			Author:    c.Username,
+			Timestamp: time.Unix(c.Timestamp, 0),
+			Score:     c.ResultVotes,
+			Text:      c.Comment,
+			Censored:  c.Censored,
+		}
+		// Politeia gives the comments on the proposal the parent 0
+		if c.ParentID != "0" {
+			comments[i].ParentID = c.ParentID
+		}
+	}
+	return comments, nil
+}
+
+// commentTree adds the replies of comments to the comments they reply to, in
+// the order they were made, and returns the comments that are not replies.
+// Replies to comments that are missing are treated as comments on the
+// proposal.
+func commentTree(comments []*ProposalComment) []*ProposalComment {
+	byID := make(map[string]*ProposalComment, len(comments))
+	for _, c := range comments {
+		c.Replies = nil
+		byID[c.ID] = c
+	}
+
+	var tree []*ProposalComment
+	for _, c := range comments {
+		if parent, ok := byID[c.ParentID]; ok && c.ParentID != "" && parent != c {
+			parent.Replies = append(parent.Replies, c)
+			continue
+		}
+		tree = append(tree, c)
+	}
+
+	sortByTime := func(comments []*ProposalComment) {
+		sort.SliceStable(comments, func(i, j int) bool {
+			return comments[i].Timestamp.Before(comments[j].Timestamp)
+		})
+	}
+	sortByTime(tree)
+	for _, c := range comments {
+		sortByTime(c.Replies)
+	}
+	return tree
+}
+
+// fetchProposalComments fetches the comments on the proposal with token from
+// Politeia and caches them.
+func (wal *Wallet) fetchProposalComments(token string) ([]*ProposalComment, error) {
+	url := fmt.Sprintf("%s/api/v1/proposals/%s/comments", politeiaHost, token)
+	b, err := getRaw(politeiaHTTPClient, url)
+	if err != nil {
+		return nil, err
+	}
+	comments, err := parseProposalComments(b)
+	if err != nil {
+		return nil, err
+	}
+	if err := wal.proposals.putComments(token, b); err != nil {
+		log.Errorf("Error caching the comments on proposal %s: %v", token, err)
+	}
+	return comments, nil
+}
+
+// ProposalComments returns the comments on the proposal with token. They are
+// fetched from Politeia, or read from the cache when it cannot be reached.
+func (wal *Wallet) ProposalComments(token string) (*ProposalComments, error) {
+	if err := checkProposalToken(token); err != nil {
+		return nil, err
+	}
+
+	comments, err := wal.fetchProposalComments(token)
+	if err != nil {
+		b, ok := wal.proposals.comments(token)
+		if !ok {
+			return nil, err
+		}
+		log.Errorf("Error fetching the comments on proposal %s: %v", token, err)
+		if comments, err = parseProposalComments(b); err != nil {
+			return nil, err
+		}
+	}
+
+	return &ProposalComments{
+		Token:    token,
+		Comments: commentTree(comments),
+		Count:    len(comments),
+	}, nil
+}
+
+// GetProposalComments gets the comments on the proposal with token.
+// It is non-blocking and sends its result to wal.Send, with any error in the
+// Err of the ProposalComments so that the page waiting for them can tell.
+func (wal *Wallet) GetProposalComments(token string) {
+	go func() {
+		defer crash.Recover("wallet GetProposalComments")
+		comments, err := wal.ProposalComments(token)
+		if err != nil {
+			comments = &ProposalComments{Token: token, Err: err}
+		}
+		wal.Send <- ResponseResp(comments)
+	}()
+}
@@ -0,0 +1,155 @@
+package wallet
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+// printCommentTree returns the IDs of comments with the IDs of their replies
+// in brackets after them.
+func printCommentTree(comments []*ProposalComment) string {
+	s := "["
+	for i, c := range comments {
+		if i > 0 && len(comments[i-1].Replies) == 0 {
+			s += " "
+		}
+		s += c.ID
+		if len(c.Replies) > 0 {
+			s += printCommentTree(c.Replies)
+		}
+	}
+	return s + "]"
+}
+
+var _ = Describe("Proposal comments", func() {
+	It("reads the comments Politeia sends", func() {
+		comments, err := parseProposalComments([]byte(`{"comments":[` +
+			`{"commentid":"1","parentid":"0","comment":"Yes","username":"alice","timestamp":1600000000,"resultvotes":-2},` +
+			`{"commentid":"2","parentid":"1","comment":"No","username":"bob","timestamp":1600000000,"censored":true}]}`))
+		Expect(err).To(BeNil())
+		Expect(comments).To(HaveLen(2))
+		Expect(comments[0].Timestamp.Unix()).To(BeEquivalentTo(1600000000))
+		comments[0].Timestamp, comments[1].Timestamp = time.Time{}, time.Time{}
+		Expect(*comments[0]).To(Equal(ProposalComment{ID: "1", Author: "alice", Score: -2, Text: "Yes"}))
+		Expect(*comments[1]).To(Equal(ProposalComment{ID: "2", ParentID: "1", Author: "bob", Text: "No", Censored: true}))
+
+		comments, err = parseProposalComments([]byte(`{"comments":[]}`))
+		Expect(err).To(BeNil())
+		Expect(comments).To(BeEmpty())
+		_, err = parseProposalComments([]byte(`{"comments":`))
+		Expect(err).NotTo(BeNil())
+	})
+
+	Describe("threading", func() {
+		type made struct {
+			id, parentID string
+			at           int64
+		}
+		tree := func(comments ...made) string {
+			var threaded []*ProposalComment
+			for _, c := range comments {
+				threaded = append(threaded, &ProposalComment{ID: c.id, ParentID: c.parentID, Timestamp: time.Unix(c.at, 0)})
+			}
+			return printCommentTree(commentTree(threaded))
+		}
+
+		It("orders the comments by when they were made", func() {
+			Expect(tree(made{"1", "", 20}, made{"2", "", 10})).To(Equal("[2 1]"))
+		})
+
+		It("adds the replies under their comment in order", func() {
+			Expect(tree(made{"1", "", 10}, made{"3", "1", 30}, made{"2", "1", 20})).To(Equal("[1[2 3]]"))
+			Expect(tree(made{"1", "", 10}, made{"2", "1", 20}, made{"3", "2", 30})).To(Equal("[1[2[3]]]"))
+		})
+
+		It("keeps the replies to comments it does not have", func() {
+			Expect(tree(made{"1", "", 20}, made{"2", "9", 10})).To(Equal("[2 1]"))
+			Expect(tree(made{"1", "1", 10})).To(Equal("[1]"))
+		})
+	})
+
+	Describe("from Politeia", func() {
+		var (
+			wal      *Wallet
+			server   *httptest.Server
+			reply    string
+			requests int
+			restore  func()
+		)
+
+		BeforeEach(func() {
+			reply, requests = "", 0
+			server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				requests++
+				if r.URL.Path != "/api/v1/proposals/"+testToken+"/comments" {
+					http.NotFound(w, r)
+					return
+				}
+				if reply == "" {
+					http.Error(w, "unavailable", http.StatusServiceUnavailable)
+					return
+				}
+				w.Write([]byte(reply))
+			}))
+			client, host := politeiaHTTPClient, politeiaHost
+			politeiaHTTPClient, politeiaHost = server.Client(), server.URL
+			restore = func() {
+				politeiaHTTPClient, politeiaHost = client, host
+			}
+			wal = &Wallet{proposals: proposalCache{dir: newTestCacheDir(), limit: proposalCacheLimit}}
+		})
+
+		AfterEach(func() {
+			restore()
+			server.Close()
+			os.RemoveAll(wal.proposals.dir)
+		})
+
+		It("fails with nothing cached", func() {
+			_, err := wal.ProposalComments(testToken)
+			Expect(err).NotTo(BeNil())
+		})
+
+		It("reads the cached comments when Politeia cannot be reached", func() {
+			reply = `{"comments":[{"commentid":"1","parentid":"0","timestamp":10},{"commentid":"2","parentid":"1","timestamp":20}]}`
+			comments, err := wal.ProposalComments(testToken)
+			Expect(err).To(BeNil())
+			Expect(comments.Token).To(Equal(testToken))
+			Expect(comments.Count).To(Equal(2))
+			Expect(printCommentTree(comments.Comments)).To(Equal("[1[2]]"))
+
+			reply = ""
+			comments, err = wal.ProposalComments(testToken)
+			Expect(err).To(BeNil())
+			Expect(printCommentTree(comments.Comments)).To(Equal("[1[2]]"))
+			Expect(requests).To(Equal(2))
+		})
+
+		It("replaces the cached comments", func() {
+			reply = `{"comments":[{"commentid":"1","parentid":"0","timestamp":10}]}`
+			wal.ProposalComments(testToken)
+			reply = `{"comments":[{"commentid":"3","parentid":"0","timestamp":30}]}`
+			wal.ProposalComments(testToken)
+
+			reply = `{"comments":`
+			comments, err := wal.ProposalComments(testToken)
+			Expect(err).To(BeNil())
+			Expect(comments.Count).To(Equal(1))
+			Expect(printCommentTree(comments.Comments)).To(Equal("[3]"))
+		})
+
+		It("rejects a token that is a path", func() {
+			reply = `{"comments":[]}`
+			for _, token := range []string{"../" + testToken, ".", ".."} {
+				_, err := wal.ProposalComments(token)
+				Expect(err).NotTo(BeNil())
+			}
+			Expect(requests).To(Equal(0))
+		})
+	})
+})
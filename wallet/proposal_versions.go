@@ -0,0 +1,209 @@
+package wallet
+
+import (
+	"encoding/hex"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+	"time"
+
+	"golang.org/x/sync/errgroup"
+
+	"github.com/planetdecred/dcrlibwallet"
+	"github.com/planetdecred/godcr/crash"
+)
+
+// politeiaHTTPClient is the client of the requests to Politeia for what the
+// proposal sync of dcrlibwallet does not keep.
+var politeiaHTTPClient = &http.Client{Timeout: 20 * time.Second}
+
+// politeiaHost is the Politeia server asked for what the proposal sync of
+// dcrlibwallet does not keep.
+var politeiaHost = dcrlibwallet.PoliteiaMainnetHost
+
+// getRaw returns the body of the response to a GET request to url made with
+// client.
+func getRaw(client *http.Client, url string) ([]byte, error) {
+	resp, err := client.Get(url)
+	if err != nil {
+		return nil, err
+	}
+
+	b, err := ioutil.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("non 200 response from server: %v", string(b))
+	}
+	return b, nil
+}
+
+// proposalIndexFile is the file of a proposal with its description.
+const proposalIndexFile = "index.md"
+
+// proposalTokenSize is the size of the censorship token of a proposal, which
+// Politeia encodes in hex.
+const proposalTokenSize = 32
+
+// checkProposalToken returns an error unless token is the hex censorship token
+// of a proposal. Checked tokens are safe to use in cache file names and URLs.
+func checkProposalToken(token string) error {
+	if b, err := hex.DecodeString(token); err != nil || len(b) != proposalTokenSize {
+		return fmt.Errorf("invalid proposal token %q", token)
+	}
+	return nil
+}
+
+// politeiaProposalDetails is a version of a proposal reported by Politeia.
+type politeiaProposalDetails struct {
+	Proposal struct {
+		Version   string `json:"version"`
+		Timestamp int64  `json:"timestamp"`
+		Files     []struct {
+			Name    string `json:"name"`
+			MIME    string `json:"mime"`
+			Payload string `json:"payload"`
+		} `json:"files"`
+	} `json:"proposal"`
+}
+
+// proposalContent returns a version of the proposal with token from the cache,
+// or fetches it from Politeia and caches it.
+func (wal *Wallet) proposalContent(token string, version int) (*ProposalContent, error) {
+	if err := checkProposalToken(token); err != nil {
+		return nil, err
+	}
+	if content, ok := wal.proposals.get(token, version); ok {
+		return content, nil
+	}
+
+	url := fmt.Sprintf("%s/api/v1/proposals/%s?version=%d", politeiaHost, token, version)
+	b, err := getRaw(politeiaHTTPClient, url)
+	if err != nil {
+		return nil, err
+	}
+	content, err := parseProposalDetails(token, version, b)
+	if err != nil {
+		return nil, err
+	}
+	if err := wal.proposals.put(token, version, b); err != nil {
+		log.Errorf("Error caching version %d of proposal %s: %v", version, token, err)
+	}
+	return content, nil
+}
+
+// ProposalVersions returns every version of the proposal with token, oldest
+// first. The versions that are not cached are fetched from Politeia.
+func (wal *Wallet) ProposalVersions(token string) (*ProposalVersions, error) {
+	proposal, err := wal.multi.Politeia.GetProposalRaw(token)
+	if err != nil {
+		return nil, err
+	}
+	latest, err := strconv.Atoi(proposal.Version)
+	if err != nil {
+		return nil, fmt.Errorf("proposal %s has an invalid version %q", token, proposal.Version)
+	}
+
+	versions := make([]ProposalVersion, latest)
+	var g errgroup.Group
+	for i := range versions {
+		i := i
+		g.Go(func() error {
+			content, err := wal.proposalContent(token, i+1)
+			if err != nil {
+				return err
+			}
+			versions[i] = ProposalVersion{
+				Version:     content.Version,
+				Timestamp:   content.Timestamp,
+				Description: content.Description,
+			}
+			return nil
+		})
+	}
+	if err := g.Wait(); err != nil {
+		return nil, err
+	}
+
+	return &ProposalVersions{Token: token, Versions: versions}, nil
+}
+
+// GetProposalVersions gets every version of the proposal with token.
+// It is non-blocking and sends its result to wal.Send, with any error in the
+// Err of the ProposalVersions so that the page waiting for them can tell.
+func (wal *Wallet) GetProposalVersions(token string) {
+	go func() {
+		defer crash.Recover("wallet GetProposalVersions")
+		versions, err := wal.ProposalVersions(token)
+		if err != nil {
+			versions = &ProposalVersions{Token: token, Err: err}
+		}
+		wal.Send <- ResponseResp(versions)
+	}()
+}
+
+// FetchProposalContent returns the description and attachments of the latest
+// version of the proposal with token. When Politeia cannot be reached it falls
+// back to the latest cached version, then to the description saved by the
+// proposal sync.
+func (wal *Wallet) FetchProposalContent(token string) (*ProposalContent, error) {
+	proposal, err := wal.multi.Politeia.GetProposalRaw(token)
+	if err != nil {
+		return nil, err
+	}
+	latest, err := strconv.Atoi(proposal.Version)
+	if err != nil {
+		return nil, fmt.Errorf("proposal %s has an invalid version %q", token, proposal.Version)
+	}
+
+	content, err := wal.proposalContent(token, latest)
+	if err == nil {
+		return content, nil
+	}
+	log.Errorf("Error fetching version %d of proposal %s: %v", latest, token, err)
+	if content, ok := wal.proposals.latest(token); ok {
+		return content, nil
+	}
+	if proposal.IndexFile != "" {
+		version, _ := strconv.Atoi(proposal.IndexFileVersion)
+		return &ProposalContent{
+			Token:       token,
+			Version:     version,
+			Timestamp:   time.Unix(proposal.Timestamp, 0),
+			Description: proposal.IndexFile,
+		}, nil
+	}
+	return nil, err
+}
+
+// RefreshProposalCache caches the latest version of every cached proposal
+// that was updated since it was cached, and the comments on it if they were
+// cached, so that they can be read offline. It is non-blocking and logs its
+// errors.
+func (wal *Wallet) RefreshProposalCache() {
+	go func() {
+		defer crash.Recover("wallet RefreshProposalCache")
+		for _, token := range wal.proposals.tokens() {
+			proposal, err := wal.multi.Politeia.GetProposalRaw(token)
+			if err != nil {
+				continue
+			}
+			latest, err := strconv.Atoi(proposal.Version)
+			if err != nil {
+				continue
+			}
+			if _, err := wal.proposalContent(token, latest); err != nil {
+				log.Errorf("Error refreshing cached proposal %s: %v", token, err)
+			}
+			if _, ok := wal.proposals.comments(token); ok {
+				if _, err := wal.fetchProposalComments(token); err != nil {
+					log.Errorf("Error refreshing the cached comments on proposal %s: %v", token, err)
+				}
+			}
+		}
+	}()
+}
@@ -1,6 +1,8 @@
 package wallet
 
 import (
+	"time"
+
 	"github.com/planetdecred/dcrlibwallet"
 )
 
@@ -55,6 +57,16 @@ type InfoShort struct {
 	IsWatchingOnly   bool
 }
 
+// WalletSummary identifies a loaded wallet. It is a copy, so it is not updated
+// when the wallet is renamed or its seed is backed up.
+type WalletSummary struct {
+	ID   int
+	Name string
+	// EncryptedSeed is the seed of the wallet until it is backed up.
+	EncryptedSeed  []byte
+	IsWatchingOnly bool
+}
+
 // Account represents information about a wallet's account
 type Account struct {
 	Number           int32
@@ -79,48 +91,17 @@ type AddedAccount struct {
 	SpendableBalance int64
 }
 
-// UpdatedAccount is sent when the wallet is done updated an account
-type UpdatedAccount struct {
-	ID int32
-}
-
 // LoadedWallets is sent when then the Wallet is done loading wallets
 type LoadedWallets struct {
 	Count              int32
 	StartUpSecuritySet bool
 }
 
-// Restored is sent when the Wallet is done restoring a wallet
-type Restored struct{}
-
-// StartUpPassphrase is sent when the startup passphrase is set
-type StartupPassphrase struct {
-	Msg string
-}
-
-// OpenWallet is sent when the startup passphrase is set
-type OpenWallet struct{}
-
-// Renamed is sent when the Wallet is done renaming a wallet
-type Renamed struct {
-	ID int
-}
-
 // CreatedSeed is sent when the Wallet is done creating a wallet
 type CreatedSeed struct {
 	Seed string
 }
 
-// DeletedWallet is sent when a wallet is deleted
-type DeletedWallet struct {
-	ID int
-}
-
-// ChangePassword is sent when the Wallet password is changed
-type ChangePassword struct {
-	ID int
-}
-
 // Transaction wraps the dcrlibwallet Transaction type and adds processed data
 type Transaction struct {
 	Txn           dcrlibwallet.Transaction
@@ -129,7 +110,6 @@ type Transaction struct {
 	WalletName    string
 	AccountName   string
 	Confirmations int32
-	DateTime      string
 }
 
 // Transactions is sent in response to Wallet.GetAllTransactions
@@ -154,18 +134,19 @@ type SyncStatus struct {
 	CurrentBlockHeight       int32
 }
 
-// Signature is sent in response to Wallet.SignMessage
-type Signature struct {
-	Signature string
-}
-
 // TxHash is sent when the Wallet successfully broadcasts a transaction
 type TxHash struct {
 	Hash string
 }
 
-type TxAuthor struct {
-	TxAuthor int
+// TxAuthor builds a transaction from the account it was created for. It is
+// sent in response to Wallet.CreateTransaction.
+type TxAuthor interface {
+	AddSendDestination(address string, atomAmount int64, sendMax bool) error
+	RemoveSendDestination(index int)
+	UseInputs(utxoKeys []string) error
+	EstimateFeeAndSize() (*dcrlibwallet.TxFeeAndSize, error)
+	EstimateMaxSendAmount() (*dcrlibwallet.Amount, error)
 }
 
 // Broadcast is sent when the Wallet  broadcasts a transaction
@@ -174,9 +155,8 @@ type Broadcast struct {
 }
 
 type UnspentOutput struct {
-	UTXO     dcrlibwallet.UnspentOutput
-	Amount   string
-	DateTime string
+	UTXO   dcrlibwallet.UnspentOutput
+	Amount string
 }
 
 // UnspentOutputs wraps the dcrlibwallet UTXO type and adds processed data
@@ -191,16 +171,125 @@ type Ticket struct {
 	Info       dcrlibwallet.TicketInfo
 	Fee        string
 	Amount     string
-	DateTime   string
-	MonthDay   string
 	DaysBehind string
 	WalletName string
+	// VSP is the status of the ticket last reported by the VSPs, or nil if
+	// the VSPs were not asked about the ticket.
+	VSP *VSPTicketStatus
+	// FeeTx is the transaction paying the VSP fee of the ticket, if the
+	// wallet paid it.
+	FeeTx *dcrlibwallet.Transaction
+}
+
+// VSPFeeStatus is the state of the fee a ticket pays to its VSP.
+type VSPFeeStatus string
+
+const (
+	// VSPFeeUnknown is the status of tickets whose VSP is not known to the
+	// wallet, such as tickets bought through a stake pool or another wallet.
+	VSPFeeUnknown   VSPFeeStatus = ""
+	VSPFeeUnpaid    VSPFeeStatus = "unpaid"
+	VSPFeePaid      VSPFeeStatus = "paid"
+	VSPFeeConfirmed VSPFeeStatus = "confirmed"
+	VSPFeeErrored   VSPFeeStatus = "errored"
+)
+
+// VSPTicketStatus is what the VSP voting a ticket reports about it.
+type VSPTicketStatus struct {
+	// Host is the VSP that knows the ticket, or empty if none of the VSPs
+	// asked knows it.
+	Host      string
+	FeeStatus VSPFeeStatus
+	FeeTxHash string
+	// VoteChoices are the agenda choices and treasury key policies the VSP
+	// recorded, by agenda ID or treasury key.
+	VoteChoices map[string]string
+	// CheckedAt is the unix time the VSPs were asked about the ticket.
+	CheckedAt int64
+}
+
+// Voting returns whether a VSP knows the ticket and has been paid to vote it.
+func (status *VSPTicketStatus) Voting() bool {
+	return status.Host != "" && (status.FeeStatus == VSPFeePaid || status.FeeStatus == VSPFeeConfirmed)
+}
+
+// Agenda is a consensus rule change, or the treasury spends signed by a
+// Politeia key, that tickets vote on.
+type Agenda struct {
+	// ID is the agenda ID, or the hex public key of a treasury key.
+	ID          string
+	Description string
+	Choices     []AgendaChoice
+	// TreasuryKey is whether the agenda is the policy of a treasury key.
+	TreasuryKey bool
+	// StartTime and ExpireTime bound the voting on a consensus agenda.
+	StartTime, ExpireTime time.Time
+}
+
+// AgendaChoice is a choice of an agenda.
+type AgendaChoice struct {
+	ID          string
+	Description string
+	IsAbstain   bool
+	IsNo        bool
+}
+
+// VSPVoteStatus is how many of the tickets at a VSP it recorded the vote
+// choices of the wallet for.
+type VSPVoteStatus struct {
+	Host     string
+	Tickets  int
+	Recorded int
+}
+
+// TicketPriceForecast is the ticket price of the next block with where it is
+// heading, for choosing when to buy tickets.
+type TicketPriceForecast struct {
+	Price  int64
+	Height int32
+	// BlockTime is when the block at Height was mined.
+	BlockTime time.Time
+	// WindowSize is the number of blocks between changes of the price and
+	// BlocksLeft how many of them remain at Price, which should take about
+	// TimeLeft.
+	WindowSize int32
+	BlocksLeft int32
+	TimeLeft   time.Duration
+	// History is the price of the past windows the wallet saw, oldest first.
+	History []TicketPricePoint
+}
+
+// TicketPricePoint is the ticket price from the start of a window.
+type TicketPricePoint struct {
+	Time  time.Time
+	Price int64
+}
+
+// TicketDetails is the lifecycle of a ticket.
+type TicketDetails struct {
+	Ticket Ticket
+	// Purchase is the ticket purchase, and Spender its vote or revocation
+	// once the ticket is spent.
+	Purchase *dcrlibwallet.Transaction
+	Spender  *dcrlibwallet.Transaction
+	// FeeTx is the transaction paying the VSP fee of the ticket, if the
+	// wallet knows it.
+	FeeTx     *dcrlibwallet.Transaction
+	FeeStatus VSPFeeStatus
+	VSPHost   string
+	// MaturityHeight is the height the ticket becomes live at and
+	// ExpiryHeight the height it expires at if it has not been chosen to
+	// vote. Both are zero while the ticket is unmined.
+	MaturityHeight int32
+	ExpiryHeight   int32
+	// VoteChoices are the choices the ticket votes with on each agenda.
+	VoteChoices map[string]string
 }
 
 type UnconfirmedPurchase struct {
 	Hash        string
 	Status      string
-	DateTime    string
+	Timestamp   int64
 	BlockHeight int32
 	Amount      string
 }
@@ -235,7 +324,24 @@ type Balance struct {
 // CreateVSP is sent when the Wallet is done creating a new VSP
 type VSPInfo struct {
 	Host string
+	// Info is the last info the VSP sent, or nil if it never answered.
 	Info *dcrlibwallet.VspInfoResponse
+	// Added is whether the VSP was added by the user, which can remove it.
+	Added bool
+	// Latency is how long the VSP took to send its info when last checked.
+	Latency time.Duration
+	// Err is why the VSP could not be reached when last checked.
+	Err       error
+	CheckedAt time.Time
+	// PubKeyChanged is whether the VSP signs with another public key than
+	// the one pinned when it was first seen.
+	PubKeyChanged bool
+}
+
+// Usable returns whether tickets can be bought through the VSP: it answered
+// when last checked and its public key did not change.
+func (vsp VSPInfo) Usable() bool {
+	return vsp.Info != nil && vsp.Err == nil && !vsp.PubKeyChanged
 }
 
 // VSP is sent when the Wallet is done getting all VSP info
@@ -243,7 +349,78 @@ type VSP struct {
 	List []VSPInfo
 }
 
+// Usable returns the VSPs tickets can be bought through.
+func (vsp *VSP) Usable() []VSPInfo {
+	var usable []VSPInfo
+	for _, info := range vsp.List {
+		if info.Usable() {
+			usable = append(usable, info)
+		}
+	}
+	return usable
+}
+
 // Proposals is sent when all proposals has been fetched
 type Proposals struct {
 	Proposals []dcrlibwallet.Proposal
 }
+
+// ProposalVersion is the description of a proposal as of one of its versions.
+type ProposalVersion struct {
+	Version     int
+	Timestamp   time.Time
+	Description string
+}
+
+// ProposalVersions is sent with every version of a proposal, oldest first,
+// or with Err set if they could not be fetched.
+type ProposalVersions struct {
+	Token    string
+	Versions []ProposalVersion
+	Err      error
+}
+
+// ProposalAttachment is a file attached to a version of a proposal, like an
+// image its description embeds or a budget.
+type ProposalAttachment struct {
+	Name string
+	MIME string
+	Data []byte
+}
+
+// ProposalContent is the description and attachments of a version of a
+// proposal.
+type ProposalContent struct {
+	Token       string
+	Version     int
+	Timestamp   time.Time
+	Description string
+	Attachments []ProposalAttachment
+}
+
+// ProposalComment is a comment on a proposal, with its replies in the order
+// they were made.
+type ProposalComment struct {
+	ID string
+	// ParentID is the ID of the comment replied to, or empty for a comment
+	// on the proposal.
+	ParentID  string
+	Author    string
+	Timestamp time.Time
+	// Score is the number of upvotes of the comment less its downvotes.
+	Score    int64
+	Text     string
+	Censored bool
+	Replies  []*ProposalComment
+}
+
+// ProposalComments is sent with the comments on a proposal that are not
+// replies, in the order they were made, or with Err set if they could not be
+// fetched.
+type ProposalComments struct {
+	Token    string
+	Comments []*ProposalComment
+	// Count is the number of comments, replies included.
+	Count int
+	Err   error
+}
@@ -0,0 +1,123 @@
+package wallet
+
+import (
+	"time"
+
+	"github.com/planetdecred/dcrlibwallet/utils"
+	"github.com/planetdecred/godcr/crash"
+)
+
+// ticketPriceHistoryConfigKey is the config key of the ticket prices the
+// wallet saw.
+const ticketPriceHistoryConfigKey = "ticket_price_history"
+
+// ticketPriceHistoryLimit is how many windows of ticket prices are kept,
+// about two years of mainnet windows.
+const ticketPriceHistoryLimit = 1460
+
+// recordedTicketPrice is the ticket price of a window as saved in the config.
+type recordedTicketPrice struct {
+	Window int32
+	Time   int64
+	Price  int64
+}
+
+// newTicketPriceForecast returns the forecast of price, the ticket price of
+// the block after height, without the history.
+func newTicketPriceForecast(net string, price int64, height int32, blockTime time.Time) (*TicketPriceForecast, error) {
+	params, err := utils.ChainParams(net)
+	if err != nil {
+		return nil, err
+	}
+
+	window := int32(params.StakeDiffWindowSize)
+	left := window - (height+1)%window
+	return &TicketPriceForecast{
+		Price:      price,
+		Height:     height,
+		BlockTime:  blockTime,
+		WindowSize: window,
+		BlocksLeft: left,
+		TimeLeft:   time.Duration(left) * params.TargetTimePerBlock,
+	}, nil
+}
+
+// TicketPriceForecast returns the next ticket price of the wallets with the
+// price of the past windows. An SPV wallet does not keep the blocks the price
+// of the next window is worked out from, so it is not estimated, and the
+// history is of the windows the wallet saw once it was synced.
+func (wal *Wallet) TicketPriceForecast() (*TicketPriceForecast, error) {
+	price, err := wal.ticketPrice()
+	if err != nil {
+		return nil, err
+	}
+	blockTime := time.Unix(wal.multi.GetBestBlock().Timestamp, 0)
+	forecast, err := newTicketPriceForecast(wal.Net, price.TicketPrice, price.Height, blockTime)
+	if err != nil {
+		return nil, err
+	}
+	if wal.multi.IsSynced() {
+		wal.recordTicketPrice(forecast)
+	}
+	forecast.History = wal.ticketPriceHistory()
+	return forecast, nil
+}
+
+// recordTicketPrice saves the price of forecast for its window, unless it is
+// saved already.
+func (wal *Wallet) recordTicketPrice(forecast *TicketPriceForecast) {
+	wal.priceHistoryMu.Lock()
+	defer wal.priceHistoryMu.Unlock()
+
+	var history []recordedTicketPrice
+	wal.multi.ReadUserConfigValue(ticketPriceHistoryConfigKey, &history)
+	window := (forecast.Height + 1) / forecast.WindowSize
+	if len(history) > 0 && history[len(history)-1].Window >= window {
+		return
+	}
+
+	// the window started this many blocks before the block after
+	// forecast.Height
+	started := forecast.WindowSize - forecast.BlocksLeft
+	blockInterval := forecast.TimeLeft / time.Duration(forecast.BlocksLeft)
+	history = append(history, recordedTicketPrice{
+		Window: window,
+		Time:   forecast.BlockTime.Add(-time.Duration(started-1) * blockInterval).Unix(),
+		Price:  forecast.Price,
+	})
+	if len(history) > ticketPriceHistoryLimit {
+		history = history[len(history)-ticketPriceHistoryLimit:]
+	}
+	wal.multi.SaveUserConfigValue(ticketPriceHistoryConfigKey, history)
+}
+
+// ticketPriceHistory returns the saved ticket prices, oldest first.
+func (wal *Wallet) ticketPriceHistory() []TicketPricePoint {
+	wal.priceHistoryMu.Lock()
+	defer wal.priceHistoryMu.Unlock()
+
+	var history []recordedTicketPrice
+	wal.multi.ReadUserConfigValue(ticketPriceHistoryConfigKey, &history)
+	points := make([]TicketPricePoint, len(history))
+	for i, recorded := range history {
+		points[i] = TicketPricePoint{Time: time.Unix(recorded.Time, 0), Price: recorded.Price}
+	}
+	return points
+}
+
+// GetTicketPriceForecast gets the ticket price forecast.
+// It is non-blocking and sends its result or any error to wal.Send.
+func (wal *Wallet) GetTicketPriceForecast() {
+	go func() {
+		defer crash.Recover("wallet GetTicketPriceForecast")
+		forecast, err := wal.TicketPriceForecast()
+		if err != nil {
+			wal.Send <- ResponseError(MultiWalletError{
+				Message: "Could not get the ticket price forecast",
+				Err:     err,
+			})
+			return
+		}
+		wal.Send <- ResponseResp(forecast)
+	}()
+}
@@ -0,0 +1,65 @@
+package wallet
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Ticket price forecast", func() {
+	It("counts the blocks left at the price", func() {
+		forecast, err := newTicketPriceForecast("testnet3", 20000000000, 1000, time.Unix(1600000000, 0))
+		Expect(err).To(BeNil())
+		Expect(forecast.WindowSize).To(BeEquivalentTo(144))
+		Expect(forecast.BlocksLeft).To(BeEquivalentTo(7))
+		Expect(forecast.TimeLeft).To(Equal(7 * 2 * time.Minute))
+
+		// the block after 1007 starts a window
+		forecast, err = newTicketPriceForecast("testnet3", 20000000000, 1007, time.Unix(1600000000, 0))
+		Expect(err).To(BeNil())
+		Expect(forecast.BlocksLeft).To(BeEquivalentTo(144))
+	})
+
+	Describe("history", func() {
+		var wal *Wallet
+
+		BeforeEach(func() {
+			wal = newTestWallet()
+		})
+
+		AfterEach(func() {
+			removeTestWallet(wal)
+		})
+
+		// record records price as the price after the block at height,
+		// with the blocks two minutes apart.
+		record := func(height int32, price int64) {
+			forecast, err := newTicketPriceForecast(wal.Net, price, height, time.Unix(int64(height)*120, 0))
+			Expect(err).To(BeNil())
+			wal.recordTicketPrice(forecast)
+		}
+
+		It("records the price of each window from when the window started", func() {
+			record(1000, 20000000000)
+			record(1001, 20000000000)
+			record(1008, 21000000000)
+
+			history := wal.ticketPriceHistory()
+			Expect(history).To(HaveLen(2))
+			Expect(history[0]).To(Equal(TicketPricePoint{Time: time.Unix(864*120, 0), Price: 20000000000}))
+			Expect(history[1]).To(Equal(TicketPricePoint{Time: time.Unix(1008*120, 0), Price: 21000000000}))
+		})
+
+		It("records the price of a window about to start", func() {
+			record(1007, 21000000000)
+			Expect(wal.ticketPriceHistory()).To(Equal([]TicketPricePoint{{Time: time.Unix(1008*120, 0), Price: 21000000000}}))
+		})
+
+		It("does not record windows before the last one recorded", func() {
+			record(1008, 21000000000)
+			record(1000, 20000000000)
+			Expect(wal.ticketPriceHistory()).To(HaveLen(1))
+		})
+	})
+})
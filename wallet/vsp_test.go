@@ -0,0 +1,372 @@
+package wallet
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"decred.org/dcrwallet/wallet"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/planetdecred/dcrlibwallet"
+)
+
+// newTestWallet returns a wallet on testnet3 in a temporary directory, with
+// no wallets loaded.
+func newTestWallet() *Wallet {
+	dir, err := ioutil.TempDir("", "godcr-wallet")
+	Expect(err).To(BeNil())
+	wal, err := NewWallet(dir, "testnet3", make(chan Response, 3), 2)
+	Expect(err).To(BeNil())
+	Expect(wal.InitMultiWallet()).To(BeNil())
+	return wal
+}
+
+// removeTestWallet shuts down a wallet of newTestWallet and removes its
+// directory.
+func removeTestWallet(wal *Wallet) {
+	wal.Shutdown()
+	os.RemoveAll(wal.root)
+}
+
+// testVSP is a vspd answering /api/v3/vspinfo with a signed response.
+type testVSP struct {
+	*httptest.Server
+	pubKey ed25519.PublicKey
+
+	mu       sync.Mutex
+	requests int
+	down     bool
+	// hold, if set, holds up the requests until it is closed.
+	hold chan struct{}
+}
+
+func newTestVSP() *testVSP {
+	pubKey, privKey, err := ed25519.GenerateKey(rand.Reader)
+	Expect(err).To(BeNil())
+	vsp := &testVSP{pubKey: pubKey}
+	vsp.Server = httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		vsp.mu.Lock()
+		vsp.requests++
+		down, hold := vsp.down, vsp.hold
+		vsp.mu.Unlock()
+		if hold != nil {
+			<-hold
+		}
+		if down || r.URL.Path != "/api/v3/vspinfo" {
+			http.Error(w, "unavailable", http.StatusServiceUnavailable)
+			return
+		}
+		body, _ := json.Marshal(dcrlibwallet.VspInfoResponse{
+			PubKey:        pubKey,
+			FeePercentage: 2,
+			Network:       "testnet3",
+		})
+		w.Header().Set("VSP-Server-Signature", base64.StdEncoding.EncodeToString(ed25519.Sign(privKey, body)))
+		w.Write(body)
+	}))
+	return vsp
+}
+
+func (vsp *testVSP) set(f func()) {
+	vsp.mu.Lock()
+	defer vsp.mu.Unlock()
+	f()
+}
+
+func (vsp *testVSP) requestCount() int {
+	vsp.mu.Lock()
+	defer vsp.mu.Unlock()
+	return vsp.requests
+}
+
+// useTestVSPs points the wallet package at a list of VSPs serving listed,
+// and makes the VSP client trust the test servers. The returned func undoes
+// it.
+func useTestVSPs(listed ...*testVSP) func() {
+	list := make(map[string]dcrlibwallet.VspInfoResponse)
+	for _, vsp := range listed {
+		list[strings.TrimPrefix(vsp.URL, "https://")] = dcrlibwallet.VspInfoResponse{Network: "testnet3"}
+	}
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(list)
+	}))
+
+	client, listURL := vspHTTPClient, vspListURL
+	vspHTTPClient, vspListURL = server.Client(), server.URL+"/?c=vsp"
+	return func() {
+		vspHTTPClient, vspListURL = client, listURL
+		server.Close()
+	}
+}
+
+var _ = Describe("VSP fee status", func() {
+	It("reads the fee transaction status of vspd", func() {
+		statuses := map[string]VSPFeeStatus{
+			"none":      VSPFeeUnpaid,
+			"received":  VSPFeePaid,
+			"broadcast": VSPFeePaid,
+			"confirmed": VSPFeeConfirmed,
+			"error":     VSPFeeErrored,
+			"":          VSPFeeUnknown,
+			"pending":   VSPFeeUnknown,
+		}
+		for feeTxStatus, status := range statuses {
+			Expect(vspFeeStatus(feeTxStatus)).To(Equal(status), feeTxStatus)
+		}
+	})
+
+	Describe("asking a VSP for the status of a ticket", func() {
+		var (
+			pubKey    ed25519.PublicKey
+			signer    ed25519.PrivateKey
+			code      int
+			server    *httptest.Server
+			request   = []byte(`{"tickethash":"abc"}`)
+			clientSig = []byte("client signature")
+		)
+
+		BeforeEach(func() {
+			var err error
+			pubKey, signer, err = ed25519.GenerateKey(rand.Reader)
+			Expect(err).To(BeNil())
+			code = http.StatusOK
+			server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				defer GinkgoRecover()
+				body, _ := ioutil.ReadAll(r.Body)
+				Expect(r.URL.Path).To(Equal("/api/v3/ticketstatus"))
+				Expect(body).To(Equal(request))
+				Expect(r.Header.Get("VSP-Client-Signature")).To(Equal(base64.StdEncoding.EncodeToString(clientSig)))
+
+				resp, _ := json.Marshal(vspTicketStatusResponse{TicketConfirmed: true, FeeTxStatus: "confirmed"})
+				w.Header().Set("VSP-Server-Signature", base64.StdEncoding.EncodeToString(ed25519.Sign(signer, resp)))
+				w.WriteHeader(code)
+				w.Write(resp)
+			}))
+		})
+
+		AfterEach(func() {
+			server.Close()
+		})
+
+		It("reads a status signed by the VSP", func() {
+			status, err := getVSPTicketStatus(server.URL, pubKey, request, clientSig)
+			Expect(err).To(BeNil())
+			Expect(vspFeeStatus(status.FeeTxStatus)).To(Equal(VSPFeeConfirmed))
+		})
+
+		It("rejects a status signed by another key", func() {
+			var err error
+			_, signer, err = ed25519.GenerateKey(rand.Reader)
+			Expect(err).To(BeNil())
+			_, err = getVSPTicketStatus(server.URL, pubKey, request, clientSig)
+			Expect(err).NotTo(BeNil())
+		})
+
+		It("rejects an error response", func() {
+			code = http.StatusBadRequest
+			_, err := getVSPTicketStatus(server.URL, pubKey, request, clientSig)
+			Expect(err).NotTo(BeNil())
+		})
+	})
+})
+
+var _ = Describe("VSP public key pinning", func() {
+	var wal *Wallet
+	first, second := []byte("first key"), []byte("second key")
+
+	BeforeEach(func() {
+		wal = newTestWallet()
+	})
+
+	AfterEach(func() {
+		removeTestWallet(wal)
+	})
+
+	It("pins the first key of each host", func() {
+		Expect(wal.pinVSPPubKey("https://a.example", first, false)).To(BeTrue())
+		Expect(wal.pinVSPPubKey("https://a.example", first, false)).To(BeTrue())
+		Expect(wal.pinVSPPubKey("https://b.example", second, false)).To(BeTrue())
+	})
+
+	It("rejects another key until it replaces the pinned key", func() {
+		wal.pinVSPPubKey("https://a.example", first, false)
+		Expect(wal.pinVSPPubKey("https://a.example", second, false)).To(BeFalse())
+		Expect(wal.pinVSPPubKey("https://a.example", first, false)).To(BeTrue())
+
+		Expect(wal.pinVSPPubKey("https://a.example", second, true)).To(BeTrue())
+		Expect(wal.pinVSPPubKey("https://a.example", first, false)).To(BeFalse())
+	})
+
+	It("pins a new key once the host is unpinned", func() {
+		wal.pinVSPPubKey("https://a.example", first, false)
+		wal.unpinVSPPubKey("https://a.example")
+		Expect(wal.pinVSPPubKey("https://a.example", second, false)).To(BeTrue())
+	})
+
+	It("refuses to pay a VSP whose key changed until the new key is trusted", func() {
+		vsp := newTestVSP()
+		defer vsp.Close()
+		restore := useTestVSPs(vsp)
+		defer restore()
+
+		wal.pinVSPPubKey(vsp.URL, first, false)
+		list := wal.vspList(false)
+		Expect(list).To(HaveLen(1))
+		Expect(list[0].PubKeyChanged).To(BeTrue())
+
+		// no wallet is loaded, so a VSP that is not refused fails on the
+		// wallet ID instead
+		_, err := wal.NewVSPD(vsp.URL, 1, 0)
+		Expect(err).To(Equal(ErrVSPPubKeyChanged))
+		ticket := Ticket{Info: dcrlibwallet.TicketInfo{Ticket: &wallet.TransactionSummary{}}}
+		Expect(wal.RetryVSPFee(1, ticket, vsp.URL, nil)).To(Equal(ErrVSPPubKeyChanged))
+
+		Expect(wal.TrustVSPPubKey(vsp.URL)).To(Succeed())
+		_, err = wal.NewVSPD(vsp.URL, 1, 0)
+		Expect(err).To(Equal(ErrIDNotExist))
+		Expect(wal.RetryVSPFee(1, ticket, vsp.URL, nil)).To(Equal(ErrIDNotExist))
+	})
+})
+
+var _ = Describe("VSP list", func() {
+	var (
+		wal           *Wallet
+		added, listed *testVSP
+		restore       func()
+	)
+
+	BeforeEach(func() {
+		wal = newTestWallet()
+		added, listed = newTestVSP(), newTestVSP()
+		restore = useTestVSPs(listed)
+		wal.multi.SaveUserConfigValue(dcrlibwallet.VSPHostConfigKey, struct {
+			Remember string
+			List     []string
+		}{List: []string{added.URL}})
+	})
+
+	AfterEach(func() {
+		restore()
+		added.Close()
+		listed.Close()
+		removeTestWallet(wal)
+	})
+
+	It("lists the added VSPs before the listed VSPs", func() {
+		list := wal.vspList(false)
+		Expect(list).To(HaveLen(2))
+		Expect(list[0].Host).To(Equal(added.URL))
+		Expect(list[0].Added).To(BeTrue())
+		Expect(list[0].Usable()).To(BeTrue())
+		Expect(list[1].Host).To(Equal(listed.URL))
+		Expect(list[1].Added).To(BeFalse())
+		Expect(list[1].Usable()).To(BeTrue())
+	})
+
+	It("asks the VSPs again only when forced", func() {
+		wal.vspList(false)
+		wal.vspList(false)
+		Expect(listed.requestCount()).To(Equal(1))
+		wal.vspList(true)
+		Expect(listed.requestCount()).To(Equal(2))
+	})
+
+	It("keeps the info of a VSP that cannot be reached", func() {
+		wal.vspList(false)
+		listed.set(func() { listed.down = true })
+		list := wal.vspList(true)
+		Expect(list).To(HaveLen(2))
+		Expect(list[1].Usable()).To(BeFalse())
+		Expect(list[1].Info).NotTo(BeNil())
+		Expect([]byte(list[1].Info.PubKey)).To(Equal([]byte(listed.pubKey)))
+	})
+
+	It("does not hold up removing a VSP while the VSPs are asked", func() {
+		wal.vspList(false)
+		hold := make(chan struct{})
+		listed.set(func() { listed.hold = hold })
+		lists := make(chan []VSPInfo)
+		go func() {
+			lists <- wal.vspList(true)
+		}()
+		Eventually(listed.requestCount).Should(Equal(2))
+
+		removed := make(chan error)
+		go func() {
+			removed <- wal.RemoveVSP(added.URL)
+		}()
+		Eventually(removed, 5*time.Second).Should(Receive(BeNil()))
+
+		close(hold)
+		var list []VSPInfo
+		Eventually(lists, 5*time.Second).Should(Receive(&list))
+		Expect(list).To(HaveLen(1))
+		Expect(list[0].Host).To(Equal(listed.URL))
+	})
+})
+
+var _ = Describe("VSP vote choices", func() {
+	It("knows when the VSP recorded the choices", func() {
+		recorded := func(recorded, choices map[string]string) bool {
+			return choicesRecorded(&VSPTicketStatus{VoteChoices: recorded}, choices)
+		}
+		Expect(recorded(nil, nil)).To(BeTrue())
+		Expect(recorded(map[string]string{"a": "yes", "b": "no"}, map[string]string{"a": "yes", "b": "no"})).To(BeTrue())
+		Expect(recorded(map[string]string{"a": "yes"}, map[string]string{"a": "no"})).To(BeFalse())
+		Expect(recorded(map[string]string{"a": "yes"}, map[string]string{"a": "yes", "b": "no"})).To(BeFalse())
+		// vspd does not record abstaining, nor forget the choices of
+		// agendas that ended
+		Expect(recorded(map[string]string{"a": "yes"}, map[string]string{"a": "yes", "b": "abstain"})).To(BeTrue())
+		Expect(recorded(map[string]string{"a": "yes", "b": "no"}, map[string]string{"a": "yes"})).To(BeTrue())
+	})
+
+	Describe("sending the choices to a VSP", func() {
+		var (
+			pubKey  ed25519.PublicKey
+			server  *httptest.Server
+			echoed  []byte
+			request = []byte(`{"votechoices":{"a":"yes"}}`)
+		)
+
+		BeforeEach(func() {
+			var (
+				privKey ed25519.PrivateKey
+				err     error
+			)
+			pubKey, privKey, err = ed25519.GenerateKey(rand.Reader)
+			Expect(err).To(BeNil())
+			echoed = request
+			server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				defer GinkgoRecover()
+				Expect(r.URL.Path).To(Equal("/api/v3/setvotechoices"))
+				resp, _ := json.Marshal(struct {
+					Request []byte `json:"request"`
+				}{echoed})
+				w.Header().Set("VSP-Server-Signature", base64.StdEncoding.EncodeToString(ed25519.Sign(privKey, resp)))
+				w.Write(resp)
+			}))
+		})
+
+		AfterEach(func() {
+			server.Close()
+		})
+
+		It("accepts the VSP echoing the request", func() {
+			Expect(setVSPVoteChoices(server.URL, pubKey, request, []byte("sig"))).To(BeNil())
+		})
+
+		It("rejects the VSP echoing another request", func() {
+			echoed = []byte(`{"votechoices":{"a":"no"}}`)
+			Expect(setVSPVoteChoices(server.URL, pubKey, request, []byte("sig"))).NotTo(BeNil())
+		})
+	})
+})
@@ -6,7 +6,9 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"path/filepath"
 	"sort"
+	"sync"
 
 	"github.com/planetdecred/dcrlibwallet"
 )
@@ -21,6 +23,13 @@ type Wallet struct {
 	Sync               chan SyncStatusUpdate
 	confirms           int32
 	OverallBlockHeight int32
+
+	// vspStatusMu guards the VSP ticket statuses saved in the config.
+	vspStatusMu sync.Mutex
+	vspDir      vspDirectory
+	proposals   proposalCache
+	// priceHistoryMu guards the ticket prices saved in the config.
+	priceHistoryMu sync.Mutex
 }
 
 // NewWallet initializies an new Wallet instance.
@@ -35,6 +44,10 @@ func NewWallet(root string, net string, send chan Response, confirms int32) (*Wa
 		Sync:     make(chan SyncStatusUpdate, 2),
 		Send:     send,
 		confirms: confirms,
+		proposals: proposalCache{
+			dir:   filepath.Join(root, net, "proposals"),
+			limit: proposalCacheLimit,
+		},
 	}
 
 	return wal, nil
@@ -169,8 +182,9 @@ func (wal *Wallet) wallets() ([]dcrlibwallet.Wallet, error) {
 	return wallets, nil
 }
 
-func (wal *Wallet) hdPrefix() string {
-	switch wal.Net {
+// hdPrefix returns the prefix of the HD paths of the accounts on net.
+func hdPrefix(net string) string {
+	switch net {
 	case "testnet3": // should use a constant
 		return dcrlibwallet.TestnetHDPath
 	case "mainnet":
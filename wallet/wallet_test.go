@@ -35,7 +35,7 @@ var (
 )
 var _ = BeforeSuite(func() {
 	var err error
-	wal, err = NewWallet(getTestDir(), testnet, make(chan Response), 2)
+	wal, err = NewWallet(getTestDir(), testnet, make(chan Response, 3), 2)
 	Expect(err).To(BeNil())
 	wal.LoadWallets()
 	resp := <-wal.Send
@@ -65,14 +65,7 @@ var _ = Describe("Wallet", func() {
 		Expect(inf.Synced).To(Equal(false))
 	})
 	It("can rename a wallet", func() {
-		tempChan := make(chan error)
-		wal.RenameWallet(1, "random", tempChan)
-		go func() {
-			err := <-tempChan
-			Expect(err).To(BeNil())
-		}()
-		resp := <-wal.Send
-		Expect(resp.Resp).To(BeAssignableToTypeOf(Renamed{}))
+		Expect(wal.RenameWallet(1, "random")).To(BeNil())
 	})
 	It("can get the current address", func() {
 		addr, err := wal.CurrentAddress(1, 0)